@@ -0,0 +1,289 @@
+package xredis
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultAccessSampleRate   = 0.01
+	defaultNamespaceSeparator = ":"
+	defaultAccessExpectedKeys = 100_000
+	defaultAccessWindow       = 24 * time.Hour
+
+	// accessFalseWarmRate is the probability that a key without sampled reads
+	// is reported warm while a namespace holds the expected number of keys.
+	accessFalseWarmRate = 0.01
+)
+
+// AccessSamplingConfig configures sampling of key reads.
+type AccessSamplingConfig struct {
+	// SampleRate is the fraction of read commands that are recorded, in
+	// (0, 1].
+	//
+	// Zero uses 0.01.
+	SampleRate float64
+
+	// Separator separates the namespace from the rest of a key. The namespace
+	// of "user:42:profile" is "user" with the default separator.
+	//
+	// Empty uses ":".
+	Separator string
+
+	// ExpectedKeys is the number of distinct keys of one namespace expected
+	// to be sampled in a window. Up to it, a key without sampled reads is
+	// reported warm with a probability of about 1%; more keys raise the
+	// probability. Memory grows by about 1.2 bytes per expected key and
+	// namespace.
+	//
+	// Zero uses 100000.
+	ExpectedKeys int
+
+	// Window is how long sampled reads are remembered. Reads are recorded per
+	// window and the last two windows are kept, so a read is forgotten after
+	// one to two windows.
+	//
+	// Zero uses 24 hours.
+	Window time.Duration
+}
+
+// ColdKeysReport estimates which keys of a namespace were not read recently.
+type ColdKeysReport struct {
+	// Namespace is the reported namespace.
+	Namespace string
+
+	// Since is the start of the oldest window of sampled reads taken into
+	// account.
+	Since time.Time
+
+	// Scanned is the number of keys found in the namespace.
+	Scanned int64
+
+	// ColdCount is the number of scanned keys without sampled reads.
+	ColdCount int64
+
+	// Cold contains up to the requested number of cold keys.
+	Cold []string
+}
+
+// ColdRatio returns the fraction of scanned keys that are cold.
+func (r ColdKeysReport) ColdRatio() float64 {
+	if r.Scanned == 0 {
+		return 0
+	}
+
+	return float64(r.ColdCount) / float64(r.Scanned)
+}
+
+// accessSampler records sampled key reads into per-namespace Bloom filters,
+// one set of filters per window.
+type accessSampler struct {
+	rate         float64
+	separator    string
+	expectedKeys int
+	window       time.Duration
+
+	mu sync.Mutex
+
+	// since is the start of the previous window, and started the start of
+	// the current one.
+	since    time.Time
+	started  time.Time
+	previous map[string]*bloomFilter
+	current  map[string]*bloomFilter
+}
+
+func newAccessSampler(cfg AccessSamplingConfig) *accessSampler {
+	now := time.Now()
+
+	return &accessSampler{
+		rate:         cfg.SampleRate,
+		separator:    cfg.Separator,
+		expectedKeys: cfg.ExpectedKeys,
+		window:       cfg.Window,
+		since:        now,
+		started:      now,
+		previous:     make(map[string]*bloomFilter),
+		current:      make(map[string]*bloomFilter),
+	}
+}
+
+func normalizeAccessSamplingConfig(cfg AccessSamplingConfig) AccessSamplingConfig {
+	if cfg.SampleRate <= 0 || cfg.SampleRate > 1 {
+		cfg.SampleRate = defaultAccessSampleRate
+	}
+
+	if cfg.Separator == "" {
+		cfg.Separator = defaultNamespaceSeparator
+	}
+
+	if cfg.ExpectedKeys <= 0 {
+		cfg.ExpectedKeys = defaultAccessExpectedKeys
+	}
+
+	if cfg.Window <= 0 {
+		cfg.Window = defaultAccessWindow
+	}
+
+	return cfg
+}
+
+func (s *accessSampler) namespace(key string) string {
+	namespace, _, _ := strings.Cut(key, s.separator)
+	return namespace
+}
+
+func (s *accessSampler) sample(cmd rdb.Cmder) {
+	if s.rate < 1 && rand.Float64() >= s.rate {
+		return
+	}
+
+	for _, key := range cmdReadKeys(cmd) {
+		s.filter(s.namespace(key)).add(key)
+	}
+}
+
+// filter returns the filter of namespace in the current window.
+func (s *accessSampler) filter(namespace string) *bloomFilter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rotate(time.Now())
+
+	filter := s.current[namespace]
+	if filter == nil {
+		filter = newBloomFilter(s.expectedKeys, accessFalseWarmRate)
+		s.current[namespace] = filter
+	}
+
+	return filter
+}
+
+// rotate starts a new window when the current one is over. The current
+// window becomes the previous one, unless it ended more than a window ago.
+func (s *accessSampler) rotate(now time.Time) {
+	elapsed := now.Sub(s.started)
+	if elapsed < s.window {
+		return
+	}
+
+	if elapsed < 2*s.window {
+		s.since, s.previous = s.started, s.current
+	} else {
+		s.since, s.previous = now, make(map[string]*bloomFilter)
+	}
+
+	s.started, s.current = now, make(map[string]*bloomFilter)
+}
+
+// filters returns the filters of namespace in the kept windows and the start
+// of the oldest one.
+func (s *accessSampler) filters(namespace string) ([]*bloomFilter, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rotate(time.Now())
+
+	var filters []*bloomFilter
+
+	for _, window := range []map[string]*bloomFilter{s.previous, s.current} {
+		if filter := window[namespace]; filter != nil {
+			filters = append(filters, filter)
+		}
+	}
+
+	return filters, s.since
+}
+
+// accessSamplingHook samples successful read commands.
+type accessSamplingHook struct {
+	passDialHook
+
+	sampler *accessSampler
+}
+
+func (h *accessSamplingHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		err := next(ctx, cmd)
+		if err == nil {
+			h.sampler.sample(cmd)
+		}
+
+		return err
+	}
+}
+
+func (h *accessSamplingHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		err := next(ctx, cmds)
+
+		for _, cmd := range cmds {
+			if cmd.Err() == nil {
+				h.sampler.sample(cmd)
+			}
+		}
+
+		return err
+	}
+}
+
+// ColdKeys scans the keys of namespace and reports the keys without sampled
+// reads in the windows of access sampling enabled with WithAccessSampling.
+//
+// The report is an estimate. Reads are sampled, so a key read n times is
+// reported cold with probability (1-SampleRate)^n. Sampled reads are kept in
+// Bloom filters, so a key that was not read is reported warm with a
+// probability of about 1% while the namespace has at most ExpectedKeys
+// sampled keys per window. Keys created in the windows may be reported cold
+// simply because they are new. At most limit cold keys are returned;
+// limit <= 0 returns only the counts.
+//
+// It returns ErrAccessSamplingDisabled when access sampling is not enabled.
+func (c *Client) ColdKeys(ctx context.Context, namespace string, limit int) (ColdKeysReport, error) {
+	if c.sampler == nil {
+		return ColdKeysReport{}, ErrAccessSamplingDisabled
+	}
+
+	filters, since := c.sampler.filters(namespace)
+
+	report := ColdKeysReport{
+		Namespace: namespace,
+		Since:     since,
+	}
+
+	var mu sync.Mutex
+
+	match := escapeGlob(namespace+c.sampler.separator) + "*"
+
+	err := c.ScanEach(ctx, ScanOptions{Match: match}, func(_ context.Context, key string) error {
+		cold := !slices.ContainsFunc(filters, func(filter *bloomFilter) bool {
+			return filter.contains(key)
+		})
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		report.Scanned++
+		if !cold {
+			return nil
+		}
+
+		report.ColdCount++
+		if len(report.Cold) < limit {
+			report.Cold = append(report.Cold, key)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return ColdKeysReport{}, err
+	}
+
+	return report, nil
+}
@@ -0,0 +1,124 @@
+package xredis_test
+
+import (
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("Access sampling", func() {
+	BeforeEach(func() {
+		client := newTestClient()
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	It("reports keys without sampled reads as cold", func() {
+		client := newTestClient(xredis.WithAccessSampling(xredis.AccessSamplingConfig{SampleRate: 1}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		for _, key := range []string{"sampling:hot", "sampling:warm", "sampling:cold:1", "sampling:cold:2"} {
+			Expect(client.Set(ctx, key, "value", time.Minute)).To(Succeed())
+		}
+
+		_, _, err := client.String(ctx, "sampling:hot")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.Raw().MGet(ctx, "sampling:warm", "sampling:missing").Err()).To(Succeed())
+
+		report, err := client.ColdKeys(ctx, "sampling", 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Namespace).To(Equal("sampling"))
+		Expect(report.Scanned).To(Equal(int64(4)))
+		Expect(report.ColdCount).To(Equal(int64(2)))
+		Expect(report.Cold).To(ConsistOf("sampling:cold:1", "sampling:cold:2"))
+		Expect(report.ColdRatio()).To(Equal(0.5))
+		Expect(report.Since).To(BeTemporally("<=", time.Now()))
+	})
+
+	It("limits the returned cold keys", func() {
+		client := newTestClient(xredis.WithAccessSampling(xredis.AccessSamplingConfig{SampleRate: 1, Separator: "/"}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		for _, key := range []string{"sampled/a", "sampled/b", "sampled/c"} {
+			Expect(client.Set(ctx, key, "value", time.Minute)).To(Succeed())
+		}
+
+		report, err := client.ColdKeys(ctx, "sampled", 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.ColdCount).To(Equal(int64(3)))
+		Expect(report.Cold).To(HaveLen(1))
+	})
+
+	It("forgets reads of past windows", func() {
+		client := newTestClient(xredis.WithAccessSampling(xredis.AccessSamplingConfig{
+			SampleRate: 1,
+			Window:     100 * time.Millisecond,
+		}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Set(ctx, "sampling:key", "value", time.Minute)).To(Succeed())
+		_, _, err := client.String(ctx, "sampling:key")
+		Expect(err).NotTo(HaveOccurred())
+
+		report, err := client.ColdKeys(ctx, "sampling", 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.ColdCount).To(BeZero())
+
+		time.Sleep(250 * time.Millisecond)
+
+		report, err = client.ColdKeys(ctx, "sampling", 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Cold).To(ConsistOf("sampling:key"))
+		Expect(report.Since).To(BeTemporally("~", time.Now(), 50*time.Millisecond))
+	})
+
+	It("scans namespaces with glob characters literally", func() {
+		client := newTestClient(xredis.WithAccessSampling(xredis.AccessSamplingConfig{SampleRate: 1}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		for _, key := range []string{"sampl*:a", "sampling:b"} {
+			Expect(client.Set(ctx, key, "value", time.Minute)).To(Succeed())
+		}
+
+		report, err := client.ColdKeys(ctx, "sampl*", 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Cold).To(ConsistOf("sampl*:a"))
+	})
+
+	It("does not sample failed reads", func() {
+		client := newTestClient(xredis.WithAccessSampling(xredis.AccessSamplingConfig{SampleRate: 1}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Raw().HSet(ctx, "sampling:hash", "field", "value").Err()).To(Succeed())
+		Expect(client.Raw().Get(ctx, "sampling:hash").Err()).To(HaveOccurred())
+
+		report, err := client.ColdKeys(ctx, "sampling", 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Cold).To(ContainElement("sampling:hash"))
+	})
+
+	It("requires access sampling", func() {
+		client := newTestClient()
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		_, err := client.ColdKeys(ctx, "sampling", 10)
+		Expect(err).To(MatchError(xredis.ErrAccessSamplingDisabled))
+	})
+})
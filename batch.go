@@ -0,0 +1,370 @@
+package xredis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// Batch queues heterogeneous Redis operations and executes them in one pipeline.
+//
+// Unlike the pipeline helpers, a Batch does not abort on the first failing
+// operation. Exec reports an error for every queued operation, so callers can
+// decide how to handle partial failures.
+//
+// A Batch is not safe for concurrent use.
+type Batch struct {
+	client *Client
+	ops    []batchOp
+}
+
+type batchOp struct {
+	// err is a validation or encoding error detected while queueing.
+	err error
+
+	// queue adds the operation command to the pipeline.
+	queue func(ctx context.Context, pipe rdb.Pipeliner) rdb.Cmder
+
+	// result converts the executed command into the operation error.
+	result func(cmd rdb.Cmder) error
+
+	// noRetry keeps the whole pipeline from being retried on network errors.
+	noRetry bool
+
+	// reserve, when set, reserves the write in its namespace quota before
+	// the pipeline is sent, and release drops the reservation when the write
+	// fails.
+	reserve func(ctx context.Context) error
+	release func(ctx context.Context)
+}
+
+// Batch returns an empty batch bound to the client.
+func (c *Client) Batch() *Batch {
+	return &Batch{client: c}
+}
+
+// Len returns the number of queued operations.
+func (b *Batch) Len() int {
+	return len(b.ops)
+}
+
+// Set queues a SET of a raw Redis value.
+//
+// ttl < 0 reports ErrInvalidTTL for this operation. Keys in a namespace
+// configured with WithNamespaceQuota are reserved one by one before the
+// pipeline is sent, and writes beyond the budget report
+// ErrNamespaceQuotaExceeded for their operation without being sent.
+func (b *Batch) Set(key string, value any, ttl time.Duration) *Batch {
+	if ttl < 0 {
+		return b.fail(ErrInvalidTTL)
+	}
+
+	b.add(func(ctx context.Context, pipe rdb.Pipeliner) rdb.Cmder {
+		return pipe.Set(ctx, key, value, ttl)
+	}, cmdError)
+
+	if b.client == nil {
+		return b
+	}
+
+	if quota, ok := b.client.namespaceQuota(key); ok {
+		op := &b.ops[len(b.ops)-1]
+		op.reserve = func(ctx context.Context) error {
+			return b.client.reserveWrite(ctx, quota, key, value, ttl)
+		}
+		op.release = func(ctx context.Context) {
+			_ = b.client.releaseQuota(ctx, quota.Namespace, key)
+		}
+	}
+
+	return b
+}
+
+// SetStruct queues a SET of a value encoded with the client Codec.
+//
+// ttl == 0 applies the TTL declared by value, as for Client.SetStruct.
+// ttl < 0 reports ErrInvalidTTL for this operation.
+// Encoding errors are reported for this operation only. Namespace quotas
+// apply as for Set.
+func (b *Batch) SetStruct(key string, value any, ttl time.Duration) *Batch {
+	if ttl < 0 {
+		return b.fail(ErrInvalidTTL)
+	}
+
+	ttl, err := defaultStructTTL(value, ttl)
+	if err != nil {
+		return b.fail(err)
+	}
+
+	if b.client == nil {
+		return b.fail(ErrInvalidPipeline)
+	}
+
+	data, err := b.client.codec.Marshal(value)
+	if err != nil {
+		return b.fail(err)
+	}
+
+	return b.Set(key, data, ttl)
+}
+
+// Get queues a GET whose value is scanned into dst after Exec.
+//
+// A missing key is reported as ErrKeyNotFound.
+func (b *Batch) Get(key string, dst any) *Batch {
+	return b.add(func(ctx context.Context, pipe rdb.Pipeliner) rdb.Cmder {
+		return pipe.Get(ctx, key)
+	}, func(cmd rdb.Cmder) error {
+		return batchNotFound(cmd.(*rdb.StringCmd).Scan(dst))
+	})
+}
+
+// GetStruct queues a GET whose value is decoded into dst with the client Codec
+// after Exec.
+//
+// A missing key is reported as ErrKeyNotFound.
+func (b *Batch) GetStruct(key string, dst any) *Batch {
+	if b.client == nil {
+		return b.fail(ErrInvalidPipeline)
+	}
+
+	codec := b.client.codec
+
+	return b.add(func(ctx context.Context, pipe rdb.Pipeliner) rdb.Cmder {
+		return pipe.Get(ctx, key)
+	}, func(cmd rdb.Cmder) error {
+		data, err := cmd.(*rdb.StringCmd).Bytes()
+		if err != nil {
+			return batchNotFound(err)
+		}
+
+		return codec.Unmarshal(data, dst)
+	})
+}
+
+// HSet queues an HSET and, when ttl > 0, an EXPIRE of the hash key.
+//
+// values supports the same input formats as Client.HSet.
+// ttl < 0 reports ErrInvalidTTL and empty values report ErrInvalidHashObject.
+func (b *Batch) HSet(key string, ttl time.Duration, values ...any) *Batch {
+	if ttl < 0 {
+		return b.fail(ErrInvalidTTL)
+	}
+
+	if len(values) == 0 {
+		return b.fail(ErrInvalidHashObject)
+	}
+
+	var converters hashConverters
+	if b.client != nil {
+		converters = b.client.converters
+	}
+
+	values, err := flattenHashValues(values, converters)
+	if err != nil {
+		return b.fail(err)
+	}
+
+	var expire *rdb.BoolCmd
+
+	return b.add(func(ctx context.Context, pipe rdb.Pipeliner) rdb.Cmder {
+		cmd := pipe.HSet(ctx, key, values...)
+		if ttl > 0 {
+			expire = pipe.Expire(ctx, key, ttl)
+		}
+
+		return cmd
+	}, func(cmd rdb.Cmder) error {
+		if err := cmd.Err(); err != nil {
+			return err
+		}
+
+		if expire != nil {
+			return expire.Err()
+		}
+
+		return nil
+	})
+}
+
+// HGetAll queues an HGETALL whose fields are scanned into dst after Exec.
+//
+// A missing or empty hash is reported as ErrKeyNotFound. Unknown fields are
+// handled as in Client.HGetAll.
+func (b *Batch) HGetAll(key string, dst any) *Batch {
+	if dst == nil {
+		return b.fail(ErrInvalidHashObject)
+	}
+
+	// The Exec context is kept for logging unknown fields.
+	var execCtx context.Context
+
+	return b.add(func(ctx context.Context, pipe rdb.Pipeliner) rdb.Cmder {
+		execCtx = ctx
+		return pipe.HGetAll(ctx, key)
+	}, func(cmd rdb.Cmder) error {
+		res := cmd.(*rdb.MapStringStringCmd)
+		if err := res.Err(); err != nil {
+			return err
+		}
+
+		if len(res.Val()) == 0 {
+			return ErrKeyNotFound
+		}
+
+		return b.client.scanHash(execCtx, key, res, dst)
+	})
+}
+
+// Incr queues an INCR. When dst is not nil, the updated value is stored in it
+// after Exec.
+//
+// As for Client.Incr, a batch with an Incr is not retried on network errors.
+func (b *Batch) Incr(key string, dst *int64) *Batch {
+	return b.addNoRetry(func(ctx context.Context, pipe rdb.Pipeliner) rdb.Cmder {
+		return pipe.Incr(ctx, key)
+	}, func(cmd rdb.Cmder) error {
+		value, err := cmd.(*rdb.IntCmd).Result()
+		if err != nil {
+			return err
+		}
+
+		if dst != nil {
+			*dst = value
+		}
+
+		return nil
+	})
+}
+
+// Delete queues a DEL of key.
+func (b *Batch) Delete(key string) *Batch {
+	return b.add(func(ctx context.Context, pipe rdb.Pipeliner) rdb.Cmder {
+		return pipe.Del(ctx, key)
+	}, cmdError)
+}
+
+// Expire queues an EXPIRE of key.
+//
+// ttl <= 0 reports ErrInvalidTTL. A missing key is reported as ErrKeyNotFound.
+func (b *Batch) Expire(key string, ttl time.Duration) *Batch {
+	if ttl <= 0 {
+		return b.fail(ErrInvalidTTL)
+	}
+
+	return b.add(func(ctx context.Context, pipe rdb.Pipeliner) rdb.Cmder {
+		return pipe.Expire(ctx, key, ttl)
+	}, func(cmd rdb.Cmder) error {
+		ok, err := cmd.(*rdb.BoolCmd).Result()
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return ErrKeyNotFound
+		}
+
+		return nil
+	})
+}
+
+// Exec executes all queued operations in one pipeline and resets the batch.
+//
+// The returned slice has one entry per queued operation, in queue order.
+// A nil entry means the operation succeeded. Operations rejected while
+// queueing or by a namespace quota are not sent to Redis and report their
+// error.
+//
+// Each operation targets one key, so batches are safe to use with standalone
+// Redis, Redis Cluster, and Ring clients.
+func (b *Batch) Exec(ctx context.Context) []error {
+	ops := b.ops
+	b.ops = nil
+
+	errs := make([]error, len(ops))
+	if len(ops) == 0 {
+		return errs
+	}
+
+	if err := validatePipelineClient(b.client); err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+
+		return errs
+	}
+
+	for i, op := range ops {
+		if op.err == nil && op.reserve != nil {
+			ops[i].err = op.reserve(ctx)
+		}
+	}
+
+	cmds := make([]rdb.Cmder, len(ops))
+	pipe := b.client.conn.Pipeline()
+
+	for _, op := range ops {
+		if op.err == nil && op.noRetry {
+			ctx = withNoRetry(ctx)
+			break
+		}
+	}
+
+	for i, op := range ops {
+		if op.err == nil {
+			cmds[i] = op.queue(ctx, pipe)
+		}
+	}
+
+	// Command errors are inspected per operation below.
+	_, _ = pipe.Exec(ctx)
+
+	for i, op := range ops {
+		if op.err != nil {
+			errs[i] = op.err
+			continue
+		}
+
+		errs[i] = op.result(cmds[i])
+		if errs[i] != nil && op.release != nil {
+			op.release(ctx)
+		}
+	}
+
+	return errs
+}
+
+func (b *Batch) add(
+	queue func(ctx context.Context, pipe rdb.Pipeliner) rdb.Cmder,
+	result func(cmd rdb.Cmder) error,
+) *Batch {
+	b.ops = append(b.ops, batchOp{queue: queue, result: result})
+	return b
+}
+
+func (b *Batch) addNoRetry(
+	queue func(ctx context.Context, pipe rdb.Pipeliner) rdb.Cmder,
+	result func(cmd rdb.Cmder) error,
+) *Batch {
+	b.ops = append(b.ops, batchOp{queue: queue, result: result, noRetry: true})
+	return b
+}
+
+func (b *Batch) fail(err error) *Batch {
+	b.ops = append(b.ops, batchOp{err: err})
+	return b
+}
+
+func cmdError(cmd rdb.Cmder) error {
+	return cmd.Err()
+}
+
+func batchNotFound(err error) error {
+	if errors.Is(err, rdb.Nil) {
+		return ErrKeyNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,106 @@
+package xredis_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("Batch", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient()
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("executes heterogeneous operations and reports errors per operation", func() {
+		Expect(client.Set(ctx, "batch:counter", 41, 0)).To(Succeed())
+		Expect(client.Set(ctx, "batch:string", "text", 0)).To(Succeed())
+
+		var (
+			counter int64
+			profile pipelineProfile
+			hash    pipelineHash
+			missing string
+		)
+
+		errs := client.Batch().
+			SetStruct("batch:profile", pipelineProfile{ID: "42", Name: "Ada", Active: true}, time.Minute).
+			HSet("batch:hash", time.Minute, "id", "7", "status", "active").
+			Incr("batch:counter", &counter).
+			Incr("batch:string", nil).
+			Set("batch:invalid", "value", -time.Second).
+			GetStruct("batch:profile", &profile).
+			HGetAll("batch:hash", &hash).
+			Get("batch:missing", &missing).
+			Exec(ctx)
+
+		Expect(errs).To(HaveLen(8))
+		Expect(errs[0]).NotTo(HaveOccurred())
+		Expect(errs[1]).NotTo(HaveOccurred())
+		Expect(errs[2]).NotTo(HaveOccurred())
+		Expect(errs[3]).To(HaveOccurred())
+		Expect(errors.Is(errs[4], xredis.ErrInvalidTTL)).To(BeTrue())
+		Expect(errs[5]).NotTo(HaveOccurred())
+		Expect(errs[6]).NotTo(HaveOccurred())
+		Expect(errors.Is(errs[7], xredis.ErrKeyNotFound)).To(BeTrue())
+
+		Expect(counter).To(Equal(int64(42)))
+		Expect(profile).To(Equal(pipelineProfile{ID: "42", Name: "Ada", Active: true}))
+		Expect(hash).To(Equal(pipelineHash{ID: "7", Status: "active"}))
+
+		ttl, err := client.Raw().TTL(ctx, "batch:hash").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ttl).To(BeNumerically(">", 0))
+
+		exists, err := client.Exists(ctx, "batch:invalid")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+
+	It("reports codec errors for the failing operation only", func() {
+		codecClient := newTestClient(xredis.WithCodec(failingPipelineCodec{}))
+		defer func() {
+			Expect(codecClient.Close()).To(Succeed())
+		}()
+
+		errs := codecClient.Batch().
+			SetStruct("batch:profile", pipelineProfile{ID: "42"}, time.Minute).
+			Set("batch:raw", "value", time.Minute).
+			Exec(ctx)
+
+		Expect(errs).To(HaveLen(2))
+		Expect(errors.Is(errs[0], errPipelineCodec)).To(BeTrue())
+		Expect(errs[1]).NotTo(HaveOccurred())
+	})
+
+	It("deletes keys and expires missing keys as not found", func() {
+		Expect(client.Set(ctx, "batch:delete", "value", 0)).To(Succeed())
+
+		batch := client.Batch().
+			Delete("batch:delete").
+			Expire("batch:missing", time.Minute)
+		Expect(batch.Len()).To(Equal(2))
+
+		errs := batch.Exec(ctx)
+		Expect(errs[0]).NotTo(HaveOccurred())
+		Expect(errors.Is(errs[1], xredis.ErrKeyNotFound)).To(BeTrue())
+		Expect(batch.Len()).To(BeZero())
+
+		exists, err := client.Exists(ctx, "batch:delete")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+
+	It("returns an empty result for an empty batch", func() {
+		Expect(client.Batch().Exec(ctx)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,249 @@
+package xredis
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const defaultBlockingChunk = time.Second
+
+// BLPop removes and returns the first element of the first non-empty list
+// among keys, blocking until an element is available.
+//
+// The wait is split into chunks of at most the configured blocking chunk
+// (see WithBlockingChunk), each bounded by the context deadline, so context
+// cancellation is honored between chunks and a connection is never blocked
+// past the caller's deadline. timeout == 0 waits until ctx is done. Chunks
+// shorter than one second are sent in fractional seconds, which requires
+// Redis 6.0 or later.
+//
+// It returns ok=false when timeout elapses without an element and ctx.Err()
+// when ctx is done first.
+func (c *Client) BLPop(ctx context.Context, timeout time.Duration, keys ...string) (key, value string, ok bool, err error) {
+	return c.blockingPop(ctx, timeout, keys, "blpop", c.conn.BLPop)
+}
+
+// BRPop removes and returns the last element of the first non-empty list
+// among keys, blocking until an element is available.
+//
+// It chunks the wait like BLPop.
+func (c *Client) BRPop(ctx context.Context, timeout time.Duration, keys ...string) (key, value string, ok bool, err error) {
+	return c.blockingPop(ctx, timeout, keys, "brpop", c.conn.BRPop)
+}
+
+func (c *Client) blockingPop(
+	ctx context.Context,
+	timeout time.Duration,
+	keys []string,
+	name string,
+	pop func(ctx context.Context, timeout time.Duration, keys ...string) *rdb.StringSliceCmd,
+) (key, value string, ok bool, err error) {
+	if timeout < 0 {
+		return "", "", false, ErrInvalidTTL
+	}
+
+	err = c.blockInChunks(ctx, timeout, func(block time.Duration) (bool, error) {
+		var cmd *rdb.StringSliceCmd
+
+		// go-redis sends whole seconds and blocks for one second on shorter
+		// timeouts, so those are sent here.
+		if block >= time.Second {
+			cmd = pop(ctx, block.Truncate(time.Second), keys...)
+		} else {
+			args := make([]any, 0, len(keys)+2)
+			args = append(args, name)
+
+			for _, key := range keys {
+				args = append(args, key)
+			}
+
+			cmd = rdb.NewStringSliceCmd(ctx, append(args, blockSeconds(block))...)
+			_ = c.conn.Process(ctx, cmd)
+		}
+
+		res, err := cmd.Result()
+		if err != nil {
+			if errors.Is(err, rdb.Nil) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		key, value, ok = res[0], res[1], true
+
+		return true, nil
+	})
+
+	return key, value, ok, err
+}
+
+// XReadBlock reads entries from streams, blocking until at least one entry is
+// available.
+//
+// args.Block is the total wait time; zero waits until ctx is done. The wait is
+// chunked like BLPop. Because XREAD is re-issued for every chunk, the special
+// ID "$" is resolved to the last stream entry ID before the first chunk, so
+// entries added between chunks are not missed.
+//
+// It returns nil streams when the wait elapses without entries and ctx.Err()
+// when ctx is done first. Missing or unpaired stream arguments return
+// ErrInvalidStream.
+func (c *Client) XReadBlock(ctx context.Context, args *rdb.XReadArgs) ([]rdb.XStream, error) {
+	if args == nil || len(args.Streams) == 0 || len(args.Streams)%2 != 0 || args.Block < 0 {
+		return nil, ErrInvalidStream
+	}
+
+	read := *args
+	read.Streams = append([]string(nil), args.Streams...)
+
+	if err := c.resolveLastStreamIDs(ctx, read.Streams); err != nil {
+		return nil, err
+	}
+
+	var streams []rdb.XStream
+
+	err := c.blockInChunks(ctx, args.Block, func(block time.Duration) (bool, error) {
+		read.Block = block
+
+		res, err := c.conn.XRead(ctx, &read).Result()
+		if err != nil {
+			if errors.Is(err, rdb.Nil) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		streams = res
+
+		return len(streams) > 0, nil
+	})
+
+	return streams, err
+}
+
+// resolveLastStreamIDs replaces "$" IDs in XREAD stream arguments with the
+// last entry ID of the stream, or "0-0" for an empty stream.
+func (c *Client) resolveLastStreamIDs(ctx context.Context, streams []string) error {
+	half := len(streams) / 2
+
+	for i := half; i < len(streams); i++ {
+		if streams[i] != "$" {
+			continue
+		}
+
+		last, err := c.conn.XRevRangeN(ctx, streams[i-half], "+", "-", 1).Result()
+		if err != nil {
+			return err
+		}
+
+		streams[i] = "0-0"
+		if len(last) > 0 {
+			streams[i] = last[0].ID
+		}
+	}
+
+	return nil
+}
+
+// Wait blocks until writes of the current connection are acknowledged by at
+// least replicas replicas and returns the number of acknowledging replicas.
+//
+// The wait is chunked like BLPop. When timeout elapses first, it returns the
+// last acknowledged count without an error.
+//
+// WAIT applies to writes sent over the same connection, so it is only
+// meaningful inside a pinned connection, such as a rdb.Conn or transaction
+// callback; on a pooled client it may run on a different connection.
+func (c *Client) Wait(ctx context.Context, replicas int, timeout time.Duration) (int64, error) {
+	if timeout < 0 {
+		return 0, ErrInvalidTTL
+	}
+
+	var acked int64
+
+	err := c.blockInChunks(ctx, timeout, func(block time.Duration) (bool, error) {
+		n, err := c.wait(ctx, replicas, block).Result()
+		if err != nil {
+			return false, err
+		}
+
+		acked = n
+
+		return n >= int64(replicas), nil
+	})
+
+	return acked, err
+}
+
+// replicaWaiter is implemented by all go-redis clients, but WAIT is not part of
+// rdb.UniversalClient.
+type replicaWaiter interface {
+	Wait(ctx context.Context, numSlaves int, timeout time.Duration) *rdb.IntCmd
+}
+
+func (c *Client) wait(ctx context.Context, replicas int, timeout time.Duration) *rdb.IntCmd {
+	if conn, ok := c.conn.(replicaWaiter); ok {
+		return conn.Wait(ctx, replicas, timeout)
+	}
+
+	cmd := rdb.NewIntCmd(ctx, "wait", replicas, timeout.Milliseconds())
+	_ = c.conn.Process(ctx, cmd)
+
+	return cmd
+}
+
+// blockSeconds formats a block duration shorter than one second as the
+// timeout of a blocking list or sorted set command, in fractional seconds
+// rounded down to milliseconds.
+func blockSeconds(block time.Duration) string {
+	return strconv.FormatFloat(float64(block.Milliseconds())/1000, 'f', -1, 64)
+}
+
+// blockInChunks calls fn with successive block durations until fn reports
+// completion, fails, the total timeout elapses, or ctx is done.
+//
+// Every block duration is bounded by the blocking chunk, the remaining
+// timeout, and the context deadline. timeout == 0 means no total limit.
+func (c *Client) blockInChunks(
+	ctx context.Context,
+	timeout time.Duration,
+	fn func(block time.Duration) (bool, error),
+) error {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		block := c.blockingChunk
+
+		if ctxDeadline, ok := ctx.Deadline(); ok {
+			block = min(block, time.Until(ctxDeadline))
+		}
+
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return nil
+			}
+
+			block = min(block, remaining)
+		}
+
+		// Zero blocks forever in Redis blocking commands.
+		done, err := fn(max(block, time.Millisecond))
+		if err != nil || done {
+			return err
+		}
+	}
+}
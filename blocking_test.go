@@ -0,0 +1,223 @@
+package xredis_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Blocking commands", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient(xredis.WithBlockingChunk(100 * time.Millisecond))
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("pops list elements from both ends", func() {
+		Expect(client.Raw().RPush(ctx, "blocking:list", "first", "last").Err()).To(Succeed())
+
+		key, value, ok, err := client.BLPop(ctx, time.Second, "blocking:missing", "blocking:list")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(key).To(Equal("blocking:list"))
+		Expect(value).To(Equal("first"))
+
+		_, value, ok, err = client.BRPop(ctx, time.Second, "blocking:list")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("last"))
+	})
+
+	It("waits across chunks for a pushed element", func() {
+		go func() {
+			defer GinkgoRecover()
+
+			time.Sleep(300 * time.Millisecond)
+			Expect(client.Raw().RPush(ctx, "blocking:list", "late").Err()).To(Succeed())
+		}()
+
+		_, value, ok, err := client.BLPop(ctx, 0, "blocking:list")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("late"))
+	})
+
+	It("returns ok=false when the timeout elapses", func() {
+		_, _, ok, err := client.BLPop(ctx, 200*time.Millisecond, "blocking:list")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("blocks no longer than a sub-second timeout", func() {
+		wholeSeconds := newTestClient()
+		defer func() {
+			Expect(wholeSeconds.Close()).To(Succeed())
+		}()
+
+		started := time.Now()
+
+		_, _, ok, err := wholeSeconds.BLPop(ctx, 300*time.Millisecond, "blocking:list")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		_, _, ok, err = wholeSeconds.BRPop(ctx, 300*time.Millisecond, "blocking:list")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+		Expect(time.Since(started)).To(BeNumerically("<", time.Second))
+	})
+
+	It("honors context cancellation between chunks", func() {
+		waitCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+		defer cancel()
+
+		started := time.Now()
+
+		_, err := client.XReadBlock(waitCtx, &rdb.XReadArgs{Streams: []string{"blocking:stream", "$"}})
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(time.Since(started)).To(BeNumerically("<", 2*time.Second))
+	})
+
+	It("reads entries added after a $ read started", func() {
+		Expect(client.Raw().XAdd(ctx, &rdb.XAddArgs{
+			Stream: "blocking:stream",
+			Values: map[string]any{"n": "old"},
+		}).Err()).To(Succeed())
+
+		go func() {
+			defer GinkgoRecover()
+
+			time.Sleep(300 * time.Millisecond)
+			Expect(client.Raw().XAdd(ctx, &rdb.XAddArgs{
+				Stream: "blocking:stream",
+				Values: map[string]any{"n": "new"},
+			}).Err()).To(Succeed())
+		}()
+
+		streams, err := client.XReadBlock(ctx, &rdb.XReadArgs{
+			Streams: []string{"blocking:stream", "$"},
+			Block:   5 * time.Second,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(streams).To(HaveLen(1))
+		Expect(streams[0].Messages).To(HaveLen(1))
+		Expect(streams[0].Messages[0].Values).To(HaveKeyWithValue("n", "new"))
+	})
+
+	It("returns replica acknowledgements", func() {
+		acked, err := client.Wait(ctx, 0, 100*time.Millisecond)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(acked).To(BeZero())
+	})
+
+	It("pops from the first non-empty list or sorted set", func() {
+		Expect(client.Raw().RPush(ctx, "{queue}:low", "a", "b", "c").Err()).To(Succeed())
+		Expect(client.Raw().ZAdd(ctx, "{queue}:delayed",
+			rdb.Z{Score: 3, Member: "late"},
+			rdb.Z{Score: 1, Member: "early"},
+		).Err()).To(Succeed())
+
+		pop, ok, err := client.LMPop(ctx, xredis.ListRight, 2, "{queue}:high", "{queue}:low")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(pop).To(Equal(xredis.ListPop{Key: "{queue}:low", Values: []string{"c", "b"}}))
+
+		zpop, ok, err := client.ZMPop(ctx, xredis.ScoreMin, 1, "{queue}:urgent", "{queue}:delayed")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(zpop).To(Equal(xredis.ZPop{Key: "{queue}:delayed", Members: []rdb.Z{{Score: 1, Member: "early"}}}))
+
+		_, ok, err = client.LMPop(ctx, xredis.ListLeft, 1, "{queue}:high")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("waits across chunks for work on any queue", func() {
+		go func() {
+			defer GinkgoRecover()
+
+			time.Sleep(300 * time.Millisecond)
+			Expect(client.Raw().RPush(ctx, "{queue}:low", "late").Err()).To(Succeed())
+			Expect(client.Raw().ZAdd(ctx, "{queue}:delayed", rdb.Z{Score: 1, Member: "late"}).Err()).To(Succeed())
+		}()
+
+		pop, ok, err := client.BLMPop(ctx, 0, xredis.ListLeft, 10, "{queue}:high", "{queue}:low")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(pop.Key).To(Equal("{queue}:low"))
+		Expect(pop.Values).To(Equal([]string{"late"}))
+
+		zpop, ok, err := client.BZMPop(ctx, 5*time.Second, xredis.ScoreMax, 1, "{queue}:delayed")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(zpop.Members).To(Equal([]rdb.Z{{Score: 1, Member: "late"}}))
+
+		started := time.Now()
+
+		_, ok, err = client.BZMPop(ctx, 200*time.Millisecond, xredis.ScoreMin, 1, "{queue}:delayed")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+		Expect(time.Since(started)).To(BeNumerically("<", time.Second))
+	})
+
+	It("hashes and checks every key of multi pops", func() {
+		scoped := newTestClient(
+			xredis.WithKeyHasher(strings.ToUpper, "{queue}:"),
+			xredis.WithKeyScope("{queue}:"),
+		)
+		defer func() {
+			Expect(scoped.Close()).To(Succeed())
+		}()
+
+		Expect(client.Raw().RPush(ctx, "{queue}:LOW", "a").Err()).To(Succeed())
+		Expect(client.Raw().ZAdd(ctx, "{queue}:DELAYED", rdb.Z{Score: 1, Member: "early"}).Err()).To(Succeed())
+
+		pop, ok, err := scoped.LMPop(ctx, xredis.ListLeft, 1, "{queue}:high", "{queue}:low")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(pop).To(Equal(xredis.ListPop{Key: "{queue}:LOW", Values: []string{"a"}}))
+
+		zpop, ok, err := scoped.BZMPop(ctx, time.Second, xredis.ScoreMin, 1, "{queue}:delayed")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(zpop.Key).To(Equal("{queue}:DELAYED"))
+
+		_, _, err = scoped.ZMPop(ctx, xredis.ScoreMin, 1, "{queue}:delayed", "blocking:zset")
+		Expect(err).To(MatchError(xredis.ErrOutOfScope))
+
+		_, _, err = scoped.BLMPop(ctx, time.Second, xredis.ListLeft, 1, "blocking:list")
+		Expect(err).To(MatchError(xredis.ErrOutOfScope))
+	})
+
+	It("validates arguments", func() {
+		_, _, err := client.LMPop(ctx, "middle", 1, "blocking:list")
+		Expect(err).To(MatchError(xredis.ErrInvalidPop))
+
+		_, _, err = client.ZMPop(ctx, xredis.ScoreMin, 0, "blocking:zset")
+		Expect(err).To(MatchError(xredis.ErrInvalidPop))
+
+		_, _, err = client.BLMPop(ctx, time.Second, xredis.ListLeft, 1)
+		Expect(err).To(MatchError(xredis.ErrInvalidPop))
+
+		_, _, err = client.BZMPop(ctx, -time.Second, xredis.ScoreMax, 1, "blocking:zset")
+		Expect(err).To(MatchError(xredis.ErrInvalidTTL))
+
+		_, _, _, err = client.BLPop(ctx, -time.Second, "blocking:list")
+		Expect(err).To(MatchError(xredis.ErrInvalidTTL))
+
+		_, err = client.XReadBlock(ctx, &rdb.XReadArgs{Streams: []string{"blocking:stream"}})
+		Expect(err).To(MatchError(xredis.ErrInvalidStream))
+
+		_, err = client.Wait(ctx, 1, -time.Second)
+		Expect(err).To(MatchError(xredis.ErrInvalidTTL))
+	})
+})
@@ -0,0 +1,69 @@
+package xredis
+
+import (
+	"hash/maphash"
+	"math"
+	"sync"
+)
+
+// bloomFilter tests set membership in fixed memory.
+//
+// It never reports an added item as missing; collisions can only report a
+// missing item as present.
+type bloomFilter struct {
+	mu     sync.Mutex
+	seed   maphash.Seed
+	hashes uint64
+	bits   []uint64
+}
+
+// newBloomFilter returns a filter that reports a missing item as present with
+// probability falsePositive once it holds capacity items.
+func newBloomFilter(capacity int, falsePositive float64) *bloomFilter {
+	size := math.Ceil(-float64(capacity) * math.Log(falsePositive) / (math.Ln2 * math.Ln2))
+	hashes := max(math.Round(size/float64(capacity)*math.Ln2), 1)
+
+	return &bloomFilter{
+		seed:   maphash.MakeSeed(),
+		hashes: uint64(hashes),
+		bits:   make([]uint64, (int(size)+63)/64),
+	}
+}
+
+func (f *bloomFilter) add(item string) {
+	h1, h2 := f.itemHashes(item)
+	size := uint64(len(f.bits)) * 64
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := range f.hashes {
+		bit := (h1 + i*h2) % size
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+func (f *bloomFilter) contains(item string) bool {
+	h1, h2 := f.itemHashes(item)
+	size := uint64(len(f.bits)) * 64
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := range f.hashes {
+		bit := (h1 + i*h2) % size
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// itemHashes returns two independent hashes of item for double hashing.
+func (f *bloomFilter) itemHashes(item string) (uint64, uint64) {
+	h := maphash.String(f.seed, item)
+
+	// Odd h2 keeps successive bit indexes distinct for power-of-two sizes.
+	return h, (h>>32 | h<<32) | 1
+}
@@ -0,0 +1,100 @@
+package xredis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	bootstrapLockKey      = "xredis:bootstrap:lock"
+	bootstrapDoneKey      = "xredis:bootstrap:done"
+	bootstrapTimeout      = time.Minute
+	bootstrapPollInterval = 100 * time.Millisecond
+)
+
+// runBootstrap runs the functions registered with WithBootstrap under a
+// distributed lock.
+//
+// Instances starting together race for the lock. The winner runs the
+// functions and records its lock token as the last completed run. The other
+// instances wait for the lock and skip the functions when a run completed
+// while they waited, so they never start before the bootstrap is done.
+func (c *Client) runBootstrap(fns []func(ctx context.Context, c *Client) error) error {
+	if len(fns) == 0 {
+		return nil
+	}
+
+	if c.opts.readOnly {
+		c.logger.LogAttrs(context.Background(), slog.LevelInfo, "redis bootstrap skipped in read-only mode")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), bootstrapTimeout)
+	defer cancel()
+
+	last, err := c.conn.Get(ctx, bootstrapDoneKey).Result()
+	if err != nil && !errors.Is(err, rdb.Nil) {
+		return fmt.Errorf("bootstrap: %w", err)
+	}
+
+	lock, err := c.waitBootstrapLock(ctx)
+	if err != nil {
+		return fmt.Errorf("bootstrap: %w", err)
+	}
+
+	// The lock expires with the bootstrap timeout if Unlock fails.
+	defer func() { _ = lock.Unlock(context.WithoutCancel(ctx)) }()
+
+	done, err := c.conn.Get(ctx, bootstrapDoneKey).Result()
+	if err != nil && !errors.Is(err, rdb.Nil) {
+		return fmt.Errorf("bootstrap: %w", err)
+	}
+
+	if done != last {
+		c.logger.LogAttrs(ctx, slog.LevelDebug, "redis bootstrap completed by another instance")
+		return nil
+	}
+
+	for _, fn := range fns {
+		if err := fn(ctx, c); err != nil {
+			return fmt.Errorf("bootstrap: %w", err)
+		}
+	}
+
+	if err := c.conn.Set(ctx, bootstrapDoneKey, lock.Token(), 0).Err(); err != nil {
+		return fmt.Errorf("bootstrap: %w", err)
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelInfo, "redis bootstrap completed", slog.Int("functions", len(fns)))
+
+	return nil
+}
+
+// waitBootstrapLock acquires the bootstrap lock, polling while another
+// instance holds it.
+func (c *Client) waitBootstrapLock(ctx context.Context) (*Lock, error) {
+	ticker := time.NewTicker(bootstrapPollInterval)
+	defer ticker.Stop()
+
+	for {
+		lock, ok, err := c.TryLock(ctx, bootstrapLockKey, bootstrapTimeout)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			return lock, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
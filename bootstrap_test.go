@@ -0,0 +1,92 @@
+package xredis_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("Bootstrap", func() {
+	BeforeEach(func() {
+		client := newTestClient()
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	It("runs the bootstrap functions in order before the constructor returns", func() {
+		client := newTestClient(
+			xredis.WithBootstrap(func(ctx context.Context, c *xredis.Client) error {
+				return c.Set(ctx, "defaults:limit", "10", 0)
+			}),
+			xredis.WithBootstrap(func(ctx context.Context, c *xredis.Client) error {
+				return c.Set(ctx, "defaults:order", "second", 0)
+			}),
+		)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		values, err := client.Raw().MGet(ctx, "defaults:limit", "defaults:order").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal([]any{"10", "second"}))
+
+		exists, err := client.Exists(ctx, "xredis:bootstrap:lock")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+
+	It("runs once for instances starting together", func() {
+		var (
+			runs atomic.Int32
+			wg   sync.WaitGroup
+		)
+
+		bootstrap := xredis.WithBootstrap(func(context.Context, *xredis.Client) error {
+			runs.Add(1)
+			time.Sleep(200 * time.Millisecond)
+
+			return nil
+		})
+
+		clients := make([]*xredis.Client, 3)
+		for i := range clients {
+			wg.Add(1)
+
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				clients[i] = newTestClient(bootstrap)
+			}()
+		}
+
+		wg.Wait()
+
+		for _, client := range clients {
+			Expect(client.Close()).To(Succeed())
+		}
+
+		Expect(runs.Load()).To(Equal(int32(1)))
+	})
+
+	It("fails the constructor when a bootstrap function fails", func() {
+		errSeed := errors.New("seed failed")
+
+		_, err := xredis.NewClient(
+			xredis.WithClientConfig(&xredis.ClientConfig{Addr: redisAddr, DB: testDB}),
+			xredis.WithBootstrap(func(context.Context, *xredis.Client) error {
+				return errSeed
+			}),
+		)
+		Expect(err).To(MatchError(errSeed))
+	})
+})
@@ -0,0 +1,78 @@
+package xredis
+
+import (
+	"context"
+	"fmt"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// boundedIncrScript atomically adds a delta to a counter unless the result
+// would cross a bound.
+//
+// KEYS[1] - counter key
+// ARGV[1] - delta
+// ARGV[2] - bound
+// ARGV[3] - "max" for an upper bound, "min" for a lower bound
+//
+// Returns {1, value} when the bound was hit and the counter was left
+// unchanged, and {0, value} otherwise.
+var boundedIncrScript = rdb.NewScript(`
+local current = tonumber(redis.call("GET", KEYS[1]) or "0")
+if current == nil or current ~= math.floor(current) then
+	return redis.error_reply("ERR value is not an integer or out of range")
+end
+
+local next = current + tonumber(ARGV[1])
+local bound = tonumber(ARGV[2])
+
+if (ARGV[3] == "max" and next > bound) or (ARGV[3] == "min" and next < bound) then
+	return {1, current}
+end
+
+return {0, redis.call("INCRBY", KEYS[1], ARGV[1])}
+`)
+
+// IncrWithCap atomically increments the integer value of key by delta unless
+// the result would exceed maxValue, for inventory counters and quotas where
+// INCR could overshoot.
+//
+// When the cap would be exceeded, the counter is left unchanged, and capped is
+// true. value is the value after the call either way; missing keys count as 0.
+// delta must be positive.
+func (c *Client) IncrWithCap(ctx context.Context, key string, delta, maxValue int64) (value int64, capped bool, err error) {
+	if delta <= 0 {
+		return 0, false, ErrInvalidDelta
+	}
+
+	return c.boundedIncr(ctx, key, delta, maxValue, "max")
+}
+
+// DecrFloor atomically decrements the integer value of key by delta unless
+// the result would fall below minValue, for balances that must not go
+// negative.
+//
+// When the floor would be crossed, the counter is left unchanged, and floored
+// is true. value is the value after the call either way; missing keys count
+// as 0. delta must be positive.
+func (c *Client) DecrFloor(ctx context.Context, key string, delta, minValue int64) (value int64, floored bool, err error) {
+	if delta <= 0 {
+		return 0, false, ErrInvalidDelta
+	}
+
+	return c.boundedIncr(ctx, key, -delta, minValue, "min")
+}
+
+func (c *Client) boundedIncr(ctx context.Context, key string, delta, bound int64, kind string) (int64, bool, error) {
+	// The script is not idempotent, like Incr.
+	result, err := boundedIncrScript.Run(withNoRetry(ctx), c.conn, []string{key}, delta, bound, kind).Int64Slice()
+	if err != nil {
+		return 0, false, err
+	}
+
+	if len(result) != 2 {
+		return 0, false, fmt.Errorf("%w: unexpected bounded increment result", ErrInvalidEntry)
+	}
+
+	return result[1], result[0] == 1, nil
+}
@@ -0,0 +1,89 @@
+package xredis_test
+
+import (
+	"sync"
+	"sync/atomic"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("Bounded counters", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient()
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("increments up to the cap", func() {
+		value, capped, err := client.IncrWithCap(ctx, "bounded:stock", 3, 5)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(capped).To(BeFalse())
+		Expect(value).To(Equal(int64(3)))
+
+		value, capped, err = client.IncrWithCap(ctx, "bounded:stock", 3, 5)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(capped).To(BeTrue())
+		Expect(value).To(Equal(int64(3)))
+
+		value, capped, err = client.IncrWithCap(ctx, "bounded:stock", 2, 5)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(capped).To(BeFalse())
+		Expect(value).To(Equal(int64(5)))
+	})
+
+	It("decrements down to the floor", func() {
+		Expect(client.Raw().Set(ctx, "bounded:credits", 10, 0).Err()).To(Succeed())
+
+		value, floored, err := client.DecrFloor(ctx, "bounded:credits", 7, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(floored).To(BeFalse())
+		Expect(value).To(Equal(int64(3)))
+
+		value, floored, err = client.DecrFloor(ctx, "bounded:credits", 4, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(floored).To(BeTrue())
+		Expect(value).To(Equal(int64(3)))
+	})
+
+	It("never overshoots under concurrency", func() {
+		var (
+			wg      sync.WaitGroup
+			applied atomic.Int64
+		)
+
+		for range 50 {
+			wg.Go(func() {
+				defer GinkgoRecover()
+
+				_, capped, err := client.IncrWithCap(ctx, "bounded:concurrent", 1, 20)
+				Expect(err).NotTo(HaveOccurred())
+				if !capped {
+					applied.Add(1)
+				}
+			})
+		}
+
+		wg.Wait()
+		Expect(applied.Load()).To(Equal(int64(20)))
+		Expect(client.Raw().Get(ctx, "bounded:concurrent").Int64()).To(Equal(int64(20)))
+	})
+
+	It("rejects invalid deltas and non-integer values", func() {
+		_, _, err := client.IncrWithCap(ctx, "bounded:stock", 0, 5)
+		Expect(err).To(MatchError(xredis.ErrInvalidDelta))
+
+		_, _, err = client.DecrFloor(ctx, "bounded:stock", -1, 0)
+		Expect(err).To(MatchError(xredis.ErrInvalidDelta))
+
+		Expect(client.Raw().Set(ctx, "bounded:text", "abc", 0).Err()).To(Succeed())
+		_, _, err = client.IncrWithCap(ctx, "bounded:text", 1, 5)
+		Expect(err).To(MatchError(ContainSubstring("not an integer")))
+	})
+})
@@ -329,7 +329,14 @@ func (c *Cache[T]) GetOrLoad(ctx context.Context, key string, loader Loader[T])
 }
 
 // Set stores a typed value in cache using default TTL.
+//
+// Under memory pressure, the policy configured with WithOOMDegradation may
+// shorten the TTL or skip the write.
 func (c *Cache[T]) Set(ctx context.Context, key string, value T) error {
+	if c.client.memory.skipCacheWrites() {
+		return nil
+	}
+
 	encoded, err := c.encode(value)
 	if err != nil {
 		return err
@@ -435,7 +442,7 @@ func (c *Cache[T]) runLoader(
 }
 
 func (c *Cache[T]) setNegative(ctx context.Context, key string) error {
-	if c.negativeTTL <= 0 {
+	if c.negativeTTL <= 0 || c.client.memory.skipCacheWrites() {
 		return nil
 	}
 
@@ -470,11 +477,11 @@ func (c *Cache[T]) key(key string) string {
 }
 
 func (c *Cache[T]) expiration(ttl time.Duration) time.Duration {
-	if ttl == 0 || c.jitter == 0 {
-		return ttl
+	if ttl != 0 && c.jitter != 0 {
+		ttl += rand.N(c.jitter)
 	}
 
-	return ttl + rand.N(c.jitter)
+	return c.client.memory.cacheTTL(ttl)
 }
 
 func normalizeCacheNotFound(err error) error {
@@ -0,0 +1,131 @@
+package xredis
+
+import (
+	"context"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// CallOption configures the commands sent with a context returned by
+// WithCallOptions.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	noRetry   bool
+	priority  PriorityClass
+	pool      string
+	operation string
+
+	// keysHashed skips WithKeyHasher for keys that are already hashed.
+	keysHashed bool
+}
+
+type callOptionsKey struct{}
+
+// WithCallOptions returns a copy of ctx that applies opts to every command
+// sent with it, including commands sent through Raw.
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	call := callOptionsFrom(ctx)
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&call)
+		}
+	}
+
+	return context.WithValue(ctx, callOptionsKey{}, call)
+}
+
+func callOptionsFrom(ctx context.Context) callOptions {
+	call, _ := ctx.Value(callOptionsKey{}).(callOptions)
+	return call
+}
+
+// retryable reports whether go-redis may retry the commands.
+func (c callOptions) retryable() bool {
+	return !c.noRetry && c.priority != PriorityBestEffort
+}
+
+// NoRetry disables go-redis retries on network errors, so a command that
+// reached Redis before the connection failed is not applied twice.
+//
+// The command fails with the network error instead. Non-idempotent helpers,
+// such as Incr and HIncrBy, and commands of PriorityBestEffort never retry.
+func NoRetry() CallOption {
+	return func(opts *callOptions) {
+		opts.noRetry = true
+	}
+}
+
+// withNoRetry marks commands sent with ctx as not retryable.
+func withNoRetry(ctx context.Context) context.Context {
+	return WithCallOptions(ctx, NoRetry())
+}
+
+// withHashedKeys marks commands sent with ctx as using keys that are already
+// hashed by WithKeyHasher, such as keys received in notifications.
+func withHashedKeys(ctx context.Context) context.Context {
+	return WithCallOptions(ctx, func(opts *callOptions) {
+		opts.keysHashed = true
+	})
+}
+
+// noRetryCmd reports a command as not retryable to go-redis.
+type noRetryCmd struct {
+	rdb.Cmder
+}
+
+func (noRetryCmd) NoRetry() bool {
+	return true
+}
+
+// wrapPipelineCmd returns a copy of cmds with one command wrapped. go-redis
+// reads the MULTI reply of a transaction by its concrete type, so the first
+// queued command is wrapped instead.
+func wrapPipelineCmd(cmds []rdb.Cmder, wrap func(rdb.Cmder) rdb.Cmder) []rdb.Cmder {
+	i := 0
+	if isTxPipeline(cmds) {
+		i = 1
+	}
+
+	if i >= len(cmds) {
+		return cmds
+	}
+
+	wrapped := make([]rdb.Cmder, len(cmds))
+	copy(wrapped, cmds)
+	wrapped[i] = wrap(cmds[i])
+
+	return wrapped
+}
+
+// callOptionsHook applies call options to commands.
+//
+// It must be the innermost hook, so other hooks observe the original
+// commands.
+type callOptionsHook struct {
+	passDialHook
+}
+
+func (callOptionsHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		if !callOptionsFrom(ctx).retryable() {
+			return next(ctx, noRetryCmd{Cmder: cmd})
+		}
+
+		return next(ctx, cmd)
+	}
+}
+
+func (callOptionsHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		if len(cmds) == 0 || callOptionsFrom(ctx).retryable() {
+			return next(ctx, cmds)
+		}
+
+		// go-redis does not retry a pipeline that contains a non-retryable
+		// command.
+		return next(ctx, wrapPipelineCmd(cmds, func(cmd rdb.Cmder) rdb.Cmder {
+			return noRetryCmd{Cmder: cmd}
+		}))
+	}
+}
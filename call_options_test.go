@@ -0,0 +1,172 @@
+package xredis_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"sync/atomic"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+// flakyConn fails the read of the first reply to a command containing marker,
+// after the command has reached Redis.
+type flakyConn struct {
+	net.Conn
+
+	marker []byte
+	sent   *atomic.Int64
+	failed *atomic.Bool
+	fail   bool
+}
+
+func (c *flakyConn) Write(p []byte) (int, error) {
+	if bytes.Contains(bytes.ToLower(p), c.marker) {
+		c.sent.Add(1)
+		c.fail = c.failed.CompareAndSwap(false, true)
+	}
+
+	return c.Conn.Write(p)
+}
+
+func (c *flakyConn) Read(p []byte) (int, error) {
+	if c.fail {
+		return 0, io.EOF
+	}
+
+	return c.Conn.Read(p)
+}
+
+func newFlakyClient(marker string, sent *atomic.Int64) *xredis.Client {
+	var failed atomic.Bool
+
+	return newTestClient(
+		xredis.WithDialer(func(ctx context.Context, network, addr string) (net.Conn, error) {
+			conn, err := (&net.Dialer{Timeout: time.Second}).DialContext(ctx, network, addr)
+			if err != nil {
+				return nil, err
+			}
+
+			return &flakyConn{Conn: conn, marker: []byte(marker), sent: sent, failed: &failed}, nil
+		}),
+		xredis.WithDialerRetryBackoff(func(int) time.Duration { return time.Millisecond }),
+	)
+}
+
+var _ = Describe("Call options", func() {
+	BeforeEach(func() {
+		client := newTestClient()
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	It("retries network errors by default", func() {
+		var sent atomic.Int64
+
+		client := newFlakyClient("incrby", &sent)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Raw().IncrBy(ctx, "retry:counter", 1).Err()).To(Succeed())
+		Expect(sent.Load()).To(Equal(int64(2)))
+	})
+
+	It("does not retry commands sent with NoRetry", func() {
+		var sent atomic.Int64
+
+		client := newFlakyClient("incrby", &sent)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		callCtx := xredis.WithCallOptions(ctx, xredis.NoRetry())
+		Expect(client.Raw().IncrBy(callCtx, "retry:counter", 1).Err()).To(HaveOccurred())
+		Expect(sent.Load()).To(Equal(int64(1)))
+	})
+
+	It("does not retry pipelines sent with NoRetry", func() {
+		var sent atomic.Int64
+
+		client := newFlakyClient("incrby", &sent)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		callCtx := xredis.WithCallOptions(ctx, xredis.NoRetry())
+		pipe := client.Raw().Pipeline()
+		pipe.IncrBy(callCtx, "retry:pipeline", 1)
+		pipe.IncrBy(callCtx, "retry:pipeline", 1)
+
+		_, err := pipe.Exec(callCtx)
+		Expect(err).To(HaveOccurred())
+		Expect(sent.Load()).To(Equal(int64(1)))
+	})
+
+	It("does not retry batches with an Incr", func() {
+		var sent atomic.Int64
+
+		client := newFlakyClient("incr", &sent)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		errs := client.Batch().
+			Set("retry:key", "value", 0).
+			Incr("retry:batch", nil).
+			Exec(ctx)
+		Expect(errs[1]).To(HaveOccurred())
+		Expect(sent.Load()).To(Equal(int64(1)))
+	})
+
+	It("does not retry best-effort commands", func() {
+		var sent atomic.Int64
+
+		client := newFlakyClient("incrby", &sent)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		callCtx := xredis.WithCallOptions(ctx, xredis.BestEffort())
+		Expect(client.Raw().IncrBy(callCtx, "retry:counter", 1).Err()).To(HaveOccurred())
+		Expect(sent.Load()).To(Equal(int64(1)))
+	})
+
+	It("retries critical commands", func() {
+		var sent atomic.Int64
+
+		client := newFlakyClient("incrby", &sent)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		callCtx := xredis.WithCallOptions(ctx, xredis.Priority(xredis.PriorityCritical))
+		Expect(client.Raw().IncrBy(callCtx, "retry:counter", 1).Err()).To(Succeed())
+		Expect(sent.Load()).To(Equal(int64(2)))
+	})
+
+	It("does not retry non-idempotent helpers", func() {
+		var sent atomic.Int64
+
+		client := newFlakyClient("incr", &sent)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		_, err := client.Incr(ctx, "retry:counter")
+		Expect(err).To(HaveOccurred())
+		Expect(sent.Load()).To(Equal(int64(1)))
+
+		value, ok, err := client.Int64(ctx, "retry:counter")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal(int64(1)))
+	})
+})
@@ -227,7 +227,7 @@ var _ = Describe("CAS and CAD", func() {
 				"processing",
 				"version",
 				1,
-			)).To(Succeed())
+			)).Error().To(Succeed())
 
 			before, err := client.Raw().PTTL(ctx, "order:42").Result()
 			Expect(err).NotTo(HaveOccurred())
@@ -266,7 +266,7 @@ var _ = Describe("CAS and CAD", func() {
 				0,
 				"status",
 				"cancelled",
-			)).To(Succeed())
+			)).Error().To(Succeed())
 
 			swapped, err := client.HCompareAndSwap(
 				ctx,
@@ -314,7 +314,7 @@ var _ = Describe("CAS and CAD", func() {
 				0,
 				"version",
 				1,
-			)).To(Succeed())
+			)).Error().To(Succeed())
 
 			swapped, err = client.HCompareAndSwap(
 				ctx,
@@ -345,7 +345,7 @@ var _ = Describe("CAS and CAD", func() {
 				"processing",
 				"version",
 				1,
-			)).To(Succeed())
+			)).Error().To(Succeed())
 
 			deleted, err := client.HCompareAndDelete(
 				ctx,
@@ -390,7 +390,7 @@ var _ = Describe("CAS and CAD", func() {
 				0,
 				"status",
 				"processing",
-			)).To(Succeed())
+			)).Error().To(Succeed())
 
 			deleted, err := client.HCompareAndDelete(
 				ctx,
@@ -4,7 +4,6 @@ import (
 	"context"
 	"time"
 
-	"github.com/google/uuid"
 	rdb "github.com/redis/go-redis/v9"
 )
 
@@ -279,7 +278,7 @@ func (s *VersionedStore[T]) SetIfAbsent(
 		return "", false, err
 	}
 
-	revision = Revision(uuid.NewString())
+	revision = Revision(s.client.newID())
 
 	created, err = s.setIfAbsent(ctx, key, data, revision, expiration)
 	if err != nil {
@@ -324,7 +323,7 @@ func (s *VersionedStore[T]) CompareAndSwap(
 		return "", false, err
 	}
 
-	revision = Revision(uuid.NewString())
+	revision = Revision(s.client.newID())
 
 	result, err := versionedStoreCompareAndSwapScript.Run(
 		ctx,
@@ -2,9 +2,16 @@ package xredis
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/redis/go-redis/extra/redisotel/v9"
 	rdb "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/metric"
 )
 
 // Client is an opinionated Redis client wrapper.
@@ -12,6 +19,38 @@ type Client struct {
 	conn    rdb.UniversalClient
 	codec   Codec
 	metrics *metrics
+	logger  *slog.Logger
+
+	blockingChunk time.Duration
+	sampler       *accessSampler
+	quotas        map[string]NamespaceQuota
+	counters      map[string]*counterAggregator
+	unknownFields UnknownFieldPolicy
+	converters    hashConverters
+
+	subscriptionHealth SubscriptionHealthConfig
+	maintenance        *maintenanceState
+	traceStatements    *atomic.Bool
+	keyPatterns        *atomic.Pointer[[]string]
+	limits             CommandLimitsConfig
+	memory             *memoryPressure
+	server             *serverState
+	shedder            *loadShedder
+
+	// Lua scripts of WithScripts by file name.
+	scripts map[string]*rdb.Script
+
+	// Named connection pools of WithPools.
+	pools map[string]rdb.UniversalClient
+
+	// Construction options, reused by WithDB.
+	opts *options
+
+	// Background workers and metric callbacks stopped by Close.
+	done      chan struct{}
+	workers   sync.WaitGroup
+	closeOnce sync.Once
+	closers   []func()
 }
 
 // NewClient creates a standalone Redis client.
@@ -47,6 +86,8 @@ func NewFailoverClient(opts ...Option) (*Client, error) {
 		return nil, err
 	}
 
+	options.topology = topologyFailover
+
 	return newClient(rdb.NewFailoverClient(redisOpts), options)
 }
 
@@ -59,6 +100,8 @@ func NewFailoverClusterClient(opts ...Option) (*Client, error) {
 		return nil, err
 	}
 
+	options.topology = topologyFailoverCluster
+
 	return newClient(rdb.NewFailoverClusterClient(redisOpts), options)
 }
 
@@ -74,6 +117,41 @@ func NewRing(opts ...Option) (*Client, error) {
 	return newClient(rdb.NewRing(redisOpts), options)
 }
 
+// WithDB returns a client bound to logical database db of the same standalone,
+// failover, or Ring deployment, configured with the options of c, including
+// its instrumentation.
+//
+// The derived client has its own connection pools and background workers, and
+// must be closed separately. Redis Cluster only has database 0, so cluster
+// clients return ErrInvalidConfig.
+func (c *Client) WithDB(db int) (*Client, error) {
+	if db < 0 {
+		return nil, fmt.Errorf("%w: invalid database %d", ErrInvalidConfig, db)
+	}
+
+	opts := *c.opts
+	if opts.readOptions != nil {
+		readOpts := *opts.readOptions
+		readOpts.DB = db
+		opts.readOptions = &readOpts
+	}
+
+	switch conn := c.conn.(type) {
+	case *rdb.Client:
+		redisOpts := *conn.Options()
+		redisOpts.DB = db
+
+		return newClient(rdb.NewClient(&redisOpts), &opts)
+	case *rdb.Ring:
+		redisOpts := *conn.Options()
+		redisOpts.DB = db
+
+		return newClient(rdb.NewRing(&redisOpts), &opts)
+	default:
+		return nil, fmt.Errorf("%w: logical databases require a standalone, failover, or ring client", ErrInvalidConfig)
+	}
+}
+
 // Raw returns the underlying go-redis client.
 func (c *Client) Raw() rdb.UniversalClient {
 	return c.conn
@@ -84,28 +162,352 @@ func (c *Client) Ping(ctx context.Context) error {
 	return c.conn.Ping(ctx).Err()
 }
 
-// Close closes the Redis client.
+// Close stops background workers and closes the Redis client.
 func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.workers.Wait()
+
+		for _, closeFn := range c.closers {
+			closeFn()
+		}
+	})
+
 	return c.conn.Close()
 }
 
 func newClient(conn rdb.UniversalClient, opts *options) (*Client, error) {
-	if err := applyTracing(conn, opts.traceOptions); err != nil {
+	// Keys are hashed before tracing and every other hook observes them.
+	if opts.keyHasher != nil {
+		addHook(conn, &keyHashHook{hasher: opts.keyHasher})
+	}
+
+	traceStatements, err := applyTracing(conn, opts)
+	if err != nil {
 		_ = conn.Close()
 		return nil, err
 	}
 
-	return &Client{
+	if opts.profilerLabels {
+		addHook(conn, profilerLabelsHook{})
+	}
+
+	var keyPatterns *atomic.Pointer[[]string]
+
+	clientMetrics := newClientMetrics(opts.metricLabels)
+	if clientMetrics != nil {
+		addHook(conn, newMetricsHook(clientMetrics))
+
+		keyPatterns = &atomic.Pointer[[]string]{}
+		addHook(conn, &keyLatencyHook{metrics: clientMetrics, patterns: keyPatterns})
+
+		if opts.commandPhaseMetrics {
+			addHook(conn, newPhaseHook(clientMetrics))
+		}
+
+		addReshardingHook(conn, clientMetrics)
+		addRetryMetricsHooks(conn, clientMetrics)
+	}
+
+	logger := newEventLogger(opts.logger, opts.loggerProvider).With(slog.String("client_id", opts.clientID))
+	if opts.logger != nil || opts.loggerProvider != nil {
+		addHook(conn, newLoggingHook(logger))
+	}
+
+	if opts.errorHandler != nil {
+		addHook(conn, &errorHandlerHook{handler: opts.errorHandler})
+	}
+
+	if opts.deadlineAudit != nil {
+		addHook(conn, newDeadlineAuditHook(*opts.deadlineAudit, logger))
+	}
+
+	if opts.maxConcurrentCommands > 0 {
+		addHook(conn, newConcurrencyLimitHook(opts.maxConcurrentCommands, commandPoolTimeout(conn), clientMetrics))
+	}
+
+	if len(opts.keyScope) > 0 {
+		addHook(conn, &keyScopeHook{prefixes: opts.keyScope})
+	}
+
+	if opts.commandLimits != (CommandLimitsConfig{}) {
+		addHook(conn, &commandLimitsHook{limits: opts.commandLimits})
+	}
+
+	if opts.readOnly {
+		addHook(conn, newReadOnlyHook(logger))
+	}
+
+	maintenance := &maintenanceState{}
+	if opts.maintenance != nil {
+		maintenance.key = opts.maintenance.Key
+	}
+
+	addHook(conn, &maintenanceHook{state: maintenance})
+
+	memory := newMemoryPressure(opts.oomDegradation)
+	addHook(conn, &memoryPressureHook{pressure: memory, logger: logger})
+
+	server := &serverState{}
+
+	busyRetry := normalizeBusyRetryConfig(BusyRetryConfig{})
+	if opts.busyRetry != nil {
+		busyRetry = *opts.busyRetry
+	}
+
+	addHook(conn, &busyRetryHook{state: server, cfg: busyRetry})
+
+	var shedder *loadShedder
+	if opts.loadShedding != nil {
+		shedder = &loadShedder{policy: *opts.loadShedding}
+		addHook(conn, &loadSheddingHook{shedder: shedder})
+	}
+
+	if opts.limiter != nil {
+		addHook(conn, limiterHook{})
+	}
+
+	if opts.scriptResultCache != nil {
+		addHook(conn, &scriptResultCacheHook{cache: newScriptResultCache(*opts.scriptResultCache)})
+	}
+
+	if opts.recording != nil {
+		addHook(conn, &recordingHook{recorder: newRecorder(opts.recording)})
+	}
+
+	var sampler *accessSampler
+	if opts.accessSampling != nil {
+		sampler = newAccessSampler(*opts.accessSampling)
+		addHook(conn, &accessSamplingHook{sampler: sampler})
+	}
+
+	pools := make(map[string]rdb.UniversalClient, len(opts.pools))
+	for name, cfg := range opts.pools {
+		pool, err := newPoolConn(conn, cfg, opts.dialer)
+		if err != nil {
+			for _, pool := range pools {
+				_ = pool.Close()
+			}
+
+			_ = conn.Close()
+
+			return nil, err
+		}
+
+		if pool != nil {
+			pools[name] = pool
+		}
+	}
+
+	chains := &connChains{}
+
+	if len(pools) > 0 {
+		addHook(conn, &poolRoutingHook{pools: pools, chains: chains})
+	}
+
+	var reads *rdb.Client
+	if opts.readOptions != nil {
+		reads = rdb.NewClient(opts.readOptions)
+		addRetryAttemptHook(reads, clientMetrics)
+		addPoolWaitHook(reads, clientMetrics, opts.commandPhaseMetrics)
+		addHook(reads, callOptionsHook{})
+		addHook(conn, &readRoutingHook{
+			reads:   reads,
+			verify:  opts.readVerification,
+			metrics: clientMetrics,
+			chains:  chains,
+		})
+	}
+
+	addRetryAttemptHook(conn, clientMetrics)
+	addPoolWaitHook(conn, clientMetrics, opts.commandPhaseMetrics)
+	addHook(conn, callOptionsHook{})
+	chains.seal()
+
+	// Writes are skipped in read-only mode, so there is nothing to count,
+	// and the accounting scripts would be rejected.
+	quotas := opts.quotas
+	if opts.readOnly {
+		quotas = nil
+	}
+
+	client := &Client{
 		conn:    conn,
 		codec:   opts.codec,
-		metrics: newClientMetrics(opts.metricLabels),
-	}, nil
+		metrics: clientMetrics,
+		logger:  logger,
+		done:    make(chan struct{}),
+
+		blockingChunk: opts.blockingChunk,
+		sampler:       sampler,
+		quotas:        quotas,
+		counters:      newCounterAggregators(opts.counters),
+		unknownFields: opts.unknownFields,
+		converters:    opts.hashConverters,
+
+		subscriptionHealth: normalizeSubscriptionHealthConfig(opts.subscriptionHealth),
+		maintenance:        maintenance,
+		traceStatements:    traceStatements,
+		keyPatterns:        keyPatterns,
+		limits:             opts.commandLimits,
+		memory:             memory,
+		server:             server,
+		shedder:            shedder,
+		pools:              pools,
+		opts:               opts,
+	}
+
+	if reads != nil {
+		client.closers = append(client.closers, func() {
+			_ = reads.Close()
+		})
+	}
+
+	for _, pool := range pools {
+		client.closers = append(client.closers, func() {
+			_ = pool.Close()
+		})
+	}
+
+	if err := client.start(opts); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// start registers metric callbacks and starts background workers.
+func (c *Client) start(opts *options) error {
+	registration, err := c.metrics.registerPoolUtilization(c.PoolUtilization)
+	if err != nil {
+		return err
+	}
+
+	c.addRegistration(registration)
+
+	registration, err = c.metrics.registerPoolWaits(c.conn.PoolStats)
+	if err != nil {
+		return err
+	}
+
+	c.addRegistration(registration)
+
+	registration, err = c.metrics.registerMemoryPressure(c.MemoryPressure)
+	if err != nil {
+		return err
+	}
+
+	c.addRegistration(registration)
+
+	registration, err = c.metrics.registerServerState(c.ServerBusy, c.ServerLoading)
+	if err != nil {
+		return err
+	}
+
+	c.addRegistration(registration)
+
+	if opts.poolPressure != nil {
+		cfg := *opts.poolPressure
+		c.goBackground("pool_pressure_watcher", func(done <-chan struct{}) {
+			c.watchPoolPressure(cfg, done)
+		})
+	}
+
+	if opts.warmConnections != nil {
+		cfg := *opts.warmConnections
+		c.goBackground("warm_connections", func(done <-chan struct{}) {
+			c.watchWarmConnections(cfg, done)
+		})
+	}
+
+	for _, aggregator := range c.counters {
+		c.goBackground("counter_aggregation", func(done <-chan struct{}) {
+			c.watchCounters(aggregator, done)
+		})
+	}
+
+	for _, policy := range opts.retention {
+		c.goBackground("retention", func(done <-chan struct{}) {
+			c.watchRetention(policy, done)
+		})
+	}
+
+	if c.shedder != nil {
+		c.goBackground("load_shedding", c.watchLoadShedding)
+	}
+
+	if opts.maintenance != nil {
+		cfg := *opts.maintenance
+		c.checkMaintenanceKey()
+		c.goBackground("maintenance_watcher", func(done <-chan struct{}) {
+			c.watchMaintenanceKey(cfg, done)
+		})
+	}
+
+	if opts.evictionPolicy != nil {
+		if err := c.startEvictionPolicyCheck(*opts.evictionPolicy); err != nil {
+			return err
+		}
+	}
+
+	c.logEffectiveConfig(opts)
+
+	if err := c.checkHashSchemas(context.Background(), opts.hashSchemas); err != nil {
+		return err
+	}
+
+	if c.scripts, err = loadScripts(opts.scripts); err != nil {
+		return err
+	}
+
+	if err := c.preloadScripts(context.Background(), c.scripts); err != nil {
+		return err
+	}
+
+	return c.runBootstrap(opts.bootstrap)
 }
 
-func applyTracing(conn rdb.UniversalClient, traceOptions []redisotel.TracingOption) error {
-	if len(traceOptions) == 0 {
-		return nil
+// addRegistration unregisters a metric callback when the client is closed.
+func (c *Client) addRegistration(registration metric.Registration) {
+	if registration != nil {
+		c.closers = append(c.closers, func() {
+			_ = registration.Unregister()
+		})
 	}
+}
+
+// goBackground runs fn in a background goroutine until the client is closed.
+// Panics in fn are recovered and restart it; see runWorker.
+func (c *Client) goBackground(worker string, fn func(done <-chan struct{})) {
+	c.workers.Add(1)
+
+	go func() {
+		defer c.workers.Done()
+
+		c.runWorker(worker, fn)
+	}()
+}
+
+// applyTracing instruments conn and returns the switch that controls whether
+// spans record full commands, or nil when tracing is disabled.
+//
+// Statements are recorded by statementHook instead of redisotel, so the
+// verbosity can change at runtime.
+func applyTracing(conn rdb.UniversalClient, opts *options) (*atomic.Bool, error) {
+	if len(opts.traceOptions) == 0 {
+		return nil, nil
+	}
+
+	traceOptions := append(slices.Clip(opts.traceOptions), redisotel.WithDBStatement(false))
+	if err := redisotel.InstrumentTracing(conn, traceOptions...); err != nil {
+		return nil, err
+	}
+
+	statements := &atomic.Bool{}
+	statements.Store(opts.traceDBStatement == nil || *opts.traceDBStatement)
+
+	addHook(conn, newStatementHook(statements, opts))
 
-	return redisotel.InstrumentTracing(conn, traceOptions...)
+	return statements, nil
 }
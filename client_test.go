@@ -0,0 +1,65 @@
+package xredis_test
+
+import (
+	"log/slog"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("WithDB", func() {
+	It("returns a client bound to another database with the same options", func() {
+		var output syncBuffer
+
+		client := newTestClient(xredis.WithLogger(slog.New(slog.NewJSONHandler(&output, nil))))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		sessions, err := client.WithDB(testDB - 1)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(sessions.Raw().FlushDB(ctx).Err()).To(Succeed())
+			Expect(sessions.Close()).To(Succeed())
+		}()
+
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+		Expect(sessions.Raw().FlushDB(ctx).Err()).To(Succeed())
+
+		Expect(sessions.Set(ctx, "withdb:key", "session", 0)).To(Succeed())
+
+		exists, err := client.Exists(ctx, "withdb:key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+
+		value, ok, err := sessions.String(ctx, "withdb:key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("session"))
+
+		Expect(sessions.Raw().Do(ctx, "XREDIS.UNKNOWN").Err()).To(HaveOccurred())
+		Expect(output.String()).To(ContainSubstring(`"msg":"redis command failed"`))
+	})
+
+	It("rejects invalid databases", func() {
+		client := newTestClient()
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		_, err := client.WithDB(-1)
+		Expect(err).To(MatchError(xredis.ErrInvalidConfig))
+	})
+
+	It("rejects cluster clients", func() {
+		client, err := xredis.NewClusterClient(xredis.WithClusterConfig(&xredis.ClusterConfig{Addrs: []string{redisAddr}}))
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		_, err = client.WithDB(1)
+		Expect(err).To(MatchError(xredis.ErrInvalidConfig))
+	})
+})
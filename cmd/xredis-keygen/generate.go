@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const xredisImport = "github.com/mkbeh/xredis"
+
+var fileTemplate = template.Must(template.New("keys").Parse(`// Code generated by xredis-keygen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .StdImports}}
+	{{printf "%q" .}}
+{{- end}}
+{{range .Imports}}
+	{{printf "%q" .}}
+{{- end}}
+)
+
+// {{.Type}} provides typed accessors of the keys declared in {{.Source}}.
+type {{.Type}} struct {
+	client *xredis.Client
+}
+
+// New{{.Type}} returns the key accessors of client.
+func New{{.Type}}(client *xredis.Client) {{.Type}} {
+	return {{.Type}}{client: client}
+}
+{{range .Keys}}
+{{- if .TTL}}
+
+// {{.Name}}TTL is the TTL of {{.Pattern}} keys.
+const {{.Name}}TTL = {{.TTL}}
+{{- end}}
+
+// {{.Name}}Key accesses a {{.Pattern}} key holding {{.Value}} values.
+type {{.Name}}Key struct {
+	client *xredis.Client
+	key    string
+	err    error
+}
+
+// {{.Name}} returns the accessor of the {{.Pattern}} key.
+{{- if .StringParams}}
+//
+// Parameters that are empty or contain {{printf "%q" $.Separator}} fail every call with xredis.ErrInvalidKey.
+{{- end}}
+func (k {{$.Type}}) {{.Name}}({{.Params}}) {{.Name}}Key {
+{{- $key := .}}
+{{- range .StringParams}}
+	if {{.}} == "" || strings.Contains({{.}}, {{printf "%q" $.Separator}}) {
+		return {{$key.Name}}Key{err: fmt.Errorf("%w: parameter {{.}} of %s: %q", xredis.ErrInvalidKey, {{printf "%q" $key.Pattern}}, {{.}})}
+	}
+{{end}}
+	return {{.Name}}Key{client: k.client, key: {{.KeyExpr}}}
+}
+
+// Key returns the Redis key.
+func (k {{.Name}}Key) Key() (string, error) {
+	return k.key, k.err
+}
+
+// Get returns the value of the key.
+//
+// It returns ok=false when the key does not exist.
+func (k {{.Name}}Key) Get(ctx context.Context) (value {{.Value}}, ok bool, err error) {
+	if k.err != nil {
+		return value, false, k.err
+	}
+{{if .Getter}}
+	return k.client.{{.Getter}}(ctx, k.key)
+{{- else}}
+	ok, err = k.client.{{if .Hash}}HGetAll{{else}}GetStruct{{end}}(ctx, k.key, &value)
+
+	return value, ok, err
+{{- end}}
+}
+
+// Set stores value{{if .TTL}} with {{.Name}}TTL{{end}}.
+{{- if .Hash}}
+//
+// Fields of the stored hash that value does not set are kept.
+{{- end}}
+func (k {{.Name}}Key) Set(ctx context.Context, value {{.Value}}) error {
+	if k.err != nil {
+		return k.err
+	}
+{{if .Hash}}
+	_, err := k.client.HSet(ctx, k.key, {{.TTLArg}}, value)
+
+	return err
+{{- else}}
+	return k.client.{{if .Getter}}Set{{else}}SetStruct{{end}}(ctx, k.key, value, {{.TTLArg}})
+{{- end}}
+}
+
+// Delete deletes the key.
+//
+// It returns ok=false when the key did not exist.
+func (k {{.Name}}Key) Delete(ctx context.Context) (bool, error) {
+	if k.err != nil {
+		return false, k.err
+	}
+
+	return k.client.Delete(ctx, k.key)
+}
+
+// Exists reports whether the key exists.
+func (k {{.Name}}Key) Exists(ctx context.Context) (bool, error) {
+	if k.err != nil {
+		return false, k.err
+	}
+
+	return k.client.Exists(ctx, k.key)
+}
+{{end}}`))
+
+// fileView is the data of fileTemplate.
+type fileView struct {
+	Source     string
+	Package    string
+	Type       string
+	Separator  string
+	StdImports []string
+	Imports    []string
+	Keys       []keyView
+}
+
+// keyView is the data of one key in fileTemplate.
+type keyView struct {
+	Name         string
+	Pattern      string
+	Value        string
+	Getter       string
+	Hash         bool
+	TTL          string
+	TTLArg       string
+	Params       string
+	StringParams []string
+	KeyExpr      string
+}
+
+// generate returns the formatted Go source of the accessors of s. source names
+// the schema file in the generated comments.
+func generate(s *schema, source string) ([]byte, error) {
+	view := fileView{
+		Source:    source,
+		Package:   s.Package,
+		Type:      s.Type,
+		Separator: s.Separator,
+		Imports:   slices.Sorted(slices.Values(append([]string{xredisImport}, s.Imports...))),
+	}
+
+	std := map[string]struct{}{"context": {}}
+
+	for _, key := range s.Keys {
+		kv := keyView{
+			Name:    key.Name,
+			Pattern: key.Pattern,
+			Value:   key.Value,
+			Getter:  scalarValues[key.Value],
+			Hash:    key.Codec == codecHash,
+			TTLArg:  "0",
+		}
+
+		if key.ttl > 0 {
+			kv.TTL = durationExpr(key.ttl)
+			kv.TTLArg = key.Name + "TTL"
+			std["time"] = struct{}{}
+		}
+
+		params := make([]string, 0, len(key.segments))
+		parts := make([]string, 0, len(key.segments))
+		literal := ""
+
+		for i, seg := range key.segments {
+			if i > 0 {
+				literal += s.Separator
+			}
+
+			if seg.param == "" {
+				literal += seg.literal
+				continue
+			}
+
+			if literal != "" {
+				parts = append(parts, strconv.Quote(literal))
+				literal = ""
+			}
+
+			params = append(params, seg.param+" "+seg.typ)
+			parts = append(parts, paramExpr(seg))
+
+			switch seg.typ {
+			case "string":
+				kv.StringParams = append(kv.StringParams, seg.param)
+				std["fmt"] = struct{}{}
+				std["strings"] = struct{}{}
+			default:
+				std["strconv"] = struct{}{}
+			}
+		}
+
+		if literal != "" || len(parts) == 0 {
+			parts = append(parts, strconv.Quote(literal))
+		}
+
+		kv.Params = strings.Join(params, ", ")
+		kv.KeyExpr = strings.Join(parts, " + ")
+		view.Keys = append(view.Keys, kv)
+	}
+
+	for name := range std {
+		view.StdImports = append(view.StdImports, name)
+	}
+
+	slices.Sort(view.StdImports)
+
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, view); err != nil {
+		return nil, err
+	}
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+
+	return out, nil
+}
+
+func paramExpr(seg segment) string {
+	switch seg.typ {
+	case "int":
+		return "strconv.Itoa(" + seg.param + ")"
+	case "int64":
+		return "strconv.FormatInt(" + seg.param + ", 10)"
+	case "uint64":
+		return "strconv.FormatUint(" + seg.param + ", 10)"
+	default:
+		return seg.param
+	}
+}
+
+// durationExpr returns a Go expression of d in its largest whole unit.
+func durationExpr(d time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+
+	for _, u := range units {
+		if d%u.unit == 0 {
+			return strconv.FormatInt(int64(d/u.unit), 10) + " * " + u.name
+		}
+	}
+
+	return "time.Duration(" + strconv.FormatInt(int64(d), 10) + ")"
+}
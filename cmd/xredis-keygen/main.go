@@ -0,0 +1,89 @@
+// Command xredis-keygen generates typed accessors of Redis keys from a schema
+// file, so code uses methods such as keys.Session(id).Get(ctx) instead of
+// building key strings by hand.
+//
+// It is meant to run with go generate:
+//
+//	//go:generate go run github.com/mkbeh/xredis/cmd/xredis-keygen -schema keys.yaml
+//
+// The schema lists the keys with their pattern, value type, and optional TTL:
+//
+//	type: Keys        # generated type, default Keys
+//	separator: ":"    # default ":"
+//	keys:
+//	  - name: Session
+//	    pattern: session:{id}
+//	    value: string
+//	    ttl: 30m
+//	  - name: LoginAttempts
+//	    pattern: user:{user:int64}:attempts
+//	    value: int64
+//	    ttl: 15m
+//	  - name: Profile
+//	    pattern: user:{user:int64}:profile
+//	    value: Profile
+//	    codec: hash
+//
+// Parameters are written as {name} or {name:type}, with types string, int,
+// int64, and uint64, and must be whole segments between separators. String
+// parameters that are empty or contain the separator are rejected with
+// xredis.ErrInvalidKey, so a parameter never builds a key of another
+// pattern, and patterns that could build the same key are rejected.
+//
+// Values are string, []byte, bool, int, int64, uint64, float64, or a type of
+// the generated package, or of a package listed under imports, stored with the
+// client codec ("json", the default) or as a hash ("hash").
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "xredis-keygen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("xredis-keygen", flag.ContinueOnError)
+
+	schemaPath := flags.String("schema", "", "schema file (required)")
+	outPath := flags.String("out", "", "output file (default: schema file name with _gen.go)")
+	pkg := flags.String("package", os.Getenv("GOPACKAGE"), "package of the generated code, unless set by the schema")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *schemaPath == "" {
+		return fmt.Errorf("%w: -schema is required", errInvalidSchema)
+	}
+
+	if *outPath == "" {
+		*outPath = strings.TrimSuffix(*schemaPath, filepath.Ext(*schemaPath)) + "_gen.go"
+	}
+
+	data, err := os.ReadFile(*schemaPath)
+	if err != nil {
+		return err
+	}
+
+	s, err := readSchema(bytes.NewReader(data), *pkg)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *schemaPath, err)
+	}
+
+	out, err := generate(s, filepath.Base(*schemaPath))
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(*outPath, out, 0o644)
+}
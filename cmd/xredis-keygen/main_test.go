@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+)
+
+func TestGinkgoSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "xredis-keygen")
+}
+
+const testSchema = `
+keys:
+  - name: Session
+    pattern: session:{id}
+    value: string
+    ttl: 30m
+  - name: LoginAttempts
+    pattern: user:{user:int64}:attempts
+    value: int64
+  - name: Profile
+    pattern: user:{user:int64}:profile
+    value: Profile
+    codec: hash
+  - name: Settings
+    pattern: settings
+    value: Settings
+`
+
+var _ = Describe("generate", func() {
+	It("generates accessors of every key", func() {
+		s, err := readSchema(strings.NewReader(testSchema), "accounts")
+		Expect(err).NotTo(HaveOccurred())
+
+		out, err := generate(s, "keys.yaml")
+		Expect(err).NotTo(HaveOccurred())
+
+		code := string(out)
+		Expect(code).To(HavePrefix("// Code generated by xredis-keygen from keys.yaml. DO NOT EDIT.\n\npackage accounts\n"))
+		Expect(code).To(ContainSubstring("const SessionTTL = 30 * time.Minute"))
+		Expect(code).To(ContainSubstring(`if id == "" || strings.Contains(id, ":") {`))
+		Expect(code).To(ContainSubstring(`key: "session:" + id}`))
+		Expect(code).To(ContainSubstring("return k.client.Set(ctx, k.key, value, SessionTTL)"))
+		Expect(code).To(ContainSubstring("func (k Keys) LoginAttempts(user int64) LoginAttemptsKey {"))
+		Expect(code).To(ContainSubstring(`key: "user:" + strconv.FormatInt(user, 10) + ":attempts"}`))
+		Expect(code).To(ContainSubstring("return k.client.Int64(ctx, k.key)"))
+		Expect(code).To(ContainSubstring("ok, err = k.client.HGetAll(ctx, k.key, &value)"))
+		Expect(code).To(ContainSubstring("_, err := k.client.HSet(ctx, k.key, 0, value)"))
+		Expect(code).To(ContainSubstring("return k.client.SetStruct(ctx, k.key, value, 0)"))
+		Expect(code).To(ContainSubstring(`key: "settings"}`))
+	})
+
+	It("writes the file next to the schema", func() {
+		dir := GinkgoT().TempDir()
+		schemaPath := filepath.Join(dir, "keys.yaml")
+		Expect(os.WriteFile(schemaPath, []byte(testSchema), 0o600)).To(Succeed())
+
+		Expect(run([]string{"-schema", schemaPath, "-package", "accounts"})).To(Succeed())
+		Expect(filepath.Join(dir, "keys_gen.go")).To(BeAnExistingFile())
+	})
+
+	DescribeTable("rejects invalid schemas",
+		func(keys string) {
+			_, err := readSchema(strings.NewReader("keys:\n"+keys), "accounts")
+			Expect(err).To(MatchError(errInvalidSchema))
+		},
+		Entry("unknown field", "  - {name: A, pattern: a, value: string, expire: 1s}\n"),
+		Entry("unexported name", "  - {name: a, pattern: a, value: string}\n"),
+		Entry("no value type", "  - {name: A, pattern: a}\n"),
+		Entry("codec of scalar", "  - {name: A, pattern: a, value: string, codec: hash}\n"),
+		Entry("unknown codec", "  - {name: A, pattern: a, value: A, codec: gob}\n"),
+		Entry("invalid ttl", "  - {name: A, pattern: a, value: string, ttl: soon}\n"),
+		Entry("partial segment", "  - {name: A, pattern: 'user-{id}', value: string}\n"),
+		Entry("unsupported parameter type", "  - {name: A, pattern: 'a:{id:float64}', value: string}\n"),
+		Entry("reserved parameter name", "  - {name: A, pattern: 'a:{strings}', value: string}\n"),
+		Entry("duplicate parameter", "  - {name: A, pattern: 'a:{id}:{id}', value: string}\n"),
+		Entry("duplicate name", "  - {name: A, pattern: a, value: string}\n  - {name: A, pattern: b, value: string}\n"),
+		Entry("overlapping patterns", "  - {name: A, pattern: 'user:{id}', value: string}\n  - {name: B, pattern: 'user:me', value: string}\n"),
+	)
+
+	It("accepts patterns that differ in typed parameters", func() {
+		_, err := readSchema(strings.NewReader(`
+keys:
+  - {name: User, pattern: 'user:{id:int64}', value: string}
+  - {name: Me, pattern: 'user:me', value: string}
+`), "accounts")
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
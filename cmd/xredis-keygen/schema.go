@@ -0,0 +1,312 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultTypeName  = "Keys"
+	defaultSeparator = ":"
+)
+
+// Codecs of struct values.
+const (
+	codecJSON = "json"
+	codecHash = "hash"
+)
+
+// errInvalidSchema is returned when a schema file cannot be parsed or
+// describes invalid keys.
+var errInvalidSchema = errors.New("invalid schema")
+
+// scalarValues maps the supported scalar value types to the Client method
+// that reads them.
+var scalarValues = map[string]string{
+	"string":  "String",
+	"[]byte":  "Bytes",
+	"bool":    "Bool",
+	"int":     "Int",
+	"int64":   "Int64",
+	"uint64":  "Uint64",
+	"float64": "Float64",
+}
+
+// paramTypes lists the supported types of pattern parameters.
+var paramTypes = map[string]struct{}{
+	"string": {}, "int": {}, "int64": {}, "uint64": {},
+}
+
+// reservedParams lists the names that generated code uses in the scope of
+// parameters.
+var reservedParams = map[string]struct{}{
+	"k": {}, "fmt": {}, "strconv": {}, "strings": {}, "xredis": {},
+}
+
+// schema is the document of a schema file.
+type schema struct {
+	Package   string      `yaml:"package"`
+	Type      string      `yaml:"type"`
+	Separator string      `yaml:"separator"`
+	Imports   []string    `yaml:"imports"`
+	Keys      []keySchema `yaml:"keys"`
+}
+
+// keySchema describes the keys of one pattern.
+type keySchema struct {
+	Name    string `yaml:"name"`
+	Pattern string `yaml:"pattern"`
+	Value   string `yaml:"value"`
+	Codec   string `yaml:"codec"`
+	TTL     string `yaml:"ttl"`
+
+	ttl      time.Duration
+	segments []segment
+}
+
+// segment is a part of a key pattern between separators: either literal text
+// or a parameter.
+type segment struct {
+	literal string
+	param   string
+	typ     string
+}
+
+// readSchema decodes and validates a schema. pkg is used when the schema does
+// not name the package of the generated code.
+func readSchema(r io.Reader, pkg string) (*schema, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var s schema
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	if err = decoder.Decode(&s); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidSchema, err)
+	}
+
+	if s.Package == "" {
+		s.Package = pkg
+	}
+
+	if s.Type == "" {
+		s.Type = defaultTypeName
+	}
+
+	if s.Separator == "" {
+		s.Separator = defaultSeparator
+	}
+
+	if err = s.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidSchema, err)
+	}
+
+	return &s, nil
+}
+
+func (s *schema) validate() error {
+	if !token.IsIdentifier(s.Package) {
+		return fmt.Errorf("invalid package name %q", s.Package)
+	}
+
+	if !token.IsIdentifier(s.Type) || !token.IsExported(s.Type) {
+		return fmt.Errorf("type name %q is not an exported identifier", s.Type)
+	}
+
+	if len(s.Keys) == 0 {
+		return errors.New("no keys")
+	}
+
+	// Declarations of the generated file.
+	decls := map[string]struct{}{s.Type: {}, "New" + s.Type: {}}
+
+	for i := range s.Keys {
+		key := &s.Keys[i]
+
+		if err := key.validate(s.Separator); err != nil {
+			return fmt.Errorf("key %q: %w", key.Name, err)
+		}
+
+		for _, decl := range []string{key.Name + "Key", key.Name + "TTL"} {
+			if _, ok := decls[decl]; ok {
+				return fmt.Errorf("key %q: duplicate declaration %s", key.Name, decl)
+			}
+
+			decls[decl] = struct{}{}
+		}
+
+		for _, other := range s.Keys[:i] {
+			if overlap(key.segments, other.segments) {
+				return fmt.Errorf("patterns %q and %q can build the same key", other.Pattern, key.Pattern)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (k *keySchema) validate(separator string) error {
+	if !token.IsIdentifier(k.Name) || !token.IsExported(k.Name) {
+		return errors.New("name is not an exported identifier")
+	}
+
+	if k.Value == "" {
+		return errors.New("no value type")
+	}
+
+	_, scalar := scalarValues[k.Value]
+
+	switch {
+	case scalar && k.Codec != "":
+		return fmt.Errorf("codec %q of scalar value %s", k.Codec, k.Value)
+	case scalar:
+	case k.Codec == "":
+		k.Codec = codecJSON
+	case k.Codec != codecJSON && k.Codec != codecHash:
+		return fmt.Errorf("unknown codec %q", k.Codec)
+	}
+
+	if k.TTL != "" {
+		ttl, err := time.ParseDuration(k.TTL)
+		if err != nil || ttl <= 0 {
+			return fmt.Errorf("invalid ttl %q", k.TTL)
+		}
+
+		k.ttl = ttl
+	}
+
+	segments, err := parsePattern(k.Pattern, separator)
+	if err != nil {
+		return err
+	}
+
+	k.segments = segments
+
+	return nil
+}
+
+// parsePattern splits pattern into segments. A parameter, written as {name}
+// or {name:type}, must be a whole segment, so a parameter value that cannot
+// contain the separator never reaches into the following segments.
+func parsePattern(pattern, separator string) ([]segment, error) {
+	if pattern == "" {
+		return nil, errors.New("empty pattern")
+	}
+
+	parts := splitPattern(pattern, separator)
+	segments := make([]segment, len(parts))
+	params := make(map[string]struct{})
+
+	for i, part := range parts {
+		if !strings.ContainsAny(part, "{}") {
+			segments[i] = segment{literal: part}
+			continue
+		}
+
+		inner, ok := strings.CutPrefix(part, "{")
+		if inner, ok = strings.CutSuffix(inner, "}"); !ok || strings.ContainsAny(inner, "{}") {
+			return nil, fmt.Errorf("parameter %q of pattern %q is not a whole segment", part, pattern)
+		}
+
+		name, typ, _ := strings.Cut(inner, ":")
+		if typ == "" {
+			typ = "string"
+		}
+
+		if _, reserved := reservedParams[name]; reserved || !token.IsIdentifier(name) {
+			return nil, fmt.Errorf("invalid parameter name %q", name)
+		}
+
+		if _, ok = paramTypes[typ]; !ok {
+			return nil, fmt.Errorf("unsupported type %q of parameter %s", typ, name)
+		}
+
+		if _, ok = params[name]; ok {
+			return nil, fmt.Errorf("duplicate parameter %s", name)
+		}
+
+		params[name] = struct{}{}
+		segments[i] = segment{param: name, typ: typ}
+	}
+
+	return segments, nil
+}
+
+// splitPattern splits pattern at the separators outside of braces, which
+// enclose parameters with types such as {id:int64}.
+func splitPattern(pattern, separator string) []string {
+	var (
+		parts []string
+		start int
+		depth int
+	)
+
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == '{':
+			depth++
+		case pattern[i] == '}':
+			depth--
+		case depth == 0 && strings.HasPrefix(pattern[i:], separator):
+			parts = append(parts, pattern[start:i])
+			start = i + len(separator)
+			i = start - 1
+		}
+	}
+
+	return append(parts, pattern[start:])
+}
+
+// overlap reports whether two patterns can build the same key.
+func overlap(a, b []segment) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !a[i].matches(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s segment) matches(other segment) bool {
+	switch {
+	case s.param == "" && other.param == "":
+		return s.literal == other.literal
+	case s.param == "":
+		return other.accepts(s.literal)
+	case other.param == "":
+		return s.accepts(other.literal)
+	default:
+		return true
+	}
+}
+
+// accepts reports whether the parameter segment s can format as literal.
+func (s segment) accepts(literal string) bool {
+	switch s.typ {
+	case "string":
+		return literal != ""
+	case "uint64":
+		n, err := strconv.ParseUint(literal, 10, 64)
+		return err == nil && strconv.FormatUint(n, 10) == literal
+	default:
+		n, err := strconv.ParseInt(literal, 10, 64)
+		return err == nil && strconv.FormatInt(n, 10) == literal
+	}
+}
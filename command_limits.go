@@ -0,0 +1,106 @@
+package xredis
+
+import (
+	"context"
+	"fmt"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// CommandLimitsConfig configures the size limits of commands sent to Redis.
+type CommandLimitsConfig struct {
+	// MaxKeyLength is the maximum length of a key in bytes. Zero disables the
+	// limit.
+	MaxKeyLength int
+
+	// MaxArgs is the maximum number of arguments of a command, including the
+	// command name. Zero disables the limit.
+	MaxArgs int
+}
+
+// commandLimitsHook rejects commands over the limits configured with
+// WithCommandLimits before they reach Redis.
+type commandLimitsHook struct {
+	passDialHook
+
+	limits CommandLimitsConfig
+}
+
+func (h *commandLimitsHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		if h.reject(cmd) {
+			return cmd.Err()
+		}
+
+		return next(ctx, cmd)
+	}
+}
+
+func (h *commandLimitsHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		return rejectPipeline(ctx, cmds, h.reject, next)
+	}
+}
+
+// reject fails cmd with ErrCommandTooLarge when it has too many arguments or
+// a key that is too long.
+func (h *commandLimitsHook) reject(cmd rdb.Cmder) bool {
+	if isConnectionSetupCmd(cmd) {
+		return false
+	}
+
+	args := cmd.Args()
+
+	if h.limits.MaxArgs > 0 && len(args) > h.limits.MaxArgs {
+		cmd.SetErr(fmt.Errorf(
+			"%w: %s has %d arguments, limit is %d",
+			ErrCommandTooLarge, cmd.Name(), len(args), h.limits.MaxArgs,
+		))
+
+		return true
+	}
+
+	if h.limits.MaxKeyLength <= 0 {
+		return false
+	}
+
+	var (
+		long  int
+		found bool
+	)
+
+	forEachKeyArg(cmd, func(i int) {
+		key, ok := args[i].(string)
+		if ok && !found && len(key) > h.limits.MaxKeyLength {
+			long, found = len(key), true
+		}
+	})
+
+	if !found {
+		return false
+	}
+
+	cmd.SetErr(fmt.Errorf(
+		"%w: %s has a key of %d bytes, limit is %d",
+		ErrCommandTooLarge, cmd.Name(), long, h.limits.MaxKeyLength,
+	))
+
+	return true
+}
+
+// keyChunks splits keys of a multi-key command into chunks that stay within
+// the MaxArgs limit of the client.
+func (c *Client) keyChunks(keys []string) [][]string {
+	size := len(keys)
+	if c.limits.MaxArgs > 0 {
+		size = max(c.limits.MaxArgs-1, 1)
+	}
+
+	var chunks [][]string
+
+	for start := 0; start < len(keys); start += size {
+		chunks = append(chunks, keys[start:min(start+size, len(keys))])
+	}
+
+	return chunks
+}
@@ -0,0 +1,96 @@
+package xredis_test
+
+import (
+	"strconv"
+	"strings"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("WithCommandLimits", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		cleanup := newTestClient()
+		Expect(cleanup.Raw().FlushDB(ctx).Err()).To(Succeed())
+		Expect(cleanup.Close()).To(Succeed())
+
+		client = newTestClient(xredis.WithCommandLimits(xredis.CommandLimitsConfig{
+			MaxKeyLength: 16,
+			MaxArgs:      4,
+		}))
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("rejects keys over the length limit", func() {
+		err := client.Set(ctx, strings.Repeat("k", 17), "value", 0)
+		Expect(err).To(MatchError(xredis.ErrCommandTooLarge))
+		Expect(err).To(MatchError(ContainSubstring("17 bytes")))
+
+		Expect(client.Set(ctx, strings.Repeat("k", 16), "value", 0)).To(Succeed())
+	})
+
+	It("rejects commands over the argument limit", func() {
+		err := client.Raw().MGet(ctx, "a", "b", "c", "d").Err()
+		Expect(err).To(MatchError(xredis.ErrCommandTooLarge))
+
+		Expect(client.Raw().MGet(ctx, "a", "b", "c").Err()).To(Succeed())
+	})
+
+	It("fails only the offending commands of a pipeline", func() {
+		var small, large *rdb.IntCmd
+
+		_, err := client.Raw().Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+			small = pipe.Del(ctx, "a", "b")
+			large = pipe.Del(ctx, "a", "b", "c", "d")
+
+			return nil
+		})
+		Expect(err).To(MatchError(xredis.ErrCommandTooLarge))
+		Expect(small.Err()).NotTo(HaveOccurred())
+		Expect(large.Err()).To(MatchError(xredis.ErrCommandTooLarge))
+	})
+
+	It("fails the whole transaction", func() {
+		var small, large *rdb.IntCmd
+
+		_, err := client.Raw().TxPipelined(ctx, func(pipe rdb.Pipeliner) error {
+			small = pipe.Incr(ctx, "limits:tx")
+			large = pipe.Del(ctx, "a", "b", "c", "d")
+
+			return nil
+		})
+		Expect(err).To(MatchError(xredis.ErrCommandTooLarge))
+		Expect(small.Err()).To(MatchError(xredis.ErrCommandTooLarge))
+		Expect(large.Err()).To(MatchError(xredis.ErrCommandTooLarge))
+
+		exists, err := client.Exists(ctx, "limits:tx")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+
+	It("splits the keys of multi-key helpers", func() {
+		keys := make([]string, 10)
+		for i := range keys {
+			keys[i] = "limits:" + strconv.Itoa(i)
+			Expect(client.Set(ctx, keys[i], strconv.Itoa(i), 0)).To(Succeed())
+		}
+
+		Expect(client.ExistsCount(ctx, keys...)).To(Equal(int64(10)))
+
+		values, err := client.MGetOrdered(ctx, keys, func() any { return new(int) })
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(HaveLen(10))
+		Expect(values[9]).To(HaveValue(Equal(9)))
+
+		result, err := client.DeleteMany(ctx, keys)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Deleted).To(Equal(int64(10)))
+	})
+})
@@ -18,19 +18,86 @@ func (c *Client) Exists(ctx context.Context, key string) (bool, error) {
 	return count == 1, nil
 }
 
+// ExistsCount returns how many of keys exist, like the multi-key form of
+// EXISTS: a key given more than once is counted more than once.
+//
+// For standalone Redis, keys are checked with one EXISTS command. For Redis
+// Cluster and Ring clients, keys are checked with single-key EXISTS commands
+// inside a pipeline to avoid multi-key hash-slot constraints, and the first
+// error is returned. With WithCommandLimits, standalone keys are checked with
+// as many EXISTS commands as MaxArgs requires.
+func (c *Client) ExistsCount(ctx context.Context, keys ...string) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	switch c.conn.(type) {
+	case *rdb.ClusterClient, *rdb.Ring:
+	default:
+		var count int64
+
+		for _, chunk := range c.keyChunks(keys) {
+			n, err := c.conn.Exists(ctx, chunk...).Result()
+			if err != nil {
+				return 0, err
+			}
+
+			count += n
+		}
+
+		return count, nil
+	}
+
+	cmds := make([]*rdb.IntCmd, len(keys))
+
+	// Per-command errors are checked below.
+	_, _ = c.conn.Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.Exists(ctx, key)
+		}
+
+		return nil
+	})
+
+	var count int64
+
+	for _, cmd := range cmds {
+		n, err := cmd.Result()
+		if err != nil {
+			return 0, err
+		}
+
+		count += n
+	}
+
+	return count, nil
+}
+
 // HExists returns whether field is an existing field in the hash stored at key.
 func (c *Client) HExists(ctx context.Context, key, field string) (bool, error) {
 	return c.conn.HExists(ctx, key, field).Result()
 }
 
 // HIncrBy increments a hash field and returns the updated value.
+//
+// It is not retried on network errors, so an increment is applied at most once.
 func (c *Client) HIncrBy(ctx context.Context, key, field string, incr int64) (int64, error) {
-	return c.conn.HIncrBy(ctx, key, field, incr).Result()
+	return c.conn.HIncrBy(withNoRetry(ctx), key, field, incr).Result()
+}
+
+// HIncrByFloat increments a hash field by a floating-point value and returns the updated value.
+//
+// It is not retried on network errors, so an increment is applied at most once.
+func (c *Client) HIncrByFloat(ctx context.Context, key, field string, incr float64) (float64, error) {
+	return c.conn.HIncrByFloat(withNoRetry(ctx), key, field, incr).Result()
 }
 
 // HGetAll returns all fields and values of the hash stored at key and scans the result into dst.
 //
-// It returns ok=false when the hash does not exist or has no fields.
+// It returns ok=false when the hash does not exist or has no fields. Fields
+// promoted from embedded structs are scanned like HSet writes them, and nil
+// embedded pointers are allocated when they receive a field. Fields that dst
+// does not declare are handled as configured with WithUnknownHashFields.
 func (c *Client) HGetAll(ctx context.Context, key string, dst any) (bool, error) {
 	if dst == nil {
 		return false, ErrInvalidHashObject
@@ -45,7 +112,7 @@ func (c *Client) HGetAll(ctx context.Context, key string, dst any) (bool, error)
 		return false, nil
 	}
 
-	if err := res.Scan(dst); err != nil {
+	if err := c.scanHash(ctx, key, res, dst); err != nil {
 		return false, err
 	}
 
@@ -70,6 +137,9 @@ func (c *Client) HGet(ctx context.Context, key, field string) (string, bool, err
 
 // HSet sets hash fields and optionally applies TTL to the hash key.
 //
+// It returns the number of fields that were added; updated fields are not
+// counted.
+//
 // values is passed to go-redis HSet, so it supports the same input formats:
 // flat field-value pairs, slices, maps, structs, and pointers to structs.
 //
@@ -79,40 +149,116 @@ func (c *Client) HGet(ctx context.Context, key, field string) (string, bool, err
 //	HSet(ctx, "user:42", time.Hour, map[string]any{"name": "Bob", "age": 30})
 //	HSet(ctx, "user:42", time.Hour, UserHash{Name: "Bob"})
 //
-// Struct values are parsed by go-redis using redis tags.
+// Struct values are parsed by go-redis using redis tags. The tagged fields of
+// untagged embedded structs are promoted into the hash like encoding/json
+// promotes them, so shared base structs need no duplicated fields.
 //
 // ttl < 0 returns ErrInvalidTTL.
 // ttl == 0 leaves the hash expiration unchanged.
-// ttl > 0 applies the expiration to the hash key after HSET.
-func (c *Client) HSet(ctx context.Context, key string, ttl time.Duration, values ...any) error {
+// ttl > 0 applies the expiration to the hash key after HSET, replacing any
+// existing expiration. Use HSetExpire to keep an existing expiration.
+func (c *Client) HSet(ctx context.Context, key string, ttl time.Duration, values ...any) (int64, error) {
+	return c.HSetExpire(ctx, key, ttl, ExpireAlways, values...)
+}
+
+// HSetExpire sets hash fields like HSet and applies TTL according to mode.
+// It returns the number of fields that were added.
+//
+// With ExpireNX, ttl is applied only when the hash has no expiration, so the
+// first write defines the hash lifetime and later writes do not extend it.
+// ExpireNX is implemented with a Lua script and works with Redis versions
+// without EXPIRE NX. With ExpireNever, the expiration is never changed.
+//
+// HSET and the expiration are executed in one transaction.
+//
+// ttl < 0 or an unknown mode returns ErrInvalidTTL.
+func (c *Client) HSetExpire(
+	ctx context.Context,
+	key string,
+	ttl time.Duration,
+	mode ExpireMode,
+	values ...any,
+) (int64, error) {
 	if ttl < 0 {
-		return ErrInvalidTTL
+		return 0, ErrInvalidTTL
+	}
+
+	if err := validateExpireMode(mode); err != nil {
+		return 0, err
 	}
 
 	if len(values) == 0 {
-		return ErrInvalidHashObject
+		return 0, ErrInvalidHashObject
 	}
 
-	if ttl == 0 {
-		return c.conn.HSet(ctx, key, values...).Err()
+	values, err := flattenHashValues(values, c.converters)
+	if err != nil {
+		return 0, err
+	}
+
+	if ttl == 0 || mode == ExpireNever {
+		return c.conn.HSet(ctx, key, values...).Result()
 	}
 
 	pipe := c.conn.TxPipeline()
-	pipe.HSet(ctx, key, values...)
-	pipe.Expire(ctx, key, ttl)
+	added := pipe.HSet(ctx, key, values...)
+	appendExpire(ctx, pipe, key, ttl, mode)
 
 	cmders, err := pipe.Exec(ctx)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	for _, cmd := range cmders {
 		if err = cmd.Err(); err != nil {
-			return err
+			return 0, err
 		}
 	}
 
-	return nil
+	return added.Val(), nil
+}
+
+// HSetMap sets all fields of the map in the hash stored at key with one HSET
+// command and optionally applies TTL to the hash key.
+//
+// It returns the number of fields that were added. Empty fields returns
+// ErrInvalidHashObject. TTL follows HSet rules.
+func (c *Client) HSetMap(ctx context.Context, key string, fields map[string]any, ttl time.Duration) (int64, error) {
+	if len(fields) == 0 {
+		return 0, ErrInvalidHashObject
+	}
+
+	return c.HSet(ctx, key, ttl, fields)
+}
+
+// HashField is one hash field and its value.
+type HashField struct {
+	// Name is the hash field name.
+	Name string
+
+	// Value is passed directly to Redis without Codec encoding.
+	Value any
+}
+
+// HSetFields sets hash fields with one HSET command and optionally applies TTL
+// to the hash key.
+//
+// Unlike HSet, fields are typed pairs, so an odd number of flat arguments
+// cannot be passed by mistake.
+//
+// It returns the number of fields that were added. Empty fields returns
+// ErrInvalidHashObject. TTL follows HSet rules.
+func (c *Client) HSetFields(ctx context.Context, key string, ttl time.Duration, fields ...HashField) (int64, error) {
+	if len(fields) == 0 {
+		return 0, ErrInvalidHashObject
+	}
+
+	values := make([]any, 0, len(fields)*2)
+	for _, field := range fields {
+		values = append(values, field.Name, field.Value)
+	}
+
+	return c.HSet(ctx, key, ttl, values...)
 }
 
 // HDel deletes fields from the hash stored at key.
@@ -139,7 +285,8 @@ func (c *Client) Get(ctx context.Context, key string, dst any) (bool, error) {
 
 // GetDel reads the value stored at key and atomically deletes the key.
 //
-// It returns ok=false when the key does not exist.
+// It returns ok=false when the key does not exist. Keys in a namespace
+// configured with WithNamespaceQuota are released from its budget.
 func (c *Client) GetDel(ctx context.Context, key string) (string, bool, error) {
 	value, err := c.conn.GetDel(ctx, key).Result()
 	if err != nil {
@@ -150,6 +297,10 @@ func (c *Client) GetDel(ctx context.Context, key string) (string, bool, error) {
 		return "", false, err
 	}
 
+	if err = c.releaseQuotas(ctx, []string{key}, nil); err != nil {
+		return value, true, err
+	}
+
 	return value, true, nil
 }
 
@@ -204,7 +355,8 @@ func (c *Client) GetStruct(ctx context.Context, key string, dst any) (bool, erro
 // GetStructDel reads an encoded value, atomically deletes the key,
 // and decodes the value into dst.
 //
-// It returns ok=false when the key does not exist.
+// It returns ok=false when the key does not exist. Namespace quotas are
+// released as for GetDel.
 func (c *Client) GetStructDel(ctx context.Context, key string, dst any) (bool, error) {
 	data, err := c.conn.GetDel(ctx, key).Bytes()
 	if err != nil {
@@ -215,6 +367,10 @@ func (c *Client) GetStructDel(ctx context.Context, key string, dst any) (bool, e
 		return false, err
 	}
 
+	if err = c.releaseQuotas(ctx, []string{key}, nil); err != nil {
+		return false, err
+	}
+
 	if err = c.codec.Unmarshal(data, dst); err != nil {
 		return false, err
 	}
@@ -257,42 +413,64 @@ func (c *Client) GetStructEx(
 }
 
 // Set executes Redis SET command.
+//
+// Keys in a namespace configured with WithNamespaceQuota are counted against
+// its budget, and writes beyond it fail with ErrNamespaceQuotaExceeded.
 func (c *Client) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
 	if ttl < 0 {
 		return ErrInvalidTTL
 	}
 
-	return c.conn.Set(ctx, key, value, ttl).Err()
+	_, err := c.quotaWrite(ctx, key, value, ttl, func() (bool, error) {
+		return true, c.conn.Set(ctx, key, value, ttl).Err()
+	})
+
+	return err
 }
 
 // SetNX sets key to value only when key does not exist.
 //
-// It returns ok=false when the key already exists.
+// It returns ok=false when the key already exists. Namespace quotas apply as
+// for Set.
 func (c *Client) SetNX(ctx context.Context, key string, value any, ttl time.Duration) (bool, error) {
 	if ttl < 0 {
 		return false, ErrInvalidTTL
 	}
 
-	return c.conn.SetNX(ctx, key, value, ttl).Result()
+	return c.quotaWrite(ctx, key, value, ttl, func() (bool, error) {
+		return c.conn.SetNX(ctx, key, value, ttl).Result()
+	})
 }
 
 // SetXX sets key to value only when key already exists.
 //
-// It returns ok=false when the key does not exist.
+// It returns ok=false when the key does not exist. Namespace quotas apply as
+// for Set.
 func (c *Client) SetXX(ctx context.Context, key string, value any, ttl time.Duration) (bool, error) {
 	if ttl < 0 {
 		return false, ErrInvalidTTL
 	}
 
-	return c.conn.SetXX(ctx, key, value, ttl).Result()
+	return c.quotaWrite(ctx, key, value, ttl, func() (bool, error) {
+		return c.conn.SetXX(ctx, key, value, ttl).Result()
+	})
 }
 
 // SetStruct marshals value and stores it using Redis SET command.
+//
+// ttl == 0 applies the TTL declared by the type of value, with TTLer or a
+// ttl struct tag, and stores values that declare none without expiration.
+// Namespace quotas apply as for Set.
 func (c *Client) SetStruct(ctx context.Context, key string, value any, ttl time.Duration) error {
 	if ttl < 0 {
 		return ErrInvalidTTL
 	}
 
+	ttl, err := defaultStructTTL(value, ttl)
+	if err != nil {
+		return err
+	}
+
 	data, err := c.codec.Marshal(value)
 	if err != nil {
 		return err
@@ -303,12 +481,18 @@ func (c *Client) SetStruct(ctx context.Context, key string, value any, ttl time.
 
 // SetStructNX marshals value and stores it only when key does not exist.
 //
-// It returns ok=false when the key already exists.
+// It returns ok=false when the key already exists. ttl == 0 applies the TTL
+// declared by value, and namespace quotas apply, as for SetStruct.
 func (c *Client) SetStructNX(ctx context.Context, key string, value any, ttl time.Duration) (bool, error) {
 	if ttl < 0 {
 		return false, ErrInvalidTTL
 	}
 
+	ttl, err := defaultStructTTL(value, ttl)
+	if err != nil {
+		return false, err
+	}
+
 	data, err := c.codec.Marshal(value)
 	if err != nil {
 		return false, err
@@ -319,12 +503,18 @@ func (c *Client) SetStructNX(ctx context.Context, key string, value any, ttl tim
 
 // SetStructXX marshals value and stores it only when key already exists.
 //
-// It returns ok=false when the key does not exist.
+// It returns ok=false when the key does not exist. ttl == 0 applies the TTL
+// declared by value, and namespace quotas apply, as for SetStruct.
 func (c *Client) SetStructXX(ctx context.Context, key string, value any, ttl time.Duration) (bool, error) {
 	if ttl < 0 {
 		return false, ErrInvalidTTL
 	}
 
+	ttl, err := defaultStructTTL(value, ttl)
+	if err != nil {
+		return false, err
+	}
+
 	data, err := c.codec.Marshal(value)
 	if err != nil {
 		return false, err
@@ -333,6 +523,55 @@ func (c *Client) SetStructXX(ctx context.Context, key string, value any, ttl tim
 	return c.SetXX(ctx, key, data, ttl)
 }
 
+// GetSetStruct marshals value, stores it, and decodes the value it replaced
+// into prev in one SET ... GET command.
+//
+// ttl == 0 applies the TTL declared by value, as for SetStruct, and ttl > 0
+// applies the given expiration; ttl < 0 returns ErrInvalidTTL. It returns ok=false, leaving prev
+// unchanged, when the key did not exist. Namespace quotas apply as for Set.
+func (c *Client) GetSetStruct(ctx context.Context, key string, value, prev any, ttl time.Duration) (bool, error) {
+	if ttl < 0 {
+		return false, ErrInvalidTTL
+	}
+
+	ttl, err := defaultStructTTL(value, ttl)
+	if err != nil {
+		return false, err
+	}
+
+	data, err := c.codec.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+
+	var (
+		old     []byte
+		existed bool
+	)
+
+	_, err = c.quotaWrite(ctx, key, data, ttl, func() (bool, error) {
+		var getErr error
+
+		old, getErr = c.conn.SetArgs(ctx, key, data, rdb.SetArgs{TTL: ttl, Get: true}).Bytes()
+		if errors.Is(getErr, rdb.Nil) {
+			return true, nil
+		}
+
+		existed = getErr == nil
+
+		return true, getErr
+	})
+	if err != nil || !existed {
+		return false, err
+	}
+
+	if err = c.codec.Unmarshal(old, prev); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 // Bool reads a Redis string value as bool.
 func (c *Client) Bool(ctx context.Context, key string) (val, ok bool, err error) {
 	res := c.conn.Get(ctx, key)
@@ -439,16 +678,34 @@ func (c *Client) String(ctx context.Context, key string) (val string, ok bool, e
 }
 
 // Incr increments an integer value and returns the updated value.
+//
+// It is not retried on network errors, so an increment is applied at most once.
 func (c *Client) Incr(ctx context.Context, key string) (int64, error) {
-	return c.conn.Incr(ctx, key).Result()
+	return c.conn.Incr(withNoRetry(ctx), key).Result()
 }
 
 // Decr decrements an integer value and returns the updated value.
+//
+// It is not retried on network errors, so a decrement is applied at most once.
 func (c *Client) Decr(ctx context.Context, key string) (int64, error) {
-	return c.conn.Decr(ctx, key).Result()
+	return c.conn.Decr(withNoRetry(ctx), key).Result()
 }
 
 // Delete deletes key.
-func (c *Client) Delete(ctx context.Context, key string) error {
-	return c.conn.Del(ctx, key).Err()
+//
+// It returns ok=false when the key did not exist. Keys in a namespace
+// configured with WithNamespaceQuota are released from its budget.
+func (c *Client) Delete(ctx context.Context, key string) (bool, error) {
+	deleted, err := c.conn.Del(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+
+	if quota, ok := c.namespaceQuota(key); ok {
+		if err = c.releaseQuota(ctx, quota.Namespace, key); err != nil {
+			return deleted > 0, err
+		}
+	}
+
+	return deleted > 0, nil
 }
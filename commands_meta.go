@@ -0,0 +1,342 @@
+package xredis
+
+import (
+	"strconv"
+	"strings"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// writeCommands contains Redis commands that modify data or publish messages.
+//
+// Script commands are listed separately because whether they write depends on
+// the script body.
+var writeCommands = map[string]struct{}{
+	// Keyspace.
+	"del": {}, "unlink": {}, "expire": {}, "expireat": {}, "pexpire": {}, "pexpireat": {},
+	"persist": {}, "rename": {}, "renamenx": {}, "copy": {}, "move": {}, "restore": {},
+	"migrate": {}, "flushdb": {}, "flushall": {}, "swapdb": {},
+
+	// Strings.
+	"set": {}, "setnx": {}, "setex": {}, "psetex": {}, "mset": {}, "msetnx": {},
+	"getset": {}, "getdel": {}, "getex": {}, "append": {}, "setrange": {},
+	"incr": {}, "incrby": {}, "incrbyfloat": {}, "decr": {}, "decrby": {},
+	"setbit": {}, "bitfield": {}, "bitop": {},
+
+	// Hashes.
+	"hset": {}, "hsetnx": {}, "hmset": {}, "hdel": {}, "hincrby": {}, "hincrbyfloat": {},
+	"hexpire": {}, "hpexpire": {}, "hexpireat": {}, "hpexpireat": {}, "hpersist": {},
+	"hgetdel": {}, "hgetex": {}, "hsetex": {},
+
+	// Lists.
+	"lpush": {}, "rpush": {}, "lpushx": {}, "rpushx": {}, "lpop": {}, "rpop": {},
+	"linsert": {}, "lset": {}, "lrem": {}, "ltrim": {}, "rpoplpush": {}, "lmove": {},
+	"blpop": {}, "brpop": {}, "brpoplpush": {}, "blmove": {}, "lmpop": {}, "blmpop": {},
+
+	// Sets.
+	"sadd": {}, "srem": {}, "spop": {}, "smove": {}, "sinterstore": {}, "sunionstore": {},
+	"sdiffstore": {},
+
+	// Sorted sets.
+	"zadd": {}, "zincrby": {}, "zrem": {}, "zremrangebyrank": {}, "zremrangebyscore": {},
+	"zremrangebylex": {}, "zpopmin": {}, "zpopmax": {}, "bzpopmin": {}, "bzpopmax": {},
+	"zmpop": {}, "bzmpop": {}, "zunionstore": {}, "zinterstore": {}, "zdiffstore": {},
+	"zrangestore": {},
+
+	// Streams.
+	"xadd": {}, "xdel": {}, "xtrim": {}, "xgroup": {}, "xack": {}, "xclaim": {},
+	"xautoclaim": {}, "xreadgroup": {}, "xsetid": {},
+
+	// HyperLogLog and geo.
+	"pfadd": {}, "pfmerge": {}, "geoadd": {}, "geosearchstore": {},
+
+	// Pub/Sub.
+	"publish": {}, "spublish": {},
+}
+
+// popCommands contains write commands that reply with nil when there is
+// nothing to pop.
+var popCommands = map[string]struct{}{
+	"lpop": {}, "rpop": {}, "spop": {}, "rpoplpush": {}, "lmove": {}, "lmpop": {}, "zmpop": {},
+	"blpop": {}, "brpop": {}, "brpoplpush": {}, "blmove": {}, "blmpop": {}, "bzpopmin": {},
+	"bzpopmax": {}, "bzmpop": {},
+}
+
+// subscribeCommands contains commands that turn a connection into a Pub/Sub
+// connection.
+var subscribeCommands = map[string]struct{}{
+	"subscribe": {}, "psubscribe": {}, "ssubscribe": {},
+}
+
+// scriptCommands contains commands that run server-side scripts and functions
+// that may write data.
+var scriptCommands = map[string]struct{}{
+	"eval": {}, "evalsha": {}, "fcall": {},
+}
+
+// readCommands contains Redis commands that read values stored at their
+// keys. Metadata commands, such as TTL and TYPE, are not reads in this sense.
+var readCommands = map[string]struct{}{
+	// Strings.
+	"get": {}, "getex": {}, "getrange": {}, "strlen": {}, "getbit": {},
+
+	// Hashes.
+	"hget": {}, "hmget": {}, "hgetall": {}, "hexists": {}, "hkeys": {}, "hvals": {},
+	"hlen": {}, "hstrlen": {}, "hrandfield": {}, "hgetex": {},
+
+	// Lists.
+	"lrange": {}, "lindex": {}, "llen": {}, "lpos": {},
+
+	// Sets.
+	"smembers": {}, "sismember": {}, "smismember": {}, "scard": {}, "srandmember": {},
+	"sscan": {},
+
+	// Sorted sets.
+	"zrange": {}, "zrangebyscore": {}, "zrangebylex": {}, "zrevrange": {},
+	"zrevrangebyscore": {}, "zscore": {}, "zmscore": {}, "zcard": {}, "zcount": {},
+	"zrank": {}, "zrevrank": {}, "zscan": {},
+
+	// Streams.
+	"xrange": {}, "xrevrange": {}, "xlen": {},
+
+	// HyperLogLog.
+	"pfcount": {},
+}
+
+// multiKeyReadCommands contains read commands whose arguments are all keys.
+var multiKeyReadCommands = map[string]struct{}{
+	"mget": {}, "exists": {},
+}
+
+// isWriteCmd reports whether cmd modifies data.
+func isWriteCmd(cmd rdb.Cmder) bool {
+	_, ok := writeCommands[cmd.Name()]
+	return ok
+}
+
+// isScriptCmd reports whether cmd runs a script or function that may write data.
+//
+// Read-only variants, such as EVAL_RO and FCALL_RO, are not script commands
+// in this sense.
+func isScriptCmd(cmd rdb.Cmder) bool {
+	_, ok := scriptCommands[cmd.Name()]
+	return ok
+}
+
+// cmdFirstKey returns the first argument after the command name, which is the
+// key for most data commands.
+func cmdFirstKey(cmd rdb.Cmder) (string, bool) {
+	args := cmd.Args()
+	if len(args) < 2 {
+		return "", false
+	}
+
+	key, ok := args[1].(string)
+
+	return key, ok
+}
+
+// cmdReadKeys returns the keys read by cmd, or nil when cmd is not a read
+// command.
+func cmdReadKeys(cmd rdb.Cmder) []string {
+	name := cmd.Name()
+
+	if _, ok := multiKeyReadCommands[name]; ok {
+		args := cmd.Args()
+		keys := make([]string, 0, len(args)-1)
+
+		for _, arg := range args[1:] {
+			if key, ok := arg.(string); ok {
+				keys = append(keys, key)
+			}
+		}
+
+		return keys
+	}
+
+	if _, ok := readCommands[name]; !ok {
+		return nil
+	}
+
+	key, ok := cmdFirstKey(cmd)
+	if !ok {
+		return nil
+	}
+
+	return []string{key}
+}
+
+// allKeyCommands contains commands whose arguments are all keys.
+var allKeyCommands = map[string]struct{}{
+	"del": {}, "unlink": {}, "exists": {}, "touch": {}, "mget": {}, "watch": {},
+	"sinter": {}, "sunion": {}, "sdiff": {}, "sinterstore": {}, "sunionstore": {},
+	"sdiffstore": {}, "pfcount": {}, "pfmerge": {},
+}
+
+// timeoutKeyCommands contains blocking commands whose arguments are keys
+// followed by a timeout.
+var timeoutKeyCommands = map[string]struct{}{
+	"blpop": {}, "brpop": {}, "bzpopmin": {}, "bzpopmax": {},
+}
+
+// pairKeyCommands contains commands with alternating key and value arguments.
+var pairKeyCommands = map[string]struct{}{
+	"mset": {}, "msetnx": {},
+}
+
+// twoKeyCommands contains commands whose first two arguments are keys.
+var twoKeyCommands = map[string]struct{}{
+	"rename": {}, "renamenx": {}, "copy": {}, "lmove": {}, "blmove": {}, "smove": {},
+	"rpoplpush": {}, "brpoplpush": {}, "zrangestore": {}, "geosearchstore": {}, "lcs": {},
+}
+
+// storeKeyCommands contains commands that take a key and store their result
+// at the key following a STORE or STOREDIST argument.
+var storeKeyCommands = map[string]struct{}{
+	"sort": {}, "georadius": {}, "georadiusbymember": {},
+}
+
+// subcommandKeyCommands contains commands whose listed subcommands take a key
+// after the subcommand. Other subcommands take no key.
+var subcommandKeyCommands = map[string]map[string]struct{}{
+	"xgroup": {"create": {}, "setid": {}, "destroy": {}, "createconsumer": {}, "delconsumer": {}},
+	"xinfo":  {"stream": {}, "groups": {}, "consumers": {}},
+	"object": {"encoding": {}, "freq": {}, "idletime": {}, "refcount": {}},
+	"memory": {"usage": {}},
+}
+
+// keylessCommands contains commands whose first argument is not a key.
+var keylessCommands = map[string]struct{}{
+	"publish": {}, "spublish": {}, "subscribe": {}, "ssubscribe": {}, "psubscribe": {},
+	"unsubscribe": {}, "sunsubscribe": {}, "punsubscribe": {}, "pubsub": {},
+	"scan": {}, "keys": {}, "client": {}, "config": {}, "script": {}, "function": {},
+
+	// Server and connection commands.
+	"ping": {}, "echo": {}, "info": {}, "cluster": {}, "command": {}, "hello": {},
+	"auth": {}, "select": {}, "readonly": {}, "readwrite": {}, "dbsize": {}, "time": {},
+	"role": {}, "slowlog": {}, "latency": {}, "acl": {}, "wait": {}, "waitaof": {},
+	"multi": {}, "exec": {}, "discard": {}, "unwatch": {}, "flushdb": {}, "flushall": {},
+	"swapdb": {},
+}
+
+// numKeysCommands maps commands that declare their key count to the index of
+// the count argument. The keys follow the count.
+var numKeysCommands = map[string]int{
+	"eval": 2, "evalsha": 2, "eval_ro": 2, "evalsha_ro": 2, "fcall": 2, "fcall_ro": 2,
+	"zunionstore": 2, "zinterstore": 2, "zdiffstore": 2,
+	"zunion": 1, "zinter": 1, "zdiff": 1, "zintercard": 1, "sintercard": 1,
+	"lmpop": 1, "zmpop": 1, "blmpop": 2, "bzmpop": 2,
+}
+
+// destNumKeysCommands contains numKeysCommands that store their result at
+// the key before the count.
+var destNumKeysCommands = map[string]struct{}{
+	"zunionstore": {}, "zinterstore": {}, "zdiffstore": {},
+}
+
+// forEachKeyArg calls fn with the index of every key argument of cmd.
+//
+// Commands not listed in the key position sets take one key as their first
+// argument.
+func forEachKeyArg(cmd rdb.Cmder, fn func(i int)) {
+	args := cmd.Args()
+	if len(args) < 2 {
+		return
+	}
+
+	name := cmd.Name()
+
+	each := func(from, to, step int) {
+		for i := from; i < to; i += step {
+			fn(i)
+		}
+	}
+
+	switch {
+	case hasCommand(keylessCommands, name):
+		return
+	case hasCommand(allKeyCommands, name):
+		each(1, len(args), 1)
+	case hasCommand(timeoutKeyCommands, name):
+		each(1, len(args)-1, 1)
+	case hasCommand(pairKeyCommands, name):
+		each(1, len(args), 2)
+	case hasCommand(twoKeyCommands, name):
+		each(1, min(len(args), 3), 1)
+	case hasCommand(storeKeyCommands, name):
+		fn(1)
+
+		for i := 2; i < len(args)-1; i++ {
+			switch strings.ToLower(argString(args[i])) {
+			case "store", "storedist":
+				fn(i + 1)
+				i++
+			}
+		}
+	case name == "bitop":
+		// BITOP operation destkey key [key ...]
+		each(2, len(args), 1)
+	case name == "xread" || name == "xreadgroup":
+		// The STREAMS argument is followed by the keys and then one ID per key.
+		for i := 1; i < len(args); i++ {
+			if strings.EqualFold(argString(args[i]), "streams") {
+				each(i+1, i+1+(len(args)-i-1)/2, 1)
+
+				return
+			}
+		}
+	case name == "migrate":
+		// MIGRATE host port key|"" destination-db timeout [... KEYS key [key ...]]
+		if len(args) > 3 && argString(args[3]) != "" {
+			fn(3)
+		}
+
+		for i := 6; i < len(args); i++ {
+			if strings.EqualFold(argString(args[i]), "keys") {
+				each(i+1, len(args), 1)
+
+				return
+			}
+		}
+	case subcommandKeyCommands[name] != nil:
+		if _, ok := subcommandKeyCommands[name][strings.ToLower(argString(args[1]))]; ok && len(args) > 2 {
+			fn(2)
+		}
+	case numKeysCommands[name] > 0:
+		pos := numKeysCommands[name]
+		if len(args) <= pos {
+			return
+		}
+
+		if hasCommand(destNumKeysCommands, name) {
+			fn(1)
+		}
+
+		n, err := strconv.Atoi(argString(args[pos]))
+		if err != nil {
+			return
+		}
+
+		each(pos+1, min(len(args), pos+1+n), 1)
+	default:
+		each(1, 2, 1)
+	}
+}
+
+func hasCommand(set map[string]struct{}, name string) bool {
+	_, ok := set[name]
+	return ok
+}
+
+func argString(arg any) string {
+	switch arg := arg.(type) {
+	case string:
+		return arg
+	case int:
+		return strconv.Itoa(arg)
+	case int64:
+		return strconv.FormatInt(arg, 10)
+	default:
+		return ""
+	}
+}
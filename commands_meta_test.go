@@ -0,0 +1,53 @@
+package xredis
+
+import (
+	"context"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("forEachKeyArg", func() {
+	DescribeTable("finds the key arguments of commands",
+		func(args []any, keys []string) {
+			cmd := rdb.NewCmd(context.Background(), args...)
+
+			found := []string{}
+			forEachKeyArg(cmd, func(i int) {
+				found = append(found, args[i].(string))
+			})
+
+			Expect(found).To(Equal(keys))
+		},
+		Entry("single key", []any{"get", "a"}, []string{"a"}),
+		Entry("keyless", []any{"publish", "events", "message"}, []string{}),
+		Entry("all keys", []any{"del", "a", "b"}, []string{"a", "b"}),
+		Entry("store of sets", []any{"sunionstore", "dst", "a", "b"}, []string{"dst", "a", "b"}),
+		Entry("merge of HyperLogLogs", []any{"pfmerge", "dst", "a", "b"}, []string{"dst", "a", "b"}),
+		Entry("blocking pop", []any{"blpop", "a", "b", 1}, []string{"a", "b"}),
+		Entry("blocking sorted set pop", []any{"bzpopmin", "a", "b", "0.5"}, []string{"a", "b"}),
+		Entry("key value pairs", []any{"mset", "a", "1", "b", "2"}, []string{"a", "b"}),
+		Entry("two keys", []any{"lmove", "a", "b", "left", "right"}, []string{"a", "b"}),
+		Entry("range store", []any{"zrangestore", "dst", "src", 0, -1}, []string{"dst", "src"}),
+		Entry("sort store", []any{"sort", "a", "limit", 0, 10, "store", "dst"}, []string{"a", "dst"}),
+		Entry("geo radius store", []any{"georadius", "a", 1, 2, 3, "km", "storedist", "dst"}, []string{"a", "dst"}),
+		Entry("bit operation", []any{"bitop", "and", "dst", "a", "b"}, []string{"dst", "a", "b"}),
+		Entry("stream read", []any{"xread", "count", 1, "streams", "a", "b", "0", "0"}, []string{"a", "b"}),
+		Entry("stream group read", []any{"xreadgroup", "group", "g", "c", "streams", "a", ">"}, []string{"a"}),
+		Entry("stream group create", []any{"xgroup", "create", "a", "g", "$"}, []string{"a"}),
+		Entry("stream group help", []any{"xgroup", "help"}, []string{}),
+		Entry("stream groups info", []any{"xinfo", "groups", "a"}, []string{"a"}),
+		Entry("stream consumers info", []any{"xinfo", "consumers", "a", "g"}, []string{"a"}),
+		Entry("object encoding", []any{"object", "encoding", "a"}, []string{"a"}),
+		Entry("memory usage", []any{"memory", "usage", "a"}, []string{"a"}),
+		Entry("memory stats", []any{"memory", "stats"}, []string{}),
+		Entry("sorted set store", []any{"zunionstore", "dst", 2, "a", "b", "weights", 1, 2}, []string{"dst", "a", "b"}),
+		Entry("list multi pop", []any{"lmpop", 2, "a", "b", "left", "count", 1}, []string{"a", "b"}),
+		Entry("blocking sorted set multi pop", []any{"bzmpop", "0.5", 2, "a", "b", "min"}, []string{"a", "b"}),
+		Entry("set intersection count", []any{"sintercard", 2, "a", "b", "limit", 1}, []string{"a", "b"}),
+		Entry("script", []any{"evalsha", "sha", 2, "a", "b", "arg"}, []string{"a", "b"}),
+		Entry("migrate", []any{"migrate", "host", "6379", "a", 0, 1000}, []string{"a"}),
+		Entry("migrate of many keys", []any{"migrate", "host", "6379", "", 0, 1000, "keys", "a", "b"}, []string{"a", "b"}),
+	)
+})
@@ -14,6 +14,24 @@ type testProfile struct {
 	Active bool   `json:"active"`
 }
 
+type testSession struct {
+	_ struct{} `redis:"ttl=15m"`
+
+	UserID string `json:"user_id"`
+}
+
+type testToken struct {
+	Value string `json:"value"`
+}
+
+func (testToken) RedisTTL() time.Duration {
+	return time.Hour
+}
+
+type testInvalidTTL struct {
+	_ struct{} `redis:"ttl=soon"`
+}
+
 type testUserHash struct {
 	ID      string `redis:"id"`
 	Name    string `redis:"name"`
@@ -21,6 +39,23 @@ type testUserHash struct {
 	Ignored string `redis:"-"`
 }
 
+type TestAudit struct {
+	CreatedBy string `redis:"created_by"`
+	UpdatedBy string `redis:"updated_by,omitempty"`
+	Name      string `redis:"name"`
+}
+
+type TestRevision struct {
+	Revision int `redis:"revision"`
+}
+
+type testAuditedHash struct {
+	TestAudit
+	*TestRevision
+
+	Name string `redis:"name"`
+}
+
 var _ = Describe("Commands", func() {
 	var client *xredis.Client
 
@@ -46,10 +81,23 @@ var _ = Describe("Commands", func() {
 			Expect(exists).To(BeTrue())
 		})
 
+		It("counts existing keys like multi-key EXISTS", func() {
+			count, err := client.ExistsCount(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(BeZero())
+
+			Expect(client.Set(ctx, "first", "value", 0)).To(Succeed())
+			Expect(client.Set(ctx, "second", "value", 0)).To(Succeed())
+
+			count, err = client.ExistsCount(ctx, "first", "second", "missing", "first")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(3)))
+		})
+
 		It("deletes a key", func() {
 			Expect(client.Set(ctx, "key", "value", 0)).To(Succeed())
-			Expect(client.Delete(ctx, "key")).To(Succeed())
-			Expect(client.Delete(ctx, "key")).To(Succeed())
+			Expect(client.Delete(ctx, "key")).To(BeTrue())
+			Expect(client.Delete(ctx, "key")).To(BeFalse())
 
 			exists, err := client.Exists(ctx, "key")
 			Expect(err).NotTo(HaveOccurred())
@@ -206,6 +254,32 @@ var _ = Describe("Commands", func() {
 			Expect(actual).To(Equal(second))
 		})
 
+		It("replaces a struct and returns the previous one", func() {
+			first := testProfile{ID: "42", Name: "Ada"}
+			second := testProfile{ID: "42", Name: "Grace"}
+
+			var previous testProfile
+			ok, err := client.GetSetStruct(ctx, "profile", first, &previous, time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+			Expect(previous).To(Equal(testProfile{}))
+
+			ok, err = client.GetSetStruct(ctx, "profile", second, &previous, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(previous).To(Equal(first))
+
+			var actual testProfile
+			ok, err = client.GetStruct(ctx, "profile", &actual)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(actual).To(Equal(second))
+
+			ttl, err := client.Raw().TTL(ctx, "profile").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ttl).To(Equal(time.Duration(-1)))
+		})
+
 		It("gets and deletes a struct atomically", func() {
 			expected := testProfile{ID: "42", Name: "Ada"}
 			Expect(client.SetStruct(ctx, "profile", expected, 0)).To(Succeed())
@@ -236,6 +310,38 @@ var _ = Describe("Commands", func() {
 			Expect(ttl).To(BeNumerically(">", 0))
 		})
 
+		It("applies the TTL declared by the struct type", func() {
+			Expect(client.SetStruct(ctx, "session", testSession{UserID: "42"}, 0)).To(Succeed())
+			Expect(client.SetStruct(ctx, "token", &testToken{Value: "secret"}, 0)).To(Succeed())
+			Expect(client.SetStruct(ctx, "override", testSession{UserID: "42"}, time.Minute)).To(Succeed())
+
+			ttl, err := client.Raw().TTL(ctx, "session").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ttl).To(BeNumerically("~", 15*time.Minute, time.Minute))
+
+			ttl, err = client.Raw().TTL(ctx, "token").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ttl).To(BeNumerically("~", time.Hour, time.Minute))
+
+			ttl, err = client.Raw().TTL(ctx, "override").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ttl).To(BeNumerically("<=", time.Minute))
+
+			var actual testSession
+			ok, err := client.GetStruct(ctx, "session", &actual)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(actual.UserID).To(Equal("42"))
+		})
+
+		It("rejects invalid TTL tags", func() {
+			Expect(client.SetStruct(ctx, "invalid", testInvalidTTL{}, 0)).
+				To(MatchError(xredis.ErrInvalidTTL))
+
+			_, err := client.SetStructNX(ctx, "invalid", &testInvalidTTL{}, 0)
+			Expect(err).To(MatchError(xredis.ErrInvalidTTL))
+		})
+
 		It("rejects negative TTLs for encoded writes", func() {
 			profile := testProfile{ID: "42"}
 
@@ -247,6 +353,9 @@ var _ = Describe("Commands", func() {
 
 			_, err = client.SetStructXX(ctx, "profile", profile, -time.Second)
 			Expect(err).To(MatchError(xredis.ErrInvalidTTL))
+
+			_, err = client.GetSetStruct(ctx, "profile", profile, &profile, -time.Second)
+			Expect(err).To(MatchError(xredis.ErrInvalidTTL))
 		})
 	})
 
@@ -258,7 +367,7 @@ var _ = Describe("Commands", func() {
 				time.Minute,
 				"name", "Ada",
 				"age", 36,
-			)).To(Succeed())
+			)).To(Equal(int64(2)))
 
 			name, ok, err := client.HGet(ctx, "user:42", "name")
 			Expect(err).NotTo(HaveOccurred())
@@ -290,7 +399,7 @@ var _ = Describe("Commands", func() {
 				Ignored: "ignored",
 			}
 
-			Expect(client.HSet(ctx, "user:42", 0, expected)).To(Succeed())
+			Expect(client.HSet(ctx, "user:42", 0, expected)).Error().To(Succeed())
 
 			var actual testUserHash
 			ok, err := client.HGetAll(ctx, "user:42", &actual)
@@ -306,6 +415,37 @@ var _ = Describe("Commands", func() {
 			Expect(exists).To(BeFalse())
 		})
 
+		It("promotes the fields of embedded structs", func() {
+			expected := testAuditedHash{
+				TestAudit:    TestAudit{CreatedBy: "ada", Name: "hidden"},
+				TestRevision: &TestRevision{Revision: 3},
+				Name:         "order",
+			}
+
+			Expect(client.HSet(ctx, "audited:1", 0, &expected)).Error().To(Succeed())
+
+			fields, err := client.Raw().HGetAll(ctx, "audited:1").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fields).To(Equal(map[string]string{"created_by": "ada", "revision": "3", "name": "order"}))
+
+			var actual testAuditedHash
+			ok, err := client.HGetAll(ctx, "audited:1", &actual)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(actual.Name).To(Equal("order"))
+			Expect(actual.CreatedBy).To(Equal("ada"))
+			Expect(actual.TestAudit.Name).To(BeEmpty())
+			Expect(actual.TestRevision).To(Equal(&TestRevision{Revision: 3}))
+
+			Expect(client.HSet(ctx, "audited:2", 0, testAuditedHash{Name: "draft"})).Error().To(Succeed())
+
+			actual = testAuditedHash{}
+			ok, err = client.HGetAll(ctx, "audited:2", &actual)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(actual.TestRevision).To(BeNil())
+		})
+
 		It("returns ok=false for missing hashes and fields", func() {
 			var user testUserHash
 			ok, err := client.HGetAll(ctx, "missing", &user)
@@ -332,32 +472,111 @@ var _ = Describe("Commands", func() {
 			Expect(value).To(Equal(int64(5)))
 		})
 
+		It("increments a hash field by a float and returns the updated value", func() {
+			value, err := client.HIncrByFloat(ctx, "order:42", "total", 10.5)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(BeNumerically("~", 10.5))
+
+			value, err = client.HIncrByFloat(ctx, "order:42", "total", -0.25)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(BeNumerically("~", 10.25))
+		})
+
 		It("leaves an existing hash expiration unchanged when ttl is zero", func() {
 			Expect(client.HSet(
 				ctx,
 				"user:42",
 				time.Minute,
 				"name", "Ada",
-			)).To(Succeed())
+			)).Error().To(Succeed())
 
 			Expect(client.HSet(
 				ctx,
 				"user:42",
 				0,
 				"age", 36,
-			)).To(Succeed())
+			)).Error().To(Succeed())
 
 			ttl, err := client.Raw().TTL(ctx, "user:42").Result()
 			Expect(err).NotTo(HaveOccurred())
 			Expect(ttl).To(BeNumerically(">", 0))
 		})
 
+		It("sets multiple hash fields from a map and from typed pairs", func() {
+			Expect(client.HSetMap(ctx, "user:42", map[string]any{
+				"name": "Ada",
+				"age":  36,
+			}, time.Minute)).To(Equal(int64(2)))
+
+			Expect(client.HSetFields(
+				ctx,
+				"user:42",
+				0,
+				xredis.HashField{Name: "name", Value: "Grace"},
+				xredis.HashField{Name: "city", Value: "London"},
+			)).To(Equal(int64(1)))
+
+			actual, err := client.Raw().HGetAll(ctx, "user:42").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual).To(Equal(map[string]string{
+				"name": "Grace",
+				"age":  "36",
+				"city": "London",
+			}))
+
+			ttl, err := client.Raw().TTL(ctx, "user:42").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ttl).To(BeNumerically(">", 0))
+		})
+
+		It("applies TTL only to hashes without expiration with ExpireNX", func() {
+			Expect(client.HSetExpire(ctx, "user:42", time.Hour, xredis.ExpireNX, "name", "Ada")).
+				Error().To(Succeed())
+
+			ttl, err := client.Raw().TTL(ctx, "user:42").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ttl).To(BeNumerically(">", 59*time.Minute))
+
+			Expect(client.HSetExpire(ctx, "user:42", time.Minute, xredis.ExpireNX, "age", 36)).
+				Error().To(Succeed())
+
+			ttl, err = client.Raw().TTL(ctx, "user:42").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ttl).To(BeNumerically(">", 59*time.Minute))
+
+			age, ok, err := client.HGet(ctx, "user:42", "age")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(age).To(Equal("36"))
+		})
+
+		It("never changes the expiration with ExpireNever", func() {
+			Expect(client.HSetExpire(ctx, "user:42", time.Hour, xredis.ExpireNever, "name", "Ada")).
+				Error().To(Succeed())
+
+			ttl, err := client.Raw().TTL(ctx, "user:42").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ttl).To(Equal(time.Duration(-1)))
+		})
+
 		It("validates hash arguments", func() {
 			Expect(client.HSet(ctx, "user:42", -time.Second, "name", "Ada")).
-				To(MatchError(xredis.ErrInvalidTTL))
+				Error().To(MatchError(xredis.ErrInvalidTTL))
 
 			Expect(client.HSet(ctx, "user:42", 0)).
-				To(MatchError(xredis.ErrInvalidHashObject))
+				Error().To(MatchError(xredis.ErrInvalidHashObject))
+
+			Expect(client.HSetMap(ctx, "user:42", nil, 0)).
+				Error().To(MatchError(xredis.ErrInvalidHashObject))
+
+			Expect(client.HSetFields(ctx, "user:42", 0)).
+				Error().To(MatchError(xredis.ErrInvalidHashObject))
+
+			Expect(client.HSetExpire(ctx, "user:42", time.Minute, xredis.ExpireMode(42), "name", "Ada")).
+				Error().To(MatchError(xredis.ErrInvalidTTL))
+
+			Expect(client.HSetMap(ctx, "user:42", map[string]any{"name": "Ada"}, -time.Second)).
+				Error().To(MatchError(xredis.ErrInvalidTTL))
 
 			ok, err := client.HGetAll(ctx, "user:42", nil)
 			Expect(err).To(MatchError(xredis.ErrInvalidHashObject))
@@ -0,0 +1,246 @@
+package xredis
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const defaultCompareTTLTolerance = time.Second
+
+// errCompareSampleFull stops the scan of Compare once enough keys are checked.
+var errCompareSampleFull = errors.New("compare sample full")
+
+// MismatchKind describes how a key differs between two deployments.
+type MismatchKind string
+
+const (
+	// MismatchMissing reports a key that exists only in the source.
+	MismatchMissing MismatchKind = "missing"
+
+	// MismatchType reports a key with different Redis types.
+	MismatchType MismatchKind = "type"
+
+	// MismatchValue reports a key with different content.
+	MismatchValue MismatchKind = "value"
+
+	// MismatchTTL reports a key whose TTLs differ by more than the tolerance,
+	// or that expires on one side only.
+	MismatchTTL MismatchKind = "ttl"
+)
+
+// CompareOptions configures Compare.
+type CompareOptions struct {
+	// SampleSize is the maximum number of source keys to check.
+	//
+	// Zero checks all keys matching the pattern.
+	SampleSize int
+
+	// TTLTolerance is the largest accepted difference between the TTLs of a
+	// key, which absorbs replication delay and clock skew.
+	//
+	// Zero uses 1 second.
+	TTLTolerance time.Duration
+
+	// IgnoreTTL disables TTL checks.
+	IgnoreTTL bool
+}
+
+// CompareMismatch is one key that differs between the compared clients.
+type CompareMismatch struct {
+	// Key is the differing key.
+	Key string
+
+	// Kind describes the difference.
+	Kind MismatchKind
+
+	// SourceTTL and TargetTTL are the TTLs of a MismatchTTL key. -1 means
+	// that the key does not expire.
+	SourceTTL time.Duration
+	TargetTTL time.Duration
+}
+
+// CompareReport is the result of Compare.
+type CompareReport struct {
+	// Checked is the number of source keys checked.
+	Checked int
+
+	// Mismatches contains the differing keys, sorted by key.
+	Mismatches []CompareMismatch
+}
+
+// Consistent reports whether no checked key differs.
+func (r CompareReport) Consistent() bool {
+	return len(r.Mismatches) == 0
+}
+
+// Compare checks that keys matching pattern in source exist with equal type,
+// content, and expiration in target, for verifying a migration or a dual-write
+// setup.
+//
+// Keys are taken in SCAN order from source, which spreads the sample over the
+// keyspace; opts.SampleSize bounds the number of checked keys. Keys that
+// exist only in target are not reported. Like Snapshot, Compare reads every
+// sampled value from both clients, and keys under concurrent writes may be
+// reported as mismatches.
+func Compare(
+	ctx context.Context,
+	source *Client,
+	target *Client,
+	pattern string,
+	opts CompareOptions,
+) (CompareReport, error) {
+	if source == nil || target == nil {
+		return CompareReport{}, ErrInvalidScan
+	}
+
+	if opts.TTLTolerance <= 0 {
+		opts.TTLTolerance = defaultCompareTTLTolerance
+	}
+
+	var (
+		mu     sync.Mutex
+		report CompareReport
+	)
+
+	err := source.ScanEachBatch(ctx, ScanOptions{Match: pattern}, func(ctx context.Context, keys []string) error {
+		mu.Lock()
+		if opts.SampleSize > 0 {
+			keys = keys[:min(len(keys), opts.SampleSize-report.Checked)]
+		}
+
+		report.Checked += len(keys)
+		full := opts.SampleSize > 0 && report.Checked >= opts.SampleSize
+		mu.Unlock()
+
+		mismatches, err := compareKeys(ctx, source, target, keys, opts)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		report.Mismatches = append(report.Mismatches, mismatches...)
+		mu.Unlock()
+
+		if full {
+			return errCompareSampleFull
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errCompareSampleFull) {
+		return CompareReport{}, err
+	}
+
+	sort.Slice(report.Mismatches, func(i, j int) bool {
+		return report.Mismatches[i].Key < report.Mismatches[j].Key
+	})
+
+	return report, nil
+}
+
+func compareKeys(ctx context.Context, source, target *Client, keys []string, opts CompareOptions) ([]CompareMismatch, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	sourceDigests, err := source.digestKeys(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	targetDigests, err := target.digestKeys(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	var sourceTTLs, targetTTLs map[string]time.Duration
+	if !opts.IgnoreTTL {
+		if sourceTTLs, err = source.keyTTLs(ctx, keys); err != nil {
+			return nil, err
+		}
+
+		if targetTTLs, err = target.keyTTLs(ctx, keys); err != nil {
+			return nil, err
+		}
+	}
+
+	var mismatches []CompareMismatch
+
+	for _, key := range keys {
+		sourceDigest, ok := sourceDigests[key]
+		if !ok {
+			// The key was removed from the source during the scan.
+			continue
+		}
+
+		targetDigest, ok := targetDigests[key]
+		switch {
+		case !ok:
+			mismatches = append(mismatches, CompareMismatch{Key: key, Kind: MismatchMissing})
+			continue
+		case targetDigest.Type != sourceDigest.Type:
+			mismatches = append(mismatches, CompareMismatch{Key: key, Kind: MismatchType})
+			continue
+		case targetDigest.Hash != sourceDigest.Hash:
+			mismatches = append(mismatches, CompareMismatch{Key: key, Kind: MismatchValue})
+			continue
+		}
+
+		if opts.IgnoreTTL {
+			continue
+		}
+
+		sourceTTL, targetTTL := sourceTTLs[key], targetTTLs[key]
+		if !ttlsMatch(sourceTTL, targetTTL, opts.TTLTolerance) {
+			mismatches = append(mismatches, CompareMismatch{
+				Key:       key,
+				Kind:      MismatchTTL,
+				SourceTTL: sourceTTL,
+				TargetTTL: targetTTL,
+			})
+		}
+	}
+
+	return mismatches, nil
+}
+
+// keyTTLs returns the PTTL of keys in one pipeline.
+func (c *Client) keyTTLs(ctx context.Context, keys []string) (map[string]time.Duration, error) {
+	pipe := c.conn.Pipeline()
+
+	cmds := make([]*rdb.DurationCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.PTTL(ctx, key)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	ttls := make(map[string]time.Duration, len(keys))
+	for i, key := range keys {
+		ttls[key] = cmds[i].Val()
+	}
+
+	return ttls, nil
+}
+
+// ttlsMatch compares two PTTL results, where negative values mean that the
+// key does not expire or does not exist.
+func ttlsMatch(a, b, tolerance time.Duration) bool {
+	if a < 0 || b < 0 {
+		return (a < 0) == (b < 0)
+	}
+
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+
+	return diff <= tolerance
+}
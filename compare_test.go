@@ -0,0 +1,89 @@
+package xredis_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("Compare", func() {
+	var source, target *xredis.Client
+
+	BeforeEach(func() {
+		source = newTestClient()
+
+		var err error
+		target, err = xredis.NewClient(xredis.WithClientConfig(&xredis.ClientConfig{Addr: redisAddr, DB: testDB - 1}))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(source.Raw().FlushDB(ctx).Err()).To(Succeed())
+		Expect(target.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(target.Raw().FlushDB(ctx).Err()).To(Succeed())
+		Expect(target.Close()).To(Succeed())
+		Expect(source.Close()).To(Succeed())
+	})
+
+	It("reports missing, type, value, and TTL mismatches", func() {
+		for _, client := range []*xredis.Client{source, target} {
+			raw := client.Raw()
+			Expect(raw.Set(ctx, "cmp:same", "value", time.Minute).Err()).To(Succeed())
+			Expect(raw.HSet(ctx, "cmp:hash", "a", "1").Err()).To(Succeed())
+		}
+
+		Expect(source.Raw().Set(ctx, "cmp:missing", "value", 0).Err()).To(Succeed())
+		Expect(source.Raw().Set(ctx, "cmp:type", "value", 0).Err()).To(Succeed())
+		Expect(target.Raw().RPush(ctx, "cmp:type", "value").Err()).To(Succeed())
+		Expect(source.Raw().Set(ctx, "cmp:value", "before", 0).Err()).To(Succeed())
+		Expect(target.Raw().Set(ctx, "cmp:value", "after", 0).Err()).To(Succeed())
+		Expect(source.Raw().Set(ctx, "cmp:ttl", "value", time.Hour).Err()).To(Succeed())
+		Expect(target.Raw().Set(ctx, "cmp:ttl", "value", time.Minute).Err()).To(Succeed())
+		Expect(target.Raw().Set(ctx, "cmp:extra", "value", 0).Err()).To(Succeed())
+
+		report, err := xredis.Compare(ctx, source, target, "cmp:*", xredis.CompareOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Checked).To(Equal(6))
+		Expect(report.Consistent()).To(BeFalse())
+		Expect(report.Mismatches).To(HaveLen(4))
+		Expect(report.Mismatches[0]).To(Equal(xredis.CompareMismatch{Key: "cmp:missing", Kind: xredis.MismatchMissing}))
+		Expect(report.Mismatches[1].Key).To(Equal("cmp:ttl"))
+		Expect(report.Mismatches[1].Kind).To(Equal(xredis.MismatchTTL))
+		Expect(report.Mismatches[1].SourceTTL).To(BeNumerically(">", 59*time.Minute))
+		Expect(report.Mismatches[1].TargetTTL).To(BeNumerically("<=", time.Minute))
+		Expect(report.Mismatches[2]).To(Equal(xredis.CompareMismatch{Key: "cmp:type", Kind: xredis.MismatchType}))
+		Expect(report.Mismatches[3]).To(Equal(xredis.CompareMismatch{Key: "cmp:value", Kind: xredis.MismatchValue}))
+	})
+
+	It("accepts TTL skew within the tolerance", func() {
+		Expect(source.Raw().Set(ctx, "cmp:ttl", "value", time.Minute).Err()).To(Succeed())
+		Expect(target.Raw().Set(ctx, "cmp:ttl", "value", time.Minute-3*time.Second).Err()).To(Succeed())
+
+		report, err := xredis.Compare(ctx, source, target, "cmp:*", xredis.CompareOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Mismatches).To(HaveLen(1))
+
+		report, err = xredis.Compare(ctx, source, target, "cmp:*", xredis.CompareOptions{TTLTolerance: 5 * time.Second})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Consistent()).To(BeTrue())
+
+		report, err = xredis.Compare(ctx, source, target, "cmp:*", xredis.CompareOptions{IgnoreTTL: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Consistent()).To(BeTrue())
+	})
+
+	It("limits the sample size", func() {
+		for i := range 50 {
+			Expect(source.Raw().Set(ctx, fmt.Sprintf("cmp:key:%d", i), "value", 0).Err()).To(Succeed())
+		}
+
+		report, err := xredis.Compare(ctx, source, target, "cmp:*", xredis.CompareOptions{SampleSize: 10})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Checked).To(Equal(10))
+		Expect(report.Mismatches).To(HaveLen(10))
+	})
+})
@@ -0,0 +1,110 @@
+package xredis
+
+import (
+	"context"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// concurrencyLimitHook bounds the number of commands and pipelines in flight,
+// as configured with WithMaxConcurrentCommands.
+type concurrencyLimitHook struct {
+	passDialHook
+
+	slots   chan struct{}
+	maxWait time.Duration
+	metrics *metrics
+}
+
+func newConcurrencyLimitHook(n int, maxWait time.Duration, m *metrics) *concurrencyLimitHook {
+	return &concurrencyLimitHook{
+		slots:   make(chan struct{}, n),
+		maxWait: maxWait,
+		metrics: m,
+	}
+}
+
+func (h *concurrencyLimitHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		if err := h.acquire(ctx); err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+		defer h.release()
+
+		return next(ctx, cmd)
+	}
+}
+
+func (h *concurrencyLimitHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		if err := h.acquire(ctx); err != nil {
+			for _, cmd := range cmds {
+				cmd.SetErr(err)
+			}
+
+			return err
+		}
+		defer h.release()
+
+		return next(ctx, cmds)
+	}
+}
+
+// acquire takes a slot, waiting until one is released, ctx is done, or the
+// maximum wait elapses.
+func (h *concurrencyLimitHook) acquire(ctx context.Context) error {
+	select {
+	case h.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	started := time.Now()
+
+	timer := time.NewTimer(h.maxWait)
+	defer timer.Stop()
+
+	select {
+	case h.slots <- struct{}{}:
+		h.metrics.recordConcurrencyWait(ctx, time.Since(started), concurrencyOutcomeAcquired)
+		return nil
+	case <-ctx.Done():
+		h.metrics.recordConcurrencyWait(ctx, time.Since(started), concurrencyOutcomeRejected)
+		return ctx.Err()
+	case <-timer.C:
+		h.metrics.recordConcurrencyWait(ctx, time.Since(started), concurrencyOutcomeRejected)
+		return ErrConcurrencyLimit
+	}
+}
+
+func (h *concurrencyLimitHook) release() {
+	<-h.slots
+}
+
+// commandPoolTimeout returns the pool timeout of conn, with the go-redis
+// default when it is not configured.
+func commandPoolTimeout(conn rdb.UniversalClient) time.Duration {
+	var poolTimeout, readTimeout time.Duration
+
+	switch conn := conn.(type) {
+	case *rdb.Client:
+		poolTimeout, readTimeout = conn.Options().PoolTimeout, conn.Options().ReadTimeout
+	case *rdb.ClusterClient:
+		poolTimeout, readTimeout = conn.Options().PoolTimeout, conn.Options().ReadTimeout
+	case *rdb.Ring:
+		poolTimeout, readTimeout = conn.Options().PoolTimeout, conn.Options().ReadTimeout
+	}
+
+	switch {
+	case poolTimeout > 0:
+		return poolTimeout
+	case readTimeout < 0:
+		return 30 * time.Second
+	case readTimeout == 0:
+		return 4 * time.Second
+	default:
+		return readTimeout + time.Second
+	}
+}
@@ -0,0 +1,83 @@
+package xredis_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("WithMaxConcurrentCommands", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient(
+			xredis.WithClientConfig(&xredis.ClientConfig{
+				Addr:        redisAddr,
+				DB:          testDB,
+				ReadTimeout: 5 * time.Second,
+				PoolTimeout: 200 * time.Millisecond,
+			}),
+			xredis.WithMaxConcurrentCommands(1),
+		)
+		Expect(client.Raw().Del(ctx, "concurrency:list").Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	// hold occupies the only slot with a blocking command for about a second.
+	hold := func() <-chan struct{} {
+		done := make(chan struct{})
+
+		go func() {
+			defer GinkgoRecover()
+			defer close(done)
+
+			_ = client.Raw().BLPop(ctx, time.Second, "concurrency:list").Err()
+		}()
+
+		time.Sleep(100 * time.Millisecond)
+
+		return done
+	}
+
+	It("runs commands within the limit", func() {
+		Expect(client.Set(ctx, "concurrency:key", "value", time.Minute)).To(Succeed())
+
+		value, ok, err := client.String(ctx, "concurrency:key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("value"))
+	})
+
+	It("fails commands that wait longer than the pool timeout", func() {
+		done := hold()
+
+		started := time.Now()
+		err := client.Raw().Get(ctx, "concurrency:key").Err()
+		Expect(err).To(MatchError(xredis.ErrConcurrencyLimit))
+		Expect(time.Since(started)).To(BeNumerically("<", 900*time.Millisecond))
+
+		<-done
+		Expect(client.Ping(ctx)).To(Succeed())
+	})
+
+	It("fails waiting commands and pipelines when their context is done", func() {
+		done := hold()
+
+		waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		pipe := client.Raw().Pipeline()
+		get := pipe.Get(waitCtx, "concurrency:key")
+		_, err := pipe.Exec(waitCtx)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(get.Err()).To(MatchError(context.DeadlineExceeded))
+
+		<-done
+	})
+})
@@ -20,12 +20,25 @@ type ClientConfig struct {
 	// If set, it takes precedence over other connection fields.
 	URL string
 
+	// Profile applies preset defaults for dev, staging, or prod to fields
+	// left at their zero value.
+	Profile Profile
+
 	// Network defines the network type: tcp or unix.
 	Network string
 
 	// Addr contains Redis address.
 	Addr string
 
+	// WriteAddrs contains write endpoints, such as a write VIP, tried in
+	// order when dialing. If set, it takes precedence over Addr.
+	WriteAddrs []string
+
+	// ReadAddrs contains read endpoints, such as load balancers in front of
+	// read replicas. Read commands are sent to them, spread over connections
+	// in turn, and fall back to the write endpoint while they are unavailable.
+	ReadAddrs []string
+
 	// NodeAddress is the Redis node address as reported by the server.
 	NodeAddress string
 
@@ -120,6 +133,10 @@ type ClusterConfig struct {
 	// If set, it takes precedence over other connection fields.
 	URL string
 
+	// Profile applies preset defaults for dev, staging, or prod to fields
+	// left at their zero value.
+	Profile Profile
+
 	// Addrs contains Redis Cluster seed node addresses.
 	Addrs []string
 
@@ -229,6 +246,10 @@ type FailoverConfig struct {
 	// If set, it takes precedence over other connection fields.
 	URL string
 
+	// Profile applies preset defaults for dev, staging, or prod to fields
+	// left at their zero value.
+	Profile Profile
+
 	// MasterName defines Redis Sentinel master name.
 	MasterName string
 
@@ -340,6 +361,10 @@ type FailoverConfig struct {
 
 // RingConfig configures a Redis Ring client for client-side sharding.
 type RingConfig struct {
+	// Profile applies preset defaults for dev, staging, or prod to fields
+	// left at their zero value.
+	Profile Profile
+
 	// Addrs contains named Redis shards.
 	Addrs map[string]string
 
@@ -640,7 +665,7 @@ func applyClientOptions(redisOpts *rdb.Options, opts *options) {
 	)
 
 	if opts.limiter != nil {
-		redisOpts.Limiter = opts.limiter
+		redisOpts.Limiter = newObservedLimiter(opts.limiter, newClientMetrics(opts.metricLabels))
 	}
 
 	if opts.pushNotificationProcessor != nil {
@@ -741,7 +766,7 @@ func applyRingOptions(redisOpts *rdb.RingOptions, opts *options) {
 	)
 
 	if opts.limiter != nil {
-		redisOpts.Limiter = opts.limiter
+		redisOpts.Limiter = newObservedLimiter(opts.limiter, newClientMetrics(opts.metricLabels))
 	}
 
 	if opts.ringNewClient != nil {
@@ -0,0 +1,199 @@
+package xredis
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultCounterFlushInterval = time.Second
+	defaultCounterFlushTimeout  = 5 * time.Second
+)
+
+// CounterAggregationConfig configures local aggregation of the counters of
+// one namespace.
+type CounterAggregationConfig struct {
+	// Namespace is the key prefix before the first ":" separator, such as
+	// "stats" for "stats:requests".
+	Namespace string
+
+	// FlushInterval defines how often merged increments are written.
+	//
+	// Zero uses 1 second.
+	FlushInterval time.Duration
+
+	// FlushTimeout bounds one flush.
+	//
+	// Zero uses 5 seconds.
+	FlushTimeout time.Duration
+}
+
+func normalizeCounterAggregationConfig(cfg CounterAggregationConfig) CounterAggregationConfig {
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = defaultCounterFlushInterval
+	}
+
+	if cfg.FlushTimeout <= 0 {
+		cfg.FlushTimeout = defaultCounterFlushTimeout
+	}
+
+	return cfg
+}
+
+// counterAggregator merges increments of one namespace until they are
+// flushed.
+type counterAggregator struct {
+	cfg CounterAggregationConfig
+
+	mu     sync.Mutex
+	deltas map[string]int64
+}
+
+func newCounterAggregator(cfg CounterAggregationConfig) *counterAggregator {
+	return &counterAggregator{cfg: cfg, deltas: make(map[string]int64)}
+}
+
+// newCounterAggregators creates an aggregator per configured namespace, or
+// returns nil when aggregation is disabled.
+func newCounterAggregators(configs map[string]CounterAggregationConfig) map[string]*counterAggregator {
+	if len(configs) == 0 {
+		return nil
+	}
+
+	aggregators := make(map[string]*counterAggregator, len(configs))
+	for namespace, cfg := range configs {
+		aggregators[namespace] = newCounterAggregator(cfg)
+	}
+
+	return aggregators
+}
+
+func (a *counterAggregator) add(key string, delta int64) {
+	a.mu.Lock()
+	a.deltas[key] += delta
+	a.mu.Unlock()
+}
+
+// take returns the merged increments and starts a new aggregation window.
+func (a *counterAggregator) take() map[string]int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(a.deltas) == 0 {
+		return nil
+	}
+
+	deltas := a.deltas
+	a.deltas = make(map[string]int64, len(deltas))
+
+	return deltas
+}
+
+// Count adds delta to the integer counter stored at key.
+//
+// Keys in a namespace configured with WithCounterAggregation are merged in
+// the client and written with one INCRBY per key at the flush interval, so
+// Count does not wait for Redis and returns nil. Other keys are incremented
+// right away, without retries on network errors.
+func (c *Client) Count(ctx context.Context, key string, delta int64) error {
+	if aggregator, ok := c.counterAggregator(key); ok {
+		if delta != 0 {
+			aggregator.add(key, delta)
+		}
+
+		return nil
+	}
+
+	return c.conn.IncrBy(withNoRetry(ctx), key, delta).Err()
+}
+
+// FlushCounters writes the increments merged by WithCounterAggregation
+// without waiting for the flush interval, for example before a planned
+// shutdown step that must observe them.
+func (c *Client) FlushCounters(ctx context.Context) error {
+	for _, aggregator := range c.counters {
+		if err := c.flushCounters(ctx, aggregator); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) counterAggregator(key string) (*counterAggregator, bool) {
+	if len(c.counters) == 0 {
+		return nil, false
+	}
+
+	namespace, _, ok := strings.Cut(key, defaultNamespaceSeparator)
+	if !ok {
+		return nil, false
+	}
+
+	aggregator, ok := c.counters[namespace]
+
+	return aggregator, ok
+}
+
+// flushCounters writes the merged increments of aggregator in one pipeline.
+//
+// Increments of a failed flush are dropped and logged rather than merged
+// back: INCRBY is not idempotent, and a pipeline that failed on the network
+// may have been applied, so a counter is never incremented twice.
+func (c *Client) flushCounters(ctx context.Context, aggregator *counterAggregator) error {
+	deltas := aggregator.take()
+	if len(deltas) == 0 {
+		return nil
+	}
+
+	_, err := c.conn.Pipelined(withNoRetry(ctx), func(pipe rdb.Pipeliner) error {
+		for key, delta := range deltas {
+			if delta != 0 {
+				pipe.IncrBy(ctx, key, delta)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		c.logger.LogAttrs(
+			ctx,
+			slog.LevelWarn,
+			"redis counter flush failed",
+			slog.String("namespace", aggregator.cfg.Namespace),
+			slog.Int("keys", len(deltas)),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return err
+}
+
+// watchCounters flushes aggregator at its interval, and once more when the
+// client is closed.
+func (c *Client) watchCounters(aggregator *counterAggregator, done <-chan struct{}) {
+	ticker := time.NewTicker(aggregator.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	flush := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), aggregator.cfg.FlushTimeout)
+		defer cancel()
+
+		_ = c.flushCounters(ctx, aggregator)
+	}
+
+	for {
+		select {
+		case <-done:
+			flush()
+			return
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
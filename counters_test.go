@@ -0,0 +1,65 @@
+package xredis_test
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("Counter aggregation", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient(xredis.WithCounterAggregation(xredis.CounterAggregationConfig{
+			Namespace:     "stats",
+			FlushInterval: time.Hour,
+		}))
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("merges increments until they are flushed", func() {
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Go(func() {
+				defer GinkgoRecover()
+
+				Expect(client.Count(ctx, "stats:requests", 2)).To(Succeed())
+			})
+		}
+
+		wg.Wait()
+		Expect(client.Count(ctx, "stats:errors", 1)).To(Succeed())
+		Expect(client.Raw().Exists(ctx, "stats:requests").Val()).To(BeZero())
+
+		Expect(client.FlushCounters(ctx)).To(Succeed())
+		Expect(client.Raw().Get(ctx, "stats:requests").Int64()).To(Equal(int64(20)))
+		Expect(client.Raw().Get(ctx, "stats:errors").Int64()).To(Equal(int64(1)))
+
+		Expect(client.FlushCounters(ctx)).To(Succeed())
+		Expect(client.Raw().Get(ctx, "stats:requests").Int64()).To(Equal(int64(20)))
+	})
+
+	It("increments keys of other namespaces right away", func() {
+		Expect(client.Count(ctx, "orders:count", 3)).To(Succeed())
+		Expect(client.Raw().Get(ctx, "orders:count").Int64()).To(Equal(int64(3)))
+	})
+
+	It("flushes merged increments on Close", func() {
+		aggregated := newTestClient(xredis.WithCounterAggregation(xredis.CounterAggregationConfig{
+			Namespace:     "stats",
+			FlushInterval: time.Hour,
+		}))
+
+		Expect(aggregated.Count(ctx, "stats:closed", 5)).To(Succeed())
+		Expect(aggregated.Close()).To(Succeed())
+
+		Expect(client.Raw().Get(ctx, "stats:closed").Int64()).To(Equal(int64(5)))
+	})
+})
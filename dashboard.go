@@ -0,0 +1,443 @@
+package xredis
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	dashboardUID              = "xredis-client"
+	dashboardSchemaVersion    = 39
+	dashboardDatasource       = "${datasource}"
+	dashboardRateInterval     = "[$__rate_interval]"
+	dashboardPanelWidth       = 12
+	dashboardPanelHeight      = 8
+	dashboardGridColumns      = 24
+	nativeMetricOperationTime = "db.client.operation.duration"
+	nativeMetricConnections   = "db.client.connection.count"
+	nativeAttrOperationName   = "db.operation.name"
+	nativeAttrConnectionState = "db.client.connection.state"
+)
+
+// DashboardJSON returns a Grafana dashboard for the metrics exposed by this
+// package and by redisotel-native through a Prometheus exporter: connection
+// pool stats, latency histograms, cache hit ratios, and error classes.
+//
+// namespace is the prefix that the exporter adds to metric names, such as
+// the namespace of a Prometheus registry. Empty means no prefix. The
+// dashboard selects the Prometheus data source and metric labels, such as
+// the ones set with WithMetricLabel, with dashboard variables, so it can be
+// imported as is.
+func DashboardJSON(namespace string) ([]byte, error) {
+	metric := func(name, suffix string) string {
+		return promMetricName(namespace, name, suffix)
+	}
+
+	rate := func(name, suffix, selector string) string {
+		return "rate(" + metric(name, suffix) + selector + dashboardRateInterval + ")"
+	}
+
+	quantile := func(q, name, by string) string {
+		return fmt.Sprintf(
+			"histogram_quantile(%s, sum by (le%s) (%s))",
+			q, by, rate(name, "_seconds_bucket", ""),
+		)
+	}
+
+	byLabel := func(attr string) string {
+		return ", " + promLabelName(attr)
+	}
+
+	rows := []dashboardRow{
+		{
+			title: "Connection pool",
+			panels: []dashboardPanel{
+				{
+					title:       "Pool utilization",
+					description: "Highest ratio of in-use connections to the pool size across nodes.",
+					unit:        "percentunit",
+					targets: []dashboardTarget{{
+						expr:   "max(" + metric("redis.client.pool.utilization", "_ratio") + ")",
+						legend: "utilization",
+					}},
+				},
+				{
+					title:       "Connections by state",
+					description: "Open connections reported by go-redis.",
+					unit:        "short",
+					targets: []dashboardTarget{{
+						expr:   "sum by (" + promLabelName(nativeAttrConnectionState) + ") (" + metric(nativeMetricConnections, "") + ")",
+						legend: "{{" + promLabelName(nativeAttrConnectionState) + "}}",
+					}},
+				},
+				{
+					title:       "Pool waits",
+					description: "Commands that waited for a free connection.",
+					unit:        "ops",
+					targets: []dashboardTarget{{
+						expr:   "sum(" + rate("redis.client.pool.waits", "_total", "") + ")",
+						legend: "waits",
+					}},
+				},
+				{
+					title:       "Average pool wait",
+					description: "Average time a waiting command spent waiting for a free connection.",
+					unit:        "s",
+					targets: []dashboardTarget{{
+						expr: "sum(" + rate("redis.client.pool.wait.duration", "_seconds_total", "") + ") / sum(" +
+							rate("redis.client.pool.waits", "_total", "") + ")",
+						legend: "average wait",
+					}},
+				},
+			},
+		},
+		{
+			title: "Latency",
+			panels: []dashboardPanel{
+				{
+					title:       "Command duration p99",
+					description: "99th percentile of command durations by command.",
+					unit:        "s",
+					targets: []dashboardTarget{{
+						expr:   quantile("0.99", nativeMetricOperationTime, byLabel(nativeAttrOperationName)),
+						legend: "{{" + promLabelName(nativeAttrOperationName) + "}}",
+					}},
+				},
+				{
+					title:       "Command phase p99",
+					description: "99th percentile of pool wait, dial, write, server, and read phases. Requires WithCommandPhaseMetrics.",
+					unit:        "s",
+					targets: []dashboardTarget{{
+						expr:   quantile("0.99", "redis.client.command.phase.duration", byLabel(metricAttrCommandPhase)),
+						legend: "{{" + promLabelName(metricAttrCommandPhase) + "}}",
+					}},
+				},
+				{
+					title:       "Retry delay p99",
+					description: "99th percentile of the time commands spent between failed attempts and retries.",
+					unit:        "s",
+					targets: []dashboardTarget{{
+						expr:   quantile("0.99", "redis.client.command.retry.delay", byLabel(metricAttrErrorClass)),
+						legend: "{{" + promLabelName(metricAttrErrorClass) + "}}",
+					}},
+				},
+				{
+					title:       "Cache loader p95",
+					description: "95th percentile of cache loader durations.",
+					unit:        "s",
+					targets: []dashboardTarget{{
+						expr:   quantile("0.95", "redis.client.cache.loader.duration", byLabel(metricAttrLoaderOutcome)),
+						legend: "{{" + promLabelName(metricAttrLoaderOutcome) + "}}",
+					}},
+				},
+			},
+		},
+		{
+			title: "Cache",
+			panels: []dashboardPanel{
+				{
+					title:       "Cache hit ratio",
+					description: "Share of cache lookups answered from Redis, negative hits included.",
+					unit:        "percentunit",
+					targets: []dashboardTarget{{
+						expr: "sum(" + rate(
+							"redis.client.cache.requests", "_total",
+							fmt.Sprintf(`{%s=~"%s|%s"}`, promLabelName(metricAttrCacheResult), cacheResultHit, cacheResultNegativeHit),
+						) + ") / sum(" + rate("redis.client.cache.requests", "_total", "") + ")",
+						legend: "hit ratio",
+					}},
+				},
+				{
+					title:       "Cache requests",
+					description: "Cache lookups by result.",
+					unit:        "ops",
+					targets: []dashboardTarget{
+						{
+							expr: "sum by (" + promLabelName(metricAttrCacheResult) + ") (" +
+								rate("redis.client.cache.requests", "_total", "") + ")",
+							legend: "{{" + promLabelName(metricAttrCacheResult) + "}}",
+						},
+						{
+							expr:   "sum(" + rate("redis.client.cache.singleflight.shared", "_total", "") + ")",
+							legend: "singleflight shared",
+						},
+					},
+				},
+			},
+		},
+		{
+			title: "Errors",
+			panels: []dashboardPanel{
+				{
+					title:       "Command errors by class",
+					description: "Failed commands by error class.",
+					unit:        "ops",
+					targets: []dashboardTarget{{
+						expr: "sum by (" + promLabelName(metricAttrErrorClass) + ") (" +
+							rate("redis.client.command.errors", "_total", "") + ")",
+						legend: "{{" + promLabelName(metricAttrErrorClass) + "}}",
+					}},
+				},
+				{
+					title:       "Caller aborts",
+					description: "Commands aborted by context cancellation or deadline, which are not counted as errors.",
+					unit:        "ops",
+					targets: []dashboardTarget{{
+						expr: "sum by (" + promLabelName(metricAttrAbortReason) + ") (" +
+							rate("redis.client.command.aborts", "_total", "") + ")",
+						legend: "{{" + promLabelName(metricAttrAbortReason) + "}}",
+					}},
+				},
+				{
+					title:       "Retries by class",
+					description: "Command attempts that failed with a retryable error.",
+					unit:        "ops",
+					targets: []dashboardTarget{{
+						expr: "sum by (" + promLabelName(metricAttrErrorClass) + ") (" +
+							rate("redis.client.command.retries", "_total", "") + ")",
+						legend: "{{" + promLabelName(metricAttrErrorClass) + "}}",
+					}},
+				},
+				{
+					title:       "Limiter decisions",
+					description: "Decisions of WithLimiter limiters and rate limiters by outcome.",
+					unit:        "ops",
+					targets: []dashboardTarget{
+						{
+							expr: "sum by (" + promLabelName(metricAttrLimiterOutcome) + ") (" +
+								rate("redis.client.limiter.decisions", "_total", "") + ")",
+							legend: "limiter {{" + promLabelName(metricAttrLimiterOutcome) + "}}",
+						},
+						{
+							expr: "sum by (" + promLabelName(metricAttrRateLimitOutcome) + ") (" +
+								rate("redis.client.rate_limiter.decisions", "_total", "") + ")",
+							legend: "rate limiter {{" + promLabelName(metricAttrRateLimitOutcome) + "}}",
+						},
+					},
+				},
+				{
+					title:       "Server pressure",
+					description: "Memory pressure after OOM errors and MOVED redirects during resharding.",
+					unit:        "short",
+					targets: []dashboardTarget{
+						{expr: "max(" + metric("redis.client.memory_pressure", "") + ")", legend: "memory pressure"},
+						{expr: "sum(" + rate("redis.client.cluster.resharding", "_total", "") + ")", legend: "resharding"},
+					},
+				},
+				{
+					title:       "Stale replica reads",
+					description: "Sampled replica reads that differed from the primary, by command.",
+					unit:        "ops",
+					targets: []dashboardTarget{{
+						expr: "sum by (" + promLabelName(metricAttrCommandName) + ") (" + rate(
+							"redis.client.read.verifications", "_total",
+							fmt.Sprintf(`{%s="%s"}`, promLabelName(metricAttrReadVerificationResult), readVerificationStale),
+						) + ")",
+						legend: "{{" + promLabelName(metricAttrCommandName) + "}}",
+					}},
+				},
+				{
+					title:       "Background panics",
+					description: "Panics recovered in background workers, which restart with backoff.",
+					unit:        "ops",
+					targets: []dashboardTarget{{
+						expr: "sum by (" + promLabelName(metricAttrWorker) + ") (" +
+							rate("redis.client.background.panics", "_total", "") + ")",
+						legend: "{{" + promLabelName(metricAttrWorker) + "}}",
+					}},
+				},
+			},
+		},
+		{
+			title: "Pub/Sub",
+			panels: []dashboardPanel{
+				{
+					title:       "Subscriptions",
+					description: "Active health-checked Pub/Sub subscriptions.",
+					unit:        "short",
+					targets: []dashboardTarget{{
+						expr:   "sum(" + metric("redis.client.pubsub.subscriptions", "") + ")",
+						legend: "subscriptions",
+					}},
+				},
+				{
+					title:       "Resubscribes",
+					description: "Channels resubscribed after a reconnect.",
+					unit:        "ops",
+					targets: []dashboardTarget{{
+						expr:   "sum(" + rate("redis.client.pubsub.resubscribes", "_total", "") + ")",
+						legend: "resubscribes",
+					}},
+				},
+			},
+		},
+	}
+
+	return json.MarshalIndent(newGrafanaDashboard(rows), "", "  ")
+}
+
+// promMetricName returns the name of an OpenTelemetry instrument as exposed
+// by the OpenTelemetry Prometheus exporter, including the unit suffix.
+func promMetricName(namespace, name, suffix string) string {
+	name = promLabelName(name) + suffix
+	if namespace == "" {
+		return name
+	}
+
+	return namespace + "_" + name
+}
+
+// promLabelName returns the Prometheus name of an OpenTelemetry attribute.
+func promLabelName(name string) string {
+	return strings.ReplaceAll(name, ".", "_")
+}
+
+type dashboardRow struct {
+	title  string
+	panels []dashboardPanel
+}
+
+type dashboardPanel struct {
+	title       string
+	description string
+	unit        string
+	targets     []dashboardTarget
+}
+
+type dashboardTarget struct {
+	expr   string
+	legend string
+}
+
+type grafanaDashboard struct {
+	UID           string            `json:"uid"`
+	Title         string            `json:"title"`
+	Tags          []string          `json:"tags"`
+	Editable      bool              `json:"editable"`
+	SchemaVersion int               `json:"schemaVersion"`
+	Refresh       string            `json:"refresh"`
+	Time          grafanaTimeRange  `json:"time"`
+	Templating    grafanaTemplating `json:"templating"`
+	Panels        []grafanaPanel    `json:"panels"`
+}
+
+type grafanaTimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type grafanaTemplating struct {
+	List []grafanaVariable `json:"list"`
+}
+
+type grafanaVariable struct {
+	Name       string             `json:"name"`
+	Label      string             `json:"label"`
+	Type       string             `json:"type"`
+	Query      string             `json:"query,omitempty"`
+	Datasource *grafanaDatasource `json:"datasource,omitempty"`
+}
+
+type grafanaDatasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type grafanaGridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type grafanaPanel struct {
+	ID          int                 `json:"id"`
+	Type        string              `json:"type"`
+	Title       string              `json:"title"`
+	Description string              `json:"description,omitempty"`
+	GridPos     grafanaGridPos      `json:"gridPos"`
+	Datasource  *grafanaDatasource  `json:"datasource,omitempty"`
+	FieldConfig *grafanaFieldConfig `json:"fieldConfig,omitempty"`
+	Targets     []grafanaTarget     `json:"targets,omitempty"`
+}
+
+type grafanaFieldConfig struct {
+	Defaults grafanaFieldDefaults `json:"defaults"`
+}
+
+type grafanaFieldDefaults struct {
+	Unit string `json:"unit"`
+}
+
+type grafanaTarget struct {
+	RefID        string             `json:"refId"`
+	Expr         string             `json:"expr"`
+	LegendFormat string             `json:"legendFormat"`
+	Datasource   *grafanaDatasource `json:"datasource"`
+}
+
+// newGrafanaDashboard lays out rows of panels two per line.
+func newGrafanaDashboard(rows []dashboardRow) grafanaDashboard {
+	datasource := &grafanaDatasource{Type: "prometheus", UID: dashboardDatasource}
+
+	dashboard := grafanaDashboard{
+		UID:           dashboardUID,
+		Title:         "Redis client (xredis)",
+		Tags:          []string{"redis", "xredis"},
+		Editable:      true,
+		SchemaVersion: dashboardSchemaVersion,
+		Refresh:       "30s",
+		Time:          grafanaTimeRange{From: "now-1h", To: "now"},
+		Templating: grafanaTemplating{List: []grafanaVariable{
+			{Name: "datasource", Label: "Data source", Type: "datasource", Query: "prometheus"},
+			{Name: "filters", Label: "Filters", Type: "adhoc", Datasource: datasource},
+		}},
+	}
+
+	id, y := 1, 0
+
+	for _, row := range rows {
+		dashboard.Panels = append(dashboard.Panels, grafanaPanel{
+			ID:      id,
+			Type:    "row",
+			Title:   row.title,
+			GridPos: grafanaGridPos{H: 1, W: dashboardGridColumns, Y: y},
+		})
+		id++
+		y++
+
+		for i, panel := range row.panels {
+			targets := make([]grafanaTarget, len(panel.targets))
+			for j, target := range panel.targets {
+				targets[j] = grafanaTarget{
+					RefID:        string(rune('A' + j)),
+					Expr:         target.expr,
+					LegendFormat: target.legend,
+					Datasource:   datasource,
+				}
+			}
+
+			dashboard.Panels = append(dashboard.Panels, grafanaPanel{
+				ID:          id,
+				Type:        "timeseries",
+				Title:       panel.title,
+				Description: panel.description,
+				GridPos: grafanaGridPos{
+					H: dashboardPanelHeight,
+					W: dashboardPanelWidth,
+					X: (i % 2) * dashboardPanelWidth,
+					Y: y + (i/2)*dashboardPanelHeight,
+				},
+				Datasource:  datasource,
+				FieldConfig: &grafanaFieldConfig{Defaults: grafanaFieldDefaults{Unit: panel.unit}},
+				Targets:     targets,
+			})
+			id++
+		}
+
+		y += (len(row.panels) + 1) / 2 * dashboardPanelHeight
+	}
+
+	return dashboard
+}
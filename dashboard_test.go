@@ -0,0 +1,75 @@
+package xredis_test
+
+import (
+	"encoding/json"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+type grafanaDashboard struct {
+	Title  string `json:"title"`
+	Panels []struct {
+		Type    string `json:"type"`
+		Title   string `json:"title"`
+		Targets []struct {
+			Expr string `json:"expr"`
+		} `json:"targets"`
+	} `json:"panels"`
+}
+
+func decodeDashboard(namespace string) grafanaDashboard {
+	data, err := xredis.DashboardJSON(namespace)
+	Expect(err).NotTo(HaveOccurred())
+
+	var dashboard grafanaDashboard
+	Expect(json.Unmarshal(data, &dashboard)).To(Succeed())
+
+	return dashboard
+}
+
+func dashboardExprs(dashboard grafanaDashboard) []string {
+	var exprs []string
+
+	for _, panel := range dashboard.Panels {
+		for _, target := range panel.Targets {
+			exprs = append(exprs, target.Expr)
+		}
+	}
+
+	return exprs
+}
+
+var _ = Describe("DashboardJSON", func() {
+	It("covers pool stats, latency, cache, and errors", func() {
+		dashboard := decodeDashboard("")
+
+		var titles []string
+		for _, panel := range dashboard.Panels {
+			titles = append(titles, panel.Title)
+		}
+
+		Expect(titles).To(ContainElements(
+			"Connection pool", "Pool utilization", "Command duration p99", "Cache hit ratio", "Command errors by class",
+		))
+		Expect(dashboardExprs(dashboard)).To(ContainElements(
+			"max(redis_client_pool_utilization_ratio)",
+			ContainSubstring("rate(db_client_operation_duration_seconds_bucket[$__rate_interval])"),
+			ContainSubstring(`redis_client_cache_requests_total{redis_client_cache_result=~"hit|negative_hit"}`),
+			"sum by (redis_client_error_class) (rate(redis_client_command_errors_total[$__rate_interval]))",
+		))
+	})
+
+	It("prefixes metric names with the namespace", func() {
+		exprs := dashboardExprs(decodeDashboard("orders"))
+		Expect(exprs).To(ContainElements(
+			"max(orders_redis_client_pool_utilization_ratio)",
+			"sum by (db_client_connection_state) (orders_db_client_connection_count)",
+		))
+
+		for _, expr := range exprs {
+			Expect(expr).To(ContainSubstring("orders_"))
+		}
+	})
+})
@@ -0,0 +1,86 @@
+package xredis
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// DBStats adapts the connection pool statistics of a Client to the
+// sql.DBStats shape, so dashboards and collectors built for database/sql
+// pools, such as the Prometheus DBStatsCollector, can monitor Redis pools
+// without changes.
+type DBStats struct {
+	client *Client
+}
+
+// DBStats returns the database/sql statistics adapter of the client.
+func (c *Client) DBStats() DBStats {
+	return DBStats{client: c}
+}
+
+// Stats returns the connection pool statistics of the client. For Redis
+// Cluster and Ring clients, the statistics of all node pools are summed.
+//
+// MaxOpenConnections is the configured pool size, and MaxIdleTimeClosed counts
+// the connections go-redis closed because they were idle or too old.
+// go-redis does not track the other closing reasons, which are always zero.
+// Pools of read endpoints and WithPools are not included.
+func (s DBStats) Stats() sql.DBStats {
+	var stats sql.DBStats
+
+	switch conn := s.client.conn.(type) {
+	case *rdb.ClusterClient:
+		sumNodeDBStats(&stats, conn.ForEachShard)
+	case *rdb.Ring:
+		sumNodeDBStats(&stats, conn.ForEachShard)
+	case *rdb.Client:
+		addNodeDBStats(&stats, conn)
+	default:
+		addPoolDBStats(&stats, conn.PoolStats())
+	}
+
+	return stats
+}
+
+func sumNodeDBStats(
+	stats *sql.DBStats,
+	forEach func(context.Context, func(context.Context, *rdb.Client) error) error,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), poolUtilizationTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+
+	// Nodes that cannot be listed are not included.
+	_ = forEach(ctx, func(_ context.Context, node *rdb.Client) error {
+		mu.Lock()
+		addNodeDBStats(stats, node)
+		mu.Unlock()
+
+		return nil
+	})
+}
+
+func addNodeDBStats(stats *sql.DBStats, node *rdb.Client) {
+	stats.MaxOpenConnections += node.Options().PoolSize
+	addPoolDBStats(stats, node.PoolStats())
+}
+
+func addPoolDBStats(stats *sql.DBStats, pool *rdb.PoolStats) {
+	if pool == nil {
+		return
+	}
+
+	inUse := max(int(pool.TotalConns)-int(pool.IdleConns), 0)
+
+	stats.OpenConnections += int(pool.TotalConns)
+	stats.InUse += inUse
+	stats.Idle += int(pool.IdleConns)
+	stats.WaitCount += int64(pool.WaitCount)
+	stats.WaitDuration += time.Duration(pool.WaitDurationNs)
+	stats.MaxIdleTimeClosed += int64(pool.StaleConns)
+}
@@ -0,0 +1,123 @@
+package xredis
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// DeadlineAuditConfig configures how commands issued with a context without a
+// deadline are reported.
+type DeadlineAuditConfig struct {
+	// Level is the level of the logged records, for example slog.LevelDebug
+	// in development and slog.LevelWarn in production.
+	Level slog.Level
+
+	// EveryCall logs every command without a deadline. By default each call
+	// site is logged once per client.
+	EveryCall bool
+}
+
+// deadlineAuditHook logs commands whose context has no deadline, with the
+// application call site that issued them.
+type deadlineAuditHook struct {
+	passDialHook
+
+	cfg    DeadlineAuditConfig
+	logger *slog.Logger
+
+	// reported contains the call sites that were already logged.
+	reported sync.Map
+}
+
+func newDeadlineAuditHook(cfg DeadlineAuditConfig, logger *slog.Logger) *deadlineAuditHook {
+	return &deadlineAuditHook{cfg: cfg, logger: logger}
+}
+
+func (h *deadlineAuditHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		if !isConnectionSetupCmd(cmd) {
+			h.audit(ctx, cmd.Name())
+		}
+
+		return next(ctx, cmd)
+	}
+}
+
+func (h *deadlineAuditHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		if len(cmds) > 0 && !isConnectionSetupCmd(cmds[0]) {
+			h.audit(ctx, "pipeline")
+		}
+
+		return next(ctx, cmds)
+	}
+}
+
+func (h *deadlineAuditHook) audit(ctx context.Context, command string) {
+	if _, ok := ctx.Deadline(); ok || !h.logger.Enabled(ctx, h.cfg.Level) {
+		return
+	}
+
+	frame, ok := callerFrame()
+	if !ok {
+		// Issued by the client itself, such as a stream producer flush.
+		return
+	}
+
+	site := frame.File + ":" + strconv.Itoa(frame.Line)
+	if !h.cfg.EveryCall {
+		if _, loaded := h.reported.LoadOrStore(site, struct{}{}); loaded {
+			return
+		}
+	}
+
+	h.logger.LogAttrs(
+		ctx,
+		h.cfg.Level,
+		"redis command without deadline",
+		slog.String("command", command),
+		slog.String("caller", site),
+		slog.String("function", frame.Function),
+	)
+}
+
+// callerFrame returns the innermost stack frame outside xredis, go-redis, and
+// their dependencies.
+func callerFrame() (runtime.Frame, bool) {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" && !isLibraryFunction(frame.Function) {
+			return frame, true
+		}
+
+		if !more {
+			return runtime.Frame{}, false
+		}
+	}
+}
+
+func isLibraryFunction(function string) bool {
+	for _, prefix := range []string{
+		"github.com/mkbeh/xredis.",
+		"github.com/mkbeh/xredis/",
+		"github.com/redis/go-redis/",
+		"golang.org/x/sync/",
+		"runtime.",
+		"runtime/pprof.",
+	} {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,88 @@
+package xredis_test
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+// deadlineAuditRecords returns the logged deadline audit records.
+func deadlineAuditRecords(logs *syncBuffer) []string {
+	var lines []string
+	for _, line := range strings.Split(logs.String(), "\n") {
+		if strings.Contains(line, `"msg":"redis command without deadline"`) {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+var _ = Describe("Deadline audit", func() {
+	It("logs each call site without a deadline once", func() {
+		var logs syncBuffer
+
+		client := newTestClient(
+			xredis.WithLogger(slog.New(slog.NewJSONHandler(&logs, nil))),
+			xredis.WithDeadlineAudit(xredis.DeadlineAuditConfig{Level: slog.LevelWarn}),
+		)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		for range 3 {
+			Expect(client.Set(context.Background(), "deadline:key", "value", 0)).To(Succeed())
+		}
+
+		bounded, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+
+		_, _, err := client.String(bounded, "deadline:key")
+		Expect(err).NotTo(HaveOccurred())
+
+		lines := deadlineAuditRecords(&logs)
+		Expect(lines).To(HaveLen(1))
+		Expect(lines[0]).To(ContainSubstring(`"level":"WARN"`))
+		Expect(lines[0]).To(ContainSubstring(`"command":"set"`))
+		Expect(lines[0]).To(ContainSubstring(`deadline_audit_test.go:`))
+		Expect(lines[0]).To(ContainSubstring(`"function":"github.com/mkbeh/xredis_test.init.`))
+	})
+
+	It("logs every call when configured", func() {
+		var logs syncBuffer
+
+		client := newTestClient(
+			xredis.WithLogger(slog.New(slog.NewJSONHandler(&logs, nil))),
+			xredis.WithDeadlineAudit(xredis.DeadlineAuditConfig{Level: slog.LevelInfo, EveryCall: true}),
+		)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		for range 2 {
+			Expect(client.Raw().Ping(context.Background()).Err()).To(Succeed())
+		}
+
+		Expect(deadlineAuditRecords(&logs)).To(HaveLen(2))
+	})
+
+	It("respects the logger level", func() {
+		var logs syncBuffer
+
+		client := newTestClient(
+			xredis.WithLogger(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelWarn}))),
+			xredis.WithDeadlineAudit(xredis.DeadlineAuditConfig{Level: slog.LevelDebug}),
+		)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Raw().Ping(context.Background()).Err()).To(Succeed())
+		Expect(deadlineAuditRecords(&logs)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,233 @@
+package xredis
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"sync/atomic"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// endpointDialer dials the first reachable address of a list.
+//
+// With rotate set, each dial starts at the next address, which spreads
+// connections over equivalent endpoints, such as read load balancers.
+// Otherwise addresses are tried in order, so the first one is preferred.
+type endpointDialer struct {
+	addrs  []string
+	rotate bool
+	next   atomic.Uint32
+	dial   func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+func newEndpointDialer(
+	addrs []string,
+	rotate bool,
+	dial func(ctx context.Context, network, addr string) (net.Conn, error),
+) *endpointDialer {
+	if dial == nil {
+		var dialer net.Dialer
+		dial = dialer.DialContext
+	}
+
+	return &endpointDialer{addrs: addrs, rotate: rotate, dial: dial}
+}
+
+func (d *endpointDialer) DialContext(ctx context.Context, network, _ string) (net.Conn, error) {
+	start := 0
+	if d.rotate {
+		start = int(d.next.Add(1)-1) % len(d.addrs)
+	}
+
+	var errs []error
+	for i := range d.addrs {
+		conn, err := d.dial(ctx, network, d.addrs[(start+i)%len(d.addrs)])
+		if err == nil {
+			return conn, nil
+		}
+
+		errs = append(errs, err)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// applyEndpoints configures separate write and read endpoints of a standalone
+// client. It returns the options of the read client, or nil when reads use
+// the write endpoint.
+func applyEndpoints(cfg *ClientConfig, redisOpts *rdb.Options) *rdb.Options {
+	if writeAddrs := normalizeAddrs(cfg.WriteAddrs); len(writeAddrs) > 0 {
+		redisOpts.Addr = writeAddrs[0]
+		if len(writeAddrs) > 1 {
+			redisOpts.Dialer = newEndpointDialer(writeAddrs, false, redisOpts.Dialer).DialContext
+		}
+	}
+
+	readAddrs := normalizeAddrs(cfg.ReadAddrs)
+	if len(readAddrs) == 0 {
+		return nil
+	}
+
+	readOpts := *redisOpts
+	readOpts.Addr = readAddrs[0]
+	readOpts.Dialer = newEndpointDialer(readAddrs, true, redisOpts.Dialer).DialContext
+
+	return &readOpts
+}
+
+// ReadVerificationConfig configures sampled verification of reads served by
+// the read endpoints of ClientConfig.ReadAddrs.
+type ReadVerificationConfig struct {
+	// SampleRate is the fraction of reads, from 0 to 1, that are also read
+	// from the write endpoint and compared.
+	SampleRate float64
+
+	// Repair returns the value of the write endpoint to the caller when a
+	// sampled read differs. Otherwise the caller receives the replica value,
+	// and the mismatch is only recorded.
+	Repair bool
+}
+
+// readRoutingHook sends read commands to a separate read client.
+//
+// Reads that fail because the read endpoints are unavailable are retried on
+// the write endpoint. Pipelines are routed only when all their commands are
+// reads, and transactions, Watch and Conn always use the write endpoint, so
+// optimistic locks check the current values.
+type readRoutingHook struct {
+	passDialHook
+
+	reads   *rdb.Client
+	verify  *ReadVerificationConfig
+	metrics *metrics
+	chains  *connChains
+}
+
+func (h *readRoutingHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	if h.chains.pinned() {
+		return next
+	}
+
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		if !isRoutableRead(cmd) {
+			return next(ctx, cmd)
+		}
+
+		if h.verify != nil && rand.Float64() < h.verify.SampleRate {
+			return h.verifyRead(ctx, cmd, next)
+		}
+
+		if err := h.reads.Process(ctx, cmd); !isEndpointUnavailable(err) {
+			return err
+		}
+
+		cmd.SetErr(nil)
+
+		return next(ctx, cmd)
+	}
+}
+
+func (h *readRoutingHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	if h.chains.pinned() {
+		return next
+	}
+
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		for _, cmd := range cmds {
+			if !isRoutableRead(cmd) {
+				return next(ctx, cmds)
+			}
+		}
+
+		pipe := h.reads.Pipeline()
+		for _, cmd := range cmds {
+			_ = pipe.Process(ctx, cmd)
+		}
+
+		if _, err := pipe.Exec(ctx); !isEndpointUnavailable(err) {
+			return err
+		}
+
+		for _, cmd := range cmds {
+			cmd.SetErr(nil)
+		}
+
+		return next(ctx, cmds)
+	}
+}
+
+// isRoutableRead reports whether cmd may be sent to the read endpoints. GETEX
+// and HGETEX read stored values but also change their TTL, so they are writes.
+func isRoutableRead(cmd rdb.Cmder) bool {
+	return cmdReadKeys(cmd) != nil && !isWriteCmd(cmd)
+}
+
+// verifyRead reads cmd from the write endpoint and then from the read
+// endpoints, and records whether the replies differ.
+//
+// The write endpoint is read first, so a replica that lags behind shows up as
+// a mismatch. A write landing between both reads can also cause one, so the
+// stale count is an upper bound.
+func (h *readRoutingHook) verifyRead(ctx context.Context, cmd rdb.Cmder, next rdb.ProcessHook) error {
+	if err := next(ctx, cmd); err != nil && !errors.Is(err, rdb.Nil) {
+		return err
+	}
+
+	primary := cmdReply(cmd)
+
+	cmd.SetErr(nil)
+
+	err := h.reads.Process(ctx, cmd)
+	if isEndpointUnavailable(err) {
+		cmd.SetErr(nil)
+
+		return next(ctx, cmd)
+	}
+
+	if cmdReply(cmd) == primary {
+		h.metrics.recordReadVerification(ctx, cmd.Name(), readVerificationMatch)
+
+		return err
+	}
+
+	h.metrics.recordReadVerification(ctx, cmd.Name(), readVerificationStale)
+
+	if !h.verify.Repair {
+		return err
+	}
+
+	cmd.SetErr(nil)
+
+	return next(ctx, cmd)
+}
+
+// cmdReply formats the reply of cmd, including its error, for comparison.
+func cmdReply(cmd rdb.Cmder) string {
+	reply := cmd.String()
+	if err := cmd.Err(); err != nil {
+		reply += " (" + err.Error() + ")"
+	}
+
+	return reply
+}
+
+// isEndpointUnavailable reports whether err means that the endpoint could not
+// serve the command, as opposed to a reply or a caller cancellation.
+func isEndpointUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	switch classifyError(err) {
+	case errorClassConnection, errorClassConnectionRefused, errorClassTimeout,
+		errorClassPoolTimeout, errorClassLoading:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,200 @@
+package xredis_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// endpointRecorder dials the test server for known endpoint names and records
+// the dialed names.
+type endpointRecorder struct {
+	mu     sync.Mutex
+	dialed []string
+	down   map[string]bool
+}
+
+func (r *endpointRecorder) dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	r.mu.Lock()
+	r.dialed = append(r.dialed, addr)
+	down := r.down[addr]
+	r.mu.Unlock()
+
+	if down {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: errors.New("connection refused")}
+	}
+
+	var dialer net.Dialer
+
+	return dialer.DialContext(ctx, network, redisAddr)
+}
+
+func (r *endpointRecorder) addrs() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.dialed...)
+}
+
+var _ = Describe("Read and write endpoints", func() {
+	newEndpointClient := func(recorder *endpointRecorder, cfg *xredis.ClientConfig, opts ...xredis.Option) *xredis.Client {
+		cfg.DB = testDB
+		cfg.MaxRetries = -1
+
+		client, err := xredis.NewClient(append([]xredis.Option{
+			xredis.WithClientConfig(cfg), xredis.WithDialer(recorder.dial),
+		}, opts...)...)
+		Expect(err).NotTo(HaveOccurred())
+
+		return client
+	}
+
+	It("routes reads to read endpoints and writes to write endpoints", func() {
+		recorder := &endpointRecorder{}
+		client := newEndpointClient(recorder, &xredis.ClientConfig{
+			WriteAddrs: []string{"write-vip:6379"},
+			ReadAddrs:  []string{"read-lb-a:6379", "read-lb-b:6379"},
+		})
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Set(ctx, "endpoints:key", "value", 0)).To(Succeed())
+		Expect(recorder.addrs()).To(Equal([]string{"write-vip:6379"}))
+
+		value, ok, err := client.String(ctx, "endpoints:key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("value"))
+		Expect(recorder.addrs()).To(ContainElement("read-lb-a:6379"))
+
+		cmds, err := client.Raw().Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+			pipe.Get(ctx, "endpoints:key")
+			pipe.Exists(ctx, "endpoints:key")
+
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmds[0].(*rdb.StringCmd).Val()).To(Equal("value"))
+		Expect(recorder.addrs()).NotTo(ContainElement("read-lb-b:6379"))
+	})
+
+	It("falls back to the write endpoint while read endpoints are down", func() {
+		recorder := &endpointRecorder{down: map[string]bool{"read-lb:6379": true}}
+		client := newEndpointClient(recorder, &xredis.ClientConfig{
+			Addr:      "write-vip:6379",
+			ReadAddrs: []string{"read-lb:6379"},
+		})
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Set(ctx, "endpoints:key", "value", 0)).To(Succeed())
+
+		value, ok, err := client.String(ctx, "endpoints:key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("value"))
+		Expect(recorder.addrs()).To(ContainElement("read-lb:6379"))
+	})
+
+	It("sends reads that change the TTL to the write endpoint", func() {
+		recorder := &endpointRecorder{}
+		client := newEndpointClient(recorder, &xredis.ClientConfig{
+			Addr:      "write-vip:6379",
+			ReadAddrs: []string{"read-lb:6379"},
+		})
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Set(ctx, "endpoints:key", "value", 0)).To(Succeed())
+
+		value, ok, err := client.GetEx(ctx, "endpoints:key", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("value"))
+		Expect(recorder.addrs()).NotTo(ContainElement("read-lb:6379"))
+		Expect(client.Raw().TTL(ctx, "endpoints:key").Val()).To(BeNumerically(">", 0))
+	})
+
+	It("keeps reads of Watch and Conn on the write endpoint", func() {
+		recorder := &endpointRecorder{}
+		client := newEndpointClient(recorder, &xredis.ClientConfig{
+			Addr:      "write-vip:6379",
+			ReadAddrs: []string{"read-lb:6379"},
+		})
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Set(ctx, "endpoints:key", "value", 0)).To(Succeed())
+
+		raw := client.Raw().(*rdb.Client)
+		err := raw.Watch(ctx, func(tx *rdb.Tx) error {
+			value, err := tx.Get(ctx, "endpoints:key").Result()
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.TxPipelined(ctx, func(pipe rdb.Pipeliner) error {
+				pipe.Set(ctx, "endpoints:key", value+"!", 0)
+
+				return nil
+			})
+
+			return err
+		}, "endpoints:key")
+		Expect(err).NotTo(HaveOccurred())
+
+		conn := raw.Conn()
+		Expect(conn.Get(ctx, "endpoints:key").Val()).To(Equal("value!"))
+		Expect(conn.Close()).To(Succeed())
+
+		Expect(recorder.addrs()).NotTo(ContainElement("read-lb:6379"))
+	})
+
+	It("tries write endpoints in order", func() {
+		recorder := &endpointRecorder{down: map[string]bool{"write-primary:6379": true}}
+		client := newEndpointClient(recorder, &xredis.ClientConfig{
+			WriteAddrs: []string{"write-primary:6379", "write-standby:6379"},
+		})
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Set(ctx, "endpoints:key", "value", 0)).To(Succeed())
+		Expect(recorder.addrs()).To(Equal([]string{"write-primary:6379", "write-standby:6379"}))
+	})
+
+	It("compares sampled reads with the write endpoint", func() {
+		recorder := &endpointRecorder{}
+		client := newEndpointClient(recorder, &xredis.ClientConfig{
+			WriteAddrs: []string{"write-vip:6379"},
+			ReadAddrs:  []string{"read-lb:6379"},
+		}, xredis.WithReadVerification(xredis.ReadVerificationConfig{SampleRate: 1, Repair: true}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		value, ok, err := client.String(ctx, "endpoints:missing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+		Expect(value).To(BeEmpty())
+		Expect(recorder.addrs()).To(Equal([]string{"write-vip:6379", "read-lb:6379"}))
+
+		Expect(client.Set(ctx, "endpoints:key", "value", 0)).To(Succeed())
+
+		value, ok, err = client.String(ctx, "endpoints:key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("value"))
+	})
+})
@@ -0,0 +1,96 @@
+package xredis
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// classifyError returns a bounded error class for a command error.
+//
+// Classes separate server and network unavailability from errors caused by
+// the commands themselves, so they can be used as metric label values.
+func classifyError(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return errorClassContextCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return errorClassDeadlineExceeded
+	case errors.Is(err, rdb.ErrPoolTimeout):
+		return errorClassPoolTimeout
+	case errors.Is(err, rdb.ErrClosed):
+		return errorClassClientClosed
+	case errors.Is(err, ErrLimiterRejected):
+		return errorClassLimiter
+	case errors.Is(err, ErrLoadShed):
+		return errorClassLoadShed
+	case errors.Is(err, ErrConcurrencyLimit):
+		return errorClassConcurrencyLimit
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return errorClassConnectionRefused
+	}
+
+	if class, ok := classifyServerError(err); ok {
+		return class
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return errorClassTimeout
+	}
+
+	if netErr != nil || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) {
+		return errorClassConnection
+	}
+
+	return errorClassOther
+}
+
+func classifyServerError(err error) (string, bool) {
+	var redisErr rdb.Error
+	if !errors.As(err, &redisErr) {
+		return "", false
+	}
+
+	msg := redisErr.Error()
+	prefix, _, _ := strings.Cut(msg, " ")
+
+	switch prefix {
+	case "MOVED":
+		return errorClassMoved, true
+	case "ASK":
+		return errorClassAsk, true
+	case "OOM":
+		return errorClassOOM, true
+	case "WRONGTYPE":
+		return errorClassWrongType, true
+	case "NOSCRIPT":
+		return errorClassNoScript, true
+	case "BUSY":
+		return errorClassBusy, true
+	case "LOADING":
+		return errorClassLoading, true
+	case "READONLY":
+		return errorClassReadOnly, true
+	case "CROSSSLOT":
+		return errorClassCrossSlot, true
+	case "CLUSTERDOWN":
+		return errorClassClusterDown, true
+	case "TRYAGAIN":
+		return errorClassTryAgain, true
+	case "NOAUTH", "WRONGPASS", "NOPERM":
+		return errorClassAuth, true
+	}
+
+	if strings.HasPrefix(msg, "ERR max number of clients") {
+		return errorClassMaxClients, true
+	}
+
+	return errorClassServer, true
+}
@@ -0,0 +1,85 @@
+package xredis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+type testRedisError string
+
+func (e testRedisError) Error() string {
+	return string(e)
+}
+
+func (testRedisError) RedisError() {}
+
+var _ = Describe("classifyError", func() {
+	DescribeTable("returns bounded error classes",
+		func(err error, class string) {
+			Expect(classifyError(err)).To(Equal(class))
+		},
+		Entry("context canceled", fmt.Errorf("wrapped: %w", context.Canceled), errorClassContextCanceled),
+		Entry("deadline exceeded", context.DeadlineExceeded, errorClassDeadlineExceeded),
+		Entry("pool timeout", rdb.ErrPoolTimeout, errorClassPoolTimeout),
+		Entry("closed client", rdb.ErrClosed, errorClassClientClosed),
+		Entry("limiter rejection", &limiterRejection{err: errors.New("over budget")}, errorClassLimiter),
+		Entry("load shedding", fmt.Errorf("get: %w", ErrLoadShed), errorClassLoadShed),
+		Entry("concurrency limit", ErrConcurrencyLimit, errorClassConcurrencyLimit),
+		Entry("connection refused", &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, errorClassConnectionRefused),
+		Entry("network timeout", &net.OpError{Op: "read", Err: os.ErrDeadlineExceeded}, errorClassTimeout),
+		Entry("connection reset", &net.OpError{Op: "read", Err: syscall.ECONNRESET}, errorClassConnection),
+		Entry("moved", testRedisError("MOVED 3999 127.0.0.1:6381"), errorClassMoved),
+		Entry("out of memory", testRedisError("OOM command not allowed when used memory > 'maxmemory'."), errorClassOOM),
+		Entry("wrong type", testRedisError("WRONGTYPE Operation against a key holding the wrong kind of value"), errorClassWrongType),
+		Entry("generic server error", testRedisError("ERR unknown command 'FOO'"), errorClassServer),
+		Entry("unknown error", errors.New("boom"), errorClassOther),
+	)
+
+	It("classifies real network timeouts", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		defer listener.Close()
+
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		Expect(err).NotTo(HaveOccurred())
+		defer conn.Close()
+
+		Expect(conn.SetReadDeadline(time.Now().Add(time.Millisecond))).To(Succeed())
+
+		_, err = conn.Read(make([]byte, 1))
+		Expect(classifyError(err)).To(Equal(errorClassTimeout))
+	})
+})
+
+var _ = Describe("abortReason", func() {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Unix(0, 0))
+	cancelExpired()
+
+	timeout := &net.OpError{Op: "read", Err: os.ErrDeadlineExceeded}
+
+	DescribeTable("separates caller aborts from errors",
+		func(ctx context.Context, err error, reason string, aborted bool) {
+			actual, ok := abortReason(ctx, err)
+			Expect(ok).To(Equal(aborted))
+			Expect(actual).To(Equal(reason))
+		},
+		Entry("context canceled", context.Background(), context.Canceled, errorClassContextCanceled, true),
+		Entry("deadline exceeded", context.Background(), fmt.Errorf("wrapped: %w", context.DeadlineExceeded), errorClassDeadlineExceeded, true),
+		Entry("socket timeout of a canceled context", canceled, timeout, errorClassContextCanceled, true),
+		Entry("socket timeout of an expired context", expired, timeout, errorClassDeadlineExceeded, true),
+		Entry("socket timeout of a live context", context.Background(), timeout, "", false),
+		Entry("server error of a canceled context", canceled, testRedisError("ERR boom"), "", false),
+	)
+})
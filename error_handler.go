@@ -0,0 +1,40 @@
+package xredis
+
+import (
+	"context"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// errorHandlerHook passes failed commands to the handler configured with
+// WithErrorHandler.
+type errorHandlerHook struct {
+	passDialHook
+
+	handler func(ctx context.Context, cmd string, err error)
+}
+
+func (h *errorHandlerHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		err := next(ctx, cmd)
+		if isCommandFailure(err) && !isConnectionSetupCmd(cmd) {
+			h.handler(ctx, cmd.Name(), err)
+		}
+
+		return err
+	}
+}
+
+func (h *errorHandlerHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		err := next(ctx, cmds)
+
+		for _, cmd := range cmds {
+			if cmdErr := cmd.Err(); isCommandFailure(cmdErr) && !isConnectionSetupCmd(cmd) {
+				h.handler(ctx, cmd.Name(), cmdErr)
+			}
+		}
+
+		return err
+	}
+}
@@ -33,6 +33,13 @@ var (
 	// ErrInvalidLock is returned when a lock, lock key, owner token, or client is invalid.
 	ErrInvalidLock = errors.New("invalid lock")
 
+	// ErrInvalidToken is returned when a request token is empty or a fencing
+	// token is not positive.
+	ErrInvalidToken = errors.New("invalid token")
+
+	// ErrInvalidDelta is returned when a bounded counter delta is not positive.
+	ErrInvalidDelta = errors.New("invalid delta")
+
 	// ErrInvalidRateLimiter is returned when a rate limiter is invalid or misconfigured.
 	ErrInvalidRateLimiter = errors.New("invalid rate limiter")
 
@@ -45,13 +52,77 @@ var (
 	// ErrInvalidPipeline is returned when pipeline input or configuration is invalid.
 	ErrInvalidPipeline = errors.New("invalid pipeline")
 
+	// ErrInvalidStream is returned when stream arguments are invalid.
+	ErrInvalidStream = errors.New("invalid stream")
+
+	// ErrInvalidPop is returned when multi-key pop arguments are invalid.
+	ErrInvalidPop = errors.New("invalid pop")
+
+	// ErrStreamProducerClosed is returned when an entry is published after
+	// StreamProducer.Close.
+	ErrStreamProducerClosed = errors.New("stream producer closed")
+
+	// ErrSubscriptionMuxClosed is returned when a subscription is created after
+	// SubscriptionMux.Close.
+	ErrSubscriptionMuxClosed = errors.New("subscription multiplexer closed")
+
 	// ErrInvalidVersionedStore is returned when a versioned store is invalid or misconfigured.
 	ErrInvalidVersionedStore = errors.New("invalid versioned store")
 
 	// ErrInvalidEntry is returned when a stored Redis entry has an invalid internal representation.
 	ErrInvalidEntry = errors.New("invalid entry")
 
+	// ErrUnknownHashFields is returned by hash reads in strict mode when the
+	// hash contains fields that the destination struct does not declare.
+	ErrUnknownHashFields = errors.New("unknown hash fields")
+
+	// ErrIncompatibleSchema is returned when a registered hash schema changes
+	// the type of a field stored by another schema version.
+	ErrIncompatibleSchema = errors.New("incompatible schema")
+
 	// ErrUnsupportedType is returned when a typed component is created with an
 	// unsupported value type.
 	ErrUnsupportedType = errors.New("unsupported type")
+
+	// ErrAccessSamplingDisabled is returned when access statistics are
+	// requested from a client without access sampling.
+	ErrAccessSamplingDisabled = errors.New("access sampling disabled")
+
+	// ErrNamespaceQuotaExceeded is returned when a write would exceed the byte
+	// budget of its namespace.
+	ErrNamespaceQuotaExceeded = errors.New("namespace quota exceeded")
+
+	// ErrReadOnlyMode is returned when a client in read-only mode rejects a
+	// command that may write, such as a script call.
+	ErrReadOnlyMode = errors.New("read-only mode")
+
+	// ErrMaintenance is returned when a client in maintenance mode rejects a
+	// command that may write.
+	ErrMaintenance = errors.New("maintenance mode")
+
+	// ErrLimiterRejected matches errors returned when the limiter configured
+	// with WithLimiter rejects a command. Such errors also unwrap to the
+	// limiter's own error.
+	ErrLimiterRejected = errors.New("limiter rejected command")
+
+	// ErrLoadShed is returned when a best-effort command is shed because the
+	// connection pool is overloaded. See WithLoadShedding.
+	ErrLoadShed = errors.New("command shed under load")
+
+	// ErrOutOfScope is returned when a command uses a key outside the
+	// prefixes configured with WithKeyScope.
+	ErrOutOfScope = errors.New("key out of scope")
+
+	// ErrConcurrencyLimit is returned when a command waits longer than the
+	// pool timeout for a slot of the WithMaxConcurrentCommands limit.
+	ErrConcurrencyLimit = errors.New("concurrent command limit reached")
+
+	// ErrCommandTooLarge is returned when a command has more arguments or a
+	// longer key than the limits configured with WithCommandLimits.
+	ErrCommandTooLarge = errors.New("command exceeds limits")
+
+	// ErrInvalidKey is returned when a key is empty, or when a key accessor
+	// generated by xredis-keygen receives a parameter that would build a key
+	// outside its pattern.
+	ErrInvalidKey = errors.New("invalid key")
 )
@@ -0,0 +1,140 @@
+package xredis
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultEvictionCheckInterval = 5 * time.Minute
+	evictionCheckTimeout         = time.Second
+)
+
+// Workload describes how an application uses Redis.
+type Workload string
+
+const (
+	// WorkloadCache holds data that can be recomputed, so Redis may evict it.
+	WorkloadCache Workload = "cache"
+
+	// WorkloadStore holds data that must not be lost to eviction.
+	WorkloadStore Workload = "store"
+)
+
+// EvictionPolicyConfig configures checks of the server maxmemory-policy
+// against the workload of the client.
+type EvictionPolicyConfig struct {
+	// Workload is the declared usage of Redis.
+	Workload Workload
+
+	// CheckInterval defines how often the policy is checked after startup.
+	//
+	// Zero uses 5 minutes. Negative values check only on startup.
+	CheckInterval time.Duration
+}
+
+func normalizeEvictionPolicyConfig(cfg EvictionPolicyConfig) EvictionPolicyConfig {
+	if cfg.CheckInterval == 0 {
+		cfg.CheckInterval = defaultEvictionCheckInterval
+	}
+
+	return cfg
+}
+
+// evictionPolicyState holds the result of the last policy check.
+type evictionPolicyState struct {
+	policy   string
+	mismatch atomic.Bool
+}
+
+// evictionMismatch returns why policy is dangerous for workload, or an empty
+// string when it is not.
+func evictionMismatch(workload Workload, policy string) string {
+	switch workload {
+	case WorkloadCache:
+		if policy == "noeviction" {
+			return "writes fail with OOM errors instead of evicting cache entries when memory is full"
+		}
+	case WorkloadStore:
+		if strings.HasPrefix(policy, "allkeys-") {
+			return "any key, including stored data, may be evicted when memory is full"
+		}
+	}
+
+	return ""
+}
+
+// checkEvictionPolicy compares the server maxmemory-policy with the declared
+// workload and warns when the result changes to a mismatch.
+//
+// In clusters and rings, one node is checked per call. Errors, for example
+// when CONFIG is disabled by a managed service, keep the previous result.
+func (c *Client) checkEvictionPolicy(cfg EvictionPolicyConfig, state *evictionPolicyState) {
+	ctx, cancel := context.WithTimeout(context.Background(), evictionCheckTimeout)
+	defer cancel()
+
+	config, err := c.conn.ConfigGet(ctx, "maxmemory-policy").Result()
+	if err != nil {
+		return
+	}
+
+	policy, ok := config["maxmemory-policy"]
+	if !ok || policy == state.policy {
+		return
+	}
+
+	state.policy = policy
+
+	reason := evictionMismatch(cfg.Workload, policy)
+	state.mismatch.Store(reason != "")
+
+	if reason != "" {
+		c.logger.LogAttrs(
+			ctx,
+			slog.LevelWarn,
+			"redis eviction policy mismatch",
+			slog.String("policy", policy),
+			slog.String("workload", string(cfg.Workload)),
+			slog.String("reason", reason),
+		)
+	}
+}
+
+// startEvictionPolicyCheck checks the policy once and, unless disabled,
+// keeps checking it in the background.
+func (c *Client) startEvictionPolicyCheck(cfg EvictionPolicyConfig) error {
+	state := &evictionPolicyState{}
+
+	registration, err := c.metrics.registerEvictionPolicyMismatch(state.mismatch.Load)
+	if err != nil {
+		return err
+	}
+
+	c.addRegistration(registration)
+	c.checkEvictionPolicy(cfg, state)
+
+	if cfg.CheckInterval > 0 {
+		c.goBackground("eviction_policy_check", func(done <-chan struct{}) {
+			c.watchEvictionPolicy(cfg, state, done)
+		})
+	}
+
+	return nil
+}
+
+func (c *Client) watchEvictionPolicy(cfg EvictionPolicyConfig, state *evictionPolicyState, done <-chan struct{}) {
+	ticker := time.NewTicker(cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			c.checkEvictionPolicy(cfg, state)
+		}
+	}
+}
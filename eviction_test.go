@@ -0,0 +1,94 @@
+package xredis_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+// policyRecording returns a recording that answers CONFIG GET maxmemory-policy
+// with policies in order.
+func policyRecording(policies ...string) *bytes.Buffer {
+	var recording bytes.Buffer
+
+	encoder := json.NewEncoder(&recording)
+	for _, policy := range policies {
+		Expect(encoder.Encode(xredis.RecordedCommand{
+			Args:  [][]byte{[]byte("config"), []byte("get"), []byte("maxmemory-policy")},
+			Reply: fmt.Appendf(nil, "*2\r\n$16\r\nmaxmemory-policy\r\n$%d\r\n%s\r\n", len(policy), policy),
+		})).To(Succeed())
+	}
+
+	return &recording
+}
+
+var _ = Describe("Eviction policy check", func() {
+	DescribeTable("warns about dangerous policies on startup",
+		func(workload xredis.Workload, policy string, warned bool) {
+			var output syncBuffer
+
+			client, err := xredis.NewReplayClient(
+				policyRecording(policy),
+				xredis.WithLogger(slog.New(slog.NewJSONHandler(&output, nil))),
+				xredis.WithEvictionPolicyCheck(xredis.EvictionPolicyConfig{Workload: workload, CheckInterval: -1}),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.Close()).To(Succeed())
+
+			if warned {
+				Expect(output.String()).To(ContainSubstring(`"msg":"redis eviction policy mismatch"`))
+				Expect(output.String()).To(ContainSubstring(`"policy":"` + policy + `"`))
+				Expect(output.String()).To(ContainSubstring(`"workload":"` + string(workload) + `"`))
+			} else {
+				Expect(output.String()).NotTo(ContainSubstring("eviction policy"))
+			}
+		},
+		Entry("noeviction cache", xredis.WorkloadCache, "noeviction", true),
+		Entry("allkeys-lru cache", xredis.WorkloadCache, "allkeys-lru", false),
+		Entry("allkeys-lfu store", xredis.WorkloadStore, "allkeys-lfu", true),
+		Entry("noeviction store", xredis.WorkloadStore, "noeviction", false),
+		Entry("volatile-lru store", xredis.WorkloadStore, "volatile-lru", false),
+	)
+
+	It("rechecks the policy periodically", func() {
+		var output syncBuffer
+
+		client, err := xredis.NewReplayClient(
+			policyRecording("allkeys-lru", "noeviction"),
+			xredis.WithLogger(slog.New(slog.NewJSONHandler(&output, nil))),
+			xredis.WithEvictionPolicyCheck(xredis.EvictionPolicyConfig{
+				Workload:      xredis.WorkloadCache,
+				CheckInterval: 10 * time.Millisecond,
+			}),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Eventually(output.String).Should(ContainSubstring(`"policy":"noeviction"`))
+		Consistently(func() int {
+			return bytes.Count([]byte(output.String()), []byte("eviction policy mismatch"))
+		}, 50*time.Millisecond).Should(Equal(1))
+	})
+
+	It("ignores servers that do not expose CONFIG", func() {
+		var output syncBuffer
+
+		client, err := xredis.NewReplayClient(
+			policyRecording(),
+			xredis.WithLogger(slog.New(slog.NewJSONHandler(&output, nil))),
+			xredis.WithEvictionPolicyCheck(xredis.EvictionPolicyConfig{Workload: xredis.WorkloadCache}),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.Close()).To(Succeed())
+
+		Expect(output.String()).NotTo(ContainSubstring("eviction policy"))
+	})
+})
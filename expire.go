@@ -0,0 +1,68 @@
+package xredis
+
+import (
+	"context"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// ExpireMode controls how a write applies its TTL to an existing key.
+type ExpireMode int
+
+const (
+	// ExpireAlways sets the TTL on every write, replacing the current
+	// expiration.
+	ExpireAlways ExpireMode = iota
+
+	// ExpireNX sets the TTL only when the key has no expiration, like
+	// EXPIRE NX. Repeated writes do not extend the key lifetime.
+	ExpireNX
+
+	// ExpireNever never changes the key expiration. The TTL is ignored.
+	ExpireNever
+)
+
+// expireNXScript sets a key expiration only when the key has none.
+//
+// It is equivalent to PEXPIRE NX, which requires Redis 7.0.
+//
+// KEYS[1] - key
+// ARGV[1] - TTL in milliseconds
+//
+// Returns 1 when the expiration was set and 0 otherwise.
+var expireNXScript = rdb.NewScript(`
+if redis.call("PTTL", KEYS[1]) ~= -1 then
+	return 0
+end
+
+return redis.call("PEXPIRE", KEYS[1], ARGV[1])
+`)
+
+func validateExpireMode(mode ExpireMode) error {
+	switch mode {
+	case ExpireAlways, ExpireNX, ExpireNever:
+		return nil
+	default:
+		return ErrInvalidTTL
+	}
+}
+
+// appendExpire queues the expiration of key according to mode.
+//
+// It queues nothing when ttl is zero or mode is ExpireNever.
+func appendExpire(ctx context.Context, pipe rdb.Pipeliner, key string, ttl time.Duration, mode ExpireMode) {
+	if ttl == 0 {
+		return
+	}
+
+	switch mode {
+	case ExpireAlways:
+		pipe.Expire(ctx, key, ttl)
+
+	case ExpireNX:
+		expireNXScript.Eval(ctx, pipe, []string{key}, max(ttl.Milliseconds(), 1))
+
+	case ExpireNever:
+	}
+}
@@ -0,0 +1,323 @@
+package xredis
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	expireDeadlinesKey   = "xredis:expire:deadlines"
+	expireScanInterval   = time.Second
+	expireScanBatch      = 100
+	expireHandlerTimeout = 30 * time.Second
+)
+
+// ExpireTracked sets the expiration of key to ttl and records its deadline,
+// so the OnExpire handlers matching key run even when the expiration
+// notification is missed.
+//
+// It returns false when key does not exist.
+func (c *Client) ExpireTracked(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	if ttl <= 0 {
+		return false, ErrInvalidTTL
+	}
+
+	// Deadlines name the key as stored, like expiration notifications.
+	stored := key
+	if c.opts.keyHasher != nil {
+		stored, _ = c.opts.keyHasher.hashKey(key)
+	}
+
+	// The deadline is recorded first: a deadline without an expiration is
+	// dropped by the scan, while an expiration without a deadline would only
+	// be observed through its notification.
+	deadline := rdb.Z{Score: float64(time.Now().Add(ttl).UnixMilli()), Member: stored}
+	if err := c.conn.ZAdd(ctx, expireDeadlinesKey, deadline).Err(); err != nil {
+		return false, err
+	}
+
+	ok, err := c.conn.PExpire(ctx, key, ttl).Result()
+	if err != nil {
+		return false, err
+	}
+
+	if !ok {
+		if err = c.conn.ZRem(ctx, expireDeadlinesKey, stored).Err(); err != nil {
+			return false, err
+		}
+	}
+
+	return ok, nil
+}
+
+// ExpireWatcher runs a handler for expired keys. See OnExpire.
+type ExpireWatcher struct {
+	client  *Client
+	pattern string
+	handler func(ctx context.Context, key string) error
+
+	pubsubs []*rdb.PubSub
+	healths []*subscriptionHealth
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// OnExpire runs handler for every key matching pattern, in path.Match
+// syntax, whose expiration was set with ExpireTracked, for business logic
+// that must run when a key expires, such as cancelling unpaid orders.
+//
+// Expirations are observed through the "expired" keyevent notifications,
+// and a scan of the recorded deadlines every second catches the ones whose
+// notification was missed, for example during a reconnect or while no
+// instance was running. Every expiration is claimed by removing its deadline,
+// so it is handled once by one watcher, even when several instances or
+// overlapping patterns observe it. When handler fails, the expiration is
+// handled again by a later scan.
+//
+// handler receives the key as stored, after WithKeyHasher, and runs on the
+// watcher goroutines, one expiration at a time per goroutine.
+//
+// Keyspace notifications must be enabled on the server, for example with
+// "notify-keyspace-events Ex". Without them, handlers only run from the scan.
+// In Redis Cluster and Ring, every primary or shard is subscribed when
+// OnExpire is called.
+func (c *Client) OnExpire(
+	ctx context.Context,
+	pattern string,
+	handler func(ctx context.Context, key string) error,
+) (*ExpireWatcher, error) {
+	if handler == nil {
+		return nil, fmt.Errorf("%w: expire handler is nil", ErrInvalidConfig)
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("%w: invalid expire pattern %q", ErrInvalidConfig, pattern)
+	}
+
+	conns, err := c.expireEventConns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &ExpireWatcher{
+		client:  c,
+		pattern: pattern,
+		handler: handler,
+		done:    make(chan struct{}),
+	}
+
+	for _, conn := range conns {
+		channel := keyeventChannel(conn, "expired")
+		pubsub := conn.Subscribe(ctx, channel)
+
+		health, err := c.watchSubscription(ctx, pubsub, []string{channel})
+		if err != nil {
+			_ = w.Close()
+			return nil, err
+		}
+
+		w.pubsubs = append(w.pubsubs, pubsub)
+		w.healths = append(w.healths, health)
+
+		go w.receive(pubsub, health)
+	}
+
+	c.goBackground("expire_scan", w.scanLoop)
+
+	return w, nil
+}
+
+// Close stops running the handler and unsubscribes. Deadlines that are due
+// remain recorded for other watchers.
+func (w *ExpireWatcher) Close() error {
+	var err error
+
+	w.closeOnce.Do(func() {
+		close(w.done)
+
+		for i, health := range w.healths {
+			if closeErr := health.close(w.pubsubs[i]); closeErr != nil && err == nil {
+				err = closeErr
+			}
+		}
+	})
+
+	return err
+}
+
+// receive handles the expired keys reported by pubsub until the subscription
+// is closed.
+func (w *ExpireWatcher) receive(pubsub *rdb.PubSub, health *subscriptionHealth) {
+	for received := range pubsub.ChannelWithSubscriptions() {
+		switch msg := received.(type) {
+		case *rdb.Subscription:
+			health.confirm(msg)
+		case *rdb.Message:
+			if w.matches(msg.Payload) {
+				w.claim(msg.Payload)
+			}
+		}
+	}
+}
+
+func (w *ExpireWatcher) scanLoop(clientDone <-chan struct{}) {
+	ticker := time.NewTicker(expireScanInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-clientDone:
+			return
+		case <-w.done:
+			return
+		case <-ticker.C:
+			if err := w.scan(); err != nil {
+				w.client.logger.LogAttrs(context.Background(), slog.LevelWarn, "redis expire scan failed",
+					slog.String("pattern", w.pattern),
+					slog.Any("error", err),
+				)
+			}
+		}
+	}
+}
+
+// scan handles the matching keys whose recorded deadline passed, and moves
+// the deadlines of keys whose expiration was extended.
+func (w *ExpireWatcher) scan() error {
+	ctx, cancel := context.WithTimeout(context.Background(), expireHandlerTimeout)
+	defer cancel()
+
+	conn := w.client.conn
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+
+	// Deadlines of other patterns stay in the set, so the scan pages past
+	// them. Deadlines skipped because earlier ones were removed are handled by
+	// the next scan.
+	for offset := int64(0); ; offset += expireScanBatch {
+		due, err := conn.ZRangeArgs(ctx, rdb.ZRangeArgs{
+			Key:     expireDeadlinesKey,
+			Start:   "-inf",
+			Stop:    now,
+			ByScore: true,
+			Offset:  offset,
+			Count:   expireScanBatch,
+		}).Result()
+		if err != nil {
+			return err
+		}
+
+		for _, key := range due {
+			if err = w.handleDue(ctx, key); err != nil {
+				return err
+			}
+		}
+
+		if len(due) < expireScanBatch {
+			return nil
+		}
+	}
+}
+
+// handleDue claims key when it expired and otherwise updates its deadline.
+func (w *ExpireWatcher) handleDue(ctx context.Context, key string) error {
+	if !w.matches(key) {
+		return nil
+	}
+
+	conn := w.client.conn
+
+	// Deadlines name the key as stored, after WithKeyHasher.
+	ttl, err := conn.PTTL(withHashedKeys(ctx), key).Result()
+	if err != nil {
+		return err
+	}
+
+	switch ttl {
+	case -2:
+		w.claim(key)
+		return nil
+	case -1:
+		// The expiration was removed, so the key no longer expires.
+		return conn.ZRem(ctx, expireDeadlinesKey, key).Err()
+	default:
+		deadline := rdb.Z{Score: float64(time.Now().Add(ttl).UnixMilli()), Member: key}
+		return conn.ZAddXX(ctx, expireDeadlinesKey, deadline).Err()
+	}
+}
+
+// claim removes the deadline of key and runs the handler when this watcher
+// removed it. A failed handler records the deadline again for the next scan.
+func (w *ExpireWatcher) claim(key string) {
+	select {
+	case <-w.done:
+		return
+	default:
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), expireHandlerTimeout)
+	defer cancel()
+
+	conn := w.client.conn
+
+	removed, err := conn.ZRem(ctx, expireDeadlinesKey, key).Result()
+	if err != nil || removed == 0 {
+		return
+	}
+
+	if err = w.handler(ctx, key); err == nil {
+		return
+	}
+
+	w.client.logger.LogAttrs(ctx, slog.LevelWarn, "redis expire handler failed",
+		slog.String("key", key),
+		slog.Any("error", err),
+	)
+
+	retry := rdb.Z{Score: float64(time.Now().UnixMilli()), Member: key}
+	_ = conn.ZAdd(context.WithoutCancel(ctx), expireDeadlinesKey, retry).Err()
+}
+
+func (w *ExpireWatcher) matches(key string) bool {
+	ok, _ := path.Match(w.pattern, key)
+	return ok
+}
+
+// expireEventConns returns the connections that receive the expiration
+// notifications of all keys.
+func (c *Client) expireEventConns(ctx context.Context) ([]rdb.UniversalClient, error) {
+	var (
+		mu    sync.Mutex
+		conns []rdb.UniversalClient
+	)
+
+	collect := func(_ context.Context, client *rdb.Client) error {
+		mu.Lock()
+		conns = append(conns, client)
+		mu.Unlock()
+
+		return nil
+	}
+
+	switch conn := c.conn.(type) {
+	case *rdb.ClusterClient:
+		if err := conn.ForEachMaster(ctx, collect); err != nil {
+			return nil, err
+		}
+	case *rdb.Ring:
+		if err := conn.ForEachShard(ctx, collect); err != nil {
+			return nil, err
+		}
+	default:
+		conns = append(conns, c.pubsubConn())
+	}
+
+	return conns, nil
+}
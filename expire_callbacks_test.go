@@ -0,0 +1,122 @@
+package xredis_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("OnExpire", func() {
+	var (
+		client  *xredis.Client
+		events  string
+		expired chan string
+	)
+
+	BeforeEach(func() {
+		client = newTestClient()
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+
+		config, err := client.Raw().ConfigGet(ctx, "notify-keyspace-events").Result()
+		Expect(err).NotTo(HaveOccurred())
+		events = config["notify-keyspace-events"]
+
+		expired = make(chan string, 10)
+	})
+
+	AfterEach(func() {
+		Expect(client.Raw().ConfigSet(ctx, "notify-keyspace-events", events).Err()).To(Succeed())
+		Expect(client.Close()).To(Succeed())
+	})
+
+	handler := func(_ context.Context, key string) error {
+		expired <- key
+		return nil
+	}
+
+	It("runs the handler for matching keys from notifications", func() {
+		Expect(client.Raw().ConfigSet(ctx, "notify-keyspace-events", "Ex").Err()).To(Succeed())
+
+		watcher, err := client.OnExpire(ctx, "orders:*", handler)
+		Expect(err).NotTo(HaveOccurred())
+		defer watcher.Close()
+
+		Expect(client.Set(ctx, "orders:1", "unpaid", 0)).To(Succeed())
+		Expect(client.Set(ctx, "carts:1", "open", 0)).To(Succeed())
+
+		ok, err := client.ExpireTracked(ctx, "orders:1", 100*time.Millisecond)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		_, err = client.ExpireTracked(ctx, "carts:1", 100*time.Millisecond)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(expired, 3*time.Second).Should(Receive(Equal("orders:1")))
+		Consistently(expired, 1500*time.Millisecond).ShouldNot(Receive())
+	})
+
+	It("runs the handler from the deadline scan when notifications are disabled", func() {
+		Expect(client.Raw().ConfigSet(ctx, "notify-keyspace-events", "").Err()).To(Succeed())
+
+		watcher, err := client.OnExpire(ctx, "orders:*", handler)
+		Expect(err).NotTo(HaveOccurred())
+		defer watcher.Close()
+
+		Expect(client.Set(ctx, "orders:2", "unpaid", 0)).To(Succeed())
+
+		_, err = client.ExpireTracked(ctx, "orders:2", 100*time.Millisecond)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(expired, 5*time.Second).Should(Receive(Equal("orders:2")))
+	})
+
+	It("retries a failed handler", func() {
+		Expect(client.Raw().ConfigSet(ctx, "notify-keyspace-events", "Ex").Err()).To(Succeed())
+
+		var calls atomic.Int32
+
+		watcher, err := client.OnExpire(ctx, "orders:*", func(ctx context.Context, key string) error {
+			if calls.Add(1) == 1 {
+				return errors.New("payment service unavailable")
+			}
+
+			return handler(ctx, key)
+		})
+		Expect(err).NotTo(HaveOccurred())
+		defer watcher.Close()
+
+		Expect(client.Set(ctx, "orders:3", "unpaid", 0)).To(Succeed())
+
+		_, err = client.ExpireTracked(ctx, "orders:3", 100*time.Millisecond)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(expired, 5*time.Second).Should(Receive(Equal("orders:3")))
+		Expect(calls.Load()).To(Equal(int32(2)))
+	})
+
+	It("does not track missing keys", func() {
+		ok, err := client.ExpireTracked(ctx, "orders:missing", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		count, err := client.Raw().ZCard(ctx, "xredis:expire:deadlines").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(BeZero())
+	})
+
+	It("validates arguments", func() {
+		_, err := client.OnExpire(ctx, "orders:[", handler)
+		Expect(err).To(MatchError(xredis.ErrInvalidConfig))
+
+		_, err = client.OnExpire(ctx, "orders:*", nil)
+		Expect(err).To(MatchError(xredis.ErrInvalidConfig))
+
+		_, err = client.ExpireTracked(ctx, "orders:1", 0)
+		Expect(err).To(MatchError(xredis.ErrInvalidTTL))
+	})
+})
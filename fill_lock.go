@@ -0,0 +1,161 @@
+package xredis
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	fillLockKeySuffix   = ":fill-lock"
+	fillChannelSuffix   = ":fill"
+	minFillPollInterval = 10 * time.Millisecond
+	maxFillPollInterval = time.Second
+	fillPollsPerTTL     = 10
+)
+
+// FillLock is the right to compute a missing value and publish it to callers
+// waiting in GetOrLock.
+type FillLock struct {
+	lock *Lock
+	key  string
+}
+
+// Key returns the key of the value being filled.
+func (l *FillLock) Key() string {
+	if l == nil {
+		return ""
+	}
+
+	return l.key
+}
+
+// Token returns the fill lock owner token.
+func (l *FillLock) Token() string {
+	if l == nil {
+		return ""
+	}
+
+	return l.lock.Token()
+}
+
+// GetOrLock returns the raw string value stored at key or, when the key does
+// not exist, a FillLock telling the caller to compute the value.
+//
+// Only one caller at a time receives a FillLock for a key. Other callers wait
+// until the value is filled: they are woken up by a Pub/Sub signal from Fill
+// or Release and also poll the key, so a lost signal only delays them. When
+// the fill lock expires because its owner died, one waiting caller receives
+// a new FillLock.
+//
+// ttl is the fill lock TTL and should exceed the time needed to compute the
+// value. ttl <= 0 returns ErrInvalidTTL. It returns ctx.Err() when ctx is done
+// before the value is filled.
+func (c *Client) GetOrLock(ctx context.Context, key string, ttl time.Duration) (string, *FillLock, error) {
+	if ttl <= 0 {
+		return "", nil, ErrInvalidTTL
+	}
+
+	value, lock, done, err := c.getOrTryFillLock(ctx, key, ttl)
+	if done || err != nil {
+		return value, lock, err
+	}
+
+	pubsub := c.pubsubConn().Subscribe(ctx, fillChannel(key))
+	defer func() {
+		_ = pubsub.Close()
+	}()
+
+	if _, err = pubsub.Receive(ctx); err != nil {
+		return "", nil, err
+	}
+
+	signals := pubsub.Channel()
+
+	ticker := time.NewTicker(fillPollInterval(ttl))
+	defer ticker.Stop()
+
+	for {
+		// Check again after subscribing, so a fill between the first check
+		// and the subscription is not missed.
+		value, lock, done, err = c.getOrTryFillLock(ctx, key, ttl)
+		if done || err != nil {
+			return value, lock, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", nil, ctx.Err()
+		case <-signals:
+		case <-ticker.C:
+		}
+	}
+}
+
+// getOrTryFillLock reads key and tries to acquire its fill lock when the key
+// does not exist. It returns done=false when another caller holds the lock.
+func (c *Client) getOrTryFillLock(
+	ctx context.Context,
+	key string,
+	ttl time.Duration,
+) (value string, lock *FillLock, done bool, err error) {
+	value, ok, err := c.String(ctx, key)
+	if err != nil || ok {
+		return value, nil, true, err
+	}
+
+	leaseLock, acquired, err := c.TryLock(ctx, fillLockKey(key), ttl)
+	if err != nil || !acquired {
+		return "", nil, false, err
+	}
+
+	return "", &FillLock{lock: leaseLock, key: key}, true, nil
+}
+
+// Fill stores value at the filled key with ttl, releases the fill lock, and
+// wakes up waiting callers.
+//
+// The value is passed directly to Redis without Codec encoding. ttl == 0
+// stores the value without expiration; ttl < 0 returns ErrInvalidTTL.
+//
+// The value is stored even when the fill lock has expired, because it is
+// still the freshest computed value.
+func (l *FillLock) Fill(ctx context.Context, value any, ttl time.Duration) error {
+	if l == nil || l.lock == nil || l.lock.client == nil {
+		return ErrInvalidLock
+	}
+
+	if err := l.lock.client.Set(ctx, l.key, value, ttl); err != nil {
+		return err
+	}
+
+	return l.Release(ctx)
+}
+
+// Release releases the fill lock without storing a value and wakes up waiting
+// callers, so one of them can take over the fill.
+//
+// Releasing a fill lock that expired or was taken over is not an error.
+func (l *FillLock) Release(ctx context.Context) error {
+	if l == nil || l.lock == nil || l.lock.client == nil {
+		return ErrInvalidLock
+	}
+
+	if err := l.lock.Unlock(ctx); err != nil && !errors.Is(err, ErrLockNotOwned) {
+		return err
+	}
+
+	return l.lock.client.conn.Publish(ctx, fillChannel(l.key), l.lock.Token()).Err()
+}
+
+func fillLockKey(key string) string {
+	return key + fillLockKeySuffix
+}
+
+func fillChannel(key string) string {
+	return key + fillChannelSuffix
+}
+
+func fillPollInterval(ttl time.Duration) time.Duration {
+	return min(max(ttl/fillPollsPerTTL, minFillPollInterval), maxFillPollInterval)
+}
@@ -0,0 +1,110 @@
+package xredis_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("GetOrLock", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient()
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("returns existing values without a lock", func() {
+		Expect(client.Set(ctx, "fill:value", "cached", 0)).To(Succeed())
+
+		value, lock, err := client.GetOrLock(ctx, "fill:value", time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lock).To(BeNil())
+		Expect(value).To(Equal("cached"))
+	})
+
+	It("lets one caller compute the value while others wait for it", func() {
+		_, lock, err := client.GetOrLock(ctx, "fill:value", 5*time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lock).NotTo(BeNil())
+		Expect(lock.Key()).To(Equal("fill:value"))
+
+		var (
+			wg     sync.WaitGroup
+			mu     sync.Mutex
+			values []string
+		)
+
+		for range 3 {
+			wg.Add(1)
+
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				value, waiterLock, err := client.GetOrLock(ctx, "fill:value", 5*time.Second)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(waiterLock).To(BeNil())
+
+				mu.Lock()
+				values = append(values, value)
+				mu.Unlock()
+			}()
+		}
+
+		time.Sleep(100 * time.Millisecond)
+		Expect(lock.Fill(ctx, "computed", time.Minute)).To(Succeed())
+
+		wg.Wait()
+		Expect(values).To(Equal([]string{"computed", "computed", "computed"}))
+	})
+
+	It("hands the fill over after a release", func() {
+		_, lock, err := client.GetOrLock(ctx, "fill:value", 5*time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lock).NotTo(BeNil())
+
+		next := make(chan *xredis.FillLock, 1)
+
+		go func() {
+			defer GinkgoRecover()
+
+			_, waiterLock, err := client.GetOrLock(ctx, "fill:value", 5*time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			next <- waiterLock
+		}()
+
+		time.Sleep(100 * time.Millisecond)
+		Expect(lock.Release(ctx)).To(Succeed())
+
+		var waiterLock *xredis.FillLock
+		Eventually(next, time.Second).Should(Receive(&waiterLock))
+		Expect(waiterLock).NotTo(BeNil())
+		Expect(waiterLock.Token()).NotTo(Equal(lock.Token()))
+	})
+
+	It("stops waiting when the context is done", func() {
+		_, lock, err := client.GetOrLock(ctx, "fill:value", 5*time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lock).NotTo(BeNil())
+
+		waitCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+
+		_, _, err = client.GetOrLock(waitCtx, "fill:value", 5*time.Second)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+
+	It("rejects a non-positive ttl", func() {
+		_, _, err := client.GetOrLock(ctx, "fill:value", 0)
+		Expect(err).To(MatchError(xredis.ErrInvalidTTL))
+	})
+})
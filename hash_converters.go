@@ -0,0 +1,168 @@
+package xredis
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+)
+
+// HashConverter converts values of a custom scalar type, such as a UUID,
+// a decimal, or an enum, to and from hash field values.
+type HashConverter[T any] struct {
+	// ToRedis returns the stored field value of v.
+	ToRedis func(v T) (string, error)
+
+	// FromRedis parses a stored field value.
+	FromRedis func(s string) (T, error)
+}
+
+// hashConverter is a HashConverter with its type erased.
+type hashConverter struct {
+	toRedis   func(v any) (string, error)
+	fromRedis func(s string) (any, error)
+}
+
+// hashConverters maps value types to their converters.
+type hashConverters map[reflect.Type]hashConverter
+
+// convert returns the stored field value of v and ok=true when the type of v
+// has a converter.
+func (h hashConverters) convert(name string, v any) (any, bool, error) {
+	if len(h) == 0 || v == nil {
+		return v, false, nil
+	}
+
+	rv := reflect.ValueOf(v)
+
+	converter, ok := h[rv.Type()]
+	if !ok && rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		if converter, ok = h[rv.Type().Elem()]; ok {
+			v = rv.Elem().Interface()
+		}
+	}
+
+	if !ok {
+		return v, false, nil
+	}
+
+	s, err := converter.toRedis(v)
+	if err != nil {
+		return nil, true, fmt.Errorf("%w: field %q: %w", ErrInvalidHashObject, name, err)
+	}
+
+	return s, true, nil
+}
+
+// convertMap returns the field-value pairs of fields with converted values,
+// or ok=false when no value has a converter.
+func (h hashConverters) convertMap(fields map[string]any) ([]any, bool, error) {
+	if len(h) == 0 {
+		return nil, false, nil
+	}
+
+	var (
+		pairs     = make([]any, 0, 2*len(fields))
+		converted bool
+	)
+
+	for name, value := range fields {
+		value, ok, err := h.convert(name, value)
+		if err != nil {
+			return nil, false, err
+		}
+
+		converted = converted || ok
+		pairs = append(pairs, name, value)
+	}
+
+	if !converted {
+		return nil, false, nil
+	}
+
+	return pairs, true, nil
+}
+
+// converterFor returns the converter of fields of type typ, or of *T fields
+// of a registered type T.
+func (h hashConverters) converterFor(typ reflect.Type) (hashConverter, bool) {
+	if converter, ok := h[typ]; ok {
+		return converter, true
+	}
+
+	if typ.Kind() == reflect.Pointer {
+		converter, ok := h[typ.Elem()]
+		return converter, ok
+	}
+
+	return hashConverter{}, false
+}
+
+// scanField parses value with the converter of field and stores it in field.
+func (h hashConverters) scanField(field reflect.Value, converter hashConverter, name, value string) error {
+	parsed, err := converter.fromRedis(value)
+	if err != nil {
+		return fmt.Errorf("%w: field %q: %w", ErrInvalidEntry, name, err)
+	}
+
+	v := reflect.ValueOf(parsed)
+	if !v.IsValid() {
+		field.SetZero()
+		return nil
+	}
+
+	if field.Kind() == reflect.Pointer && v.Type() != field.Type() {
+		ptr := reflect.New(field.Type().Elem())
+		ptr.Elem().Set(v)
+		v = ptr
+	}
+
+	field.Set(v)
+
+	return nil
+}
+
+// scanStruct scans the fields of the struct dst that have converters and
+// returns values without them, for go-redis to scan the other fields.
+func (h hashConverters) scanStruct(values map[string]string, dst any) (map[string]string, error) {
+	if len(h) == 0 {
+		return values, nil
+	}
+
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return values, nil
+	}
+
+	v = v.Elem()
+
+	rest := values
+
+	for _, field := range hashStructOf(v.Type()).fields {
+		converter, ok := h.converterFor(field.typ)
+		if !ok {
+			continue
+		}
+
+		value, ok := values[field.name]
+		if !ok {
+			continue
+		}
+
+		if len(rest) == len(values) {
+			rest = maps.Clone(values)
+		}
+
+		delete(rest, field.name)
+
+		target, ok := hashFieldByIndex(v, field.index, true)
+		if !ok || !target.CanSet() {
+			continue
+		}
+
+		if err := h.scanField(target, converter, field.name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return rest, nil
+}
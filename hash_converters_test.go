@@ -0,0 +1,109 @@
+package xredis_test
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+type testMoney struct {
+	Cents    int64
+	Currency string
+}
+
+var testMoneyConverter = xredis.HashConverter[testMoney]{
+	ToRedis: func(v testMoney) (string, error) {
+		if v.Currency == "" {
+			return "", errors.New("missing currency")
+		}
+
+		return fmt.Sprintf("%d %s", v.Cents, v.Currency), nil
+	},
+	FromRedis: func(s string) (testMoney, error) {
+		cents, currency, ok := strings.Cut(s, " ")
+		if !ok {
+			return testMoney{}, fmt.Errorf("malformed amount %q", s)
+		}
+
+		n, err := strconv.ParseInt(cents, 10, 64)
+		if err != nil {
+			return testMoney{}, err
+		}
+
+		return testMoney{Cents: n, Currency: currency}, nil
+	},
+}
+
+type testInvoiceHash struct {
+	ID       string     `redis:"id"`
+	Total    testMoney  `redis:"total"`
+	Discount *testMoney `redis:"discount,omitempty"`
+}
+
+var _ = Describe("Hash converters", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient(xredis.WithHashConverter(testMoneyConverter))
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("writes and reads struct fields with converters", func() {
+		expected := testInvoiceHash{
+			ID:       "42",
+			Total:    testMoney{Cents: 1250, Currency: "EUR"},
+			Discount: &testMoney{Cents: 100, Currency: "EUR"},
+		}
+
+		Expect(client.HSet(ctx, "invoice:42", 0, expected)).To(Equal(int64(3)))
+
+		fields, err := client.Raw().HGetAll(ctx, "invoice:42").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fields).To(Equal(map[string]string{"id": "42", "total": "1250 EUR", "discount": "100 EUR"}))
+
+		var actual testInvoiceHash
+		ok, err := client.HGetAll(ctx, "invoice:42", &actual)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(actual).To(Equal(expected))
+
+		errs := client.Batch().HGetAll("invoice:42", &actual).Exec(ctx)
+		Expect(errs).To(HaveEach(Succeed()))
+	})
+
+	It("converts field-value pairs and map values", func() {
+		Expect(client.HSet(ctx, "invoice:1", 0, "total", testMoney{Cents: 5, Currency: "USD"})).Error().To(Succeed())
+		Expect(client.HSetMap(ctx, "invoice:2", map[string]any{
+			"id":    "2",
+			"total": &testMoney{Cents: 7, Currency: "USD"},
+		}, 0)).Error().To(Succeed())
+
+		Expect(client.Raw().HGet(ctx, "invoice:1", "total").Val()).To(Equal("5 USD"))
+		Expect(client.Raw().HGet(ctx, "invoice:2", "total").Val()).To(Equal("7 USD"))
+	})
+
+	It("reports conversion errors", func() {
+		_, err := client.HSet(ctx, "invoice:1", 0, "total", testMoney{Cents: 5})
+		Expect(err).To(MatchError(xredis.ErrInvalidHashObject))
+		Expect(err).To(MatchError(ContainSubstring("missing currency")))
+
+		errs := client.Batch().HSet("invoice:1", 0, testInvoiceHash{ID: "1"}).Exec(ctx)
+		Expect(errs[0]).To(MatchError(xredis.ErrInvalidHashObject))
+
+		Expect(client.Raw().HSet(ctx, "invoice:2", "total", "free").Err()).To(Succeed())
+
+		var actual testInvoiceHash
+		_, err = client.HGetAll(ctx, "invoice:2", &actual)
+		Expect(err).To(MatchError(xredis.ErrInvalidEntry))
+		Expect(err).To(MatchError(ContainSubstring(`field "total"`)))
+	})
+})
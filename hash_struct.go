@@ -0,0 +1,360 @@
+package xredis
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// hashStructField is a hash field declared with a "redis" tag by a struct
+// type, directly or through embedded structs.
+type hashStructField struct {
+	name      string
+	index     []int
+	typ       reflect.Type
+	omitEmpty bool
+}
+
+// hashStructGroup contains the hash fields declared by one embedded struct.
+type hashStructGroup struct {
+	index  []int
+	fields []string
+}
+
+// hashStruct maps the hash fields of a struct type.
+type hashStruct struct {
+	fields []hashStructField
+
+	// embedded contains the embedded structs with promoted fields. go-redis
+	// maps only the fields declared by the struct itself.
+	embedded []hashStructGroup
+}
+
+// hashStructs caches the hash field mapping of struct types.
+var hashStructs sync.Map // map[reflect.Type]*hashStruct
+
+// hashStructOf returns the hash field mapping of the struct type typ.
+func hashStructOf(typ reflect.Type) *hashStruct {
+	if cached, ok := hashStructs.Load(typ); ok {
+		return cached.(*hashStruct)
+	}
+
+	mapping := newHashStruct(typ)
+	hashStructs.Store(typ, mapping)
+
+	return mapping
+}
+
+// newHashStruct maps the tagged fields of typ and promotes the tagged fields
+// of untagged embedded structs like encoding/json: a field hides fields with
+// the same name at a deeper embedding level, and names declared twice at the
+// same level are dropped.
+func newHashStruct(typ reflect.Type) *hashStruct {
+	type candidate struct {
+		typ   reflect.Type
+		index []int
+	}
+
+	var (
+		mapping hashStruct
+		taken   = make(map[string]struct{})
+		visited = make(map[reflect.Type]struct{})
+		next    = []candidate{{typ: typ}}
+	)
+
+	for len(next) > 0 {
+		current := next
+		next = nil
+
+		var level []hashStructField
+
+		for _, c := range current {
+			if _, ok := visited[c.typ]; ok {
+				continue
+			}
+
+			visited[c.typ] = struct{}{}
+
+			for i := range c.typ.NumField() {
+				field := c.typ.Field(i)
+				tag := field.Tag.Get("redis")
+				index := append(slices.Clip(c.index), i)
+
+				if field.Anonymous && tag == "" {
+					if embedded := embeddedHashStruct(field); embedded != nil {
+						next = append(next, candidate{typ: embedded, index: index})
+					}
+
+					continue
+				}
+
+				name, options, _ := strings.Cut(tag, ",")
+				if name == "" || name == "-" || !field.IsExported() {
+					continue
+				}
+
+				level = append(level, hashStructField{
+					name:      name,
+					index:     index,
+					typ:       field.Type,
+					omitEmpty: slices.Contains(strings.Split(options, ","), "omitempty"),
+				})
+			}
+		}
+
+		counts := make(map[string]int, len(level))
+		for _, field := range level {
+			counts[field.name]++
+		}
+
+		for _, field := range level {
+			if _, ok := taken[field.name]; ok || counts[field.name] > 1 {
+				continue
+			}
+
+			mapping.add(field)
+		}
+
+		for name := range counts {
+			taken[name] = struct{}{}
+		}
+	}
+
+	return &mapping
+}
+
+func (h *hashStruct) add(field hashStructField) {
+	h.fields = append(h.fields, field)
+
+	if len(field.index) == 1 {
+		return
+	}
+
+	parent := field.index[:len(field.index)-1]
+
+	i := slices.IndexFunc(h.embedded, func(group hashStructGroup) bool {
+		return slices.Equal(group.index, parent)
+	})
+	if i < 0 {
+		h.embedded = append(h.embedded, hashStructGroup{index: parent})
+		i = len(h.embedded) - 1
+	}
+
+	h.embedded[i].fields = append(h.embedded[i].fields, field.name)
+}
+
+// embeddedHashStruct returns the struct type of an embedded field whose
+// fields are promoted, or nil when the field is not promoted. Embedded
+// structs of unexported types are not promoted.
+func embeddedHashStruct(field reflect.StructField) reflect.Type {
+	typ := field.Type
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct || !field.IsExported() {
+		return nil
+	}
+
+	return typ
+}
+
+// flattenHashValues expands struct values with embedded structs or fields
+// with converters into field-value pairs, so HSET also writes their promoted
+// fields, and converts values with converters. Other values are passed to
+// go-redis unchanged.
+func flattenHashValues(values []any, converters hashConverters) ([]any, error) {
+	var flat []any
+
+	for i, value := range values {
+		var name string
+		if i > 0 {
+			name, _ = values[i-1].(string)
+		}
+
+		pairs, ok, err := flattenHashValue(name, value, converters)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			if flat != nil {
+				flat = append(flat, value)
+			}
+
+			continue
+		}
+
+		if flat == nil {
+			flat = slices.Clone(values[:i])
+		}
+
+		flat = append(flat, pairs...)
+	}
+
+	if flat == nil {
+		return values, nil
+	}
+
+	return flat, nil
+}
+
+// flattenHashValue returns the values that replace the HSET argument value,
+// or ok=false when value is passed unchanged. name is the preceding field
+// name, if any.
+func flattenHashValue(name string, value any, converters hashConverters) ([]any, bool, error) {
+	if converted, ok, err := converters.convert(name, value); ok || err != nil {
+		return []any{converted}, ok, err
+	}
+
+	if fields, ok := value.(map[string]any); ok {
+		return converters.convertMap(fields)
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, false, nil
+	}
+
+	mapping := hashStructOf(v.Type())
+	if len(mapping.embedded) == 0 && !mapping.hasConverters(converters) {
+		return nil, false, nil
+	}
+
+	pairs, err := mapping.appendValues(nil, v, converters)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return pairs, true, nil
+}
+
+// hasConverters reports whether a field of the struct has a converter.
+func (h *hashStruct) hasConverters(converters hashConverters) bool {
+	if len(converters) == 0 {
+		return false
+	}
+
+	return slices.ContainsFunc(h.fields, func(field hashStructField) bool {
+		_, ok := converters.converterFor(field.typ)
+		return ok
+	})
+}
+
+// appendValues appends the fields of the struct v as field-value pairs,
+// skipping fields of nil embedded pointers and empty omitempty fields like
+// go-redis, and converting fields with converters.
+func (h *hashStruct) appendValues(dst []any, v reflect.Value, converters hashConverters) ([]any, error) {
+	for _, field := range h.fields {
+		value, ok := hashFieldByIndex(v, field.index, false)
+		if !ok || !value.CanInterface() || (field.omitEmpty && isEmptyHashValue(value)) {
+			continue
+		}
+
+		converted, _, err := converters.convert(field.name, value.Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		dst = append(dst, field.name, converted)
+	}
+
+	return dst, nil
+}
+
+// scanEmbeddedHashFields scans the promoted fields of values into the
+// embedded structs of dst, allocating nil embedded pointers that receive
+// fields.
+func scanEmbeddedHashFields(values map[string]string, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	v = v.Elem()
+
+	for _, group := range hashStructOf(v.Type()).embedded {
+		fields := make(map[string]string, len(group.fields))
+		for _, name := range group.fields {
+			if value, ok := values[name]; ok {
+				fields[name] = value
+			}
+		}
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		embedded, ok := hashFieldByIndex(v, group.index, true)
+		if !ok {
+			continue
+		}
+
+		if embedded.Kind() == reflect.Pointer {
+			if embedded.IsNil() {
+				embedded.Set(reflect.New(embedded.Type().Elem()))
+			}
+
+			embedded = embedded.Elem()
+		}
+
+		if err := rdb.NewMapStringStringResult(fields, nil).Scan(embedded.Addr().Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hashFieldByIndex returns the nested field of v at index. Nil embedded
+// pointers are allocated when alloc is set and report ok=false otherwise.
+func hashFieldByIndex(v reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !alloc || !v.CanSet() {
+					return reflect.Value{}, false
+				}
+
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(x)
+	}
+
+	return v, true
+}
+
+// isEmptyHashValue reports whether an omitempty field is skipped, following
+// go-redis.
+func isEmptyHashValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	case reflect.Struct:
+		return v.Type() == reflect.TypeFor[time.Time]() && v.IsZero()
+	default:
+		return false
+	}
+}
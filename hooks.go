@@ -0,0 +1,104 @@
+package xredis
+
+import (
+	"strings"
+	"sync/atomic"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// addHook installs hook on conn.
+//
+// Process hooks run on the top-level client, so Redis Cluster and Ring hooks
+// observe commands after redirects and shard routing. Dial hooks are installed
+// on every node client because Cluster and Ring clients dial through them.
+func addHook(conn rdb.UniversalClient, hook rdb.Hook) {
+	conn.AddHook(hook)
+
+	switch conn := conn.(type) {
+	case *rdb.ClusterClient:
+		conn.OnNewNode(func(node *rdb.Client) {
+			node.AddHook(nodeDialHook{hook: hook})
+		})
+	case *rdb.Ring:
+		conn.OnNewNode(func(node *rdb.Client) {
+			node.AddHook(nodeDialHook{hook: hook})
+		})
+	}
+}
+
+// nodeDialHook forwards only the dial hook of a top-level hook to a node client.
+type nodeDialHook struct {
+	hook rdb.Hook
+}
+
+func (h nodeDialHook) DialHook(next rdb.DialHook) rdb.DialHook {
+	return h.hook.DialHook(next)
+}
+
+func (nodeDialHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return next
+}
+
+func (nodeDialHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return next
+}
+
+// passDialHook is embedded by hooks that do not intercept dialing.
+type passDialHook struct{}
+
+func (passDialHook) DialHook(next rdb.DialHook) rdb.DialHook {
+	return next
+}
+
+// connChains tells the hook chain of the client apart from the chains that
+// go-redis builds for Tx, such as in Watch, and Conn. They reuse the client
+// hooks, but every command of a Tx or Conn must stay on its connection.
+//
+// go-redis builds the client chain while hooks are added and the Tx and Conn
+// chains later, so chains built after seal are pinned to one connection. A
+// hook added through Raw after New also rebuilds the client chain, which then
+// keeps every command on the main pool as well.
+type connChains struct {
+	sealed atomic.Bool
+}
+
+// seal marks the chains built from now on as pinned.
+func (c *connChains) seal() {
+	c.sealed.Store(true)
+}
+
+// pinned reports whether the chain being built is pinned to one connection.
+func (c *connChains) pinned() bool {
+	return c.sealed.Load()
+}
+
+// isConnectionSetupCmd reports whether cmd is sent by go-redis while
+// initializing a new connection.
+//
+// go-redis runs connection handshake commands through client hooks, and
+// tolerates some of their errors, such as CLIENT SETINFO on older servers.
+func isConnectionSetupCmd(cmd rdb.Cmder) bool {
+	var sub string
+	if args := cmd.Args(); len(args) > 1 {
+		sub, _ = args[1].(string)
+	}
+
+	return isConnectionSetup(cmd.Name(), sub)
+}
+
+// isConnectionSetup reports whether a command with the lowercase name and
+// the subcommand sub is a connection handshake command.
+func isConnectionSetup(name, sub string) bool {
+	switch name {
+	case "hello", "auth", "select", "readonly":
+		return true
+	case "client":
+		switch strings.ToLower(sub) {
+		case "setname", "setinfo", "maint_notifications":
+			return true
+		}
+	}
+
+	return false
+}
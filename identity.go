@@ -0,0 +1,50 @@
+package xredis
+
+import (
+	"context"
+	"strings"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// ClientIdentity describes a pooled connection as Redis sees it.
+type ClientIdentity struct {
+	// ClientInfo is the CLIENT INFO reply of the connection, including its
+	// id, addr, resp, and lib-name fields.
+	rdb.ClientInfo
+
+	// NameApplied reports whether Redis reports the client ID configured with
+	// WithClientID as the connection name.
+	NameApplied bool
+
+	// SuffixApplied reports whether the library name reported by Redis
+	// contains the suffix configured with WithIdentitySuffix. It is true
+	// without a suffix. Redis reports library names since version 7.2.
+	SuffixApplied bool
+}
+
+// WhoAmI returns the CLIENT INFO of a pooled connection and verifies that the
+// configured client identity was applied to it.
+//
+// It helps to tell from the server side, for example in CLIENT LIST or the
+// slow log, which service a connection belongs to. For Redis Cluster and Ring
+// clients, the connection belongs to one of the nodes.
+func (c *Client) WhoAmI(ctx context.Context) (*ClientIdentity, error) {
+	info, err := c.conn.ClientInfo(ctx).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &ClientIdentity{
+		ClientInfo:    *info,
+		NameApplied:   info.Name == c.opts.clientID,
+		SuffixApplied: true,
+	}
+
+	if suffix := c.opts.identitySuffix; suffix != "" {
+		// go-redis reports the library name as go-redis(<suffix>,<go version>).
+		identity.SuffixApplied = strings.HasPrefix(info.LibName, "go-redis("+suffix+",")
+	}
+
+	return identity, nil
+}
@@ -0,0 +1,66 @@
+package xredis_test
+
+import (
+	"fmt"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+// clientInfoReply returns a CLIENT INFO reply for a connection with name and
+// lib-name.
+func clientInfoReply(name, libName string) []byte {
+	info := fmt.Sprintf(
+		"id=42 addr=10.0.0.7:51234 laddr=10.0.0.1:6379 fd=8 name=%s age=3 idle=0 flags=N db=0 sub=0 psub=0 "+
+			"ssub=0 multi=-1 watch=0 qbuf=26 qbuf-free=20448 argv-mem=10 multi-mem=0 rbs=1024 rbp=0 obl=0 oll=0 "+
+			"omem=0 tot-mem=22298 events=r cmd=client|info user=default redir=-1 resp=3 lib-name=%s lib-ver=9.21.0\n",
+		name, libName,
+	)
+
+	return []byte(fmt.Sprintf("$%d\r\n%s\r\n", len(info), info))
+}
+
+var _ = Describe("WhoAmI", func() {
+	newIdentityClient := func(name, libName string) *xredis.Client {
+		client, err := xredis.NewReplayClient(
+			groupRecording(xredis.RecordedCommand{
+				Args:  recordedArgs("client", "info"),
+				Reply: clientInfoReply(name, libName),
+			}),
+			xredis.WithClientID("orders-api"),
+			xredis.WithIdentitySuffix("checkout"),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		return client
+	}
+
+	It("returns the connection identity", func() {
+		client := newIdentityClient("orders-api", "go-redis(checkout,go1.26)")
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		identity, err := client.WhoAmI(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identity.ID).To(Equal(int64(42)))
+		Expect(identity.Addr).To(Equal("10.0.0.7:51234"))
+		Expect(identity.Resp).To(Equal(3))
+		Expect(identity.LibName).To(Equal("go-redis(checkout,go1.26)"))
+		Expect(identity.NameApplied).To(BeTrue())
+		Expect(identity.SuffixApplied).To(BeTrue())
+	})
+
+	It("reports identities that were not applied", func() {
+		client := newIdentityClient("", "")
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		identity, err := client.WhoAmI(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identity.NameApplied).To(BeFalse())
+		Expect(identity.SuffixApplied).To(BeFalse())
+	})
+})
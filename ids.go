@@ -0,0 +1,24 @@
+package xredis
+
+import "github.com/google/uuid"
+
+// GenerateUUID returns a new time-ordered UUIDv7 string.
+//
+// It is the default ID generator of clients, see WithIDGenerator.
+func GenerateUUID() string {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return uuid.NewString()
+	}
+
+	return id.String()
+}
+
+// newID returns a new ID from the generator configured with WithIDGenerator.
+func (c *Client) newID() string {
+	if c == nil || c.opts == nil || c.opts.idGenerator == nil {
+		return GenerateUUID()
+	}
+
+	return c.opts.idGenerator()
+}
@@ -0,0 +1,61 @@
+package xredis_test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/google/uuid"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("ID generation", func() {
+	It("generates time-ordered UUIDv7 strings", func() {
+		first, second := xredis.GenerateUUID(), xredis.GenerateUUID()
+
+		id, err := uuid.Parse(first)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id.Version()).To(Equal(uuid.Version(7)))
+		Expect(first < second).To(BeTrue())
+	})
+
+	It("names clients with UUIDv7 by default", func() {
+		client, err := xredis.NewClient(xredis.WithClientConfig(&xredis.ClientConfig{Addr: redisAddr, DB: testDB}))
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		name, err := client.Raw().ClientGetName(ctx).Result()
+		Expect(err).NotTo(HaveOccurred())
+
+		id, err := uuid.Parse(name)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id.Version()).To(Equal(uuid.Version(7)))
+	})
+
+	It("uses the configured generator for client names and lock tokens", func() {
+		var next atomic.Int64
+
+		client, err := xredis.NewClient(
+			xredis.WithClientConfig(&xredis.ClientConfig{Addr: redisAddr, DB: testDB}),
+			xredis.WithIDGenerator(func() string {
+				return fmt.Sprintf("orders-v42-%d", next.Add(1))
+			}),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Raw().ClientGetName(ctx).Val()).To(Equal("orders-v42-1"))
+
+		lock, acquired, err := client.TryLock(ctx, "ids:lock", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(acquired).To(BeTrue())
+		Expect(lock.Token()).To(Equal("orders-v42-2"))
+		Expect(lock.Unlock(ctx)).To(Succeed())
+	})
+})
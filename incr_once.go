@@ -0,0 +1,78 @@
+package xredis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const incrOnceTokenInfix = ":once:"
+
+// incrOnceScript atomically increments a counter unless the request token
+// was already applied.
+//
+// KEYS[1] - counter key
+// KEYS[2] - request token key
+// ARGV[1] - token retention in milliseconds
+var incrOnceScript = rdb.NewScript(`
+if redis.call("EXISTS", KEYS[2]) == 1 then
+	return {0, tonumber(redis.call("GET", KEYS[1]) or "0")}
+end
+
+local value = redis.call("INCR", KEYS[1])
+redis.call("SET", KEYS[2], "1", "PX", ARGV[1])
+
+return {1, value}
+`)
+
+// IncrOnce increments the integer value of key unless token was already used
+// for key within ttl, so retried requests and redelivered messages are
+// counted once.
+//
+// It returns the current value and applied=false when the token was already
+// used. The token is remembered for ttl, which must cover the retry or
+// redelivery window; the counter itself does not expire.
+//
+// Tokens are stored in keys that hash to the same Redis Cluster slot as key.
+func (c *Client) IncrOnce(ctx context.Context, key, token string, ttl time.Duration) (value int64, applied bool, err error) {
+	if ttl <= 0 {
+		return 0, false, ErrInvalidTTL
+	}
+
+	if token == "" {
+		return 0, false, ErrInvalidToken
+	}
+
+	result, err := incrOnceScript.Run(
+		ctx,
+		c.conn,
+		[]string{key, sameSlotKey(key, incrOnceTokenInfix+token)},
+		durationToMs(ttl),
+	).Int64Slice()
+	if err != nil {
+		return 0, false, err
+	}
+
+	if len(result) != 2 {
+		return 0, false, fmt.Errorf("%w: unexpected increment result", ErrInvalidEntry)
+	}
+
+	return result[1], result[0] == 1, nil
+}
+
+// sameSlotKey returns key with suffix appended, hashing to the same Redis
+// Cluster slot as key.
+//
+// A key with a hash tag keeps it; other keys are wrapped in one.
+func sameSlotKey(key, suffix string) string {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			return key + suffix
+		}
+	}
+
+	return "{" + key + "}" + suffix
+}
@@ -0,0 +1,84 @@
+package xredis_test
+
+import (
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("IncrOnce", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient()
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("applies each token once", func() {
+		value, applied, err := client.IncrOnce(ctx, "once:counter", "req-1", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(applied).To(BeTrue())
+		Expect(value).To(Equal(int64(1)))
+
+		value, applied, err = client.IncrOnce(ctx, "once:counter", "req-1", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(applied).To(BeFalse())
+		Expect(value).To(Equal(int64(1)))
+
+		value, applied, err = client.IncrOnce(ctx, "once:counter", "req-2", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(applied).To(BeTrue())
+		Expect(value).To(Equal(int64(2)))
+
+		ttl, err := client.Raw().PTTL(ctx, "{once:counter}:once:req-1").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ttl).To(BeNumerically(">", 0))
+	})
+
+	It("keeps hash tags of counter keys", func() {
+		_, applied, err := client.IncrOnce(ctx, "once:{user:1}:visits", "req", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(applied).To(BeTrue())
+
+		exists, err := client.Exists(ctx, "once:{user:1}:visits:once:req")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeTrue())
+	})
+
+	It("applies tokens again after their TTL", func() {
+		_, _, err := client.IncrOnce(ctx, "once:counter", "req", 50*time.Millisecond)
+		Expect(err).NotTo(HaveOccurred())
+
+		time.Sleep(100 * time.Millisecond)
+
+		value, applied, err := client.IncrOnce(ctx, "once:counter", "req", 50*time.Millisecond)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(applied).To(BeTrue())
+		Expect(value).To(Equal(int64(2)))
+	})
+
+	It("does not remember tokens of failed increments", func() {
+		Expect(client.Set(ctx, "once:text", "not a number", 0)).To(Succeed())
+
+		_, _, err := client.IncrOnce(ctx, "once:text", "req", time.Minute)
+		Expect(err).To(HaveOccurred())
+
+		exists, err := client.Exists(ctx, "{once:text}:once:req")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+
+	It("validates arguments", func() {
+		_, _, err := client.IncrOnce(ctx, "once:counter", "req", 0)
+		Expect(err).To(MatchError(xredis.ErrInvalidTTL))
+
+		_, _, err = client.IncrOnce(ctx, "once:counter", "", time.Minute)
+		Expect(err).To(MatchError(xredis.ErrInvalidToken))
+	})
+})
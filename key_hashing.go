@@ -0,0 +1,110 @@
+package xredis
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// keyHasher rewrites the identifier part of keys with a configured prefix.
+type keyHasher struct {
+	hash     func(string) string
+	prefixes []string
+}
+
+// hashKey returns key with the part after a matching prefix hashed, and
+// ok=false when no prefix matches.
+func (h *keyHasher) hashKey(key string) (string, bool) {
+	if hashed, ok := h.hashPrefixed(key); ok {
+		return hashed, true
+	}
+
+	// Keys that xredis derives from a caller key with sameSlotKey, such as
+	// those of IncrOnce and AppendHistory, wrap the caller key in a hash tag.
+	// The tag is hashed like the caller key, so both keys stay in one slot.
+	if tagged, ok := strings.CutPrefix(key, "{"); ok {
+		if end := strings.IndexByte(tagged, '}'); end > 0 {
+			if hashed, ok := h.hashPrefixed(tagged[:end]); ok {
+				return "{" + hashed + "}" + tagged[end+1:], true
+			}
+		}
+	}
+
+	return key, false
+}
+
+func (h *keyHasher) hashPrefixed(key string) (string, bool) {
+	for _, prefix := range h.prefixes {
+		if id, ok := strings.CutPrefix(key, prefix); ok && id != "" {
+			return prefix + h.hash(id), true
+		}
+	}
+
+	return key, false
+}
+
+// rewrite hashes the keys of cmd in place.
+func (h *keyHasher) rewrite(cmd rdb.Cmder) {
+	args := cmd.Args()
+
+	forEachKeyArg(cmd, func(i int) {
+		key, ok := args[i].(string)
+		if !ok {
+			return
+		}
+
+		if hashed, ok := h.hashKey(key); ok {
+			args[i] = hashed
+		}
+	})
+}
+
+// keyHashHook hashes keys before other hooks and Redis see them, so the
+// original identifiers do not reach traces, logs, or the Redis keyspace.
+type keyHashHook struct {
+	passDialHook
+
+	hasher *keyHasher
+}
+
+func (h *keyHashHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		if !callOptionsFrom(ctx).keysHashed {
+			h.hasher.rewrite(cmd)
+		}
+
+		return next(ctx, cmd)
+	}
+}
+
+func (h *keyHashHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		if callOptionsFrom(ctx).keysHashed {
+			return next(ctx, cmds)
+		}
+
+		for _, cmd := range cmds {
+			h.hasher.rewrite(cmd)
+		}
+
+		return next(ctx, cmds)
+	}
+}
+
+// HMACKeyHasher returns a key hasher for WithKeyHasher that replaces
+// identifiers with the hex-encoded HMAC-SHA256 of secret.
+//
+// A keyed hash keeps low-entropy identifiers, such as phone numbers, from
+// being recovered by hashing every candidate.
+func HMACKeyHasher(secret []byte) func(string) string {
+	return func(id string) string {
+		mac := hmac.New(sha256.New, secret)
+		_, _ = mac.Write([]byte(id))
+
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+}
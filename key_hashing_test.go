@@ -0,0 +1,135 @@
+package xredis_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Key hashing", func() {
+	var (
+		client *xredis.Client
+		plain  *xredis.Client
+	)
+
+	hash := func(id string) string {
+		return strings.ToUpper(id) + "#"
+	}
+
+	BeforeEach(func() {
+		client = newTestClient(xredis.WithKeyHasher(hash, "user:email:"))
+		plain = newTestClient()
+		Expect(plain.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+		Expect(plain.Close()).To(Succeed())
+	})
+
+	It("hashes the identifier of keys with a configured prefix", func() {
+		Expect(client.Set(ctx, "user:email:ada@example.com", "42", time.Minute)).To(Succeed())
+		Expect(client.Set(ctx, "user:id:42", "ada", time.Minute)).To(Succeed())
+
+		var value string
+		ok, err := client.Get(ctx, "user:email:ada@example.com", &value)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("42"))
+
+		keys, err := plain.Raw().Keys(ctx, "user:*").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(ConsistOf("user:email:ADA@EXAMPLE.COM#", "user:id:42"))
+	})
+
+	It("hashes every key of multi-key commands and pipelines", func() {
+		Expect(client.Raw().MSet(ctx, "user:email:a", "1", "user:email:b", "2").Err()).To(Succeed())
+
+		values, err := client.Raw().MGet(ctx, "user:email:a", "user:email:b").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal([]any{"1", "2"}))
+
+		_, err = client.Raw().Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+			pipe.Rename(ctx, "user:email:a", "user:email:c")
+			pipe.Del(ctx, "user:email:b")
+
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		keys, err := plain.Raw().Keys(ctx, "user:*").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(ConsistOf("user:email:C#"))
+	})
+
+	It("hashes keys that do not come first", func() {
+		raw := client.Raw()
+		Expect(raw.SAdd(ctx, "user:email:a", "x").Err()).To(Succeed())
+		Expect(raw.SAdd(ctx, "user:email:b", "y").Err()).To(Succeed())
+		Expect(raw.SUnionStore(ctx, "user:email:c", "user:email:a", "user:email:b").Err()).To(Succeed())
+		Expect(raw.Set(ctx, "user:email:d", "1", 0).Err()).To(Succeed())
+		Expect(raw.BitOpOr(ctx, "user:email:e", "user:email:d").Err()).To(Succeed())
+		Expect(raw.RPush(ctx, "user:email:f", "z").Err()).To(Succeed())
+		Expect(raw.XAdd(ctx, &rdb.XAddArgs{Stream: "user:email:g", Values: []any{"k", "v"}}).Err()).To(Succeed())
+
+		popped, err := raw.BLPop(ctx, time.Second, "user:email:missing", "user:email:f").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(popped).To(Equal([]string{"user:email:F#", "z"}))
+
+		streams, err := raw.XRead(ctx, &rdb.XReadArgs{Streams: []string{"user:email:g", "0"}, Count: 1}).Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(streams).To(HaveLen(1))
+		Expect(streams[0].Stream).To(Equal("user:email:G#"))
+
+		Expect(raw.XGroupCreate(ctx, "user:email:g", "workers", "0").Err()).To(Succeed())
+
+		keys, err := plain.Raw().Keys(ctx, "user:*").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(ConsistOf(
+			"user:email:A#", "user:email:B#", "user:email:C#", "user:email:D#", "user:email:E#", "user:email:G#",
+		))
+	})
+
+	It("hashes the declared keys of scripts", func() {
+		_, capped, err := client.IncrWithCap(ctx, "user:email:ada", 1, 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(capped).To(BeFalse())
+
+		value, err := plain.Raw().Get(ctx, "user:email:ADA#").Int64()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal(int64(1)))
+	})
+
+	It("hashes keys derived from hashed keys", func() {
+		_, applied, err := client.IncrOnce(ctx, "user:email:ada", "token", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(applied).To(BeTrue())
+
+		_, err = client.AppendHistory(ctx, "user:email:bob", map[string]string{"name": "bob"}, 10)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.History(ctx, "user:email:bob")
+		Expect(err).NotTo(HaveOccurred())
+
+		keys, err := plain.Raw().Keys(ctx, "*user:*").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(ConsistOf(
+			"user:email:ADA#",
+			"{user:email:ADA#}:once:token",
+			"user:email:BOB#",
+			xredis.ValueHistoryKey("user:email:BOB#"),
+		))
+	})
+
+	It("hashes identifiers with a keyed HMAC", func() {
+		hasher := xredis.HMACKeyHasher([]byte("secret"))
+
+		Expect(hasher("ada@example.com")).To(HaveLen(64))
+		Expect(hasher("ada@example.com")).To(Equal(hasher("ada@example.com")))
+		Expect(hasher("ada@example.com")).NotTo(Equal(xredis.HMACKeyHasher([]byte("other"))("ada@example.com")))
+	})
+})
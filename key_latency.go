@@ -0,0 +1,134 @@
+package xredis
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// keyPatternOther labels commands whose first key matches no tracked
+// pattern.
+const keyPatternOther = "other"
+
+// TrackKeyLatency records the duration of commands in the
+// redis.client.key.duration histogram, labeled by the first of patterns that
+// matches their first key, so teams can set latency SLOs per data family,
+// such as sessions or catalog entries, instead of per Redis command.
+//
+// Patterns use path.Match syntax, such as "session:*". Commands whose first
+// key matches no pattern are labeled "other", and commands without keys are
+// not recorded, so the label has at most len(patterns)+1 values. Pipelines
+// record their duration once per distinct pattern of their commands.
+//
+// Each call replaces the tracked patterns; calling it without patterns stops
+// tracking. A malformed pattern returns ErrInvalidConfig. TrackKeyLatency has
+// no effect when wrapper metrics are disabled.
+func (c *Client) TrackKeyLatency(patterns ...string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%w: key pattern %q: %w", ErrInvalidConfig, pattern, err)
+		}
+	}
+
+	if c.keyPatterns == nil {
+		return nil
+	}
+
+	if len(patterns) == 0 {
+		c.keyPatterns.Store(nil)
+		return nil
+	}
+
+	patterns = slices.Clone(patterns)
+	c.keyPatterns.Store(&patterns)
+
+	return nil
+}
+
+// keyLatencyHook records command durations by key pattern while patterns are
+// tracked.
+type keyLatencyHook struct {
+	passDialHook
+
+	metrics  *metrics
+	patterns *atomic.Pointer[[]string]
+}
+
+func (h *keyLatencyHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		patterns := h.patterns.Load()
+		if patterns == nil || isConnectionSetupCmd(cmd) {
+			return next(ctx, cmd)
+		}
+
+		started := time.Now()
+		err := next(ctx, cmd)
+
+		if pattern, ok := keyPattern(cmd, *patterns); ok {
+			h.metrics.recordKeyDuration(ctx, pattern, time.Since(started))
+		}
+
+		return err
+	}
+}
+
+func (h *keyLatencyHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		patterns := h.patterns.Load()
+		if patterns == nil {
+			return next(ctx, cmds)
+		}
+
+		started := time.Now()
+		err := next(ctx, cmds)
+		elapsed := time.Since(started)
+
+		var recorded []string
+
+		for _, cmd := range cmds {
+			pattern, ok := keyPattern(cmd, *patterns)
+			if !ok || isConnectionSetupCmd(cmd) || slices.Contains(recorded, pattern) {
+				continue
+			}
+
+			recorded = append(recorded, pattern)
+			h.metrics.recordKeyDuration(ctx, pattern, elapsed)
+		}
+
+		return err
+	}
+}
+
+// keyPattern returns the first of patterns that matches the first key of cmd,
+// or keyPatternOther. It reports ok=false for commands without keys.
+func keyPattern(cmd rdb.Cmder, patterns []string) (string, bool) {
+	var (
+		key   string
+		found bool
+	)
+
+	args := cmd.Args()
+
+	forEachKeyArg(cmd, func(i int) {
+		if !found {
+			key, found = args[i].(string)
+		}
+	})
+
+	if !found {
+		return "", false
+	}
+
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, key); ok {
+			return pattern, true
+		}
+	}
+
+	return keyPatternOther, true
+}
@@ -0,0 +1,44 @@
+package xredis_test
+
+import (
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("TrackKeyLatency", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient()
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("tracks commands and pipelines by key pattern", func() {
+		Expect(client.TrackKeyLatency("session:*", "catalog:*")).To(Succeed())
+
+		Expect(client.Set(ctx, "session:1", "token", time.Minute)).To(Succeed())
+		Expect(client.Ping(ctx)).To(Succeed())
+
+		_, err := client.Raw().Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+			pipe.Get(ctx, "catalog:1")
+			pipe.Get(ctx, "orders:1")
+
+			return nil
+		})
+		Expect(err).To(MatchError(rdb.Nil))
+
+		Expect(client.TrackKeyLatency()).To(Succeed())
+		Expect(client.Set(ctx, "session:1", "token", time.Minute)).To(Succeed())
+	})
+
+	It("rejects malformed patterns", func() {
+		Expect(client.TrackKeyLatency("session:[")).To(MatchError(xredis.ErrInvalidConfig))
+	})
+})
@@ -0,0 +1,106 @@
+package xredis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// internalKeyPrefix prefixes the keys of xredis features, such as schemas,
+// quotas, and the bootstrap lock.
+const internalKeyPrefix = "xredis:"
+
+// keyspaceCommands contains commands that affect every key of a database.
+var keyspaceCommands = map[string]struct{}{
+	"flushdb": {}, "flushall": {}, "swapdb": {},
+}
+
+// keyScopeHook rejects commands on keys outside the prefixes configured with
+// WithKeyScope before they reach Redis.
+type keyScopeHook struct {
+	passDialHook
+
+	prefixes []string
+}
+
+func (h *keyScopeHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		if h.reject(cmd) {
+			return cmd.Err()
+		}
+
+		return next(ctx, cmd)
+	}
+}
+
+func (h *keyScopeHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		return rejectPipeline(ctx, cmds, h.reject, next)
+	}
+}
+
+// reject fails cmd with ErrOutOfScope when one of its keys is out of scope.
+func (h *keyScopeHook) reject(cmd rdb.Cmder) bool {
+	if isConnectionSetupCmd(cmd) {
+		return false
+	}
+
+	if hasCommand(keyspaceCommands, cmd.Name()) {
+		cmd.SetErr(fmt.Errorf("%w: %s affects every key", ErrOutOfScope, cmd.Name()))
+		return true
+	}
+
+	args := cmd.Args()
+
+	var (
+		outside string
+		found   bool
+	)
+
+	forEachKeyArg(cmd, func(i int) {
+		key, ok := args[i].(string)
+		if ok && !found && !h.inScope(key) {
+			outside, found = key, true
+		}
+	})
+
+	if !found {
+		return false
+	}
+
+	cmd.SetErr(fmt.Errorf("%w: %s on key %q", ErrOutOfScope, cmd.Name(), outside))
+
+	return true
+}
+
+func (h *keyScopeHook) inScope(key string) bool {
+	if h.hasScopePrefix(key) {
+		return true
+	}
+
+	// Keys that xredis derives from a caller key with sameSlotKey, such as
+	// those of IncrOnce and FencedSet, wrap the caller key in a hash tag.
+	if tagged, ok := strings.CutPrefix(key, "{"); ok {
+		if end := strings.IndexByte(tagged, '}'); end > 0 {
+			return h.hasScopePrefix(tagged[:end] + tagged[end+1:])
+		}
+	}
+
+	return false
+}
+
+func (h *keyScopeHook) hasScopePrefix(key string) bool {
+	if strings.HasPrefix(key, internalKeyPrefix) {
+		return true
+	}
+
+	for _, prefix := range h.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,119 @@
+package xredis_test
+
+import (
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("WithKeyScope", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		cleanup := newTestClient()
+		Expect(cleanup.Raw().FlushDB(ctx).Err()).To(Succeed())
+		Expect(cleanup.Close()).To(Succeed())
+
+		client = newTestClient(xredis.WithKeyScope("checkout:"))
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("allows keys under the scope", func() {
+		Expect(client.Set(ctx, "checkout:cart:1", "items", 0)).To(Succeed())
+
+		value, ok, err := client.String(ctx, "checkout:cart:1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("items"))
+
+		Expect(client.Ping(ctx)).To(Succeed())
+	})
+
+	It("rejects keys outside the scope", func() {
+		err := client.Set(ctx, "billing:invoice:1", "paid", 0)
+		Expect(err).To(MatchError(xredis.ErrOutOfScope))
+		Expect(err).To(MatchError(ContainSubstring(`"billing:invoice:1"`)))
+
+		err = client.Raw().MGet(ctx, "checkout:cart:1", "billing:invoice:1").Err()
+		Expect(err).To(MatchError(xredis.ErrOutOfScope))
+
+		err = client.Raw().Rename(ctx, "checkout:cart:1", "billing:cart:1").Err()
+		Expect(err).To(MatchError(xredis.ErrOutOfScope))
+
+		Expect(client.Raw().FlushDB(ctx).Err()).To(MatchError(xredis.ErrOutOfScope))
+	})
+
+	It("fails only the offending commands of a pipeline", func() {
+		var inScope, outOfScope *rdb.StatusCmd
+
+		_, err := client.Raw().Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+			inScope = pipe.Set(ctx, "checkout:cart:2", "items", 0)
+			outOfScope = pipe.Set(ctx, "billing:invoice:2", "paid", 0)
+
+			return nil
+		})
+		Expect(err).To(MatchError(xredis.ErrOutOfScope))
+		Expect(inScope.Err()).NotTo(HaveOccurred())
+		Expect(outOfScope.Err()).To(MatchError(xredis.ErrOutOfScope))
+
+		exists, err := client.Exists(ctx, "checkout:cart:2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeTrue())
+	})
+
+	It("fails the whole transaction", func() {
+		var inScope, outOfScope *rdb.StatusCmd
+
+		_, err := client.Raw().TxPipelined(ctx, func(pipe rdb.Pipeliner) error {
+			inScope = pipe.Set(ctx, "checkout:cart:3", "items", 0)
+			outOfScope = pipe.Set(ctx, "billing:invoice:3", "paid", 0)
+
+			return nil
+		})
+		Expect(err).To(MatchError(xredis.ErrOutOfScope))
+		Expect(inScope.Err()).To(MatchError(xredis.ErrOutOfScope))
+		Expect(outOfScope.Err()).To(MatchError(xredis.ErrOutOfScope))
+
+		exists, err := client.Exists(ctx, "checkout:cart:3")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+
+	It("allows keys derived from keys in scope", func() {
+		value, applied, err := client.IncrOnce(ctx, "checkout:orders", "token-1", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(applied).To(BeTrue())
+		Expect(value).To(Equal(int64(1)))
+
+		ok, err := client.FencedSet(ctx, "checkout:cart:4", "items", 1, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		_, _, err = client.IncrOnce(ctx, "billing:orders", "token-1", time.Minute)
+		Expect(err).To(MatchError(xredis.ErrOutOfScope))
+	})
+
+	It("checks the keys of stream group commands", func() {
+		_, err := client.XGroupCreate(ctx, "checkout:events", "workers", xredis.StreamEarliest)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.GroupLag(ctx, "checkout:events", "workers")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.GroupLag(ctx, "billing:events", "workers")
+		Expect(err).To(MatchError(xredis.ErrOutOfScope))
+	})
+
+	It("checks the declared keys of scripts", func() {
+		script := rdb.NewScript(`return redis.call("GET", KEYS[1])`)
+
+		err := script.Run(ctx, client.Raw(), []string{"billing:invoice:1"}).Err()
+		Expect(err).To(MatchError(xredis.ErrOutOfScope))
+	})
+})
@@ -0,0 +1,106 @@
+package xredis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// clusterSlots is the number of Redis Cluster hash slots.
+const clusterSlots = 16384
+
+// SlotForKey returns the Redis Cluster hash slot of key, from 0 to 16383.
+//
+// When key contains a hash tag, a non-empty part between the first "{" and
+// the following "}", only the tag is hashed, so "{user:42}:profile" and
+// "{user:42}:orders" share a slot. Multi-key commands fail with CROSSSLOT
+// when the slots of their keys differ.
+func SlotForKey(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+
+	return int(crc16(key)) % clusterSlots
+}
+
+// crc16 returns the CRC16-CCITT (XModem) checksum of s, which Redis Cluster
+// uses for key slots.
+func crc16(s string) uint16 {
+	var crc uint16
+
+	for i := range len(s) {
+		crc ^= uint16(s[i]) << 8
+
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
+
+// KeyNode describes how a client routes a key.
+type KeyNode struct {
+	// Key is the key sent to Redis, hashed when it matches a prefix of
+	// WithKeyHasher.
+	Key string
+
+	// Slot is the Redis Cluster hash slot of Key. It is reported for every
+	// topology, so hash tags can be checked before moving to Redis Cluster.
+	Slot int
+
+	// Addr is the address of the node that serves Key: the master of the
+	// slot for Redis Cluster, the shard for Ring, and the configured address
+	// otherwise.
+	Addr string
+}
+
+// NodeForKey returns the node the client sends commands on key to, from the
+// cluster slots or ring shards the client already knows, loading them first
+// if needed. It does not follow later redirects, and reads routed to replicas
+// with ClusterConfig.ReadOnly or to ClientConfig.ReadAddrs are not reflected.
+//
+// An empty key returns ErrInvalidKey.
+func (c *Client) NodeForKey(ctx context.Context, key string) (KeyNode, error) {
+	if key == "" {
+		return KeyNode{}, fmt.Errorf("%w: empty key", ErrInvalidKey)
+	}
+
+	if hasher := c.opts.keyHasher; hasher != nil && !callOptionsFrom(ctx).keysHashed {
+		key, _ = hasher.hashKey(key)
+	}
+
+	node := KeyNode{Key: key, Slot: SlotForKey(key)}
+
+	var (
+		shard *rdb.Client
+		err   error
+	)
+
+	switch conn := c.conn.(type) {
+	case *rdb.ClusterClient:
+		shard, err = conn.MasterForKey(ctx, key)
+	case *rdb.Ring:
+		shard, err = conn.GetShardClientForKey(key)
+	case *rdb.Client:
+		shard = conn
+	}
+
+	if err != nil {
+		return KeyNode{}, err
+	}
+
+	if shard != nil {
+		node.Addr = shard.Options().Addr
+	}
+
+	return node, nil
+}
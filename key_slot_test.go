@@ -0,0 +1,51 @@
+package xredis_test
+
+import (
+	"strings"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("Key slots", func() {
+	DescribeTable("SlotForKey",
+		func(key string, slot int) {
+			Expect(xredis.SlotForKey(key)).To(Equal(slot))
+		},
+		Entry("plain key", "foo", 12182),
+		Entry("another key", "bar", 5061),
+		Entry("empty key", "", 0),
+		Entry("hash tag", "{user1000}.following", xredis.SlotForKey("user1000")),
+		Entry("first hash tag", "foo{bar}{zap}", xredis.SlotForKey("bar")),
+		Entry("empty hash tag", "foo{}{bar}", xredis.SlotForKey("foo{}{bar}")),
+	)
+
+	It("routes standalone keys to the configured address", func() {
+		client := newTestClient()
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		node, err := client.NodeForKey(ctx, "{user:42}:profile")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(node.Key).To(Equal("{user:42}:profile"))
+		Expect(node.Slot).To(Equal(xredis.SlotForKey("user:42")))
+		Expect(node.Addr).To(Equal(redisAddr))
+
+		_, err = client.NodeForKey(ctx, "")
+		Expect(err).To(MatchError(xredis.ErrInvalidKey))
+	})
+
+	It("reports hashed keys", func() {
+		client := newTestClient(xredis.WithKeyHasher(strings.ToUpper, "user:email:"))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		node, err := client.NodeForKey(ctx, "user:email:ada@example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(node.Key).To(Equal("user:email:ADA@EXAMPLE.COM"))
+		Expect(node.Slot).To(Equal(xredis.SlotForKey("user:email:ADA@EXAMPLE.COM")))
+	})
+})
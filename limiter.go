@@ -0,0 +1,106 @@
+package xredis
+
+import (
+	"context"
+	"errors"
+
+	rdb "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const limiterRejectedEvent = "redis.limiter.rejected"
+
+// observedLimiter wraps a limiter configured with WithLimiter and records its
+// decisions.
+type observedLimiter struct {
+	limiter rdb.Limiter
+	metrics *metrics
+}
+
+func newObservedLimiter(limiter rdb.Limiter, m *metrics) *observedLimiter {
+	return &observedLimiter{limiter: limiter, metrics: m}
+}
+
+// Allow implements rdb.Limiter.
+//
+// Rejections are returned as *limiterRejection, so the client can tell them
+// apart from Redis errors while callers still see the original error.
+func (l *observedLimiter) Allow() error {
+	// go-redis does not pass the command context to the limiter.
+	ctx := context.Background()
+
+	if err := l.limiter.Allow(); err != nil {
+		l.metrics.recordLimiterDecision(ctx, limiterOutcomeRejected)
+		return &limiterRejection{err: err}
+	}
+
+	l.metrics.recordLimiterDecision(ctx, limiterOutcomeAllowed)
+
+	return nil
+}
+
+// ReportResult implements rdb.Limiter.
+func (l *observedLimiter) ReportResult(result error) {
+	l.limiter.ReportResult(result)
+}
+
+// limiterRejection is an error returned by a limiter configured with
+// WithLimiter.
+//
+// It keeps the message of the original error, unwraps to it, and matches
+// ErrLimiterRejected.
+type limiterRejection struct {
+	err error
+}
+
+func (e *limiterRejection) Error() string {
+	return e.err.Error()
+}
+
+func (e *limiterRejection) Unwrap() error {
+	return e.err
+}
+
+func (*limiterRejection) Is(target error) bool {
+	return target == ErrLimiterRejected
+}
+
+// limiterHook marks commands rejected by the limiter on the active trace span.
+type limiterHook struct {
+	passDialHook
+}
+
+func (limiterHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		err := next(ctx, cmd)
+		markLimiterRejection(ctx, err, 1)
+
+		return err
+	}
+}
+
+func (limiterHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		err := next(ctx, cmds)
+		markLimiterRejection(ctx, err, len(cmds))
+
+		return err
+	}
+}
+
+func markLimiterRejection(ctx context.Context, err error, cmds int) {
+	if !errors.Is(err, ErrLimiterRejected) {
+		return
+	}
+
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	span.AddEvent(limiterRejectedEvent, trace.WithAttributes(
+		attribute.String("error", err.Error()),
+		attribute.Int("db.operation.batch.size", cmds),
+	))
+}
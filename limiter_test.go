@@ -0,0 +1,76 @@
+package xredis_test
+
+import (
+	"errors"
+	"sync/atomic"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var errOverBudget = errors.New("over budget")
+
+type switchLimiter struct {
+	reject  atomic.Bool
+	results atomic.Int64
+}
+
+func (l *switchLimiter) Allow() error {
+	if l.reject.Load() {
+		return errOverBudget
+	}
+
+	return nil
+}
+
+func (l *switchLimiter) ReportResult(error) {
+	l.results.Add(1)
+}
+
+var _ = Describe("Limiter", func() {
+	var (
+		limiter *switchLimiter
+		client  *xredis.Client
+	)
+
+	BeforeEach(func() {
+		limiter = &switchLimiter{}
+		client = newTestClient(xredis.WithLimiter(limiter))
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("passes allowed commands and their results to the limiter", func() {
+		Expect(client.Raw().Set(ctx, "limiter:key", "value", 0).Err()).To(Succeed())
+		Expect(limiter.results.Load()).To(BeNumerically(">", 0))
+	})
+
+	It("marks rejected commands with ErrLimiterRejected", func() {
+		limiter.reject.Store(true)
+
+		err := client.Raw().Get(ctx, "limiter:key").Err()
+		Expect(err).To(MatchError(xredis.ErrLimiterRejected))
+		Expect(err).To(MatchError(errOverBudget))
+		Expect(err.Error()).To(Equal(errOverBudget.Error()))
+	})
+
+	It("marks rejected pipelines with ErrLimiterRejected", func() {
+		limiter.reject.Store(true)
+
+		pipe := client.Raw().Pipeline()
+		get := pipe.Get(ctx, "limiter:key")
+		_, err := pipe.Exec(ctx)
+
+		Expect(err).To(MatchError(xredis.ErrLimiterRejected))
+		Expect(get.Err()).To(MatchError(errOverBudget))
+	})
+
+	It("does not mark Redis errors as limiter rejections", func() {
+		err := client.Raw().Do(ctx, "XREDIS.UNKNOWN").Err()
+		Expect(err).To(HaveOccurred())
+		Expect(err).NotTo(MatchError(xredis.ErrLimiterRejected))
+	})
+})
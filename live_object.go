@@ -0,0 +1,201 @@
+package xredis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const liveObjectReloadTimeout = 5 * time.Second
+
+// LiveObject keeps a struct in sync with a Redis hash through keyspace
+// notifications.
+//
+// The struct is updated in place on a background goroutine, so it must be
+// read inside View.
+type LiveObject struct {
+	client   *Client
+	key      string
+	dst      reflect.Value
+	onUpdate func(error)
+
+	pubsub *rdb.PubSub
+	health *subscriptionHealth
+	reload chan struct{}
+
+	mu sync.RWMutex
+}
+
+// LiveObject loads the hash stored at key into dst, a pointer to a struct
+// with "redis" field tags, and keeps dst updated until Close, for documents
+// read on every request, such as configuration or routing tables.
+//
+// It subscribes to the keyspace notifications of key and reloads the whole
+// hash after every change, and after go-redis resubscribed a broken
+// subscription, since changes may have been missed in the meantime. Bursts of
+// changes are coalesced into one reload. A missing hash leaves dst zeroed.
+//
+// onUpdate, if not nil, is called after every reload with nil, with
+// ErrKeyNotFound when the hash was deleted or expired, or with the reload
+// error, in which case dst keeps its previous value. It runs on the reload
+// goroutine, outside of View.
+//
+// Keyspace notifications must be enabled on the server, for example with
+// "notify-keyspace-events Khgx". In Redis Cluster, the subscription is made
+// on the primary that owns key. Ring clients are not supported.
+func (c *Client) LiveObject(ctx context.Context, key string, dst any, onUpdate func(error)) (*LiveObject, error) {
+	value := reflect.ValueOf(dst)
+	if !value.IsValid() || value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: live object destination must be a non-nil pointer to a struct", ErrInvalidHashObject)
+	}
+
+	// Notifications name the key as stored, after WithKeyHasher.
+	stored := key
+	if c.opts.keyHasher != nil {
+		stored, _ = c.opts.keyHasher.hashKey(key)
+	}
+
+	conn, err := c.keyspaceConn(ctx, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	channel := keyspaceChannel(conn, stored)
+	pubsub := conn.Subscribe(ctx, channel)
+
+	// Subscribe before the first load, so no change is missed in between.
+	health, err := c.watchSubscription(ctx, pubsub, []string{channel})
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &LiveObject{
+		client:   c,
+		key:      key,
+		dst:      value,
+		onUpdate: onUpdate,
+		pubsub:   pubsub,
+		health:   health,
+		reload:   make(chan struct{}, 1),
+	}
+
+	if err = obj.load(ctx); err != nil && !errors.Is(err, ErrKeyNotFound) {
+		_ = health.close(pubsub)
+		return nil, err
+	}
+
+	go obj.receive()
+	go obj.run()
+
+	return obj, nil
+}
+
+// View calls fn while no update is applied to the destination struct.
+func (o *LiveObject) View(fn func()) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	fn()
+}
+
+// Close stops updating the destination struct and unsubscribes.
+func (o *LiveObject) Close() error {
+	return o.health.close(o.pubsub)
+}
+
+// receive turns keyspace notifications and resubscriptions into reload
+// requests until the subscription is closed.
+func (o *LiveObject) receive() {
+	defer close(o.reload)
+
+	for received := range o.pubsub.ChannelWithSubscriptions() {
+		if sub, ok := received.(*rdb.Subscription); ok {
+			o.health.confirm(sub)
+		}
+
+		select {
+		case o.reload <- struct{}{}:
+		default:
+			// A reload is already pending and will observe this change.
+		}
+	}
+}
+
+func (o *LiveObject) run() {
+	for range o.reload {
+		select {
+		case <-o.health.done:
+			return
+		default:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), liveObjectReloadTimeout)
+		err := o.load(ctx)
+		cancel()
+
+		if o.onUpdate != nil {
+			o.onUpdate(err)
+		}
+	}
+}
+
+// load reads the hash into a fresh value and copies it to the destination
+// struct, so readers never observe a partially scanned struct.
+func (o *LiveObject) load(ctx context.Context) error {
+	fresh := reflect.New(o.dst.Elem().Type())
+
+	ok, err := o.client.HGetAll(ctx, o.key, fresh.Interface())
+	if err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	o.dst.Elem().Set(fresh.Elem())
+	o.mu.Unlock()
+
+	if !ok {
+		return ErrKeyNotFound
+	}
+
+	return nil
+}
+
+// keyspaceConn returns the connection that receives the keyspace
+// notifications of key.
+func (c *Client) keyspaceConn(ctx context.Context, key string) (rdb.UniversalClient, error) {
+	switch conn := c.conn.(type) {
+	case *rdb.ClusterClient:
+		return conn.MasterForKey(ctx, key)
+	case *rdb.Ring:
+		return nil, fmt.Errorf("%w: live objects are not supported with Ring clients", ErrInvalidConfig)
+	default:
+		return c.pubsubConn(), nil
+	}
+}
+
+// keyspaceChannel returns the keyspace notification channel of key.
+func keyspaceChannel(conn rdb.UniversalClient, key string) string {
+	return "__keyspace@" + notificationDB(conn) + "__:" + key
+}
+
+// keyeventChannel returns the keyevent notification channel of event.
+func keyeventChannel(conn rdb.UniversalClient, event string) string {
+	return "__keyevent@" + notificationDB(conn) + "__:" + event
+}
+
+// notificationDB returns the database number in the notification channels of
+// conn. Cluster and Ring clients only use database 0.
+func notificationDB(conn rdb.UniversalClient) string {
+	var db int
+	if client, ok := conn.(*rdb.Client); ok {
+		db = client.Options().DB
+	}
+
+	return strconv.Itoa(db)
+}
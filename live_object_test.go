@@ -0,0 +1,86 @@
+package xredis_test
+
+import (
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+type testRoutingTable struct {
+	Primary string `redis:"primary"`
+	Weight  int    `redis:"weight"`
+}
+
+var _ = Describe("LiveObject", func() {
+	var (
+		client  *xredis.Client
+		events  string
+		updates chan error
+	)
+
+	BeforeEach(func() {
+		client = newTestClient()
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+
+		config, err := client.Raw().ConfigGet(ctx, "notify-keyspace-events").Result()
+		Expect(err).NotTo(HaveOccurred())
+		events = config["notify-keyspace-events"]
+		Expect(client.Raw().ConfigSet(ctx, "notify-keyspace-events", "Khgx").Err()).To(Succeed())
+
+		updates = make(chan error, 10)
+	})
+
+	AfterEach(func() {
+		Expect(client.Raw().ConfigSet(ctx, "notify-keyspace-events", events).Err()).To(Succeed())
+		Expect(client.Close()).To(Succeed())
+	})
+
+	read := func(obj *xredis.LiveObject, table *testRoutingTable) testRoutingTable {
+		var snapshot testRoutingTable
+		obj.View(func() {
+			snapshot = *table
+		})
+
+		return snapshot
+	}
+
+	It("loads the hash and applies later changes", func() {
+		Expect(client.HSet(ctx, "routes:orders", 0, "primary", "eu-1", "weight", 10)).Error().To(Succeed())
+
+		var table testRoutingTable
+		obj, err := client.LiveObject(ctx, "routes:orders", &table, func(err error) {
+			updates <- err
+		})
+		Expect(err).NotTo(HaveOccurred())
+		defer obj.Close()
+
+		Expect(read(obj, &table)).To(Equal(testRoutingTable{Primary: "eu-1", Weight: 10}))
+
+		Expect(client.HSet(ctx, "routes:orders", 0, "primary", "us-1")).Error().To(Succeed())
+		Eventually(updates, time.Second).Should(Receive(BeNil()))
+		Eventually(func() testRoutingTable {
+			return read(obj, &table)
+		}, time.Second).Should(Equal(testRoutingTable{Primary: "us-1", Weight: 10}))
+
+		Expect(client.Delete(ctx, "routes:orders")).Error().To(Succeed())
+		Eventually(updates, time.Second).Should(Receive(MatchError(xredis.ErrKeyNotFound)))
+		Expect(read(obj, &table)).To(Equal(testRoutingTable{}))
+	})
+
+	It("starts with a zero value for a missing hash", func() {
+		table := testRoutingTable{Primary: "stale"}
+		obj, err := client.LiveObject(ctx, "routes:missing", &table, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obj.Close()).To(Succeed())
+
+		Expect(table).To(Equal(testRoutingTable{}))
+	})
+
+	It("rejects destinations that are not struct pointers", func() {
+		var table testRoutingTable
+		_, err := client.LiveObject(ctx, "routes:orders", table, nil)
+		Expect(err).To(MatchError(xredis.ErrInvalidHashObject))
+	})
+})
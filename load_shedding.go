@@ -0,0 +1,214 @@
+package xredis
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultLoadSheddingMaxWait       = 10 * time.Millisecond
+	defaultLoadSheddingCheckInterval = 100 * time.Millisecond
+)
+
+// LoadSheddingPolicy configures when operations are shed by priority class.
+// Operations of PriorityCritical are never shed.
+type LoadSheddingPolicy struct {
+	// MaxWait is the average time commands may wait for a free connection
+	// before operations of PriorityBestEffort are shed. Pool timeouts always
+	// start shedding.
+	//
+	// Zero uses 10 milliseconds.
+	MaxWait time.Duration
+
+	// NormalMaxWait is the average wait time above which operations of
+	// PriorityNormal are shed as well. It takes effect only while best-effort
+	// operations are shed.
+	//
+	// Zero never sheds normal operations.
+	NormalMaxWait time.Duration
+
+	// CheckInterval defines how often pool wait times are sampled. Shedding
+	// stops after an interval in which the average wait stayed below MaxWait.
+	//
+	// Zero uses 100 milliseconds.
+	CheckInterval time.Duration
+}
+
+func normalizeLoadSheddingPolicy(policy LoadSheddingPolicy) LoadSheddingPolicy {
+	if policy.MaxWait <= 0 {
+		policy.MaxWait = defaultLoadSheddingMaxWait
+	}
+
+	if policy.CheckInterval <= 0 {
+		policy.CheckInterval = defaultLoadSheddingCheckInterval
+	}
+
+	return policy
+}
+
+// shedLevel defines which priority classes are shed.
+type shedLevel int32
+
+const (
+	shedNone shedLevel = iota
+	shedBestEffort
+	shedNormal
+)
+
+// loadShedder tracks which priority classes are shed.
+type loadShedder struct {
+	policy LoadSheddingPolicy
+	level  atomic.Int32
+}
+
+func (s *loadShedder) active() bool {
+	return s != nil && shedLevel(s.level.Load()) != shedNone
+}
+
+func (s *loadShedder) shed(ctx context.Context) bool {
+	if s == nil {
+		return false
+	}
+
+	switch level := shedLevel(s.level.Load()); callOptionsFrom(ctx).priority {
+	case PriorityBestEffort:
+		return level >= shedBestEffort
+	case PriorityNormal:
+		return level >= shedNormal
+	default:
+		return false
+	}
+}
+
+// poolWaits is a sample of the cumulative pool wait statistics.
+type poolWaits struct {
+	count    uint32
+	duration time.Duration
+	timeouts uint32
+}
+
+func samplePoolWaits(stats *rdb.PoolStats) poolWaits {
+	if stats == nil {
+		return poolWaits{}
+	}
+
+	return poolWaits{
+		count:    stats.WaitCount,
+		duration: time.Duration(stats.WaitDurationNs),
+		timeouts: stats.Timeouts,
+	}
+}
+
+// LoadShedding reports whether the client currently sheds operations. It
+// always returns false without WithLoadShedding.
+func (c *Client) LoadShedding() bool {
+	return c.shedder.active()
+}
+
+func (c *Client) watchLoadShedding(done <-chan struct{}) {
+	policy := c.shedder.policy
+
+	ticker := time.NewTicker(policy.CheckInterval)
+	defer ticker.Stop()
+
+	last := samplePoolWaits(c.conn.PoolStats())
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			current := samplePoolWaits(c.conn.PoolStats())
+			c.updateLoadShedding(last, current)
+			last = current
+		}
+	}
+}
+
+// updateLoadShedding switches load shedding on or off based on the commands
+// that waited for a connection between two samples.
+func (c *Client) updateLoadShedding(last, current poolWaits) {
+	var (
+		waits    = current.count - last.count
+		timeouts = current.timeouts - last.timeouts
+		average  time.Duration
+	)
+
+	if waits > 0 {
+		average = (current.duration - last.duration) / time.Duration(waits)
+	}
+
+	policy := c.shedder.policy
+	level := shedNone
+
+	if timeouts > 0 || average > policy.MaxWait {
+		level = shedBestEffort
+
+		if policy.NormalMaxWait > 0 && average > policy.NormalMaxWait {
+			level = shedNormal
+		}
+	}
+
+	if shedLevel(c.shedder.level.Swap(int32(level))) == level {
+		return
+	}
+
+	ctx := context.Background()
+
+	if level != shedNone {
+		shed := PriorityBestEffort
+		if level == shedNormal {
+			shed = PriorityNormal
+		}
+
+		c.logger.LogAttrs(
+			ctx,
+			slog.LevelWarn,
+			"redis load shedding started",
+			slog.String("priority", shed.String()),
+			slog.Duration("average_wait", average),
+			slog.Uint64("pool_timeouts", uint64(timeouts)),
+			slog.Duration("max_wait", policy.MaxWait),
+		)
+
+		return
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelInfo, "redis load shedding stopped", slog.Duration("average_wait", average))
+}
+
+// loadSheddingHook fails commands of shed priority classes.
+type loadSheddingHook struct {
+	passDialHook
+
+	shedder *loadShedder
+}
+
+func (h *loadSheddingHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		if h.shedder.shed(ctx) {
+			cmd.SetErr(ErrLoadShed)
+			return ErrLoadShed
+		}
+
+		return next(ctx, cmd)
+	}
+}
+
+func (h *loadSheddingHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		if h.shedder.shed(ctx) {
+			for _, cmd := range cmds {
+				cmd.SetErr(ErrLoadShed)
+			}
+
+			return ErrLoadShed
+		}
+
+		return next(ctx, cmds)
+	}
+}
@@ -0,0 +1,105 @@
+package xredis_test
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Load shedding", func() {
+	var client *xredis.Client
+
+	newSheddingClient := func(policy xredis.LoadSheddingPolicy) {
+		var err error
+
+		client, err = xredis.NewClient(
+			xredis.WithClientConfig(&xredis.ClientConfig{
+				Addr:        redisAddr,
+				DB:          testDB,
+				PoolSize:    1,
+				PoolTimeout: 5 * time.Second,
+			}),
+			xredis.WithLoadShedding(policy),
+		)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		newSheddingClient(xredis.LoadSheddingPolicy{
+			MaxWait:       time.Millisecond,
+			CheckInterval: 500 * time.Millisecond,
+		})
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	// exhaustPool blocks the only connection and queues a command behind it.
+	exhaustPool := func() {
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			_ = client.Raw().BLPop(ctx, 200*time.Millisecond, "shedding:list").Err()
+		}()
+
+		time.Sleep(20 * time.Millisecond)
+
+		go func() {
+			defer wg.Done()
+			_ = client.Raw().Get(ctx, "shedding:key").Err()
+		}()
+
+		wg.Wait()
+	}
+
+	It("serves best-effort commands without pool pressure", func() {
+		Expect(client.LoadShedding()).To(BeFalse())
+
+		err := client.Raw().Set(xredis.WithCallOptions(ctx, xredis.BestEffort()), "shedding:key", "1", 0).Err()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.Raw().Del(ctx, "shedding:key").Err()).To(Succeed())
+	})
+
+	It("fails best-effort commands while commands wait for connections", func() {
+		exhaustPool()
+		Eventually(client.LoadShedding).Should(BeTrue())
+
+		bestEffort := xredis.WithCallOptions(ctx, xredis.BestEffort())
+		Expect(client.Raw().Get(bestEffort, "shedding:key").Err()).To(MatchError(xredis.ErrLoadShed))
+
+		_, err := client.Raw().Pipelined(bestEffort, func(pipe rdb.Pipeliner) error {
+			pipe.Get(bestEffort, "shedding:key")
+			return nil
+		})
+		Expect(err).To(MatchError(xredis.ErrLoadShed))
+
+		Expect(client.Raw().Get(ctx, "shedding:key").Err()).To(MatchError(rdb.Nil))
+
+		Eventually(client.LoadShedding, 2*time.Second).Should(BeFalse())
+		Expect(client.Raw().Get(bestEffort, "shedding:key").Err()).To(MatchError(rdb.Nil))
+	})
+
+	It("sheds normal commands above NormalMaxWait but keeps critical ones", func() {
+		Expect(client.Close()).To(Succeed())
+		newSheddingClient(xredis.LoadSheddingPolicy{
+			MaxWait:       time.Millisecond,
+			NormalMaxWait: 50 * time.Millisecond,
+			CheckInterval: 500 * time.Millisecond,
+		})
+
+		exhaustPool()
+		Eventually(client.LoadShedding).Should(BeTrue())
+
+		Expect(client.Raw().Get(ctx, "shedding:key").Err()).To(MatchError(xredis.ErrLoadShed))
+
+		critical := xredis.WithCallOptions(ctx, xredis.Priority(xredis.PriorityCritical))
+		Expect(client.Raw().Get(critical, "shedding:key").Err()).To(MatchError(rdb.Nil))
+	})
+})
@@ -4,7 +4,6 @@ import (
 	"context"
 	"time"
 
-	"github.com/google/uuid"
 	rdb "github.com/redis/go-redis/v9"
 )
 
@@ -55,7 +54,7 @@ func (l *Lock) Token() string {
 //
 // It returns acquired=false when the lock already exists.
 func (c *Client) TryLock(ctx context.Context, key string, ttl time.Duration) (*Lock, bool, error) {
-	return c.TryLockWithToken(ctx, key, uuid.NewString(), ttl)
+	return c.TryLockWithToken(ctx, key, c.newID(), ttl)
 }
 
 // TryLockWithToken tries to acquire a Redis lock using the provided owner token.
@@ -4,7 +4,6 @@ import (
 	"context"
 	"time"
 
-	"github.com/google/uuid"
 	rdb "github.com/redis/go-redis/v9"
 )
 
@@ -62,6 +61,53 @@ end
 return 0
 `)
 
+// fencingTokenSuffix names the key that stores the latest fencing token
+// accepted for a key written with FencedSet.
+const fencingTokenSuffix = ":fencing"
+
+// fencingAcceptScript accepts a fencing token unless a newer one was already
+// accepted.
+//
+// KEYS[1] - accepted fencing token key
+// ARGV[1] - fencing token
+var fencingAcceptScript = rdb.NewScript(`
+local accepted = tonumber(redis.call("GET", KEYS[1]))
+if accepted and accepted > tonumber(ARGV[1]) then
+	return 0
+end
+
+redis.call("SET", KEYS[1], ARGV[1])
+
+return 1
+`)
+
+// fencedSetScript stores a value unless a write with a newer fencing token
+// was already applied.
+//
+// KEYS[1] - value key
+// KEYS[2] - accepted fencing token key
+// ARGV[1] - fencing token
+// ARGV[2] - value
+// ARGV[3] - TTL in milliseconds, 0 means no expiration
+var fencedSetScript = rdb.NewScript(`
+local accepted = tonumber(redis.call("GET", KEYS[2]))
+if accepted and accepted > tonumber(ARGV[1]) then
+	return 0
+end
+
+local ttl = tonumber(ARGV[3])
+
+if ttl > 0 then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ttl)
+	redis.call("SET", KEYS[2], ARGV[1], "PX", ttl)
+else
+	redis.call("SET", KEYS[1], ARGV[2])
+	redis.call("SET", KEYS[2], ARGV[1])
+end
+
+return 1
+`)
+
 // FencedLock represents an acquired Redis lease lock with a fencing token.
 //
 // The fencing token is a monotonically increasing number. It is useful only if
@@ -178,7 +224,7 @@ func (c *Client) TryFencedLock(
 	ttl time.Duration,
 	opts ...FencedLockOption,
 ) (*FencedLock, bool, error) {
-	return c.TryFencedLockWithToken(ctx, key, fencingKey, uuid.NewString(), ttl, opts...)
+	return c.TryFencedLockWithToken(ctx, key, fencingKey, c.newID(), ttl, opts...)
 }
 
 // TryFencedLockWithToken tries to acquire a Redis lock using the provided owner
@@ -403,3 +449,117 @@ func parseFencedLockResult(result []any) (bool, int64, error) {
 
 	return true, fencingToken, nil
 }
+
+// AcceptFencingToken records fencingToken as the latest token accepted by the
+// resource guarded by key, unless the resource already accepted a newer one.
+//
+// It returns accepted=false for a stale token, issued to a holder whose lock
+// expired and was acquired by another client since. Tokens equal to the latest
+// accepted one are accepted, so a holder may perform several writes. Downstream
+// systems that keep their state outside Redis call it before every protected
+// write; the key does not expire.
+//
+// fencingToken <= 0 returns ErrInvalidToken.
+func (c *Client) AcceptFencingToken(ctx context.Context, key string, fencingToken int64) (bool, error) {
+	if c == nil {
+		return false, ErrInvalidLock
+	}
+
+	metricOutcome := lockOutcomeError
+
+	defer func() {
+		c.metrics.recordLockOperation(ctx, lockTypeFenced, lockOperationFence, metricOutcome)
+	}()
+
+	if key == "" {
+		return false, ErrInvalidLock
+	}
+
+	if fencingToken <= 0 {
+		return false, ErrInvalidToken
+	}
+
+	accepted, err := fencingAcceptScript.Run(ctx, c.conn, []string{key}, fencingToken).Int64()
+	if err != nil {
+		return false, err
+	}
+
+	if accepted != 1 {
+		metricOutcome = lockOutcomeStale
+
+		return false, nil
+	}
+
+	metricOutcome = lockOutcomeSuccess
+
+	return true, nil
+}
+
+// FencedSet stores value at key like Set, unless key was already written with
+// a newer fencing token.
+//
+// It returns ok=false for a stale token, so a holder whose lock expired cannot
+// overwrite the writes of the next holder. The latest token is stored in a key
+// that hashes to the same Redis Cluster slot as key and shares its TTL.
+//
+// ttl < 0 returns ErrInvalidTTL, and ttl == 0 stores the value without
+// expiration. fencingToken <= 0 returns ErrInvalidToken.
+func (c *Client) FencedSet(
+	ctx context.Context,
+	key string,
+	value any,
+	fencingToken int64,
+	ttl time.Duration,
+) (bool, error) {
+	if c == nil {
+		return false, ErrInvalidLock
+	}
+
+	metricOutcome := lockOutcomeError
+
+	defer func() {
+		c.metrics.recordLockOperation(ctx, lockTypeFenced, lockOperationFence, metricOutcome)
+	}()
+
+	if ttl < 0 {
+		return false, ErrInvalidTTL
+	}
+
+	if fencingToken <= 0 {
+		return false, ErrInvalidToken
+	}
+
+	stored, err := fencedSetScript.Run(
+		ctx,
+		c.conn,
+		[]string{key, sameSlotKey(key, fencingTokenSuffix)},
+		fencingToken,
+		value,
+		durationToMs(ttl),
+	).Int64()
+	if err != nil {
+		return false, err
+	}
+
+	if stored != 1 {
+		metricOutcome = lockOutcomeStale
+
+		return false, nil
+	}
+
+	metricOutcome = lockOutcomeSuccess
+
+	return true, nil
+}
+
+// Set stores value at key with the fencing token of the lock, like
+// Client.FencedSet.
+//
+// It returns ok=false when a holder of a later lock already wrote key.
+func (l *FencedLock) Set(ctx context.Context, key string, value any, ttl time.Duration) (bool, error) {
+	if l == nil || l.lock == nil {
+		return false, ErrInvalidLock
+	}
+
+	return l.lock.client.FencedSet(ctx, key, value, l.fencingToken, ttl)
+}
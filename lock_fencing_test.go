@@ -75,4 +75,56 @@ var _ = Describe("FencedLock", func() {
 		Expect(acquired).To(BeFalse())
 		Expect(lock).To(BeNil())
 	})
+
+	It("rejects writes of a stale lock holder", func() {
+		staleLock, acquired, err := client.TryFencedLock(
+			ctx,
+			"lock:{order:42}",
+			"fencing:{order:42}",
+			time.Minute,
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(acquired).To(BeTrue())
+
+		// The lease of the first holder expires while it is paused.
+		Expect(client.Raw().Del(ctx, "lock:{order:42}").Err()).To(Succeed())
+
+		currentLock, acquired, err := client.TryFencedLock(
+			ctx,
+			"lock:{order:42}",
+			"fencing:{order:42}",
+			time.Minute,
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(acquired).To(BeTrue())
+
+		ok, err := currentLock.Set(ctx, "order:{order:42}:state", "paid", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		ok, err = staleLock.Set(ctx, "order:{order:42}:state", "pending", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		state, _, err := client.String(ctx, "order:{order:42}:state")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(Equal("paid"))
+	})
+
+	It("accepts fencing tokens that are not older than the latest one", func() {
+		ok, err := client.AcceptFencingToken(ctx, "accepted:order:42", 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		ok, err = client.AcceptFencingToken(ctx, "accepted:order:42", 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		ok, err = client.AcceptFencingToken(ctx, "accepted:order:42", 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		_, err = client.AcceptFencingToken(ctx, "accepted:order:42", 0)
+		Expect(err).To(MatchError(xredis.ErrInvalidToken))
+	})
 })
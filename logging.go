@@ -0,0 +1,274 @@
+package xredis
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"sync"
+
+	rdb "github.com/redis/go-redis/v9"
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+const loggerName = "github.com/mkbeh/xredis"
+
+// newEventLogger builds the client event logger from the configured slog
+// logger and OpenTelemetry logger provider.
+//
+// It returns a logger with a discard handler when neither is configured.
+func newEventLogger(logger *slog.Logger, provider otellog.LoggerProvider) *slog.Logger {
+	var handlers []slog.Handler
+
+	if logger != nil {
+		handlers = append(handlers, logger.Handler())
+	}
+
+	if provider != nil {
+		handlers = append(handlers, &otelLogHandler{logger: provider.Logger(loggerName)})
+	}
+
+	switch len(handlers) {
+	case 0:
+		return slog.New(slog.DiscardHandler)
+	case 1:
+		return slog.New(handlers[0])
+	default:
+		return slog.New(fanoutHandler(handlers))
+	}
+}
+
+// loggingHook logs command errors and connection failures and recoveries.
+type loggingHook struct {
+	logger *slog.Logger
+
+	// failedAddrs contains addresses whose last dial attempt failed.
+	failedAddrs sync.Map
+}
+
+func newLoggingHook(logger *slog.Logger) *loggingHook {
+	return &loggingHook{logger: logger}
+}
+
+func (h *loggingHook) DialHook(next rdb.DialHook) rdb.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := next(ctx, network, addr)
+		if err != nil {
+			h.failedAddrs.Store(addr, struct{}{})
+			h.logger.LogAttrs(
+				ctx,
+				slog.LevelWarn,
+				"redis dial failed",
+				slog.String("addr", addr),
+				slog.String("error", err.Error()),
+			)
+
+			return nil, err
+		}
+
+		if _, failed := h.failedAddrs.LoadAndDelete(addr); failed {
+			h.logger.LogAttrs(
+				ctx,
+				slog.LevelInfo,
+				"redis connection restored",
+				slog.String("addr", addr),
+			)
+		}
+
+		return conn, nil
+	}
+}
+
+func (h *loggingHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		err := next(ctx, cmd)
+		if isCommandFailure(err) && !isConnectionSetupCmd(cmd) {
+			h.logCommandError(ctx, cmd, err)
+		}
+
+		return err
+	}
+}
+
+func (h *loggingHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		err := next(ctx, cmds)
+
+		for _, cmd := range cmds {
+			if cmdErr := cmd.Err(); isCommandFailure(cmdErr) && !isConnectionSetupCmd(cmd) {
+				h.logCommandError(ctx, cmd, cmdErr)
+			}
+		}
+
+		return err
+	}
+}
+
+func (h *loggingHook) logCommandError(ctx context.Context, cmd rdb.Cmder, err error) {
+	h.logger.LogAttrs(
+		ctx,
+		slog.LevelWarn,
+		"redis command failed",
+		slog.String("command", cmd.Name()),
+		slog.String("error_class", classifyError(err)),
+		slog.String("error", err.Error()),
+	)
+}
+
+// isCommandFailure reports whether err is a command failure.
+//
+// redis.Nil reports a missing value and is not treated as a failure.
+func isCommandFailure(err error) bool {
+	return err != nil && !errors.Is(err, rdb.Nil)
+}
+
+// fanoutHandler sends slog records to multiple handlers.
+type fanoutHandler []slog.Handler
+
+func (h fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h fanoutHandler) Handle(ctx context.Context, record slog.Record) error {
+	var errs []error
+
+	for _, handler := range h {
+		if handler.Enabled(ctx, record.Level) {
+			errs = append(errs, handler.Handle(ctx, record.Clone()))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (h fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(fanoutHandler, len(h))
+	for i, handler := range h {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+
+	return handlers
+}
+
+func (h fanoutHandler) WithGroup(name string) slog.Handler {
+	handlers := make(fanoutHandler, len(h))
+	for i, handler := range h {
+		handlers[i] = handler.WithGroup(name)
+	}
+
+	return handlers
+}
+
+// otelLogHandler emits slog records as OpenTelemetry log records.
+type otelLogHandler struct {
+	logger otellog.Logger
+	attrs  []otellog.KeyValue
+	group  string
+}
+
+func (h *otelLogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.logger.Enabled(ctx, otellog.EnabledParameters{Severity: otelSeverity(level)})
+}
+
+func (h *otelLogHandler) Handle(ctx context.Context, record slog.Record) error {
+	var rec otellog.Record
+
+	rec.SetTimestamp(record.Time)
+	rec.SetSeverity(otelSeverity(record.Level))
+	rec.SetSeverityText(record.Level.String())
+	rec.SetBody(otellog.StringValue(record.Message))
+	rec.AddAttributes(h.attrs...)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		rec.AddAttributes(otelKeyValue(h.group, attr))
+		return true
+	})
+
+	h.logger.Emit(ctx, rec)
+
+	return nil
+}
+
+func (h *otelLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := *h
+	next.attrs = make([]otellog.KeyValue, 0, len(h.attrs)+len(attrs))
+	next.attrs = append(next.attrs, h.attrs...)
+
+	for _, attr := range attrs {
+		next.attrs = append(next.attrs, otelKeyValue(h.group, attr))
+	}
+
+	return &next
+}
+
+func (h *otelLogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	next := *h
+	next.group = groupKey(h.group, name)
+
+	return &next
+}
+
+func otelKeyValue(group string, attr slog.Attr) otellog.KeyValue {
+	return otellog.KeyValue{Key: groupKey(group, attr.Key), Value: otelValue(attr.Value)}
+}
+
+func otelValue(value slog.Value) otellog.Value {
+	switch value.Kind() {
+	case slog.KindString:
+		return otellog.StringValue(value.String())
+	case slog.KindInt64:
+		return otellog.Int64Value(value.Int64())
+	case slog.KindUint64:
+		return otellog.Int64Value(int64(value.Uint64())) //nolint:gosec // Log attribute values only.
+	case slog.KindFloat64:
+		return otellog.Float64Value(value.Float64())
+	case slog.KindBool:
+		return otellog.BoolValue(value.Bool())
+	case slog.KindDuration:
+		return otellog.Int64Value(value.Duration().Nanoseconds())
+	case slog.KindGroup:
+		attrs := value.Group()
+		kvs := make([]otellog.KeyValue, len(attrs))
+
+		for i, attr := range attrs {
+			kvs[i] = otelKeyValue("", attr)
+		}
+
+		return otellog.MapValue(kvs...)
+	case slog.KindLogValuer:
+		return otelValue(value.Resolve())
+	default:
+		return otellog.StringValue(value.String())
+	}
+}
+
+func otelSeverity(level slog.Level) otellog.Severity {
+	switch {
+	case level >= slog.LevelError:
+		return otellog.SeverityError
+	case level >= slog.LevelWarn:
+		return otellog.SeverityWarn
+	case level >= slog.LevelInfo:
+		return otellog.SeverityInfo
+	default:
+		return otellog.SeverityDebug
+	}
+}
+
+func groupKey(group, key string) string {
+	if group == "" {
+		return key
+	}
+
+	return group + "." + key
+}
@@ -0,0 +1,223 @@
+package xredis_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+type recordingLoggerProvider struct {
+	embedded.LoggerProvider
+
+	logger *recordingLogger
+}
+
+func (p *recordingLoggerProvider) Logger(_ string, _ ...otellog.LoggerOption) otellog.Logger {
+	return p.logger
+}
+
+type recordingLogger struct {
+	embedded.Logger
+
+	mu      sync.Mutex
+	records []otellog.Record
+}
+
+func (l *recordingLogger) Emit(_ context.Context, record otellog.Record) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.records = append(l.records, record.Clone())
+}
+
+func (*recordingLogger) Enabled(_ context.Context, _ otellog.EnabledParameters) bool {
+	return true
+}
+
+func (l *recordingLogger) bodies() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bodies := make([]string, 0, len(l.records))
+	for _, record := range l.records {
+		bodies = append(bodies, record.Body().AsString())
+	}
+
+	return bodies
+}
+
+var _ = Describe("Logging", func() {
+	It("logs command errors to slog and OpenTelemetry", func() {
+		var output syncBuffer
+
+		provider := &recordingLoggerProvider{logger: &recordingLogger{}}
+		client := newTestClient(
+			xredis.WithLogger(slog.New(slog.NewJSONHandler(&output, nil))),
+			xredis.WithLoggerProvider(provider),
+		)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Raw().Do(ctx, "XREDIS.UNKNOWN").Err()).To(HaveOccurred())
+
+		Expect(output.String()).To(ContainSubstring(`"msg":"redis command failed"`))
+		Expect(output.String()).To(ContainSubstring(`"command":"xredis.unknown"`))
+		Expect(output.String()).To(ContainSubstring(`"client_id":"xredis-test"`))
+		Expect(provider.logger.bodies()).To(ContainElement("redis command failed"))
+	})
+
+	It("does not log missing keys", func() {
+		var output syncBuffer
+
+		client := newTestClient(xredis.WithLogger(slog.New(slog.NewJSONHandler(&output, nil))))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		_, ok, err := client.String(ctx, "logging:missing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+		Expect(output.String()).To(BeEmpty())
+	})
+
+	It("logs dial failures", func() {
+		var output syncBuffer
+
+		client, err := xredis.NewClient(
+			xredis.WithClientConfig(&xredis.ClientConfig{
+				Addr:        "127.0.0.1:1",
+				DialTimeout: time.Second,
+				MaxRetries:  -1,
+			}),
+			xredis.WithLogger(slog.New(slog.NewJSONHandler(&output, nil))),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Ping(ctx)).NotTo(Succeed())
+		Expect(output.String()).To(ContainSubstring(`"msg":"redis dial failed"`))
+		Expect(output.String()).To(ContainSubstring(`"addr":"127.0.0.1:1"`))
+	})
+
+	It("logs the effective configuration at debug level without secrets", func() {
+		var output syncBuffer
+
+		client, err := xredis.NewClient(
+			xredis.WithClientConfig(&xredis.ClientConfig{
+				Addr:        redisAddr,
+				Password:    "configured-secret",
+				DialTimeout: 3 * time.Second,
+			}),
+			xredis.WithLogger(slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.Close()).To(Succeed())
+
+		Expect(output.String()).To(ContainSubstring(`"msg":"redis client configured"`))
+		Expect(output.String()).To(ContainSubstring(`"topology":"standalone"`))
+		Expect(output.String()).To(ContainSubstring(`"password_set":true`))
+		Expect(output.String()).To(ContainSubstring(`"dial":3000000000`))
+		Expect(output.String()).To(MatchRegexp(`"pool":\{"size":[1-9]`))
+		Expect(output.String()).NotTo(ContainSubstring("configured-secret"))
+	})
+
+	It("does not log the effective configuration above debug level", func() {
+		var output syncBuffer
+
+		client := newTestClient(xredis.WithLogger(slog.New(slog.NewJSONHandler(&output, nil))))
+		Expect(client.Close()).To(Succeed())
+
+		Expect(output.String()).NotTo(ContainSubstring("redis client configured"))
+	})
+})
+
+var _ = Describe("Error handler", func() {
+	type failure struct {
+		cmd string
+		err error
+	}
+
+	var (
+		mu       sync.Mutex
+		failures []failure
+		client   *xredis.Client
+	)
+
+	BeforeEach(func() {
+		failures = nil
+		client = newTestClient(xredis.WithErrorHandler(func(_ context.Context, cmd string, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			failures = append(failures, failure{cmd: cmd, err: err})
+		}))
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("reports failed commands and pipeline commands", func() {
+		Expect(client.Raw().Do(ctx, "XREDIS.UNKNOWN").Err()).To(HaveOccurred())
+
+		Expect(client.Set(ctx, "handler:string", "value", 0)).To(Succeed())
+		_, err := client.Raw().Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+			pipe.Incr(ctx, "handler:string")
+			pipe.Incr(ctx, "handler:counter")
+
+			return nil
+		})
+		Expect(err).To(HaveOccurred())
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		Expect(failures).To(HaveLen(2))
+		Expect(failures[0].cmd).To(Equal("xredis.unknown"))
+		Expect(failures[1].cmd).To(Equal("incr"))
+		Expect(failures[1].err).To(MatchError(ContainSubstring("not an integer")))
+	})
+
+	It("does not report missing keys", func() {
+		_, ok, err := client.String(ctx, "handler:missing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		Expect(failures).To(BeEmpty())
+	})
+})
@@ -39,8 +39,8 @@ var _ = BeforeSuite(func() {
 	Expect(client.Ping(ctx)).To(Succeed())
 })
 
-func newTestClient() *xredis.Client {
-	client, err := xredis.NewClient(
+func newTestClient(opts ...xredis.Option) *xredis.Client {
+	client, err := xredis.NewClient(append([]xredis.Option{
 		xredis.WithClientConfig(&xredis.ClientConfig{
 			Addr:         redisAddr,
 			DB:           testDB,
@@ -49,7 +49,7 @@ func newTestClient() *xredis.Client {
 			WriteTimeout: 5 * time.Second,
 		}),
 		xredis.WithClientID("xredis-test"),
-	)
+	}, opts...)...)
 	Expect(err).NotTo(HaveOccurred())
 
 	return client
@@ -0,0 +1,183 @@
+package xredis
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultMaintenanceCheckInterval = time.Second
+	maintenanceCheckTimeout         = time.Second
+)
+
+// MaintenanceConfig configures the Redis key that switches maintenance mode
+// on all clients watching it.
+type MaintenanceConfig struct {
+	// Key is the watched key. Maintenance mode is on while the key exists.
+	Key string
+
+	// CheckInterval defines how often the key is checked.
+	//
+	// Zero uses 1 second.
+	CheckInterval time.Duration
+}
+
+func normalizeMaintenanceConfig(cfg MaintenanceConfig) MaintenanceConfig {
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = defaultMaintenanceCheckInterval
+	}
+
+	return cfg
+}
+
+// maintenanceState holds the local and fleet-wide maintenance switches.
+type maintenanceState struct {
+	key    string
+	local  atomic.Bool
+	remote atomic.Bool
+}
+
+func (s *maintenanceState) enabled() bool {
+	return s.local.Load() || s.remote.Load()
+}
+
+// SetMaintenanceMode switches maintenance mode of this client.
+//
+// In maintenance mode, commands that may write, including script and function
+// calls, fail with ErrMaintenance, while reads work normally. The mode is on
+// while it is switched on here or the key configured with
+// WithMaintenanceWatcher exists.
+func (c *Client) SetMaintenanceMode(on bool) {
+	if c.maintenance.local.Swap(on) != on {
+		c.logMaintenance(on, "local")
+	}
+}
+
+// SetFleetMaintenanceMode creates or deletes the key configured with
+// WithMaintenanceWatcher, which switches maintenance mode on every client
+// watching it within one check interval.
+func (c *Client) SetFleetMaintenanceMode(ctx context.Context, on bool) error {
+	key := c.maintenance.key
+	if key == "" {
+		return fmt.Errorf("%w: maintenance key is not configured", ErrInvalidConfig)
+	}
+
+	var err error
+	if on {
+		err = c.conn.Set(ctx, key, time.Now().UTC().Format(time.RFC3339), 0).Err()
+	} else {
+		err = c.conn.Del(ctx, key).Err()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	c.setRemoteMaintenance(on)
+
+	return nil
+}
+
+// MaintenanceMode reports whether the client is in maintenance mode.
+func (c *Client) MaintenanceMode() bool {
+	return c.maintenance.enabled()
+}
+
+func (c *Client) setRemoteMaintenance(on bool) {
+	if c.maintenance.remote.Swap(on) != on {
+		c.logMaintenance(on, "key")
+	}
+}
+
+func (c *Client) logMaintenance(on bool, source string) {
+	msg := "redis maintenance mode disabled"
+	if on {
+		msg = "redis maintenance mode enabled"
+	}
+
+	c.logger.LogAttrs(context.Background(), slog.LevelInfo, msg, slog.String("source", source))
+}
+
+// checkMaintenanceKey updates the fleet-wide switch from the maintenance key.
+//
+// Errors keep the previous state, so a Redis outage does not end a freeze.
+func (c *Client) checkMaintenanceKey() {
+	ctx, cancel := context.WithTimeout(context.Background(), maintenanceCheckTimeout)
+	defer cancel()
+
+	exists, err := c.conn.Exists(ctx, c.maintenance.key).Result()
+	if err != nil {
+		return
+	}
+
+	c.setRemoteMaintenance(exists > 0)
+}
+
+func (c *Client) watchMaintenanceKey(cfg MaintenanceConfig, done <-chan struct{}) {
+	ticker := time.NewTicker(cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			c.checkMaintenanceKey()
+		}
+	}
+}
+
+// maintenanceHook rejects commands that may write while maintenance mode is on.
+// A transaction with a rejected command fails as a whole.
+//
+// Commands on the maintenance key itself pass, so a frozen client can still
+// end a fleet-wide freeze.
+type maintenanceHook struct {
+	passDialHook
+
+	state *maintenanceState
+}
+
+func (h *maintenanceHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		if h.reject(cmd) {
+			return cmd.Err()
+		}
+
+		return next(ctx, cmd)
+	}
+}
+
+func (h *maintenanceHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		if !h.state.enabled() {
+			return next(ctx, cmds)
+		}
+
+		return rejectPipeline(ctx, cmds, h.reject, next)
+	}
+}
+
+// reject fails cmd without sending it to Redis when cmd may write.
+func (h *maintenanceHook) reject(cmd rdb.Cmder) bool {
+	if !h.state.enabled() || isConnectionSetupCmd(cmd) {
+		return false
+	}
+
+	if !isWriteCmd(cmd) && !isScriptCmd(cmd) {
+		return false
+	}
+
+	if key, ok := cmdFirstKey(cmd); ok && h.state.key != "" && key == h.state.key {
+		return false
+	}
+
+	cmd.SetErr(fmt.Errorf("%w: %s is not allowed", ErrMaintenance, cmd.Name()))
+
+	return true
+}
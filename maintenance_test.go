@@ -0,0 +1,115 @@
+package xredis_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Maintenance mode", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient()
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("rejects writes and serves reads", func() {
+		Expect(client.Set(ctx, "maintenance:key", "value", 0)).To(Succeed())
+
+		client.SetMaintenanceMode(true)
+		Expect(client.MaintenanceMode()).To(BeTrue())
+
+		Expect(client.Set(ctx, "maintenance:key", "changed", 0)).To(MatchError(xredis.ErrMaintenance))
+		_, err := client.Incr(ctx, "maintenance:counter")
+		Expect(err).To(MatchError(xredis.ErrMaintenance))
+		Expect(client.Raw().Eval(ctx, "return 1", nil).Err()).To(MatchError(xredis.ErrMaintenance))
+
+		value, ok, err := client.String(ctx, "maintenance:key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("value"))
+
+		client.SetMaintenanceMode(false)
+		Expect(client.Set(ctx, "maintenance:key", "changed", 0)).To(Succeed())
+	})
+
+	It("rejects only the writes of a pipeline", func() {
+		client.SetMaintenanceMode(true)
+
+		var get *rdb.StringCmd
+		cmds, err := client.Raw().Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+			pipe.Set(ctx, "maintenance:key", "value", 0)
+			get = pipe.Get(ctx, "maintenance:key")
+
+			return nil
+		})
+		Expect(errors.Is(err, xredis.ErrMaintenance)).To(BeTrue())
+		Expect(cmds[0].Err()).To(MatchError(xredis.ErrMaintenance))
+		Expect(get.Err()).To(Equal(rdb.Nil))
+	})
+
+	It("fails transactions with a rejected write as a whole", func() {
+		watched := newTestClient(xredis.WithMaintenanceWatcher(xredis.MaintenanceConfig{Key: "maintenance:switch"}))
+		defer func() {
+			Expect(watched.Close()).To(Succeed())
+		}()
+
+		watched.SetMaintenanceMode(true)
+
+		cmds, err := watched.Raw().TxPipelined(ctx, func(pipe rdb.Pipeliner) error {
+			pipe.Set(ctx, "maintenance:switch", "on", 0)
+			pipe.Set(ctx, "maintenance:key", "value", 0)
+
+			return nil
+		})
+		Expect(err).To(MatchError(xredis.ErrMaintenance))
+		Expect(cmds[0].Err()).To(MatchError(xredis.ErrMaintenance))
+
+		exists, err := client.Exists(ctx, "maintenance:switch")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+
+	It("follows the maintenance key", func() {
+		cfg := xredis.MaintenanceConfig{Key: "maintenance:switch", CheckInterval: 20 * time.Millisecond}
+
+		first := newTestClient(xredis.WithMaintenanceWatcher(cfg))
+		defer func() {
+			Expect(first.Close()).To(Succeed())
+		}()
+
+		second := newTestClient(xredis.WithMaintenanceWatcher(cfg))
+		defer func() {
+			Expect(second.Close()).To(Succeed())
+		}()
+
+		Expect(first.SetFleetMaintenanceMode(ctx, true)).To(Succeed())
+		Expect(first.MaintenanceMode()).To(BeTrue())
+		Eventually(second.MaintenanceMode).Should(BeTrue())
+		Expect(second.Set(ctx, "maintenance:key", "value", 0)).To(MatchError(xredis.ErrMaintenance))
+
+		third := newTestClient(xredis.WithMaintenanceWatcher(cfg))
+		defer func() {
+			Expect(third.Close()).To(Succeed())
+		}()
+
+		Expect(third.MaintenanceMode()).To(BeTrue())
+
+		Expect(second.SetFleetMaintenanceMode(ctx, false)).To(Succeed())
+		Eventually(first.MaintenanceMode).Should(BeFalse())
+		Expect(first.Set(ctx, "maintenance:key", "value", 0)).To(Succeed())
+	})
+
+	It("requires a maintenance key for fleet-wide switches", func() {
+		Expect(client.SetFleetMaintenanceMode(ctx, true)).To(MatchError(xredis.ErrInvalidConfig))
+	})
+})
@@ -0,0 +1,148 @@
+package xredis
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const defaultMemoryPressureWindow = 30 * time.Second
+
+// OOMDegradationConfig configures how typed caches degrade while Redis is
+// under memory pressure.
+type OOMDegradationConfig struct {
+	// SkipCacheWrites makes Cache writes, including values stored by
+	// GetOrLoad, succeed without reaching Redis.
+	SkipCacheWrites bool
+
+	// TTLFactor scales the TTLs of Cache writes, for example 0.5 halves them.
+	// Entries without a TTL are not changed.
+	//
+	// Values outside (0, 1) keep TTLs unchanged.
+	TTLFactor float64
+
+	// Window defines how long the client stays under memory pressure after
+	// the last OOM error.
+	//
+	// Zero uses 30 seconds.
+	Window time.Duration
+}
+
+func normalizeOOMDegradationConfig(cfg OOMDegradationConfig) OOMDegradationConfig {
+	if cfg.TTLFactor <= 0 || cfg.TTLFactor >= 1 {
+		cfg.TTLFactor = 1
+	}
+
+	if cfg.Window <= 0 {
+		cfg.Window = defaultMemoryPressureWindow
+	}
+
+	return cfg
+}
+
+// memoryPressure tracks OOM errors returned by Redis.
+type memoryPressure struct {
+	degradation OOMDegradationConfig
+
+	// lastOOM is the time of the last OOM error in Unix nanoseconds.
+	lastOOM atomic.Int64
+}
+
+func newMemoryPressure(cfg *OOMDegradationConfig) *memoryPressure {
+	pressure := &memoryPressure{
+		degradation: normalizeOOMDegradationConfig(OOMDegradationConfig{}),
+	}
+
+	if cfg != nil {
+		pressure.degradation = *cfg
+	}
+
+	return pressure
+}
+
+// observe records an OOM error and reports whether it started a new period
+// of memory pressure.
+func (p *memoryPressure) observe(now time.Time) bool {
+	last := p.lastOOM.Swap(now.UnixNano())
+
+	return last == 0 || now.Sub(time.Unix(0, last)) > p.degradation.Window
+}
+
+func (p *memoryPressure) active() bool {
+	last := p.lastOOM.Load()
+
+	return last != 0 && time.Since(time.Unix(0, last)) <= p.degradation.Window
+}
+
+// skipCacheWrites reports whether cache writes are skipped right now.
+func (p *memoryPressure) skipCacheWrites() bool {
+	return p.degradation.SkipCacheWrites && p.active()
+}
+
+// cacheTTL returns ttl scaled by the degradation policy.
+func (p *memoryPressure) cacheTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 || p.degradation.TTLFactor == 1 || !p.active() {
+		return ttl
+	}
+
+	return max(time.Duration(float64(ttl)*p.degradation.TTLFactor), time.Millisecond)
+}
+
+// MemoryPressure reports whether Redis rejected a command with an OOM error
+// recently, within the window configured with WithOOMDegradation.
+func (c *Client) MemoryPressure() bool {
+	return c.memory.active()
+}
+
+// memoryPressureHook detects OOM errors returned by Redis.
+type memoryPressureHook struct {
+	passDialHook
+
+	pressure *memoryPressure
+	logger   *slog.Logger
+}
+
+func (h *memoryPressureHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		err := next(ctx, cmd)
+		h.observe(ctx, cmd, err)
+
+		return err
+	}
+}
+
+func (h *memoryPressureHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		err := next(ctx, cmds)
+
+		for _, cmd := range cmds {
+			if h.observe(ctx, cmd, cmd.Err()) {
+				break
+			}
+		}
+
+		return err
+	}
+}
+
+// observe records err when it is an OOM error and reports whether it was one.
+func (h *memoryPressureHook) observe(ctx context.Context, cmd rdb.Cmder, err error) bool {
+	if err == nil || classifyError(err) != errorClassOOM {
+		return false
+	}
+
+	if h.pressure.observe(time.Now()) {
+		h.logger.LogAttrs(
+			ctx,
+			slog.LevelWarn,
+			"redis memory pressure detected",
+			slog.String("command", cmd.Name()),
+			slog.Duration("window", h.pressure.degradation.Window),
+		)
+	}
+
+	return true
+}
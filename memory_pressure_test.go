@@ -0,0 +1,128 @@
+package xredis_test
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+type oomError string
+
+func (e oomError) Error() string {
+	return string(e)
+}
+
+func (oomError) RedisError() {}
+
+// oomHook fails commands on oomKey with an OOM error while enabled.
+type oomHook struct {
+	enabled atomic.Bool
+}
+
+const oomKey = "oom:trigger"
+
+func (*oomHook) DialHook(next rdb.DialHook) rdb.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (h *oomHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		if args := cmd.Args(); h.enabled.Load() && len(args) > 1 && args[1] == oomKey {
+			cmd.SetErr(oomError("OOM command not allowed when used memory > 'maxmemory'."))
+			return cmd.Err()
+		}
+
+		return next(ctx, cmd)
+	}
+}
+
+func (*oomHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return next
+}
+
+var _ = Describe("Memory pressure", func() {
+	var (
+		hook   *oomHook
+		client *xredis.Client
+	)
+
+	newOOMClient := func(opts ...xredis.Option) {
+		hook = &oomHook{}
+		client = newTestClient(opts...)
+		client.Raw().AddHook(hook)
+		Expect(client.Raw().Del(ctx, "oom:cache:key").Err()).To(Succeed())
+	}
+
+	triggerOOM := func() {
+		hook.enabled.Store(true)
+		Expect(client.Raw().Set(ctx, oomKey, "value", 0).Err()).To(MatchError(ContainSubstring("OOM")))
+	}
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("detects OOM errors", func() {
+		newOOMClient(xredis.WithOOMDegradation(xredis.OOMDegradationConfig{Window: 100 * time.Millisecond}))
+		Expect(client.MemoryPressure()).To(BeFalse())
+
+		triggerOOM()
+		Expect(client.MemoryPressure()).To(BeTrue())
+		Eventually(client.MemoryPressure).Should(BeFalse())
+	})
+
+	It("skips cache writes under memory pressure", func() {
+		newOOMClient(xredis.WithOOMDegradation(xredis.OOMDegradationConfig{SkipCacheWrites: true}))
+
+		cache, err := xredis.NewCache[string](client, xredis.WithCachePrefix("oom:cache:"))
+		Expect(err).NotTo(HaveOccurred())
+
+		triggerOOM()
+		Expect(cache.Set(ctx, "key", "value")).To(Succeed())
+
+		value, err := cache.GetOrLoad(ctx, "key", func(context.Context) (string, error) {
+			return "loaded", nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal("loaded"))
+		Expect(client.Raw().Exists(ctx, "oom:cache:key").Val()).To(BeZero())
+	})
+
+	It("shortens cache TTLs under memory pressure", func() {
+		newOOMClient(xredis.WithOOMDegradation(xredis.OOMDegradationConfig{TTLFactor: 0.5}))
+
+		cache, err := xredis.NewCache[string](
+			client,
+			xredis.WithCachePrefix("oom:cache:"),
+			xredis.WithCacheTTL(time.Minute),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(cache.Set(ctx, "key", "value")).To(Succeed())
+		Expect(client.Raw().TTL(ctx, "oom:cache:key").Val()).To(BeNumerically(">", 50*time.Second))
+
+		triggerOOM()
+		Expect(cache.Set(ctx, "key", "value")).To(Succeed())
+		Expect(client.Raw().TTL(ctx, "oom:cache:key").Val()).To(BeNumerically("~", 30*time.Second, time.Second))
+	})
+
+	It("does not degrade caches without a policy", func() {
+		newOOMClient()
+
+		cache, err := xredis.NewCache[string](client, xredis.WithCachePrefix("oom:cache:"))
+		Expect(err).NotTo(HaveOccurred())
+
+		triggerOOM()
+		Expect(client.MemoryPressure()).To(BeTrue())
+		Expect(cache.Set(ctx, "key", "value")).To(Succeed())
+		Expect(client.Raw().Exists(ctx, "oom:cache:key").Val()).To(BeEquivalentTo(1))
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	rdb "github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 )
@@ -17,6 +18,7 @@ const metricsInstrumentationName = "github.com/mkbeh/xredis"
 // publication.
 type metrics struct {
 	attributes attribute.Set
+	meter      metric.Meter
 
 	// Cache metrics.
 	cacheRequests           metric.Int64Counter
@@ -29,6 +31,48 @@ type metrics struct {
 	// Rate limiter metrics.
 	rateLimitDecisions metric.Int64Counter
 	rateLimitDuration  metric.Float64Histogram
+
+	// Client limiter metrics.
+	limiterDecisions metric.Int64Counter
+
+	// Command metrics.
+	commandErrors        metric.Int64Counter
+	commandAborts        metric.Int64Counter
+	commandPhaseDuration metric.Float64Histogram
+	commandRetries       metric.Int64Counter
+	commandRetryDelay    metric.Float64Histogram
+
+	// Concurrency limit metrics.
+	concurrencyWait metric.Float64Histogram
+
+	// Key pattern metrics.
+	keyDuration metric.Float64Histogram
+
+	// Pool metrics.
+	poolUtilization  metric.Float64ObservableGauge
+	poolWaits        metric.Int64ObservableCounter
+	poolWaitDuration metric.Float64ObservableCounter
+
+	// Memory metrics.
+	memoryPressure         metric.Int64ObservableGauge
+	evictionPolicyMismatch metric.Int64ObservableGauge
+
+	// Server state metrics.
+	serverUnavailable metric.Int64ObservableGauge
+
+	// Cluster metrics.
+	clusterResharding metric.Int64Counter
+
+	// Pub/Sub metrics.
+	pubSubSubscriptions       metric.Int64UpDownCounter
+	pubSubResubscribes        metric.Int64Counter
+	pubSubResubscribeDuration metric.Float64Histogram
+
+	// Background worker metrics.
+	backgroundPanics metric.Int64Counter
+
+	// Replica read metrics.
+	readVerifications metric.Int64Counter
 }
 
 var globalMetrics atomic.Pointer[metrics]
@@ -104,13 +148,256 @@ func newMetrics(provider metric.MeterProvider) (*metrics, error) {
 		return nil, err
 	}
 
+	limiterDecisions, err := meter.Int64Counter(
+		"redis.client.limiter.decisions",
+		metric.WithDescription(
+			"Number of decisions made by the limiter configured with WithLimiter.",
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	commandErrors, err := meter.Int64Counter(
+		"redis.client.command.errors",
+		metric.WithDescription(
+			"Number of failed Redis commands by error class.",
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	commandAborts, err := meter.Int64Counter(
+		"redis.client.command.aborts",
+		metric.WithDescription(
+			"Number of commands aborted by context cancellation or deadline, by command name and reason.",
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	commandPhaseDuration, err := meter.Float64Histogram(
+		"redis.client.command.phase.duration",
+		metric.WithDescription(
+			"Duration of Redis command phases: pool wait, dial, write, server, and read.",
+		),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(
+			commandPhaseDurationBuckets...,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	commandRetries, err := meter.Int64Counter(
+		"redis.client.command.retries",
+		metric.WithDescription(
+			"Number of Redis command attempts that failed with a retryable error and were considered for a retry.",
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	commandRetryDelay, err := meter.Float64Histogram(
+		"redis.client.command.retry.delay",
+		metric.WithDescription(
+			"Cumulative time Redis commands spent between failed attempts and their retries, including backoff.",
+		),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(
+			commandRetryDelayBuckets...,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrencyWait, err := meter.Float64Histogram(
+		"redis.client.concurrency.wait",
+		metric.WithDescription(
+			"Time Redis commands waited for a slot of the WithMaxConcurrentCommands limit, by outcome.",
+		),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(
+			commandPhaseDurationBuckets...,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	keyDuration, err := meter.Float64Histogram(
+		"redis.client.key.duration",
+		metric.WithDescription(
+			"Duration of Redis commands by the key pattern of TrackKeyLatency that matches their first key.",
+		),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(
+			commandPhaseDurationBuckets...,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	poolUtilization, err := meter.Float64ObservableGauge(
+		"redis.client.pool.utilization",
+		metric.WithDescription(
+			"Ratio of in-use connections to the configured Redis connection pool size.",
+		),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	poolWaits, err := meter.Int64ObservableCounter(
+		"redis.client.pool.waits",
+		metric.WithDescription(
+			"Number of times a command waited for a free Redis connection.",
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	poolWaitDuration, err := meter.Float64ObservableCounter(
+		"redis.client.pool.wait.duration",
+		metric.WithDescription(
+			"Total time commands waited for a free Redis connection.",
+		),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	memoryPressure, err := meter.Int64ObservableGauge(
+		"redis.client.memory_pressure",
+		metric.WithDescription(
+			"Whether Redis recently rejected commands with OOM errors: 1 under memory pressure, 0 otherwise.",
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	evictionPolicyMismatch, err := meter.Int64ObservableGauge(
+		"redis.client.eviction_policy.mismatch",
+		metric.WithDescription(
+			"Whether the Redis maxmemory-policy is dangerous for the declared workload: 1 on mismatch, 0 otherwise.",
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	serverUnavailable, err := meter.Int64ObservableGauge(
+		"redis.client.server.unavailable",
+		metric.WithDescription(
+			"Whether the latest reply of Redis rejected commands with BUSY or LOADING: 1 in that state, 0 otherwise.",
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterResharding, err := meter.Int64Counter(
+		"redis.client.cluster.resharding",
+		metric.WithDescription(
+			"Number of commands redirected with MOVED, which indicates moved hash slots.",
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pubSubSubscriptions, err := meter.Int64UpDownCounter(
+		"redis.client.pubsub.subscriptions",
+		metric.WithDescription(
+			"Number of active Redis Pub/Sub subscriptions.",
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pubSubResubscribes, err := meter.Int64Counter(
+		"redis.client.pubsub.resubscribes",
+		metric.WithDescription(
+			"Number of Redis Pub/Sub channels resubscribed after a reconnect.",
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pubSubResubscribeDuration, err := meter.Float64Histogram(
+		"redis.client.pubsub.resubscribe.duration",
+		metric.WithDescription(
+			"Time from a detected Redis Pub/Sub connection failure to the resubscription.",
+		),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(
+			pubSubResubscribeDurationBuckets...,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	backgroundPanics, err := meter.Int64Counter(
+		"redis.client.background.panics",
+		metric.WithDescription(
+			"Number of panics recovered in background workers.",
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	readVerifications, err := meter.Int64Counter(
+		"redis.client.read.verifications",
+		metric.WithDescription(
+			"Number of replica reads compared with the primary, by result.",
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &metrics{
-		cacheRequests:           cacheRequests,
-		cacheLoaderDuration:     cacheLoaderDuration,
-		cacheSingleflightShared: cacheSingleflightShared,
-		lockOperations:          lockOperations,
-		rateLimitDecisions:      rateLimitDecisions,
-		rateLimitDuration:       rateLimitDuration,
+		meter:                     meter,
+		cacheRequests:             cacheRequests,
+		cacheLoaderDuration:       cacheLoaderDuration,
+		cacheSingleflightShared:   cacheSingleflightShared,
+		lockOperations:            lockOperations,
+		rateLimitDecisions:        rateLimitDecisions,
+		rateLimitDuration:         rateLimitDuration,
+		limiterDecisions:          limiterDecisions,
+		commandErrors:             commandErrors,
+		commandAborts:             commandAborts,
+		commandPhaseDuration:      commandPhaseDuration,
+		commandRetries:            commandRetries,
+		commandRetryDelay:         commandRetryDelay,
+		concurrencyWait:           concurrencyWait,
+		keyDuration:               keyDuration,
+		poolUtilization:           poolUtilization,
+		poolWaits:                 poolWaits,
+		poolWaitDuration:          poolWaitDuration,
+		memoryPressure:            memoryPressure,
+		evictionPolicyMismatch:    evictionPolicyMismatch,
+		serverUnavailable:         serverUnavailable,
+		clusterResharding:         clusterResharding,
+		pubSubSubscriptions:       pubSubSubscriptions,
+		pubSubResubscribes:        pubSubResubscribes,
+		pubSubResubscribeDuration: pubSubResubscribeDuration,
+		backgroundPanics:          backgroundPanics,
+		readVerifications:         readVerifications,
 	}, nil
 }
 
@@ -222,6 +509,358 @@ func (m *metrics) recordRateLimitDecision(
 	)
 }
 
+func (m *metrics) recordLimiterDecision(ctx context.Context, outcome string) {
+	if m == nil {
+		return
+	}
+
+	m.limiterDecisions.Add(
+		ctx,
+		1,
+		metric.WithAttributeSet(m.attributes),
+		metric.WithAttributes(
+			attribute.String(metricAttrLimiterOutcome, outcome),
+		),
+	)
+}
+
+func (m *metrics) recordClusterResharding(ctx context.Context, cmds int) {
+	if m == nil {
+		return
+	}
+
+	m.clusterResharding.Add(
+		ctx,
+		int64(cmds),
+		metric.WithAttributeSet(m.attributes),
+	)
+}
+
+func (m *metrics) recordCommandError(
+	ctx context.Context,
+	command string,
+	errorClass string,
+) {
+	if m == nil {
+		return
+	}
+
+	m.commandErrors.Add(
+		ctx,
+		1,
+		metric.WithAttributeSet(m.attributes),
+		metric.WithAttributes(
+			attribute.String(metricAttrCommandName, command),
+			attribute.String(metricAttrErrorClass, errorClass),
+		),
+	)
+}
+
+// recordCommandAbort counts a command aborted by its context, by reason.
+func (m *metrics) recordCommandAbort(ctx context.Context, command, reason string) {
+	if m == nil {
+		return
+	}
+
+	m.commandAborts.Add(
+		ctx,
+		1,
+		metric.WithAttributeSet(m.attributes),
+		metric.WithAttributes(
+			attribute.String(metricAttrCommandName, command),
+			attribute.String(metricAttrAbortReason, reason),
+		),
+	)
+}
+
+// recordCommandPhase records the duration of one command phase.
+//
+// Dial phases are recorded without a command name.
+func (m *metrics) recordCommandPhase(
+	ctx context.Context,
+	command string,
+	phase string,
+	duration time.Duration,
+) {
+	if m == nil {
+		return
+	}
+
+	attrs := []attribute.KeyValue{attribute.String(metricAttrCommandPhase, phase)}
+	if command != "" {
+		attrs = append(attrs, attribute.String(metricAttrCommandName, command))
+	}
+
+	m.commandPhaseDuration.Record(
+		ctx,
+		duration.Seconds(),
+		metric.WithAttributeSet(m.attributes),
+		metric.WithAttributes(attrs...),
+	)
+}
+
+func (m *metrics) recordCommandRetries(
+	ctx context.Context,
+	command string,
+	errorClass string,
+	retries int,
+	delay time.Duration,
+) {
+	if m == nil {
+		return
+	}
+
+	attrs := metric.WithAttributes(
+		attribute.String(metricAttrCommandName, command),
+		attribute.String(metricAttrErrorClass, errorClass),
+	)
+
+	m.commandRetries.Add(ctx, int64(retries), metric.WithAttributeSet(m.attributes), attrs)
+	m.commandRetryDelay.Record(ctx, delay.Seconds(), metric.WithAttributeSet(m.attributes), attrs)
+}
+
+// recordConcurrencyWait records the wait of a command for a concurrency slot.
+func (m *metrics) recordConcurrencyWait(ctx context.Context, wait time.Duration, outcome string) {
+	if m == nil {
+		return
+	}
+
+	m.concurrencyWait.Record(
+		ctx,
+		wait.Seconds(),
+		metric.WithAttributeSet(m.attributes),
+		metric.WithAttributes(
+			attribute.String(metricAttrConcurrencyOutcome, outcome),
+		),
+	)
+}
+
+func (m *metrics) recordKeyDuration(ctx context.Context, pattern string, duration time.Duration) {
+	if m == nil {
+		return
+	}
+
+	m.keyDuration.Record(
+		ctx,
+		duration.Seconds(),
+		metric.WithAttributeSet(m.attributes),
+		metric.WithAttributes(
+			attribute.String(metricAttrKeyPattern, pattern),
+		),
+	)
+}
+
+func (m *metrics) addPubSubSubscriptions(ctx context.Context, delta int64) {
+	if m == nil {
+		return
+	}
+
+	m.pubSubSubscriptions.Add(
+		ctx,
+		delta,
+		metric.WithAttributeSet(m.attributes),
+	)
+}
+
+// recordPubSubResubscribe records one resubscribed channel. A zero downtime
+// means that the failure was not observed, and only the resubscription is
+// counted.
+func (m *metrics) recordPubSubResubscribe(ctx context.Context, downtime time.Duration) {
+	if m == nil {
+		return
+	}
+
+	m.pubSubResubscribes.Add(
+		ctx,
+		1,
+		metric.WithAttributeSet(m.attributes),
+	)
+
+	if downtime > 0 {
+		m.pubSubResubscribeDuration.Record(
+			ctx,
+			downtime.Seconds(),
+			metric.WithAttributeSet(m.attributes),
+		)
+	}
+}
+
+func (m *metrics) recordBackgroundPanic(ctx context.Context, worker string) {
+	if m == nil {
+		return
+	}
+
+	m.backgroundPanics.Add(
+		ctx,
+		1,
+		metric.WithAttributeSet(m.attributes),
+		metric.WithAttributes(
+			attribute.String(metricAttrWorker, worker),
+		),
+	)
+}
+
+func (m *metrics) recordReadVerification(ctx context.Context, command, result string) {
+	if m == nil {
+		return
+	}
+
+	m.readVerifications.Add(
+		ctx,
+		1,
+		metric.WithAttributeSet(m.attributes),
+		metric.WithAttributes(
+			attribute.String(metricAttrCommandName, command),
+			attribute.String(metricAttrReadVerificationResult, result),
+		),
+	)
+}
+
+// registerPoolWaits registers stats as the pool wait statistics source of
+// one Client.
+func (m *metrics) registerPoolWaits(stats func() *rdb.PoolStats) (metric.Registration, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	return m.meter.RegisterCallback(
+		func(_ context.Context, observer metric.Observer) error {
+			poolStats := stats()
+			if poolStats == nil {
+				return nil
+			}
+
+			observer.ObserveInt64(
+				m.poolWaits,
+				int64(poolStats.WaitCount),
+				metric.WithAttributeSet(m.attributes),
+			)
+
+			observer.ObserveFloat64(
+				m.poolWaitDuration,
+				time.Duration(poolStats.WaitDurationNs).Seconds(),
+				metric.WithAttributeSet(m.attributes),
+			)
+
+			return nil
+		},
+		m.poolWaits,
+		m.poolWaitDuration,
+	)
+}
+
+// registerPoolUtilization registers observe as the pool utilization source
+// of one Client.
+func (m *metrics) registerPoolUtilization(
+	observe func(ctx context.Context) (float64, error),
+) (metric.Registration, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	return m.meter.RegisterCallback(
+		func(ctx context.Context, observer metric.Observer) error {
+			utilization, err := observe(ctx)
+			if err != nil {
+				return nil
+			}
+
+			observer.ObserveFloat64(
+				m.poolUtilization,
+				utilization,
+				metric.WithAttributeSet(m.attributes),
+			)
+
+			return nil
+		},
+		m.poolUtilization,
+	)
+}
+
+// registerMemoryPressure registers pressure as the memory pressure source of
+// one Client.
+func (m *metrics) registerMemoryPressure(pressure func() bool) (metric.Registration, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	return m.meter.RegisterCallback(
+		func(_ context.Context, observer metric.Observer) error {
+			var value int64
+			if pressure() {
+				value = 1
+			}
+
+			observer.ObserveInt64(
+				m.memoryPressure,
+				value,
+				metric.WithAttributeSet(m.attributes),
+			)
+
+			return nil
+		},
+		m.memoryPressure,
+	)
+}
+
+// registerEvictionPolicyMismatch registers mismatch as the eviction policy
+// check result of one Client.
+func (m *metrics) registerEvictionPolicyMismatch(mismatch func() bool) (metric.Registration, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	return m.meter.RegisterCallback(
+		func(_ context.Context, observer metric.Observer) error {
+			var value int64
+			if mismatch() {
+				value = 1
+			}
+
+			observer.ObserveInt64(
+				m.evictionPolicyMismatch,
+				value,
+				metric.WithAttributeSet(m.attributes),
+			)
+
+			return nil
+		},
+		m.evictionPolicyMismatch,
+	)
+}
+
+// registerServerState registers busy and loading as the server state source of
+// one Client.
+func (m *metrics) registerServerState(busy, loading func() bool) (metric.Registration, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	return m.meter.RegisterCallback(
+		func(_ context.Context, observer metric.Observer) error {
+			for state, active := range map[string]func() bool{
+				errorClassBusy:    busy,
+				errorClassLoading: loading,
+			} {
+				var value int64
+				if active() {
+					value = 1
+				}
+
+				observer.ObserveInt64(
+					m.serverUnavailable,
+					value,
+					metric.WithAttributeSet(m.attributes),
+					metric.WithAttributes(attribute.String(metricAttrServerState, state)),
+				)
+			}
+
+			return nil
+		},
+		m.serverUnavailable,
+	)
+}
+
 func newClientMetrics(labels map[string]string) *metrics {
 	base := globalMetrics.Load()
 	if base == nil {
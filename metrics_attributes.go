@@ -11,6 +11,28 @@ const (
 
 	metricAttrRateLimitAlgorithm = "redis.client.rate_limiter.algorithm"
 	metricAttrRateLimitOutcome   = "redis.client.rate_limiter.outcome"
+
+	metricAttrLimiterOutcome = "redis.client.limiter.outcome"
+
+	metricAttrConcurrencyOutcome = "redis.client.concurrency.outcome"
+
+	metricAttrKeyPattern = "redis.client.key.pattern"
+
+	metricAttrServerState = "redis.client.server.state"
+
+	metricAttrCommandName  = "redis.client.command.name"
+	metricAttrCommandPhase = "redis.client.command.phase"
+	metricAttrErrorClass   = "redis.client.error.class"
+	metricAttrAbortReason  = "redis.client.abort.reason"
+
+	metricAttrWorker = "redis.client.worker"
+
+	metricAttrReadVerificationResult = "redis.client.read.verification.result"
+)
+
+const (
+	readVerificationMatch = "match"
+	readVerificationStale = "stale"
 )
 
 const (
@@ -40,12 +62,14 @@ const (
 	lockOperationAcquire = "acquire"
 	lockOperationExtend  = "extend"
 	lockOperationUnlock  = "unlock"
+	lockOperationFence   = "fence"
 )
 
 const (
 	lockOutcomeSuccess   = "success"
 	lockOutcomeContended = "contended"
 	lockOutcomeNotOwned  = "not_owned"
+	lockOutcomeStale     = "stale"
 	lockOutcomeError     = "error"
 )
 
@@ -61,6 +85,47 @@ const (
 	rateLimitOutcomeError    = "error"
 )
 
+const (
+	limiterOutcomeAllowed  = "allowed"
+	limiterOutcomeRejected = "rejected"
+)
+
+const (
+	concurrencyOutcomeAcquired = "acquired"
+	concurrencyOutcomeRejected = "rejected"
+)
+
+const (
+	errorClassTimeout           = "timeout"
+	errorClassConnectionRefused = "connection_refused"
+	errorClassConnection        = "connection"
+	errorClassPoolTimeout       = "pool_timeout"
+	errorClassClientClosed      = "client_closed"
+	errorClassContextCanceled   = "context_canceled"
+	errorClassDeadlineExceeded  = "deadline_exceeded"
+	errorClassMoved             = "moved"
+	errorClassAsk               = "ask"
+	errorClassOOM               = "oom"
+	errorClassWrongType         = "wrongtype"
+	errorClassNoScript          = "noscript"
+	errorClassBusy              = "busy"
+	errorClassLoading           = "loading"
+	errorClassReadOnly          = "readonly"
+	errorClassCrossSlot         = "crossslot"
+	errorClassClusterDown       = "clusterdown"
+	errorClassTryAgain          = "tryagain"
+	errorClassAuth              = "auth"
+	errorClassMaxClients        = "max_clients"
+	errorClassLimiter           = "limiter"
+	errorClassLoadShed          = "load_shed"
+	errorClassConcurrencyLimit  = "concurrency_limit"
+	errorClassServer            = "server"
+	errorClassOther             = "other"
+
+	// errorClassNone labels retries of commands that eventually succeeded.
+	errorClassNone = "none"
+)
+
 // Histogram boundaries are expressed in seconds.
 var cacheLoaderDurationBuckets = []float64{
 	0.005,
@@ -95,3 +160,54 @@ var rateLimitDurationBuckets = []float64{
 	0.5,
 	1,
 }
+
+// Histogram boundaries are expressed in seconds.
+var commandPhaseDurationBuckets = []float64{
+	0.00005,
+	0.0001,
+	0.00025,
+	0.0005,
+	0.001,
+	0.0025,
+	0.005,
+	0.01,
+	0.025,
+	0.05,
+	0.1,
+	0.25,
+	0.5,
+	1,
+	2.5,
+}
+
+// Histogram boundaries are expressed in seconds.
+var commandRetryDelayBuckets = []float64{
+	0.001,
+	0.0025,
+	0.005,
+	0.01,
+	0.025,
+	0.05,
+	0.1,
+	0.25,
+	0.5,
+	1,
+	2.5,
+	5,
+}
+
+// Histogram boundaries are expressed in seconds.
+var pubSubResubscribeDurationBuckets = []float64{
+	0.01,
+	0.05,
+	0.1,
+	0.25,
+	0.5,
+	1,
+	2.5,
+	5,
+	10,
+	30,
+	60,
+	120,
+}
@@ -0,0 +1,78 @@
+package xredis
+
+import (
+	"context"
+	"errors"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// metricsHook records wrapper-level command metrics.
+type metricsHook struct {
+	passDialHook
+
+	metrics *metrics
+}
+
+func newMetricsHook(m *metrics) *metricsHook {
+	return &metricsHook{metrics: m}
+}
+
+func (h *metricsHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		err := next(ctx, cmd)
+		h.recordCommand(ctx, cmd, err)
+
+		return err
+	}
+}
+
+func (h *metricsHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		err := next(ctx, cmds)
+
+		for _, cmd := range cmds {
+			h.recordCommand(ctx, cmd, cmd.Err())
+		}
+
+		return err
+	}
+}
+
+// recordCommand counts failed commands. Commands aborted by their caller are
+// counted separately from errors, so client-side cancellations and
+// deadlines do not look like Redis failures.
+func (h *metricsHook) recordCommand(ctx context.Context, cmd rdb.Cmder, err error) {
+	if !isCommandFailure(err) || isConnectionSetupCmd(cmd) {
+		return
+	}
+
+	if reason, ok := abortReason(ctx, err); ok {
+		h.metrics.recordCommandAbort(ctx, cmd.Name(), reason)
+		return
+	}
+
+	h.metrics.recordCommandError(ctx, cmd.Name(), classifyError(err))
+}
+
+// abortReason reports whether err aborted a command because ctx was canceled
+// or its deadline passed, and returns the reason.
+//
+// With ContextTimeoutEnabled, go-redis turns the context deadline into a
+// socket deadline, so the abort surfaces as a network timeout or a closed
+// connection while ctx is done.
+func abortReason(ctx context.Context, err error) (string, bool) {
+	switch class := classifyError(err); class {
+	case errorClassContextCanceled, errorClassDeadlineExceeded:
+		return class, true
+	case errorClassTimeout, errorClassConnection:
+		switch {
+		case errors.Is(ctx.Err(), context.Canceled):
+			return errorClassContextCanceled, true
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return errorClassDeadlineExceeded, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,207 @@
+package xredis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// ListEnd selects the end of a list that LMPop and BLMPop pop from.
+type ListEnd string
+
+const (
+	// ListLeft pops from the head of a list.
+	ListLeft ListEnd = "left"
+
+	// ListRight pops from the tail of a list.
+	ListRight ListEnd = "right"
+)
+
+// ScoreOrder selects the members that ZMPop and BZMPop pop from a sorted set.
+type ScoreOrder string
+
+const (
+	// ScoreMin pops the members with the lowest scores.
+	ScoreMin ScoreOrder = "min"
+
+	// ScoreMax pops the members with the highest scores.
+	ScoreMax ScoreOrder = "max"
+)
+
+// ListPop contains the elements popped from a list by LMPop or BLMPop.
+type ListPop struct {
+	// Key is the first non-empty list among the requested keys.
+	Key string
+
+	// Values are the popped elements, in pop order.
+	Values []string
+}
+
+// ZPop contains the members popped from a sorted set by ZMPop or BZMPop.
+type ZPop struct {
+	// Key is the first non-empty sorted set among the requested keys.
+	Key string
+
+	// Members are the popped members with their scores, in pop order.
+	Members []rdb.Z
+}
+
+// LMPop pops up to count elements from the first non-empty list among keys.
+//
+// It returns ok=false when all lists are empty. In Redis Cluster, all keys
+// must hash to the same slot. LMPOP requires Redis 7.0 or later.
+func (c *Client) LMPop(ctx context.Context, end ListEnd, count int64, keys ...string) (ListPop, bool, error) {
+	if !validListEnd(end) || count < 1 || len(keys) == 0 {
+		return ListPop{}, false, ErrInvalidPop
+	}
+
+	key, values, err := c.conn.LMPop(ctx, string(end), count, keys...).Result()
+	if err != nil {
+		if errors.Is(err, rdb.Nil) {
+			return ListPop{}, false, nil
+		}
+
+		return ListPop{}, false, err
+	}
+
+	return ListPop{Key: key, Values: values}, true, nil
+}
+
+// BLMPop pops up to count elements from the first non-empty list among keys,
+// blocking until an element is available.
+//
+// It chunks the wait like BLPop and returns ok=false when timeout elapses
+// without an element and ctx.Err() when ctx is done first. BLMPOP requires
+// Redis 7.0 or later.
+func (c *Client) BLMPop(ctx context.Context, timeout time.Duration, end ListEnd, count int64, keys ...string) (ListPop, bool, error) {
+	if timeout < 0 {
+		return ListPop{}, false, ErrInvalidTTL
+	}
+
+	if !validListEnd(end) || count < 1 || len(keys) == 0 {
+		return ListPop{}, false, ErrInvalidPop
+	}
+
+	var (
+		pop ListPop
+		ok  bool
+	)
+
+	err := c.blockInChunks(ctx, timeout, func(block time.Duration) (bool, error) {
+		var cmd *rdb.KeyValuesCmd
+
+		if block >= time.Second {
+			cmd = c.conn.BLMPop(ctx, block.Truncate(time.Second), string(end), count, keys...)
+		} else {
+			cmd = rdb.NewKeyValuesCmd(ctx, multiPopArgs("blmpop", block, string(end), count, keys)...)
+			_ = c.conn.Process(ctx, cmd)
+		}
+
+		key, values, err := cmd.Result()
+		if err != nil {
+			if errors.Is(err, rdb.Nil) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		pop, ok = ListPop{Key: key, Values: values}, true
+
+		return true, nil
+	})
+
+	return pop, ok, err
+}
+
+// ZMPop pops up to count members from the first non-empty sorted set among
+// keys.
+//
+// It returns ok=false when all sorted sets are empty. In Redis Cluster, all
+// keys must hash to the same slot. ZMPOP requires Redis 7.0 or later.
+func (c *Client) ZMPop(ctx context.Context, order ScoreOrder, count int64, keys ...string) (ZPop, bool, error) {
+	if !validScoreOrder(order) || count < 1 || len(keys) == 0 {
+		return ZPop{}, false, ErrInvalidPop
+	}
+
+	key, members, err := c.conn.ZMPop(ctx, string(order), count, keys...).Result()
+	if err != nil {
+		if errors.Is(err, rdb.Nil) {
+			return ZPop{}, false, nil
+		}
+
+		return ZPop{}, false, err
+	}
+
+	return ZPop{Key: key, Members: members}, true, nil
+}
+
+// BZMPop pops up to count members from the first non-empty sorted set among
+// keys, blocking until a member is available.
+//
+// It chunks the wait like BLPop and returns ok=false when timeout elapses
+// without a member and ctx.Err() when ctx is done first. BZMPOP requires
+// Redis 7.0 or later.
+func (c *Client) BZMPop(ctx context.Context, timeout time.Duration, order ScoreOrder, count int64, keys ...string) (ZPop, bool, error) {
+	if timeout < 0 {
+		return ZPop{}, false, ErrInvalidTTL
+	}
+
+	if !validScoreOrder(order) || count < 1 || len(keys) == 0 {
+		return ZPop{}, false, ErrInvalidPop
+	}
+
+	var (
+		pop ZPop
+		ok  bool
+	)
+
+	err := c.blockInChunks(ctx, timeout, func(block time.Duration) (bool, error) {
+		var cmd *rdb.ZSliceWithKeyCmd
+
+		if block >= time.Second {
+			cmd = c.conn.BZMPop(ctx, block.Truncate(time.Second), string(order), count, keys...)
+		} else {
+			cmd = rdb.NewZSliceWithKeyCmd(ctx, multiPopArgs("bzmpop", block, string(order), count, keys)...)
+			_ = c.conn.Process(ctx, cmd)
+		}
+
+		key, members, err := cmd.Result()
+		if err != nil {
+			if errors.Is(err, rdb.Nil) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		pop, ok = ZPop{Key: key, Members: members}, true
+
+		return true, nil
+	})
+
+	return pop, ok, err
+}
+
+// multiPopArgs returns the arguments of BLMPOP or BZMPOP with a block
+// duration shorter than one second, which go-redis would send as one second.
+func multiPopArgs(name string, block time.Duration, from string, count int64, keys []string) []any {
+	args := make([]any, 0, len(keys)+6)
+	args = append(args, name, blockSeconds(block), len(keys))
+
+	for _, key := range keys {
+		args = append(args, key)
+	}
+
+	return append(args, from, "count", count)
+}
+
+func validListEnd(end ListEnd) bool {
+	return end == ListLeft || end == ListRight
+}
+
+func validScoreOrder(order ScoreOrder) bool {
+	return order == ScoreMin || order == ScoreMax
+}
@@ -0,0 +1,204 @@
+package xredis
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// namespaceStatsSamples is the number of keys per prefix whose memory usage
+// is measured.
+const namespaceStatsSamples = 64
+
+// NamespaceStat contains the statistics of the keys under one prefix.
+type NamespaceStat struct {
+	// Prefix is the key prefix.
+	Prefix string `json:"prefix"`
+
+	// Keys is the number of keys with the prefix.
+	Keys int64 `json:"keys"`
+
+	// SampledKeys is the number of keys whose memory usage was measured.
+	SampledKeys int64 `json:"sampled_keys"`
+
+	// SampledBytes is the memory usage of the sampled keys.
+	SampledBytes int64 `json:"sampled_bytes"`
+
+	// EstimatedBytes extrapolates the memory usage of the sampled keys to all
+	// keys with the prefix.
+	EstimatedBytes int64 `json:"estimated_bytes"`
+}
+
+// NamespaceStats counts the keys under every prefix and estimates their
+// memory usage from MEMORY USAGE of up to 64 keys per prefix, giving teams a
+// quick view of what their service stores.
+//
+// A key is counted under the longest matching prefix. Without prefixes, keys
+// are grouped by namespace, the part up to and including the first ":", and
+// keys without a namespace are counted under the empty prefix. Stats are
+// sorted by prefix.
+//
+// It scans the whole keyspace, or the keys of the prefix when only one is
+// given, like ScanEachBatch, so avoid calling it on every request against
+// large keyspaces.
+func (c *Client) NamespaceStats(ctx context.Context, prefixes ...string) ([]NamespaceStat, error) {
+	var opts ScanOptions
+	if len(prefixes) == 1 {
+		opts.Match = escapeGlob(prefixes[0]) + "*"
+	}
+
+	var (
+		mu    sync.Mutex
+		stats = make(map[string]*NamespaceStat)
+	)
+
+	for _, prefix := range prefixes {
+		stats[prefix] = &NamespaceStat{Prefix: prefix}
+	}
+
+	err := c.ScanEachBatch(ctx, opts, func(ctx context.Context, keys []string) error {
+		var sampled []string
+
+		mu.Lock()
+		for _, key := range keys {
+			prefix, ok := namespacePrefix(key, prefixes)
+			if !ok {
+				continue
+			}
+
+			stat := stats[prefix]
+			if stat == nil {
+				stat = &NamespaceStat{Prefix: prefix}
+				stats[prefix] = stat
+			}
+
+			stat.Keys++
+			if stat.SampledKeys < namespaceStatsSamples {
+				stat.SampledKeys++
+				sampled = append(sampled, key)
+			}
+		}
+		mu.Unlock()
+
+		return c.sampleNamespaceMemory(ctx, sampled, prefixes, &mu, stats)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]NamespaceStat, 0, len(stats))
+	for _, stat := range stats {
+		if stat.SampledKeys > 0 {
+			stat.EstimatedBytes = stat.SampledBytes * stat.Keys / stat.SampledKeys
+		}
+
+		result = append(result, *stat)
+	}
+
+	slices.SortFunc(result, func(a, b NamespaceStat) int {
+		return cmp.Compare(a.Prefix, b.Prefix)
+	})
+
+	return result, nil
+}
+
+// sampleNamespaceMemory adds the memory usage of keys to their stats. Keys
+// deleted since the scan are no longer sampled.
+func (c *Client) sampleNamespaceMemory(
+	ctx context.Context,
+	keys []string,
+	prefixes []string,
+	mu *sync.Mutex,
+	stats map[string]*NamespaceStat,
+) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	cmds := make([]*rdb.IntCmd, len(keys))
+
+	_, err := c.conn.Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.MemoryUsage(ctx, key)
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, rdb.Nil) {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for i, key := range keys {
+		prefix, _ := namespacePrefix(key, prefixes)
+		stat := stats[prefix]
+
+		usage, err := cmds[i].Result()
+		if err != nil {
+			stat.SampledKeys--
+			continue
+		}
+
+		stat.SampledBytes += usage
+	}
+
+	return nil
+}
+
+// namespacePrefix returns the longest of prefixes that key starts with, or
+// the namespace of key when prefixes is empty.
+func namespacePrefix(key string, prefixes []string) (string, bool) {
+	if len(prefixes) == 0 {
+		namespace, _, ok := strings.Cut(key, defaultNamespaceSeparator)
+		if !ok {
+			return "", true
+		}
+
+		return namespace + defaultNamespaceSeparator, true
+	}
+
+	var (
+		longest string
+		found   bool
+	)
+
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix) && (!found || len(prefix) > len(longest)) {
+			longest, found = prefix, true
+		}
+	}
+
+	return longest, found
+}
+
+// NamespaceStatsHandler returns an HTTP handler that renders NamespaceStats
+// for prefixes as a JSON array, for mounting on an internal admin endpoint.
+//
+// Every request scans the keyspace, so protect the endpoint from frequent
+// polling.
+func (c *Client) NamespaceStatsHandler(prefixes ...string) http.Handler {
+	prefixes = slices.Clone(prefixes)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stats, err := c.NamespaceStats(r.Context(), prefixes...)
+		if err != nil {
+			c.logger.LogAttrs(r.Context(), slog.LevelWarn, "redis namespace stats failed", slog.Any("error", err))
+			http.Error(w, "namespace stats failed", http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(stats)
+	})
+}
@@ -0,0 +1,84 @@
+package xredis_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("NamespaceStats", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient()
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+
+		for i := range 100 {
+			Expect(client.Set(ctx, "orders:"+strconv.Itoa(i), "order", 0)).To(Succeed())
+		}
+
+		for i := range 10 {
+			Expect(client.Set(ctx, "orders:archive:"+strconv.Itoa(i), "archived", 0)).To(Succeed())
+			Expect(client.Set(ctx, "users:"+strconv.Itoa(i), "user", 0)).To(Succeed())
+		}
+
+		Expect(client.Set(ctx, "standalone", "value", 0)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("groups keys by namespace without prefixes", func() {
+		stats, err := client.NamespaceStats(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats).To(HaveLen(3))
+
+		Expect(stats[0].Prefix).To(Equal(""))
+		Expect(stats[0].Keys).To(Equal(int64(1)))
+
+		Expect(stats[1].Prefix).To(Equal("orders:"))
+		Expect(stats[1].Keys).To(Equal(int64(110)))
+		Expect(stats[1].SampledKeys).To(Equal(int64(64)))
+		Expect(stats[1].SampledBytes).To(BeNumerically(">", 0))
+		Expect(stats[1].EstimatedBytes).To(BeNumerically(">", stats[1].SampledBytes))
+
+		Expect(stats[2].Prefix).To(Equal("users:"))
+		Expect(stats[2].Keys).To(Equal(int64(10)))
+		Expect(stats[2].EstimatedBytes).To(Equal(stats[2].SampledBytes))
+	})
+
+	It("counts keys under the longest matching prefix", func() {
+		stats, err := client.NamespaceStats(ctx, "orders:", "orders:archive:", "carts:")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats).To(HaveLen(3))
+
+		Expect(stats[0].Prefix).To(Equal("carts:"))
+		Expect(stats[0].Keys).To(BeZero())
+
+		Expect(stats[1].Prefix).To(Equal("orders:"))
+		Expect(stats[1].Keys).To(Equal(int64(100)))
+
+		Expect(stats[2].Prefix).To(Equal("orders:archive:"))
+		Expect(stats[2].Keys).To(Equal(int64(10)))
+	})
+
+	It("renders stats as JSON", func() {
+		recorder := httptest.NewRecorder()
+		client.NamespaceStatsHandler("users:").ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+
+		var stats []xredis.NamespaceStat
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &stats)).To(Succeed())
+		Expect(stats).To(HaveLen(1))
+		Expect(stats[0].Prefix).To(Equal("users:"))
+		Expect(stats[0].Keys).To(Equal(int64(10)))
+	})
+})
@@ -4,17 +4,22 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
 	"net"
+	"reflect"
+	"slices"
 	"strings"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/redis/go-redis/extra/redisotel/v9"
 	rdb "github.com/redis/go-redis/v9"
 	"github.com/redis/go-redis/v9/auth"
 	"github.com/redis/go-redis/v9/maintnotifications"
 	"github.com/redis/go-redis/v9/push"
 	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -32,21 +37,98 @@ func (f optionFunc) apply(opts *options) {
 type options struct {
 	cfg any
 
+	// Applied option bundles.
+	presets []Preset
+
+	// Resolved topology, set by the constructor-specific options methods.
+	topology string
+	failover *rdb.FailoverOptions
+	profile  Profile
+
+	// Read endpoint options of a standalone client with separate endpoints.
+	readOptions      *rdb.Options
+	readVerification *ReadVerificationConfig
+
 	// Client identity.
 	clientID       string
 	identitySuffix string
+	idGenerator    func() string
+
+	// Feature rollout, resolved with the client ID.
+	featureRollout FeatureRollout
+	featureGates   []featureGate
+	features       []string
+
+	// Logging.
+	logger         *slog.Logger
+	loggerProvider otellog.LoggerProvider
+	panicHandler   func(BackgroundPanic)
+	errorHandler   func(ctx context.Context, cmd string, err error)
 
 	// Runtime dependencies.
-	tls         *tls.Config
-	limiter     rdb.Limiter
-	codec       Codec
-	credentials credentialsOptions
+	tls                   *tls.Config
+	limiter               rdb.Limiter
+	maxConcurrentCommands int
+	codec                 Codec
+	credentials           credentialsOptions
 
 	// Connection hooks.
 	dialer             func(ctx context.Context, network, addr string) (net.Conn, error)
 	onConnect          func(ctx context.Context, cn *rdb.Conn) error
 	dialerRetryBackoff func(attempt int) time.Duration
 
+	// Named connection pools.
+	pools map[string]PoolConfig
+
+	// Pool monitoring.
+	poolPressure    *PoolPressureConfig
+	warmConnections *WarmConnectionsConfig
+	loadShedding    *LoadSheddingPolicy
+
+	// Pub/Sub monitoring.
+	subscriptionHealth SubscriptionHealthConfig
+
+	// Access analysis.
+	accessSampling *AccessSamplingConfig
+
+	// Memory governance.
+	quotas map[string]NamespaceQuota
+
+	// Locally aggregated counter namespaces.
+	counters map[string]CounterAggregationConfig
+
+	// Sorted set retention applied in the background.
+	retention []RetentionPolicy
+
+	// Hash object schemas checked at startup.
+	hashSchemas    []HashSchema
+	unknownFields  UnknownFieldPolicy
+	hashConverters hashConverters
+
+	// Functions run once per startup under a distributed lock.
+	bootstrap []func(ctx context.Context, c *Client) error
+
+	// Lua scripts loaded at startup.
+	scripts []scriptSource
+
+	// Command interception.
+	keyHasher         *keyHasher
+	keyScope          []string
+	commandLimits     CommandLimitsConfig
+	readOnly          bool
+	deadlineAudit     *DeadlineAuditConfig
+	maintenance       *MaintenanceConfig
+	scriptResultCache *ScriptResultCacheConfig
+	oomDegradation    *OOMDegradationConfig
+	busyRetry         *BusyRetryConfig
+	evictionPolicy    *EvictionPolicyConfig
+
+	// Blocking commands.
+	blockingChunk time.Duration
+
+	// Testing.
+	recording io.Writer
+
 	// Cluster hooks.
 	clusterNewClient func(opt *rdb.Options) *rdb.Client
 	clusterSlots     func(context.Context) ([]rdb.ClusterSlot, error)
@@ -60,11 +142,18 @@ type options struct {
 	pushNotificationProcessor push.NotificationProcessor
 	maintNotificationsConfig  *maintnotifications.Config
 
-	// Wrapper metric labels.
-	metricLabels map[string]string
+	// Wrapper metrics.
+	metricLabels        map[string]string
+	commandPhaseMetrics bool
+
+	// Profiling.
+	profilerLabels bool
 
 	// Tracing.
-	traceOptions []redisotel.TracingOption
+	traceOptions        []redisotel.TracingOption
+	traceDBStatement    *bool
+	traceCommandFilter  func(cmd rdb.Cmder) bool
+	traceCommandsFilter func(cmds []rdb.Cmder) bool
 }
 
 type credentialsOptions struct {
@@ -75,20 +164,34 @@ type credentialsOptions struct {
 
 func newOptions(opts ...Option) *options {
 	options := &options{
-		codec:        JSONCodec{},
-		metricLabels: make(map[string]string),
+		codec:         JSONCodec{},
+		metricLabels:  make(map[string]string),
+		blockingChunk: defaultBlockingChunk,
 	}
 
+	// Presets go first, so explicit options override them.
 	for _, opt := range opts {
-		if opt != nil {
+		if presets, ok := opt.(presetOptions); ok {
+			presets.apply(options)
+		}
+	}
+
+	for _, opt := range opts {
+		if _, ok := opt.(presetOptions); !ok && opt != nil {
 			opt.apply(options)
 		}
 	}
 
+	if options.idGenerator == nil {
+		options.idGenerator = GenerateUUID
+	}
+
 	if options.clientID == "" {
-		options.clientID = uuid.NewString()
+		options.clientID = options.idGenerator()
 	}
 
+	options.applyFeatureGates()
+
 	if options.codec == nil {
 		options.codec = JSONCodec{}
 	}
@@ -111,7 +214,13 @@ func (o *options) clientOptions() (*rdb.Options, error) {
 		return nil, err
 	}
 
+	if err = o.applyProfile(cfg.Profile, clientProfileFields(redisOpts)); err != nil {
+		return nil, err
+	}
+
 	applyClientOptions(redisOpts, o)
+	o.readOptions = applyEndpoints(cfg, redisOpts)
+	o.topology = topologyStandalone
 
 	return redisOpts, nil
 }
@@ -127,7 +236,12 @@ func (o *options) clusterOptions() (*rdb.ClusterOptions, error) {
 		return nil, err
 	}
 
+	if err = o.applyProfile(cfg.Profile, clusterProfileFields(redisOpts)); err != nil {
+		return nil, err
+	}
+
 	applyClusterOptions(redisOpts, o)
+	o.topology = topologyCluster
 
 	return redisOpts, nil
 }
@@ -143,7 +257,12 @@ func (o *options) failoverOptions() (*rdb.FailoverOptions, error) {
 		return nil, err
 	}
 
+	if err = o.applyProfile(cfg.Profile, failoverProfileFields(redisOpts)); err != nil {
+		return nil, err
+	}
+
 	applyFailoverOptions(redisOpts, o)
+	o.failover = redisOpts
 
 	return redisOpts, nil
 }
@@ -159,11 +278,165 @@ func (o *options) ringOptions() (*rdb.RingOptions, error) {
 		return nil, err
 	}
 
+	if err = o.applyProfile(cfg.Profile, ringProfileFields(redisOpts)); err != nil {
+		return nil, err
+	}
+
 	applyRingOptions(redisOpts, o)
+	o.topology = topologyRing
 
 	return redisOpts, nil
 }
 
+// enabledSubsystems returns the names of optional client subsystems enabled by
+// the options.
+func (o *options) enabledSubsystems(metricsEnabled bool) []string {
+	subsystems := make([]string, 0, 8)
+
+	for _, preset := range o.presets {
+		subsystems = append(subsystems, "preset:"+preset.name)
+	}
+
+	if metricsEnabled {
+		subsystems = append(subsystems, "metrics")
+	}
+
+	if metricsEnabled && o.commandPhaseMetrics {
+		subsystems = append(subsystems, "command_phase_metrics")
+	}
+
+	if o.profilerLabels {
+		subsystems = append(subsystems, "profiler_labels")
+	}
+
+	if len(o.traceOptions) > 0 {
+		subsystems = append(subsystems, "tracing")
+	}
+
+	if o.loggerProvider != nil {
+		subsystems = append(subsystems, "otel_logs")
+	}
+
+	if len(o.pools) > 0 {
+		subsystems = append(subsystems, "named_pools")
+	}
+
+	if o.poolPressure != nil {
+		subsystems = append(subsystems, "pool_pressure_watcher")
+	}
+
+	if o.warmConnections != nil {
+		subsystems = append(subsystems, "warm_connections")
+	}
+
+	if o.loadShedding != nil {
+		subsystems = append(subsystems, "load_shedding")
+	}
+
+	if o.readOptions != nil {
+		subsystems = append(subsystems, "read_endpoints")
+	}
+
+	if o.readOptions != nil && o.readVerification != nil {
+		subsystems = append(subsystems, "read_verification")
+	}
+
+	if o.accessSampling != nil {
+		subsystems = append(subsystems, "access_sampling")
+	}
+
+	if len(o.quotas) > 0 {
+		subsystems = append(subsystems, "namespace_quotas")
+	}
+
+	if len(o.counters) > 0 {
+		subsystems = append(subsystems, "counter_aggregation")
+	}
+
+	if len(o.retention) > 0 {
+		subsystems = append(subsystems, "retention")
+	}
+
+	if len(o.hashSchemas) > 0 {
+		subsystems = append(subsystems, "hash_schemas")
+	}
+
+	if len(o.hashConverters) > 0 {
+		subsystems = append(subsystems, "hash_converters")
+	}
+
+	if len(o.bootstrap) > 0 {
+		subsystems = append(subsystems, "bootstrap")
+	}
+
+	if len(o.scripts) > 0 {
+		subsystems = append(subsystems, "scripts")
+	}
+
+	if o.limiter != nil {
+		subsystems = append(subsystems, "limiter")
+	}
+
+	if o.busyRetry != nil {
+		subsystems = append(subsystems, "busy_retry")
+	}
+
+	if o.commandLimits != (CommandLimitsConfig{}) {
+		subsystems = append(subsystems, "command_limits")
+	}
+
+	if o.maxConcurrentCommands > 0 {
+		subsystems = append(subsystems, "concurrency_limit")
+	}
+
+	if len(o.keyScope) > 0 {
+		subsystems = append(subsystems, "key_scope")
+	}
+
+	if o.readOnly {
+		subsystems = append(subsystems, "read_only_mode")
+	}
+
+	if o.deadlineAudit != nil {
+		subsystems = append(subsystems, "deadline_audit")
+	}
+
+	if o.maintenance != nil {
+		subsystems = append(subsystems, "maintenance_watcher")
+	}
+
+	if o.scriptResultCache != nil {
+		subsystems = append(subsystems, "script_result_cache")
+	}
+
+	if o.oomDegradation != nil {
+		subsystems = append(subsystems, "oom_degradation")
+	}
+
+	if o.evictionPolicy != nil {
+		subsystems = append(subsystems, "eviction_policy_check")
+	}
+
+	if o.recording != nil {
+		subsystems = append(subsystems, "recording")
+	}
+
+	for _, feature := range o.features {
+		subsystems = append(subsystems, "feature:"+feature)
+	}
+
+	if o.errorHandler != nil {
+		subsystems = append(subsystems, "error_handler")
+	}
+
+	if o.credentials.provider != nil || o.credentials.providerContext != nil ||
+		o.credentials.streamingProvider != nil {
+		subsystems = append(subsystems, "credentials_provider")
+	}
+
+	return subsystems
+}
+
 func (o *options) addTraceOption(opt redisotel.TracingOption) {
 	o.traceOptions = append(o.traceOptions, opt)
 }
@@ -217,6 +490,64 @@ func WithClientID(id string) Option {
 	})
 }
 
+// WithIDGenerator configures how the client generates IDs: its client ID
+// when WithClientID is not set, lock owner tokens, versioned store
+// revisions, and rate limiter IDs. generate must return unique values.
+//
+// The default, GenerateUUID, returns time-ordered UUIDv7 strings, so client
+// names sort chronologically in CLIENT LIST. A custom generator can add
+// deploy metadata, for example:
+//
+//	xredis.WithIDGenerator(func() string {
+//		return "orders-v42-" + xredis.GenerateUUID()
+//	})
+func WithIDGenerator(generate func() string) Option {
+	return optionFunc(func(opts *options) {
+		if generate != nil {
+			opts.idGenerator = generate
+		}
+	})
+}
+
+// WithFeatureRollout enables each feature of rollout on its percentage of
+// client instances, for example to roll out an experimental subsystem to 10%
+// of a fleet first.
+//
+// Instances are selected by hashing the feature name with the client ID, so
+// a stable WithClientID, such as the host name, keeps the selection across
+// restarts, and raising a percentage keeps the instances already selected.
+// Features are queried with Client.FeatureEnabled, and WithFeature applies
+// options only where a feature is enabled.
+func WithFeatureRollout(rollout FeatureRollout) Option {
+	return optionFunc(func(opts *options) {
+		if opts.featureRollout == nil {
+			opts.featureRollout = make(FeatureRollout, len(rollout))
+		}
+
+		for name, percent := range rollout {
+			if name != "" {
+				opts.featureRollout[name] = min(max(percent, 0), 100)
+			}
+		}
+	})
+}
+
+// WithFeature applies opts only when the feature is enabled on this instance
+// by WithFeatureRollout, so risky subsystems can be rolled out gradually:
+//
+//	xredis.WithFeature("script_result_cache",
+//		xredis.WithScriptResultCache(cacheCfg),
+//	)
+//
+// Gated options are applied after all other options, in order.
+func WithFeature(name string, opts ...Option) Option {
+	return optionFunc(func(o *options) {
+		if name != "" && len(opts) > 0 {
+			o.featureGates = append(o.featureGates, featureGate{name: name, opts: opts})
+		}
+	})
+}
+
 // WithIdentitySuffix configures go-redis identity suffix.
 func WithIdentitySuffix(suffix string) Option {
 	return optionFunc(func(opts *options) {
@@ -226,6 +557,61 @@ func WithIdentitySuffix(suffix string) Option {
 	})
 }
 
+// WithLogger configures the structured logger for client events,
+// such as command errors and connection failures.
+//
+// Client events are not logged by default.
+func WithLogger(logger *slog.Logger) Option {
+	return optionFunc(func(opts *options) {
+		if logger != nil {
+			opts.logger = logger
+		}
+	})
+}
+
+// WithLoggerProvider configures an OpenTelemetry logger provider that receives
+// client events as OpenTelemetry log records.
+//
+// When WithLogger is also configured, events are sent to both.
+func WithLoggerProvider(provider otellog.LoggerProvider) Option {
+	return optionFunc(func(opts *options) {
+		if provider != nil {
+			opts.loggerProvider = provider
+		}
+	})
+}
+
+// WithPanicHandler configures a function called with every panic recovered in
+// a background worker, after the panic is logged and counted. The worker is
+// restarted with exponential backoff when the handler returns.
+//
+// The handler runs on the worker goroutine; it may panic itself to crash the
+// process instead.
+func WithPanicHandler(handler func(BackgroundPanic)) Option {
+	return optionFunc(func(opts *options) {
+		if handler != nil {
+			opts.panicHandler = handler
+		}
+	})
+}
+
+// WithErrorHandler configures a function called with every failed command,
+// after go-redis retries, so errors can be forwarded to error trackers or
+// alerting with custom sampling without wrapping every call site. cmd is the
+// lowercase command name.
+//
+// Missing keys are not failures. Failed commands of a pipeline are reported
+// one by one. The handler runs on the goroutine that issued the command, so
+// it should return quickly; errors.Is distinguishes caller aborts, such as
+// context.Canceled, from Redis failures.
+func WithErrorHandler(handler func(ctx context.Context, cmd string, err error)) Option {
+	return optionFunc(func(opts *options) {
+		if handler != nil {
+			opts.errorHandler = handler
+		}
+	})
+}
+
 // Encoding options.
 
 // WithCodec configures value codec.
@@ -249,6 +635,10 @@ func WithTLSConfig(cfg *tls.Config) Option {
 }
 
 // WithLimiter configures go-redis limiter for standalone and ring clients.
+//
+// Limiter decisions are exported as wrapper-level metrics, and rejected
+// commands are marked on their trace spans. Rejection errors match
+// ErrLimiterRejected.
 func WithLimiter(limiter rdb.Limiter) Option {
 	return optionFunc(func(opts *options) {
 		if limiter != nil {
@@ -257,6 +647,21 @@ func WithLimiter(limiter rdb.Limiter) Option {
 	})
 }
 
+// WithMaxConcurrentCommands limits the commands and pipelines in flight to n,
+// so a misbehaving code path cannot exhaust the connection pool for the rest
+// of the service.
+//
+// Commands over the limit wait for a slot until their context is done or the
+// pool timeout elapses, and then fail with the context error or
+// ErrConcurrencyLimit before taking a connection. Wait times are exported as
+// wrapper-level metrics. Blocking commands hold a slot for their whole wait,
+// while Pub/Sub subscriptions do not take slots. n <= 0 disables the limit.
+func WithMaxConcurrentCommands(n int) Option {
+	return optionFunc(func(opts *options) {
+		opts.maxConcurrentCommands = max(n, 0)
+	})
+}
+
 // WithDialer configures custom Redis connection dialer.
 func WithDialer(dialer func(ctx context.Context, network, addr string) (net.Conn, error)) Option {
 	return optionFunc(func(opts *options) {
@@ -284,6 +689,490 @@ func WithDialerRetryBackoff(fn func(attempt int) time.Duration) Option {
 	})
 }
 
+// Pool options.
+
+// WithReadVerification compares a sample of the reads served by the read
+// endpoints of ClientConfig.ReadAddrs with the write endpoint, to quantify
+// replica staleness. Results are counted in
+// redis.client.read.verifications by command and result, match or stale.
+//
+// Sampled reads cost an extra round trip to the write endpoint. Pipelines are
+// not sampled. The option has no effect without read endpoints, and sample
+// rates outside of (0, 1] disable or saturate sampling.
+func WithReadVerification(cfg ReadVerificationConfig) Option {
+	return optionFunc(func(opts *options) {
+		if cfg.SampleRate <= 0 {
+			opts.readVerification = nil
+			return
+		}
+
+		cfg.SampleRate = min(cfg.SampleRate, 1)
+		opts.readVerification = &cfg
+	})
+}
+
+// WithPools creates additional connection pools with the client settings and
+// the pool settings of each PoolConfig. Commands use a named pool when their
+// context carries the Pool call option, and blocking commands, such as BLPOP
+// and XREAD with BLOCK, use the BlockingPool pool automatically when it is
+// configured, so they cannot starve the main pool. Subscriptions use the
+// PubSubPool pool the same way. PoolConfig.Addrs moves a pool to dedicated
+// nodes.
+//
+// Transactions and the commands of Watch and Conn always use the main pool,
+// and so do WAIT and WAITAOF. Empty names are ignored.
+func WithPools(pools map[string]PoolConfig) Option {
+	return optionFunc(func(opts *options) {
+		for name, cfg := range pools {
+			if name == "" {
+				continue
+			}
+
+			if opts.pools == nil {
+				opts.pools = make(map[string]PoolConfig)
+			}
+
+			opts.pools[name] = cfg
+		}
+	})
+}
+
+// WithPoolPressureWatcher enables a background watcher that logs a warning and
+// calls cfg.OnPressure when connection pool utilization stays at or above
+// cfg.Threshold for cfg.Duration.
+//
+// Zero fields use defaults: 90% utilization for 30 seconds, sampled every second.
+func WithPoolPressureWatcher(cfg PoolPressureConfig) Option {
+	return optionFunc(func(opts *options) {
+		cfg = normalizePoolPressureConfig(cfg)
+		opts.poolPressure = &cfg
+	})
+}
+
+// WithWarmConnections keeps at least one healthy connection to every node,
+// including nodes that are rarely used, by pinging all nodes in the
+// background.
+//
+// For Redis Cluster clients, masters and replicas are pinged, so the first
+// command after a failover of a quiet shard does not wait for a dial. Nodes
+// that fail a ping are logged as warnings once until they recover.
+//
+// Zero fields use defaults: every node is pinged every 10 seconds.
+func WithWarmConnections(cfg WarmConnectionsConfig) Option {
+	return optionFunc(func(opts *options) {
+		cfg = normalizeWarmConnectionsConfig(cfg)
+		opts.warmConnections = &cfg
+	})
+}
+
+// WithLoadShedding fails best-effort operations, marked with the BestEffort
+// call option, with ErrLoadShed while commands wait too long for a free
+// connection. Other operations keep queueing for connections, so important
+// paths do not compete with best-effort traffic during overload.
+//
+// With policy.NormalMaxWait, operations without a priority class are shed on
+// longer waits too, and only operations of PriorityCritical keep queueing.
+//
+// Zero fields use defaults: shedding starts when commands waited 10
+// milliseconds on average, sampled every 100 milliseconds.
+func WithLoadShedding(policy LoadSheddingPolicy) Option {
+	return optionFunc(func(opts *options) {
+		policy = normalizeLoadSheddingPolicy(policy)
+		opts.loadShedding = &policy
+	})
+}
+
+// WithSubscriptionHealth configures health checks of subscriptions created
+// with Subscribe and SubscribeWithHistory.
+//
+// Zero fields use defaults: a ping every 5 seconds, and subscriptions broken
+// for 30 seconds are reported. Without this option, the defaults are used and
+// outages are only logged.
+func WithSubscriptionHealth(cfg SubscriptionHealthConfig) Option {
+	return optionFunc(func(opts *options) {
+		opts.subscriptionHealth = cfg
+	})
+}
+
+// Analysis options.
+
+// WithAccessSampling records a sample of key reads into per-namespace Bloom
+// filters, so ColdKeys can estimate which keys are not read.
+//
+// Sampling runs in a command hook and costs one random number per read
+// command plus a filter update per sampled key. Each namespace keeps a filter
+// for each of the last two windows, of about 120 KiB with the default
+// ExpectedKeys.
+func WithAccessSampling(cfg AccessSamplingConfig) Option {
+	return optionFunc(func(opts *options) {
+		cfg = normalizeAccessSamplingConfig(cfg)
+		opts.accessSampling = &cfg
+	})
+}
+
+// WithNamespaceQuota limits the bytes written with Set, SetStruct, their NX,
+// XX, and GetSet variants, and the same writes of a Batch to keys of
+// quota.Namespace, so teams sharing a Redis deployment stay within their
+// memory budget.
+//
+// Usage is tracked in Redis counters under the "xredis:quota:" prefix, so all
+// clients with the same quota share one budget. Writes beyond the budget fail
+// with ErrNamespaceQuotaExceeded unless quota.Evict frees space. PatchStruct
+// and AppendHistory are counted after the write and never rejected. Quotas
+// are ignored in read-only mode, with an empty namespace, or with a
+// non-positive budget; a later quota for the same namespace replaces the
+// earlier one.
+func WithNamespaceQuota(quota NamespaceQuota) Option {
+	return optionFunc(func(opts *options) {
+		if quota.Namespace == "" || quota.MaxBytes <= 0 {
+			return
+		}
+
+		if opts.quotas == nil {
+			opts.quotas = make(map[string]NamespaceQuota)
+		}
+
+		opts.quotas[quota.Namespace] = quota
+	})
+}
+
+// WithCounterAggregation merges the increments of Client.Count to keys in
+// cfg.Namespace in the client and writes them at cfg.FlushInterval with one
+// pipelined INCRBY per key, which reduces write traffic for telemetry-style
+// counters.
+//
+// Merged increments are lost when the process crashes: at most the
+// increments of one flush interval, plus those of a flush in flight. Flushes
+// that fail are logged and dropped, and Close flushes the remaining
+// increments. Configurations with an empty namespace are ignored; a later one
+// for the same namespace replaces the earlier one.
+func WithCounterAggregation(cfg CounterAggregationConfig) Option {
+	return optionFunc(func(opts *options) {
+		if cfg.Namespace == "" {
+			return
+		}
+
+		if opts.counters == nil {
+			opts.counters = make(map[string]CounterAggregationConfig)
+		}
+
+		opts.counters[cfg.Namespace] = normalizeCounterAggregationConfig(cfg)
+	})
+}
+
+// WithRetention applies each policy in the background at its interval with
+// ApplyRetention, so sliding-window sorted sets, such as recent events, do
+// not grow unbounded. Failures are logged and retried at the next interval.
+//
+// Policies with an empty Match or a MaxAge under one millisecond are ignored.
+func WithRetention(policies ...RetentionPolicy) Option {
+	return optionFunc(func(opts *options) {
+		for _, policy := range policies {
+			if policy.Match == "" || policy.MaxAge < time.Millisecond {
+				continue
+			}
+
+			if policy.Interval <= 0 {
+				policy.Interval = defaultRetentionInterval
+			}
+
+			opts.retention = append(opts.retention, policy)
+		}
+	})
+}
+
+// WithHashSchemas registers struct types used with HSet and HGetAll.
+//
+// Client constructors compare each schema with the one stored in Redis under
+// the "xredis:schema:" prefix and fail with ErrIncompatibleSchema when a field
+// changed its type, instead of letting readers fail later with scan errors.
+// New and compatible schemas are stored, unless a newer version is already
+// stored.
+func WithHashSchemas(schemas ...HashSchema) Option {
+	return optionFunc(func(opts *options) {
+		opts.hashSchemas = append(opts.hashSchemas, schemas...)
+	})
+}
+
+// WithBootstrap runs fn after the client is connected, before the client
+// constructor returns, for creating indexes, loading scripts or functions, and
+// seeding default keys.
+//
+// A distributed lock under the "xredis:bootstrap:" prefix serializes
+// bootstraps across instances: when instances start together, one runs the
+// registered functions in order while the others wait for it and skip them.
+// Instances started later run them again, so fn must be idempotent. The
+// whole bootstrap, including the wait, is bounded by one minute, and errors
+// fail the constructor. Bootstraps are skipped in read-only mode. Nil
+// functions are ignored.
+func WithBootstrap(fn func(ctx context.Context, c *Client) error) Option {
+	return optionFunc(func(opts *options) {
+		if fn != nil {
+			opts.bootstrap = append(opts.bootstrap, fn)
+		}
+	})
+}
+
+// WithScripts registers the Lua scripts of fsys that match glob, usually an
+// embed.FS, and exposes them by file name through Client.Script.
+//
+// Client constructors read the scripts and load them into the script cache of
+// every master, or of every shard of a ring, so script management is
+// declarative and the first call of a script does not send its body. A glob
+// that is malformed or matches no files, an unreadable file, and two scripts
+// with the same file name fail the constructor with ErrInvalidConfig.
+func WithScripts(fsys fs.FS, glob string) Option {
+	return optionFunc(func(opts *options) {
+		opts.scripts = append(opts.scripts, scriptSource{fsys: fsys, glob: glob})
+	})
+}
+
+// WithUnknownHashFields sets how HGetAll and Batch.HGetAll handle hash fields
+// that the destination struct does not declare with a "redis" tag.
+//
+// The default UnknownFieldsIgnore matches go-redis. UnknownFieldsLog and
+// UnknownFieldsError catch drift between writers and readers early.
+func WithUnknownHashFields(policy UnknownFieldPolicy) Option {
+	return optionFunc(func(opts *options) {
+		opts.unknownFields = policy
+	})
+}
+
+// WithHashConverter registers converter for values of type T in hashes.
+//
+// HSet, HSetMany, and Batch.HSet store T values, whether passed as field-value
+// pairs, map values, or struct fields, as the string returned by ToRedis,
+// instead of rejecting types that go-redis cannot write. HGetAll and
+// Batch.HGetAll scan fields of type T, or *T, with FromRedis. Conversion
+// errors are reported as ErrInvalidHashObject on writes and ErrInvalidEntry
+// on reads.
+//
+// A later converter for the same type replaces the earlier one. Converters
+// with a nil function are ignored.
+func WithHashConverter[T any](converter HashConverter[T]) Option {
+	return optionFunc(func(opts *options) {
+		if converter.ToRedis == nil || converter.FromRedis == nil {
+			return
+		}
+
+		if opts.hashConverters == nil {
+			opts.hashConverters = make(hashConverters)
+		}
+
+		opts.hashConverters[reflect.TypeFor[T]()] = hashConverter{
+			toRedis: func(v any) (string, error) {
+				return converter.ToRedis(v.(T))
+			},
+			fromRedis: func(s string) (any, error) {
+				return converter.FromRedis(s)
+			},
+		}
+	})
+}
+
+// Command options.
+
+// WithReadOnlyMode turns every mutating command into a no-op while reads work
+// normally, for load testing and shadow deployments against production data.
+//
+// Skipped writes succeed with zero values and are logged at debug level
+// through the client logger. Skipped pops, such as BLPop and LMPop, report
+// that there was nothing to pop. Commands that read and write, such as GetEx
+// and GetDel, return the stored value without changing it. Script and
+// function calls, and transactions that write, are rejected with
+// ErrReadOnlyMode, which also affects helpers built on Lua scripts, such as
+// locks, rate limiters, and compare operations.
+func WithReadOnlyMode(on bool) Option {
+	return optionFunc(func(opts *options) {
+		opts.readOnly = on
+	})
+}
+
+// WithKeyScope restricts the client to keys starting with one of prefixes, so
+// one team's client cannot touch another team's namespace on shared
+// infrastructure. Commands on other keys fail with ErrOutOfScope before they
+// reach Redis; in pipelines, only the offending commands fail.
+//
+// The scope applies to every key of regular commands, such as the streams of
+// XREAD, and to the declared keys of scripts, but not to Pub/Sub channels or
+// key patterns. FLUSHDB, FLUSHALL, and SWAPDB are always out of scope. Keys of
+// xredis features under the "xredis:" prefix are always in scope, and so are
+// keys that xredis derives from keys in scope by wrapping them in a hash tag,
+// such as those of IncrOnce and FencedSet.
+// Repeated calls extend the scope; empty prefixes are ignored.
+func WithKeyScope(prefixes ...string) Option {
+	return optionFunc(func(opts *options) {
+		for _, prefix := range prefixes {
+			if prefix != "" {
+				opts.keyScope = append(opts.keyScope, prefix)
+			}
+		}
+	})
+}
+
+// WithCommandLimits rejects commands with more than cfg.MaxArgs arguments or a
+// key longer than cfg.MaxKeyLength bytes with ErrCommandTooLarge before they
+// reach Redis, protecting the server from pathological generated input. In
+// pipelines, only the offending commands fail.
+//
+// ExistsCount, DeleteMany, UnlinkMany, and MGetOrdered split their keys into
+// commands within MaxArgs instead of failing. Keys are checked after
+// WithKeyHasher hashes them. Negative limits are treated as zero, which
+// disables the limit.
+func WithCommandLimits(cfg CommandLimitsConfig) Option {
+	return optionFunc(func(opts *options) {
+		opts.commandLimits = CommandLimitsConfig{
+			MaxKeyLength: max(cfg.MaxKeyLength, 0),
+			MaxArgs:      max(cfg.MaxArgs, 0),
+		}
+	})
+}
+
+// WithKeyHasher hashes the identifier part of keys starting with one of
+// prefixes, so PII-bearing identifiers, such as emails or phone numbers, never
+// reach Redis, traces, or logs. With the prefix "user:email:", the key
+// "user:email:ada@example.com" is stored as "user:email:" + hash("ada@example.com").
+//
+// Keys are rewritten in a command hook that runs before every other hook, so
+// the rest of the API takes the original keys. Keys returned by Redis, such
+// as those of SCAN, are the hashed ones. Hashing applies to every key of
+// regular commands, such as the sources of SUNIONSTORE and BITOP or the
+// streams of XREAD, and to the declared keys of scripts, but not to Pub/Sub
+// channels or key patterns. Keys that xredis derives from a key, such as the
+// token keys of IncrOnce and ValueHistoryKey, are hashed like the key and stay
+// in its Redis Cluster slot.
+//
+// A nil hash or an empty prefix list disables hashing; see HMACKeyHasher for
+// a keyed hash.
+func WithKeyHasher(hash func(string) string, prefixes ...string) Option {
+	return optionFunc(func(opts *options) {
+		prefixes = slices.DeleteFunc(slices.Clone(prefixes), func(prefix string) bool {
+			return prefix == ""
+		})
+
+		if hash == nil || len(prefixes) == 0 {
+			opts.keyHasher = nil
+			return
+		}
+
+		opts.keyHasher = &keyHasher{hash: hash, prefixes: prefixes}
+	})
+}
+
+// WithDeadlineAudit logs commands issued with a context without a deadline,
+// with the call site that issued them, so unbounded Redis calls can be found
+// before they hang in production. Records are logged at cfg.Level through the
+// client logger, once per call site unless cfg.EveryCall is set.
+//
+// Commands are never rejected. Commands issued by background workers of the
+// client are not audited.
+func WithDeadlineAudit(cfg DeadlineAuditConfig) Option {
+	return optionFunc(func(opts *options) {
+		opts.deadlineAudit = &cfg
+	})
+}
+
+// WithMaintenanceWatcher switches maintenance mode on while cfg.Key exists,
+// so SetFleetMaintenanceMode or any tool creating the key freezes writes of
+// every client watching it, for example during a migration.
+//
+// The key is checked when the client is created and then every
+// cfg.CheckInterval, once per second by default. An empty key is ignored.
+func WithMaintenanceWatcher(cfg MaintenanceConfig) Option {
+	return optionFunc(func(opts *options) {
+		if cfg.Key != "" {
+			cfg = normalizeMaintenanceConfig(cfg)
+			opts.maintenance = &cfg
+		}
+	})
+}
+
+// WithScriptResultCache caches results of the scripts and functions listed in
+// cfg.Scripts in the client for cfg.TTL, keyed by script and arguments, so
+// repeated identical calls of heavy read-only scripts do not reach Redis.
+//
+// Cached results are shared between callers and must not be modified. An
+// empty script list is ignored.
+func WithScriptResultCache(cfg ScriptResultCacheConfig) Option {
+	return optionFunc(func(opts *options) {
+		if len(cfg.Scripts) > 0 {
+			cfg = normalizeScriptResultCacheConfig(cfg)
+			opts.scriptResultCache = &cfg
+		}
+	})
+}
+
+// WithOOMDegradation configures how typed caches degrade after Redis rejects
+// commands with OOM errors, so applications ride out memory pressure with
+// fewer or shorter-lived cache entries instead of failing cache writes.
+//
+// OOM errors are detected without this option too; see Client.MemoryPressure.
+func WithOOMDegradation(cfg OOMDegradationConfig) Option {
+	return optionFunc(func(opts *options) {
+		cfg = normalizeOOMDegradationConfig(cfg)
+		opts.oomDegradation = &cfg
+	})
+}
+
+// WithBusyRetry configures the retries of commands that Redis rejects with
+// BUSY, while it runs a slow script, or LOADING, while it loads its dataset
+// after a restart or a replica resync.
+//
+// Such commands were not applied, so they are retried with the longer backoff
+// of cfg instead of the go-redis retry schedule, which is meant for network
+// errors and gives up within milliseconds. Pipelines are retried when every
+// command was rejected. Commands sent with NoRetry or PriorityBestEffort fail
+// right away. Without this option, the BusyRetryConfig defaults apply.
+func WithBusyRetry(cfg BusyRetryConfig) Option {
+	return optionFunc(func(opts *options) {
+		cfg = normalizeBusyRetryConfig(cfg)
+		opts.busyRetry = &cfg
+	})
+}
+
+// WithEvictionPolicyCheck checks the server maxmemory-policy against
+// cfg.Workload on startup and periodically, and logs a warning on dangerous
+// mismatches, such as noeviction for a cache or allkeys-lru for a store.
+//
+// Configs with a workload other than WorkloadCache or WorkloadStore are
+// ignored.
+func WithEvictionPolicyCheck(cfg EvictionPolicyConfig) Option {
+	return optionFunc(func(opts *options) {
+		if cfg.Workload == WorkloadCache || cfg.Workload == WorkloadStore {
+			cfg = normalizeEvictionPolicyConfig(cfg)
+			opts.evictionPolicy = &cfg
+		}
+	})
+}
+
+// WithBlockingChunk configures the longest single wait of blocking helpers,
+// such as BLPop, XReadBlock, and Wait. Longer waits are split into chunks so
+// context cancellation is observed between them.
+//
+// Non-positive values are ignored. The default is one second.
+func WithBlockingChunk(d time.Duration) Option {
+	return optionFunc(func(opts *options) {
+		if d > 0 {
+			opts.blockingChunk = d
+		}
+	})
+}
+
+// Testing options.
+
+// WithRecording records every command and its raw RESP reply to w as JSON
+// lines, so the recording can be served later by NewReplayClient.
+//
+// Recordings contain command arguments and replies verbatim, including keys
+// and values. Writes to w are serialized.
+func WithRecording(w io.Writer) Option {
+	return optionFunc(func(opts *options) {
+		if w != nil {
+			opts.recording = w
+		}
+	})
+}
+
 // Credentials options.
 
 // WithCredentialsProvider configures Redis credentials provider.
@@ -395,6 +1284,33 @@ func WithMetricLabel(key, value string) Option {
 	})
 }
 
+// WithCommandPhaseMetrics enables redis.client.command.phase.duration, which
+// splits command time into pool wait, dial, write, server, and read phases.
+// Blocking commands, such as BLPOP and WAIT, have no server phase, and Pub/Sub
+// connections are not measured after they subscribe.
+//
+// Phases are measured by parsing the RESP traffic of every connection, which
+// adds CPU overhead proportional to the traffic volume. The option has no
+// effect unless wrapper metrics are enabled with InitObservability.
+func WithCommandPhaseMetrics(on bool) Option {
+	return optionFunc(func(opts *options) {
+		opts.commandPhaseMetrics = on
+	})
+}
+
+// WithProfilerLabels runs commands with pprof labels, so CPU and goroutine
+// profiles of the service can be sliced by the Redis operations in flight.
+//
+// Commands are labeled with redis.command, the lowercase command name or
+// "pipeline", and with redis.operation when the Operation call option is set.
+// Labels are added with pprof.Do, which allocates per command, so the option
+// is disabled by default.
+func WithProfilerLabels(on bool) Option {
+	return optionFunc(func(opts *options) {
+		opts.profilerLabels = on
+	})
+}
+
 // Tracing options.
 
 // WithTracerProvider enables tracing and configures OpenTelemetry tracer provider.
@@ -407,9 +1323,13 @@ func WithTracerProvider(provider trace.TracerProvider) Option {
 }
 
 // WithTracingDBStatement controls whether raw Redis commands are recorded in spans.
+//
+// It sets the initial trace verbosity, which Client.SetTraceVerbosity can
+// change at runtime.
 func WithTracingDBStatement(on bool) Option {
 	return optionFunc(func(opts *options) {
 		opts.addTraceOption(redisotel.WithDBStatement(on))
+		opts.traceDBStatement = &on
 	})
 }
 
@@ -436,6 +1356,7 @@ func WithTracingCommandFilter(filter func(cmd rdb.Cmder) bool) Option {
 	return optionFunc(func(opts *options) {
 		if filter != nil {
 			opts.addTraceOption(redisotel.WithCommandFilter(filter))
+			opts.traceCommandFilter = filter
 		}
 	})
 }
@@ -445,6 +1366,7 @@ func WithTracingCommandsFilter(filter func(cmds []rdb.Cmder) bool) Option {
 	return optionFunc(func(opts *options) {
 		if filter != nil {
 			opts.addTraceOption(redisotel.WithCommandsFilter(filter))
+			opts.traceCommandsFilter = filter
 		}
 	})
 }
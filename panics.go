@@ -0,0 +1,127 @@
+package xredis
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+)
+
+const (
+	minPanicBackoff = 100 * time.Millisecond
+	maxPanicBackoff = 30 * time.Second
+)
+
+// BackgroundPanic describes a panic recovered in a background worker of a
+// Client, such as a watcher, prober, or subscription health check.
+type BackgroundPanic struct {
+	// Worker names the background worker, such as "maintenance_watcher".
+	Worker string
+
+	// Value is the value passed to panic.
+	Value any
+
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+
+	// Restarts is the number of times the worker was restarted after earlier
+	// panics without running stably in between.
+	Restarts int
+
+	// Backoff is the delay before the worker is restarted.
+	Backoff time.Duration
+}
+
+// runWorker runs fn until it returns or the client is closed. Panics are
+// recovered, reported, and fn is restarted with exponential backoff.
+//
+// The backoff is reset after fn ran for longer than the maximum backoff.
+func (c *Client) runWorker(worker string, fn func(done <-chan struct{})) {
+	restarts := 0
+
+	for {
+		started := time.Now()
+
+		recovered, value, stack := runRecovered(func() {
+			fn(c.done)
+		})
+		if !recovered {
+			return
+		}
+
+		if time.Since(started) > maxPanicBackoff {
+			restarts = 0
+		}
+
+		backoff := panicBackoff(restarts)
+		c.reportPanic(BackgroundPanic{
+			Worker:   worker,
+			Value:    value,
+			Stack:    stack,
+			Restarts: restarts,
+			Backoff:  backoff,
+		})
+
+		timer := time.NewTimer(backoff)
+
+		select {
+		case <-c.done:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		restarts++
+	}
+}
+
+// runRecovered calls fn and reports whether it panicked, with the panic value
+// and stack trace.
+func runRecovered(fn func()) (panicked bool, value any, stack []byte) {
+	defer func() {
+		if value = recover(); value != nil {
+			panicked = true
+			stack = debug.Stack()
+		}
+	}()
+
+	fn()
+
+	return false, nil, nil
+}
+
+// reportPanic logs the panic, counts it, and passes it to the handler
+// configured with WithPanicHandler.
+func (c *Client) reportPanic(info BackgroundPanic) {
+	ctx := context.Background()
+
+	c.logger.LogAttrs(
+		ctx,
+		slog.LevelError,
+		"redis background worker panicked",
+		slog.String("worker", info.Worker),
+		slog.String("panic", fmt.Sprint(info.Value)),
+		slog.Int("restarts", info.Restarts),
+		slog.Duration("backoff", info.Backoff),
+		slog.String("stack", string(info.Stack)),
+	)
+
+	c.metrics.recordBackgroundPanic(ctx, info.Worker)
+
+	if c.opts.panicHandler != nil {
+		c.opts.panicHandler(info)
+	}
+}
+
+func panicBackoff(restarts int) time.Duration {
+	backoff := minPanicBackoff
+	for range restarts {
+		backoff *= 2
+		if backoff >= maxPanicBackoff {
+			return maxPanicBackoff
+		}
+	}
+
+	return backoff
+}
@@ -0,0 +1,99 @@
+package xredis_test
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+// panickingHandler panics once when a record with message msg is handled.
+type panickingHandler struct {
+	slog.Handler
+
+	msg      string
+	panicked *atomic.Bool
+}
+
+func (h panickingHandler) Handle(ctx context.Context, record slog.Record) error {
+	if record.Message == h.msg && h.panicked.CompareAndSwap(false, true) {
+		panic("handler failure")
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h panickingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h.Handler = h.Handler.WithAttrs(attrs)
+	return h
+}
+
+var _ = Describe("Background panics", func() {
+	It("recovers, reports, and restarts panicking workers", func() {
+		var (
+			logs   syncBuffer
+			mu     sync.Mutex
+			panics []xredis.BackgroundPanic
+		)
+
+		logger := slog.New(panickingHandler{
+			Handler:  slog.NewJSONHandler(&logs, nil),
+			msg:      "redis maintenance mode enabled",
+			panicked: &atomic.Bool{},
+		})
+
+		cfg := xredis.MaintenanceConfig{Key: "panics:maintenance", CheckInterval: 20 * time.Millisecond}
+
+		setup := newTestClient()
+		defer func() {
+			Expect(setup.Close()).To(Succeed())
+		}()
+
+		Expect(setup.Raw().Del(ctx, cfg.Key).Err()).To(Succeed())
+
+		client := newTestClient(
+			xredis.WithMaintenanceWatcher(cfg),
+			xredis.WithLogger(logger),
+			xredis.WithPanicHandler(func(info xredis.BackgroundPanic) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				panics = append(panics, info)
+			}),
+		)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Raw().Set(ctx, cfg.Key, "1", 0).Err()).To(Succeed())
+
+		Eventually(func() int {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return len(panics)
+		}).Should(Equal(1))
+
+		mu.Lock()
+		info := panics[0]
+		mu.Unlock()
+
+		Expect(info.Worker).To(Equal("maintenance_watcher"))
+		Expect(info.Value).To(Equal("handler failure"))
+		Expect(string(info.Stack)).To(ContainSubstring("panickingHandler.Handle"))
+		Expect(info.Restarts).To(BeZero())
+		Expect(info.Backoff).To(Equal(100 * time.Millisecond))
+
+		Expect(logs.String()).To(ContainSubstring(`"msg":"redis background worker panicked"`))
+		Expect(logs.String()).To(ContainSubstring(`"worker":"maintenance_watcher"`))
+		Expect(logs.String()).To(ContainSubstring(`"stack":"goroutine`))
+
+		Expect(client.Raw().Del(ctx, cfg.Key).Err()).To(Succeed())
+		Eventually(logs.String).Should(ContainSubstring("redis maintenance mode disabled"))
+	})
+})
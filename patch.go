@@ -0,0 +1,100 @@
+package xredis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	patchResultMissing   = 0
+	patchResultPatched   = 1
+	patchResultNotObject = -1
+)
+
+// patchStructScript atomically merges top-level fields into a JSON object
+// stored as a Redis string value, preserving the key expiration.
+//
+// KEYS[1] - key
+// ARGV[1] - JSON object with fields to set
+//
+// Returns 1 when the value was patched, 0 when the key does not exist, and -1
+// when the stored value is not a JSON object.
+var patchStructScript = rdb.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if not current then
+	return 0
+end
+
+local ok, doc = pcall(cjson.decode, current)
+if not ok or type(doc) ~= "table" or doc[1] ~= nil then
+	return -1
+end
+
+local patch = cjson.decode(ARGV[1])
+for field, value in pairs(patch) do
+	doc[field] = value
+end
+
+redis.call("SET", KEYS[1], cjson.encode(doc), "KEEPTTL")
+
+return 1
+`)
+
+// PatchStruct atomically sets top-level fields of a JSON object stored at key.
+//
+// The value is decoded, modified, and re-encoded server-side in one Lua
+// script, so concurrent patches of different fields do not overwrite each
+// other. A nil field value stores JSON null. The key expiration is preserved.
+//
+// PatchStruct requires the client to use JSONCodec and returns
+// ErrUnsupportedType otherwise. ErrInvalidEntry is returned when the stored
+// value is not a JSON object.
+//
+// Redis cjson decodes numbers as double-precision floats and encodes empty
+// arrays as empty objects, so values relying on large integers or empty
+// arrays should be updated with SetStruct or VersionedStore instead.
+//
+// A patched key in a namespace configured with WithNamespaceQuota is counted
+// with its new size after the patch, so a patch is never rejected by the
+// quota.
+//
+// It returns ok=false when the key does not exist.
+func (c *Client) PatchStruct(ctx context.Context, key string, fields map[string]any) (bool, error) {
+	switch c.codec.(type) {
+	case JSONCodec, *JSONCodec:
+	default:
+		return false, fmt.Errorf("%w: struct patch requires JSON codec", ErrUnsupportedType)
+	}
+
+	if len(fields) == 0 {
+		return c.Exists(ctx, key)
+	}
+
+	patch, err := json.Marshal(fields)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := patchStructScript.Run(ctx, c.conn, []string{key}, patch).Int()
+	if err != nil {
+		return false, err
+	}
+
+	switch result {
+	case patchResultPatched:
+		if err = c.recountWritten(ctx, key); err != nil {
+			return true, err
+		}
+
+		return true, nil
+	case patchResultMissing:
+		return false, nil
+	case patchResultNotObject:
+		return false, fmt.Errorf("%w: stored value is not a JSON object", ErrInvalidEntry)
+	default:
+		return false, fmt.Errorf("%w: unexpected patch result %d", ErrInvalidEntry, result)
+	}
+}
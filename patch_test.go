@@ -0,0 +1,86 @@
+package xredis_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+type patchProfile struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Visits int    `json:"visits"`
+	Active bool   `json:"active"`
+}
+
+var _ = Describe("PatchStruct", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient()
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("patches top-level fields and preserves the expiration", func() {
+		Expect(client.SetStruct(
+			ctx,
+			"profile:42",
+			patchProfile{ID: "42", Name: "Ada", Visits: 1},
+			time.Minute,
+		)).To(Succeed())
+
+		ok, err := client.PatchStruct(ctx, "profile:42", map[string]any{
+			"visits": 2,
+			"active": true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		var profile patchProfile
+
+		ok, err = client.GetStruct(ctx, "profile:42", &profile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(profile).To(Equal(patchProfile{ID: "42", Name: "Ada", Visits: 2, Active: true}))
+
+		ttl, err := client.Raw().PTTL(ctx, "profile:42").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ttl).To(BeNumerically(">", 0))
+	})
+
+	It("returns ok=false for a missing key", func() {
+		ok, err := client.PatchStruct(ctx, "profile:missing", map[string]any{"name": "Ada"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		exists, err := client.Exists(ctx, "profile:missing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+
+	It("rejects values that are not JSON objects", func() {
+		Expect(client.Set(ctx, "profile:raw", "plain text", 0)).To(Succeed())
+
+		ok, err := client.PatchStruct(ctx, "profile:raw", map[string]any{"name": "Ada"})
+		Expect(errors.Is(err, xredis.ErrInvalidEntry)).To(BeTrue())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("requires the JSON codec", func() {
+		codecClient := newTestClient(xredis.WithCodec(failingPipelineCodec{}))
+		defer func() {
+			Expect(codecClient.Close()).To(Succeed())
+		}()
+
+		ok, err := codecClient.PatchStruct(ctx, "profile:42", map[string]any{"name": "Ada"})
+		Expect(errors.Is(err, xredis.ErrUnsupportedType)).To(BeTrue())
+		Expect(ok).To(BeFalse())
+	})
+})
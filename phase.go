@@ -0,0 +1,315 @@
+package xredis
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	commandPhasePoolWait = "pool_wait"
+	commandPhaseDial     = "dial"
+	commandPhaseWrite    = "write"
+	commandPhaseServer   = "server"
+	commandPhaseRead     = "read"
+
+	// commandNamePipeline labels writes that contain more than one command.
+	commandNamePipeline = "pipeline"
+)
+
+// phaseHook measures how command time splits into dial, write, server, and
+// read phases by observing connection I/O.
+//
+// The server phase lasts from the end of the command write to the first byte
+// of its reply, so it includes the network round trip. It is not recorded for
+// blocking commands, whose replies wait for data rather than for Redis, nor
+// on Pub/Sub connections, whose replies are pushed without a command. Pool
+// wait is recorded by poolWaitHook.
+type phaseHook struct {
+	metrics *metrics
+}
+
+func newPhaseHook(m *metrics) *phaseHook {
+	return &phaseHook{metrics: m}
+}
+
+func (h *phaseHook) DialHook(next rdb.DialHook) rdb.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		started := time.Now()
+
+		conn, err := next(ctx, network, addr)
+
+		h.metrics.recordCommandPhase(ctx, "", commandPhaseDial, time.Since(started))
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &phaseConn{Conn: conn, metrics: h.metrics}, nil
+	}
+}
+
+func (*phaseHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return next
+}
+
+func (*phaseHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return next
+}
+
+// phaseConn times writes, reply waits, and reply reads of one connection.
+type phaseConn struct {
+	net.Conn
+
+	metrics *metrics
+
+	mu         sync.Mutex
+	tracker    respTracker
+	pending    []pendingReply
+	frameStart time.Time
+
+	// pubsub marks a connection that subscribed to channels. Its replies
+	// no longer match commands, so nothing more is recorded.
+	pubsub bool
+}
+
+// pendingReply is a command waiting for its reply.
+type pendingReply struct {
+	name    string
+	written time.Time
+
+	// setup marks connection handshake commands, which are not recorded.
+	setup bool
+
+	// blocking marks commands that wait for data, whose server phase is not
+	// recorded.
+	blocking bool
+}
+
+func (c *phaseConn) Write(p []byte) (int, error) {
+	started := time.Now()
+
+	n, err := c.Conn.Write(p)
+
+	written := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pubsub {
+		return n, err
+	}
+
+	var (
+		name     string
+		recorded int
+	)
+
+	for _, args := range c.tracker.writeCommands(p[:n]) {
+		command := pendingReply{
+			name:     respCommandName(args),
+			written:  written,
+			setup:    isConnectionSetupArgs(args),
+			blocking: isBlockingArgs(args),
+		}
+
+		if _, ok := subscribeCommands[command.name]; ok {
+			c.pubsub = true
+			c.pending = nil
+
+			return n, err
+		}
+
+		c.pending = append(c.pending, command)
+
+		if !command.setup {
+			name = command.name
+			recorded++
+		}
+	}
+
+	if recorded == 0 {
+		return n, err
+	}
+
+	if recorded > 1 {
+		name = commandNamePipeline
+	}
+
+	c.metrics.recordCommandPhase(context.Background(), name, commandPhaseWrite, written.Sub(started))
+
+	return n, err
+}
+
+func (c *phaseConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+
+	received := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pubsub {
+		return n, err
+	}
+
+	if err != nil {
+		// A failed read, such as a socket deadline set from the context of
+		// an aborted command, discards the connection, so replies still
+		// pending are never recorded as server time.
+		c.pending = nil
+	}
+
+	if n == 0 {
+		return n, err
+	}
+
+	frameStart := c.frameStart
+	if !c.tracker.readPending() {
+		frameStart = received
+	}
+
+	for range c.tracker.readReplies(p[:n]) {
+		c.recordReply(frameStart, received)
+
+		// Later frames of this read started arriving with it.
+		frameStart = received
+	}
+
+	c.frameStart = frameStart
+
+	return n, err
+}
+
+// recordReply records the server and read phases of the oldest pending
+// command, whose reply started arriving at frameStart and completed at
+// received.
+func (c *phaseConn) recordReply(frameStart, received time.Time) {
+	if len(c.pending) == 0 {
+		return
+	}
+
+	command := c.pending[0]
+	c.pending = c.pending[1:]
+
+	if command.setup {
+		return
+	}
+
+	ctx := context.Background()
+	if !command.blocking {
+		c.metrics.recordCommandPhase(ctx, command.name, commandPhaseServer, frameStart.Sub(command.written))
+	}
+	c.metrics.recordCommandPhase(ctx, command.name, commandPhaseRead, received.Sub(frameStart))
+}
+
+// poolWaitHook records how long commands wait for a pool connection.
+//
+// go-redis asks for the arguments of a command to write it right after
+// taking a connection, so the wait lasts until the last Args call. The hook
+// must run right before callOptionsHook, so no other hook asks for them
+// later. The wait of a retried command includes its earlier attempts.
+type poolWaitHook struct {
+	passDialHook
+
+	metrics *metrics
+}
+
+func addPoolWaitHook(conn rdb.UniversalClient, m *metrics, enabled bool) {
+	if enabled && m != nil {
+		addHook(conn, poolWaitHook{metrics: m})
+	}
+}
+
+func (h poolWaitHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		started := time.Now()
+		waited := &poolWaitCmd{Cmder: cmd}
+
+		err := next(ctx, waited)
+
+		h.record(ctx, cmd.Name(), started, waited, err)
+
+		return err
+	}
+}
+
+func (h poolWaitHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		started := time.Now()
+		waited := &poolWaitCmd{}
+
+		err := next(ctx, wrapPipelineCmd(cmds, func(cmd rdb.Cmder) rdb.Cmder {
+			waited.Cmder = cmd
+			return waited
+		}))
+
+		if waited.Cmder != nil {
+			h.record(ctx, commandNamePipeline, started, waited, err)
+		}
+
+		return err
+	}
+}
+
+func (h poolWaitHook) record(ctx context.Context, name string, started time.Time, cmd *poolWaitCmd, err error) {
+	switch {
+	case errors.Is(err, rdb.ErrPoolTimeout):
+		// The command never got a connection.
+		h.metrics.recordCommandPhase(ctx, name, commandPhasePoolWait, time.Since(started))
+	case !cmd.argsAt.IsZero():
+		h.metrics.recordCommandPhase(ctx, name, commandPhasePoolWait, cmd.argsAt.Sub(started))
+	}
+}
+
+// poolWaitCmd remembers when go-redis last asked for the arguments of a
+// command.
+type poolWaitCmd struct {
+	rdb.Cmder
+
+	argsAt time.Time
+}
+
+func (c *poolWaitCmd) Args() []any {
+	c.argsAt = time.Now()
+	return c.Cmder.Args()
+}
+
+// isBlockingArgs is isBlockingCmd for a command written to a connection,
+// extended by WAIT and WAITAOF.
+func isBlockingArgs(args [][]byte) bool {
+	switch name := respCommandName(args); name {
+	case "wait", "waitaof":
+		return true
+	case "xread", "xreadgroup":
+		return slices.ContainsFunc(args[1:], func(arg []byte) bool {
+			return strings.EqualFold(string(arg), "block")
+		})
+	default:
+		_, ok := blockingCommands[name]
+		return ok
+	}
+}
+
+func isConnectionSetupArgs(args [][]byte) bool {
+	var sub string
+	if len(args) > 1 {
+		sub = string(args[1])
+	}
+
+	return isConnectionSetup(respCommandName(args), sub)
+}
+
+func respCommandName(args [][]byte) string {
+	if len(args) == 0 {
+		return ""
+	}
+
+	return strings.ToLower(string(args[0]))
+}
@@ -2,6 +2,7 @@ package xredis
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	rdb "github.com/redis/go-redis/v9"
@@ -106,12 +107,18 @@ type HSetItem struct {
 	//
 	// Zero leaves the existing expiration unchanged.
 	Expiration time.Duration
+
+	// ExpireMode controls how Expiration is applied.
+	//
+	// The zero value, ExpireAlways, replaces any existing expiration.
+	ExpireMode ExpireMode
 }
 
 // HSetMany sets fields in multiple Redis hashes using one pipeline.
 //
 // Each item is written as an independent HSET command.
-// If an item has a positive TTL, an EXPIRE command is added for that hash key.
+// If an item has a positive TTL, an expiration command following the item
+// ExpireMode is added for that hash key.
 //
 // This helper is safe to use with standalone Redis, Redis Cluster,
 // and Ring clients because each command operates on one key.
@@ -132,15 +139,21 @@ func (c *Client) HSetMany(ctx context.Context, items []HSetItem) error {
 				return ErrInvalidTTL
 			}
 
+			if err := validateExpireMode(item.ExpireMode); err != nil {
+				return err
+			}
+
 			if len(item.Values) == 0 {
 				return ErrInvalidHashObject
 			}
 
-			pipe.HSet(ctx, item.Key, item.Values...)
-
-			if item.Expiration > 0 {
-				pipe.Expire(ctx, item.Key, item.Expiration)
+			values, err := flattenHashValues(item.Values, c.converters)
+			if err != nil {
+				return err
 			}
+
+			pipe.HSet(ctx, item.Key, values...)
+			appendExpire(ctx, pipe, item.Key, item.Expiration, item.ExpireMode)
 		}
 
 		return nil
@@ -149,73 +162,319 @@ func (c *Client) HSetMany(ctx context.Context, items []HSetItem) error {
 	return err
 }
 
+// DeleteResult reports the outcome of a bulk key removal.
+type DeleteResult struct {
+	// Deleted is the number of keys that existed and were removed.
+	Deleted int64
+
+	// Failed contains keys whose removal command failed.
+	//
+	// Failed keys can be retried selectively. Keys that did not exist are not
+	// failures.
+	Failed []string
+}
+
+func (r *DeleteResult) add(other DeleteResult) {
+	r.Deleted += other.Deleted
+	r.Failed = append(r.Failed, other.Failed...)
+}
+
 // DeleteMany deletes keys.
 //
-// For standalone Redis, keys are deleted using one multi-key DEL command.
-// For Redis Cluster and Ring clients, keys are deleted with single-key DEL
+// For standalone Redis, keys are deleted using one multi-key DEL command, or
+// as many as the MaxArgs limit of WithCommandLimits requires. For Redis
+// Cluster and Ring clients, keys are deleted with single-key DEL commands
+// inside a pipeline to avoid multi-key hash-slot constraints.
+//
+// The result reports the number of deleted keys and the keys whose DEL failed.
+// When any key fails, the first error is returned together with the result.
+// For standalone Redis, a failed DEL fails all of its keys. Deleted keys in a
+// namespace configured with WithNamespaceQuota are released from its budget.
+//
+// During Redis Cluster resharding, keys whose DEL still fails with a MOVED or
+// ASK redirect after go-redis followed its redirects are retried selectively.
+//
+// For very large input, split keys into batches at the call site.
+func (c *Client) DeleteMany(ctx context.Context, keys []string) (DeleteResult, error) {
+	return c.removeMany(ctx, keys, rdb.Cmdable.Del, rdb.Pipeliner.Del)
+}
+
+// UnlinkMany unlinks keys.
+//
+// UNLINK removes keys from the keyspace and reclaims memory asynchronously,
+// which is preferable for large values.
+//
+// For standalone Redis, keys are unlinked using one multi-key UNLINK command,
+// or as many as the MaxArgs limit of WithCommandLimits requires.
+// For Redis Cluster and Ring clients, keys are unlinked with single-key UNLINK
 // commands inside a pipeline to avoid multi-key hash-slot constraints.
 //
+// The result reports the number of unlinked keys and the keys whose UNLINK
+// failed, like DeleteMany, and namespace quotas are released the same way.
+//
 // For very large input, split keys into batches at the call site.
-func (c *Client) DeleteMany(ctx context.Context, keys []string) error {
+func (c *Client) UnlinkMany(ctx context.Context, keys []string) (DeleteResult, error) {
+	return c.removeMany(ctx, keys, rdb.Cmdable.Unlink, rdb.Pipeliner.Unlink)
+}
+
+func (c *Client) removeMany(
+	ctx context.Context,
+	keys []string,
+	remove func(rdb.Cmdable, context.Context, ...string) *rdb.IntCmd,
+	pipeRemove func(rdb.Pipeliner, context.Context, ...string) *rdb.IntCmd,
+) (DeleteResult, error) {
 	if err := validatePipelineClient(c); err != nil {
-		return err
+		return DeleteResult{}, err
 	}
 
 	if len(keys) == 0 {
-		return nil
+		return DeleteResult{}, nil
 	}
 
 	switch c.conn.(type) {
 	case *rdb.ClusterClient, *rdb.Ring:
-		_, err := c.conn.Pipelined(ctx, func(pipe rdb.Pipeliner) error {
-			for _, key := range keys {
-				pipe.Del(ctx, key)
+		cmds := make([]*rdb.IntCmd, len(keys))
+
+		run := func(indexes []int) {
+			// Per-command errors are collected below.
+			_, _ = c.conn.Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+				for _, i := range indexes {
+					cmds[i] = pipeRemove(pipe, ctx, keys[i])
+				}
+
+				return nil
+			})
+		}
+
+		run(allIndexes(len(keys)))
+		c.retryRedirected(ctx, len(cmds), func(i int) bool { return isRedirectError(cmds[i].Err()) }, run)
+
+		var (
+			result   DeleteResult
+			firstErr error
+		)
+
+		for i, cmd := range cmds {
+			if err := cmd.Err(); err != nil {
+				result.Failed = append(result.Failed, keys[i])
+				if firstErr == nil {
+					firstErr = err
+				}
+
+				continue
 			}
 
-			return nil
-		})
+			result.Deleted += cmd.Val()
+		}
 
-		return err
+		if err := c.releaseQuotas(ctx, keys, result.Failed); err != nil && firstErr == nil {
+			firstErr = err
+		}
+
+		return result, firstErr
 
 	default:
-		return c.conn.Del(ctx, keys...).Err()
+		var (
+			result   DeleteResult
+			firstErr error
+		)
+
+		for _, chunk := range c.keyChunks(keys) {
+			deleted, err := remove(c.conn, ctx, chunk...).Result()
+			if err != nil {
+				result.Failed = append(result.Failed, chunk...)
+				if firstErr == nil {
+					firstErr = err
+				}
+
+				continue
+			}
+
+			result.Deleted += deleted
+		}
+
+		if err := c.releaseQuotas(ctx, keys, result.Failed); err != nil && firstErr == nil {
+			firstErr = err
+		}
+
+		return result, firstErr
 	}
 }
 
-// UnlinkMany unlinks keys.
+// MGetOrdered reads keys and decodes their values with the client Codec into
+// values returned by newDst, which should return a new pointer for each call.
 //
-// UNLINK removes keys from the keyspace and reclaims memory asynchronously,
-// which is preferable for large values.
+// The result is aligned with keys: result[i] holds the value of keys[i], or
+// nil when the key does not exist.
 //
-// For standalone Redis, keys are unlinked using one multi-key UNLINK command.
-// For Redis Cluster and Ring clients, keys are unlinked with single-key UNLINK
-// commands inside a pipeline to avoid multi-key hash-slot constraints.
+// For standalone Redis, keys are read with one MGET command, or as many as the
+// MaxArgs limit of WithCommandLimits requires. For Redis Cluster and Ring
+// clients, keys are read with single-key GET commands inside a pipeline to
+// avoid multi-key hash-slot constraints. Keys redirected during resharding are
+// retried like in DeleteMany.
 //
 // For very large input, split keys into batches at the call site.
-func (c *Client) UnlinkMany(ctx context.Context, keys []string) error {
+func (c *Client) MGetOrdered(ctx context.Context, keys []string, newDst func() any) ([]any, error) {
 	if err := validatePipelineClient(c); err != nil {
-		return err
+		return nil, err
+	}
+
+	if newDst == nil {
+		return nil, ErrInvalidPipeline
 	}
 
 	if len(keys) == 0 {
-		return nil
+		return []any{}, nil
 	}
 
+	var (
+		values = make([][]byte, len(keys))
+		found  = make([]bool, len(keys))
+	)
+
 	switch c.conn.(type) {
 	case *rdb.ClusterClient, *rdb.Ring:
-		_, err := c.conn.Pipelined(ctx, func(pipe rdb.Pipeliner) error {
-			for _, key := range keys {
-				pipe.Unlink(ctx, key)
+		cmds := make([]*rdb.StringCmd, len(keys))
+
+		run := func(indexes []int) {
+			// Per-command errors are checked below.
+			_, _ = c.conn.Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+				for _, i := range indexes {
+					cmds[i] = pipe.Get(ctx, keys[i])
+				}
+
+				return nil
+			})
+		}
+
+		run(allIndexes(len(keys)))
+		c.retryRedirected(ctx, len(cmds), func(i int) bool { return isRedirectError(cmds[i].Err()) }, run)
+
+		for i, cmd := range cmds {
+			data, err := cmd.Bytes()
+			if errors.Is(err, rdb.Nil) {
+				continue
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			values[i], found[i] = data, true
+		}
+
+	default:
+		offset := 0
+
+		for _, chunk := range c.keyChunks(keys) {
+			replies, err := c.conn.MGet(ctx, chunk...).Result()
+			if err != nil {
+				return nil, err
+			}
+
+			for i, reply := range replies {
+				if data, ok := reply.(string); ok {
+					values[offset+i], found[offset+i] = []byte(data), true
+				}
+			}
+
+			offset += len(chunk)
+		}
+	}
+
+	result := make([]any, len(keys))
+	for i, value := range values {
+		if !found[i] {
+			continue
+		}
+
+		dst := newDst()
+		if err := c.codec.Unmarshal(value, dst); err != nil {
+			return nil, err
+		}
+
+		result[i] = dst
+	}
+
+	return result, nil
+}
+
+// KeyField identifies one field of a Redis hash.
+type KeyField struct {
+	// Key is the Redis hash key.
+	Key string
+
+	// Field is the hash field.
+	Field string
+}
+
+// HGetMulti reads hash fields of many keys with HGET commands in one pipeline
+// and scans their values into values returned by newDst, which should return
+// a new pointer for each call.
+//
+// The result is aligned with pairs: result[i] holds the value of pairs[i], or
+// nil when the hash or field does not exist. Values are scanned like
+// go-redis StringCmd.Scan, so destinations may be pointers to strings,
+// numbers, booleans, byte slices, or encoding.BinaryUnmarshaler values.
+//
+// For Redis Cluster and Ring clients, go-redis groups the commands by node.
+// Pairs redirected during resharding are retried like in DeleteMany.
+//
+// For very large input, split pairs into batches at the call site.
+func (c *Client) HGetMulti(ctx context.Context, pairs []KeyField, newDst func() any) ([]any, error) {
+	if err := validatePipelineClient(c); err != nil {
+		return nil, err
+	}
+
+	if newDst == nil {
+		return nil, ErrInvalidPipeline
+	}
+
+	if len(pairs) == 0 {
+		return []any{}, nil
+	}
+
+	cmds := make([]*rdb.StringCmd, len(pairs))
+
+	run := func(indexes []int) {
+		// Per-command errors are checked below.
+		_, _ = c.conn.Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+			for _, i := range indexes {
+				cmds[i] = pipe.HGet(ctx, pairs[i].Key, pairs[i].Field)
 			}
 
 			return nil
 		})
+	}
 
-		return err
+	run(allIndexes(len(pairs)))
+	c.retryRedirected(ctx, len(cmds), func(i int) bool { return isRedirectError(cmds[i].Err()) }, run)
 
-	default:
-		return c.conn.Unlink(ctx, keys...).Err()
+	result := make([]any, len(pairs))
+	for i, cmd := range cmds {
+		if errors.Is(cmd.Err(), rdb.Nil) {
+			continue
+		}
+
+		dst := newDst()
+		if err := cmd.Scan(dst); err != nil {
+			return nil, err
+		}
+
+		result[i] = dst
 	}
+
+	return result, nil
+}
+
+// allIndexes returns the indexes of a slice of length n.
+func allIndexes(n int) []int {
+	indexes := make([]int, n)
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	return indexes
 }
 
 func validatePipelineClient(client *Client) error {
@@ -332,7 +332,7 @@ var _ = Describe("Pipeline", func() {
 				"hash:user:42",
 				time.Minute,
 				"name", "Ada",
-			)).To(Succeed())
+			)).Error().To(Succeed())
 
 			Expect(client.HSetMany(ctx, []xredis.HSetItem{
 				{
@@ -347,6 +347,33 @@ var _ = Describe("Pipeline", func() {
 			Expect(ttl).To(BeNumerically(">", 0))
 		})
 
+		It("keeps an existing hash expiration with ExpireNX", func() {
+			Expect(client.HSet(ctx, "hash:user:42", time.Hour, "name", "Ada")).Error().To(Succeed())
+
+			Expect(client.HSetMany(ctx, []xredis.HSetItem{
+				{
+					Key:        "hash:user:42",
+					Values:     []any{"age", 36},
+					Expiration: time.Minute,
+					ExpireMode: xredis.ExpireNX,
+				},
+				{
+					Key:        "hash:user:43",
+					Values:     []any{"name", "Grace"},
+					Expiration: time.Minute,
+					ExpireMode: xredis.ExpireNX,
+				},
+			})).To(Succeed())
+
+			ttl, err := client.Raw().TTL(ctx, "hash:user:42").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ttl).To(BeNumerically(">", 59*time.Minute))
+
+			ttl, err = client.Raw().TTL(ctx, "hash:user:43").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ttl).To(BeNumerically("~", time.Minute, time.Second))
+		})
+
 		It("rejects empty hash values without executing queued commands", func() {
 			err := client.HSetMany(ctx, []xredis.HSetItem{
 				{
@@ -401,11 +428,13 @@ var _ = Describe("Pipeline", func() {
 				{Key: "delete:2", Value: "two"},
 			})).To(Succeed())
 
-			Expect(client.DeleteMany(ctx, []string{
+			result, err := client.DeleteMany(ctx, []string{
 				"delete:1",
 				"delete:2",
 				"delete:missing",
-			})).To(Succeed())
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(xredis.DeleteResult{Deleted: 2}))
 
 			exists, err := client.Exists(ctx, "delete:1")
 			Expect(err).NotTo(HaveOccurred())
@@ -417,8 +446,18 @@ var _ = Describe("Pipeline", func() {
 		})
 
 		It("does nothing for an empty key list", func() {
-			Expect(client.DeleteMany(ctx, nil)).To(Succeed())
-			Expect(client.DeleteMany(ctx, []string{})).To(Succeed())
+			Expect(client.DeleteMany(ctx, nil)).To(BeZero())
+			Expect(client.DeleteMany(ctx, []string{})).To(BeZero())
+		})
+
+		It("reports failed keys", func() {
+			closed := newTestClient()
+			Expect(closed.Close()).To(Succeed())
+
+			result, err := closed.DeleteMany(ctx, []string{"delete:1", "delete:2"})
+			Expect(err).To(HaveOccurred())
+			Expect(result.Deleted).To(BeZero())
+			Expect(result.Failed).To(Equal([]string{"delete:1", "delete:2"}))
 		})
 	})
 
@@ -429,11 +468,13 @@ var _ = Describe("Pipeline", func() {
 				{Key: "unlink:2", Value: "two"},
 			})).To(Succeed())
 
-			Expect(client.UnlinkMany(ctx, []string{
+			result, err := client.UnlinkMany(ctx, []string{
 				"unlink:1",
 				"unlink:2",
 				"unlink:missing",
-			})).To(Succeed())
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(xredis.DeleteResult{Deleted: 2}))
 
 			exists, err := client.Exists(ctx, "unlink:1")
 			Expect(err).NotTo(HaveOccurred())
@@ -445,8 +486,134 @@ var _ = Describe("Pipeline", func() {
 		})
 
 		It("does nothing for an empty key list", func() {
-			Expect(client.UnlinkMany(ctx, nil)).To(Succeed())
-			Expect(client.UnlinkMany(ctx, []string{})).To(Succeed())
+			Expect(client.UnlinkMany(ctx, nil)).To(BeZero())
+			Expect(client.UnlinkMany(ctx, []string{})).To(BeZero())
+		})
+
+		It("reports failed keys", func() {
+			closed := newTestClient()
+			Expect(closed.Close()).To(Succeed())
+
+			result, err := closed.UnlinkMany(ctx, []string{"unlink:1", "unlink:2"})
+			Expect(err).To(HaveOccurred())
+			Expect(result.Deleted).To(BeZero())
+			Expect(result.Failed).To(Equal([]string{"unlink:1", "unlink:2"}))
+		})
+	})
+
+	Describe("MGetOrdered", func() {
+		newProfile := func() any {
+			return new(pipelineProfile)
+		}
+
+		It("returns values aligned with the key order", func() {
+			Expect(client.SetStructMany(ctx, []xredis.SetItem{
+				{Key: "mget:1", Value: pipelineProfile{ID: "1", Name: "Ada"}},
+				{Key: "mget:3", Value: pipelineProfile{ID: "3", Name: "Grace"}},
+			})).To(Succeed())
+
+			values, err := client.MGetOrdered(ctx, []string{"mget:3", "mget:2", "mget:1"}, newProfile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal([]any{
+				&pipelineProfile{ID: "3", Name: "Grace"},
+				nil,
+				&pipelineProfile{ID: "1", Name: "Ada"},
+			}))
+		})
+
+		It("reads keys one by one on Ring clients", func() {
+			ring, err := xredis.NewRing(xredis.WithRingConfig(&xredis.RingConfig{
+				Addrs: map[string]string{"shard": redisAddr},
+				DB:    testDB,
+			}))
+			Expect(err).NotTo(HaveOccurred())
+			defer func() {
+				Expect(ring.Close()).To(Succeed())
+			}()
+
+			Expect(client.SetStruct(ctx, "mget:2", pipelineProfile{ID: "2"}, 0)).To(Succeed())
+
+			values, err := ring.MGetOrdered(ctx, []string{"mget:1", "mget:2"}, newProfile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal([]any{nil, &pipelineProfile{ID: "2"}}))
+		})
+
+		It("returns codec errors", func() {
+			Expect(client.Set(ctx, "mget:1", "not json", 0)).To(Succeed())
+
+			_, err := client.MGetOrdered(ctx, []string{"mget:1"}, newProfile)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects a nil constructor", func() {
+			_, err := client.MGetOrdered(ctx, []string{"mget:1"}, nil)
+			Expect(err).To(MatchError(xredis.ErrInvalidPipeline))
+		})
+	})
+
+	Describe("HGetMulti", func() {
+		newString := func() any {
+			return new(string)
+		}
+
+		ptr := func(value string) *string {
+			return &value
+		}
+
+		It("returns values aligned with the pairs", func() {
+			Expect(client.Raw().HSet(ctx, "hgetmulti:1", "name", "Ada", "city", "London").Err()).To(Succeed())
+			Expect(client.Raw().HSet(ctx, "hgetmulti:2", "name", "Grace").Err()).To(Succeed())
+
+			values, err := client.HGetMulti(ctx, []xredis.KeyField{
+				{Key: "hgetmulti:2", Field: "name"},
+				{Key: "hgetmulti:1", Field: "email"},
+				{Key: "hgetmulti:3", Field: "name"},
+				{Key: "hgetmulti:1", Field: "city"},
+			}, newString)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal([]any{ptr("Grace"), nil, nil, ptr("London")}))
+		})
+
+		It("scans numeric fields", func() {
+			Expect(client.Raw().HSet(ctx, "hgetmulti:1", "visits", 7).Err()).To(Succeed())
+
+			values, err := client.HGetMulti(ctx, []xredis.KeyField{{Key: "hgetmulti:1", Field: "visits"}}, func() any {
+				return new(int64)
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*values[0].(*int64)).To(Equal(int64(7)))
+		})
+
+		It("reads pairs on Ring clients", func() {
+			ring, err := xredis.NewRing(xredis.WithRingConfig(&xredis.RingConfig{
+				Addrs: map[string]string{"shard": redisAddr},
+				DB:    testDB,
+			}))
+			Expect(err).NotTo(HaveOccurred())
+			defer func() {
+				Expect(ring.Close()).To(Succeed())
+			}()
+
+			Expect(client.Raw().HSet(ctx, "hgetmulti:2", "name", "Grace").Err()).To(Succeed())
+
+			values, err := ring.HGetMulti(ctx, []xredis.KeyField{
+				{Key: "hgetmulti:1", Field: "name"},
+				{Key: "hgetmulti:2", Field: "name"},
+			}, newString)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal([]any{nil, ptr("Grace")}))
+		})
+
+		It("returns command errors", func() {
+			Expect(client.Set(ctx, "hgetmulti:1", "plain", 0)).To(Succeed())
+
+			_, err := client.HGetMulti(ctx, []xredis.KeyField{{Key: "hgetmulti:1", Field: "name"}}, newString)
+			Expect(err).To(MatchError(ContainSubstring("WRONGTYPE")))
+		})
+
+		It("rejects a nil constructor", func() {
+			_, err := client.HGetMulti(ctx, []xredis.KeyField{{Key: "hgetmulti:1", Field: "name"}}, nil)
+			Expect(err).To(MatchError(xredis.ErrInvalidPipeline))
 		})
 	})
 
@@ -459,9 +626,13 @@ var _ = Describe("Pipeline", func() {
 			To(MatchError(xredis.ErrInvalidPipeline))
 		Expect(invalidClient.HSetMany(ctx, nil)).
 			To(MatchError(xredis.ErrInvalidPipeline))
-		Expect(invalidClient.DeleteMany(ctx, nil)).
-			To(MatchError(xredis.ErrInvalidPipeline))
-		Expect(invalidClient.UnlinkMany(ctx, nil)).
-			To(MatchError(xredis.ErrInvalidPipeline))
+		_, err := invalidClient.DeleteMany(ctx, nil)
+		Expect(err).To(MatchError(xredis.ErrInvalidPipeline))
+		_, err = invalidClient.UnlinkMany(ctx, nil)
+		Expect(err).To(MatchError(xredis.ErrInvalidPipeline))
+		_, err = invalidClient.MGetOrdered(ctx, nil, nil)
+		Expect(err).To(MatchError(xredis.ErrInvalidPipeline))
+		_, err = invalidClient.HGetMulti(ctx, nil, nil)
+		Expect(err).To(MatchError(xredis.ErrInvalidPipeline))
 	})
 })
@@ -0,0 +1,171 @@
+package xredis
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultPoolPressureThreshold     = 0.9
+	defaultPoolPressureDuration      = 30 * time.Second
+	defaultPoolPressureCheckInterval = time.Second
+	poolUtilizationTimeout           = time.Second
+)
+
+// PoolPressureConfig configures the connection pool pressure watcher.
+type PoolPressureConfig struct {
+	// Threshold is the pool utilization ratio in (0, 1] that is considered
+	// pressure.
+	//
+	// Zero uses 0.9.
+	Threshold float64
+
+	// Duration defines how long utilization must stay at or above Threshold
+	// before pressure is reported.
+	//
+	// Zero uses 30 seconds.
+	Duration time.Duration
+
+	// CheckInterval defines how often pool utilization is sampled.
+	//
+	// Zero uses 1 second.
+	CheckInterval time.Duration
+
+	// OnPressure is called once per pressure episode, in addition to the
+	// warning logged through the client logger.
+	OnPressure func(PoolPressure)
+}
+
+// PoolPressure describes a sustained connection pool pressure episode.
+type PoolPressure struct {
+	// Utilization is the pool utilization ratio at the time of the report.
+	Utilization float64
+
+	// Since is the time when utilization first reached the threshold.
+	Since time.Time
+}
+
+// PoolUtilization returns the ratio of in-use connections to the configured
+// pool size.
+//
+// For Redis Cluster and Ring clients, it returns the highest utilization of
+// all node pools because one exhausted node pool is enough to cause pool
+// timeouts.
+func (c *Client) PoolUtilization(ctx context.Context) (float64, error) {
+	switch conn := c.conn.(type) {
+	case *rdb.ClusterClient:
+		return maxPoolUtilization(ctx, conn.ForEachShard)
+	case *rdb.Ring:
+		return maxPoolUtilization(ctx, conn.ForEachShard)
+	case *rdb.Client:
+		return nodePoolUtilization(conn), nil
+	default:
+		return 0, nil
+	}
+}
+
+func maxPoolUtilization(
+	ctx context.Context,
+	forEach func(context.Context, func(context.Context, *rdb.Client) error) error,
+) (float64, error) {
+	var (
+		mu          sync.Mutex
+		utilization float64
+	)
+
+	err := forEach(ctx, func(_ context.Context, node *rdb.Client) error {
+		value := nodePoolUtilization(node)
+
+		mu.Lock()
+		utilization = max(utilization, value)
+		mu.Unlock()
+
+		return nil
+	})
+
+	return utilization, err
+}
+
+func nodePoolUtilization(node *rdb.Client) float64 {
+	size := node.Options().PoolSize
+	if size <= 0 {
+		return 0
+	}
+
+	stats := node.PoolStats()
+	inUse := int(stats.TotalConns) - int(stats.IdleConns)
+
+	return float64(max(inUse, 0)) / float64(size)
+}
+
+func (c *Client) watchPoolPressure(cfg PoolPressureConfig, done <-chan struct{}) {
+	ticker := time.NewTicker(cfg.CheckInterval)
+	defer ticker.Stop()
+
+	var (
+		since    time.Time
+		reported bool
+	)
+
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), poolUtilizationTimeout)
+			utilization, err := c.PoolUtilization(ctx)
+			cancel()
+
+			if err != nil || utilization < cfg.Threshold {
+				since, reported = time.Time{}, false
+				continue
+			}
+
+			if since.IsZero() {
+				since = now
+			}
+
+			if reported || now.Sub(since) < cfg.Duration {
+				continue
+			}
+
+			reported = true
+			c.reportPoolPressure(cfg, PoolPressure{Utilization: utilization, Since: since})
+		}
+	}
+}
+
+func (c *Client) reportPoolPressure(cfg PoolPressureConfig, pressure PoolPressure) {
+	c.logger.LogAttrs(
+		context.Background(),
+		slog.LevelWarn,
+		"redis connection pool under sustained pressure",
+		slog.Float64("utilization", pressure.Utilization),
+		slog.Float64("threshold", cfg.Threshold),
+		slog.Time("since", pressure.Since),
+	)
+
+	if cfg.OnPressure != nil {
+		cfg.OnPressure(pressure)
+	}
+}
+
+func normalizePoolPressureConfig(cfg PoolPressureConfig) PoolPressureConfig {
+	if cfg.Threshold <= 0 || cfg.Threshold > 1 {
+		cfg.Threshold = defaultPoolPressureThreshold
+	}
+
+	if cfg.Duration <= 0 {
+		cfg.Duration = defaultPoolPressureDuration
+	}
+
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = defaultPoolPressureCheckInterval
+	}
+
+	return cfg
+}
@@ -0,0 +1,103 @@
+package xredis_test
+
+import (
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+func newPoolTestClient(poolSize int, opts ...xredis.Option) *xredis.Client {
+	client, err := xredis.NewClient(append([]xredis.Option{
+		xredis.WithClientConfig(&xredis.ClientConfig{
+			Addr:     redisAddr,
+			DB:       testDB,
+			PoolSize: poolSize,
+		}),
+		xredis.WithClientID("xredis-pool-test"),
+	}, opts...)...)
+	Expect(err).NotTo(HaveOccurred())
+
+	return client
+}
+
+func holdConn(client *xredis.Client) *rdb.Conn {
+	conn := client.Raw().(*rdb.Client).Conn()
+	Expect(conn.Ping(ctx).Err()).To(Succeed())
+
+	return conn
+}
+
+var _ = Describe("Pool", func() {
+	It("reports pool utilization", func() {
+		client := newPoolTestClient(2)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		conn := holdConn(client)
+
+		utilization, err := client.PoolUtilization(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(utilization).To(BeNumerically("~", 0.5))
+
+		Expect(conn.Close()).To(Succeed())
+
+		utilization, err = client.PoolUtilization(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(utilization).To(BeZero())
+	})
+
+	It("reports sustained pool pressure once per episode", func() {
+		reports := make(chan xredis.PoolPressure, 4)
+
+		client := newPoolTestClient(
+			2,
+			xredis.WithPoolPressureWatcher(xredis.PoolPressureConfig{
+				Threshold:     0.5,
+				Duration:      50 * time.Millisecond,
+				CheckInterval: 10 * time.Millisecond,
+				OnPressure: func(pressure xredis.PoolPressure) {
+					reports <- pressure
+				},
+			}),
+		)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		conn := holdConn(client)
+		defer func() {
+			Expect(conn.Close()).To(Succeed())
+		}()
+
+		var pressure xredis.PoolPressure
+		Eventually(reports, time.Second).Should(Receive(&pressure))
+		Expect(pressure.Utilization).To(BeNumerically(">=", 0.5))
+		Expect(pressure.Since).NotTo(BeZero())
+
+		Consistently(reports, 200*time.Millisecond).ShouldNot(Receive())
+	})
+
+	It("reports pool statistics in the database/sql shape", func() {
+		client := newPoolTestClient(2)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		conn := holdConn(client)
+
+		stats := client.DBStats().Stats()
+		Expect(stats.MaxOpenConnections).To(Equal(2))
+		Expect(stats.InUse).To(Equal(1))
+		Expect(stats.OpenConnections).To(Equal(stats.InUse + stats.Idle))
+
+		Expect(conn.Close()).To(Succeed())
+
+		stats = client.DBStats().Stats()
+		Expect(stats.InUse).To(BeZero())
+		Expect(stats.Idle).To(Equal(stats.OpenConnections))
+	})
+})
@@ -0,0 +1,258 @@
+package xredis
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// BlockingPool is the name of the pool that blocking commands use
+// automatically when WithPools configures it.
+const BlockingPool = "blocking"
+
+// PubSubPool is the name of the pool that Pub/Sub subscriptions use
+// automatically when WithPools configures it.
+const PubSubPool = "pubsub"
+
+// blockingCommands contains commands that may block a connection while
+// waiting for data. XREAD and XREADGROUP only block with the BLOCK argument.
+//
+// WAIT and WAITAOF also block, but only count the writes of their own
+// connection, so they are never moved to another pool.
+var blockingCommands = map[string]struct{}{
+	"blpop": {}, "brpop": {}, "brpoplpush": {}, "blmove": {}, "blmpop": {},
+	"bzpopmin": {}, "bzpopmax": {}, "bzmpop": {},
+}
+
+// PoolConfig configures a named connection pool of WithPools.
+//
+// Zero fields use the values of the main pool.
+type PoolConfig struct {
+	// Addrs routes the pool to dedicated nodes instead of the client
+	// addresses, which isolates long-lived connections, such as blocking
+	// commands and subscriptions, from the nodes serving requests.
+	// Connections are spread over the addresses, and unreachable ones are
+	// skipped. Only standalone and failover clients support Addrs.
+	Addrs []string
+
+	// Size defines the connection pool size.
+	Size int
+
+	// MinIdleConns defines the minimum number of idle connections.
+	MinIdleConns int
+
+	// MaxIdleConns defines the maximum number of idle connections.
+	MaxIdleConns int
+
+	// MaxActiveConns limits allocated connections.
+	MaxActiveConns int
+
+	// PoolTimeout defines how long a command waits for a free connection.
+	PoolTimeout time.Duration
+
+	// ConnMaxIdleTime defines the maximum connection idle time.
+	ConnMaxIdleTime time.Duration
+}
+
+// poolFields points to the go-redis option fields set by PoolConfig.
+type poolFields struct {
+	size            *int
+	minIdleConns    *int
+	maxIdleConns    *int
+	maxActiveConns  *int
+	poolTimeout     *time.Duration
+	connMaxIdleTime *time.Duration
+}
+
+func (cfg PoolConfig) apply(fields poolFields) {
+	setIfNonZero(fields.size, cfg.Size)
+	setIfNonZero(fields.minIdleConns, cfg.MinIdleConns)
+	setIfNonZero(fields.maxIdleConns, cfg.MaxIdleConns)
+	setIfNonZero(fields.maxActiveConns, cfg.MaxActiveConns)
+	setIfNonZero(fields.poolTimeout, cfg.PoolTimeout)
+	setIfNonZero(fields.connMaxIdleTime, cfg.ConnMaxIdleTime)
+}
+
+// setIfNonZero sets *field to value when value is not zero.
+func setIfNonZero[T comparable](field *T, value T) {
+	var zero T
+	if value != zero {
+		*field = value
+	}
+}
+
+// newPoolConn creates a client with the settings of conn and the pool
+// settings of cfg, or returns nil for unsupported client types.
+//
+// dial is the dialer of WithDialer, used to reach the pool addresses.
+func newPoolConn(
+	conn rdb.UniversalClient,
+	cfg PoolConfig,
+	dial func(ctx context.Context, network, addr string) (net.Conn, error),
+) (rdb.UniversalClient, error) {
+	var pool rdb.UniversalClient
+
+	addrs := normalizeAddrs(cfg.Addrs)
+
+	switch conn := conn.(type) {
+	case *rdb.Client:
+		opt := *conn.Options()
+		cfg.apply(poolFields{
+			&opt.PoolSize, &opt.MinIdleConns, &opt.MaxIdleConns, &opt.MaxActiveConns,
+			&opt.PoolTimeout, &opt.ConnMaxIdleTime,
+		})
+
+		if len(addrs) > 0 {
+			// The client dialer of failover clients and separate write
+			// endpoints ignores the dialed address.
+			if dial == nil {
+				dial = rdb.NewDialer(&opt)
+			}
+
+			opt.Addr = addrs[0]
+			opt.Dialer = newEndpointDialer(addrs, true, dial).DialContext
+		}
+
+		pool = rdb.NewClient(&opt)
+
+	case *rdb.ClusterClient:
+		if len(addrs) > 0 {
+			return nil, fmt.Errorf("%w: pool addresses require a standalone or failover client", ErrInvalidConfig)
+		}
+
+		opt := *conn.Options()
+		cfg.apply(poolFields{
+			&opt.PoolSize, &opt.MinIdleConns, &opt.MaxIdleConns, &opt.MaxActiveConns,
+			&opt.PoolTimeout, &opt.ConnMaxIdleTime,
+		})
+		pool = rdb.NewClusterClient(&opt)
+
+	case *rdb.Ring:
+		if len(addrs) > 0 {
+			return nil, fmt.Errorf("%w: pool addresses require a standalone or failover client", ErrInvalidConfig)
+		}
+
+		opt := *conn.Options()
+		cfg.apply(poolFields{
+			&opt.PoolSize, &opt.MinIdleConns, &opt.MaxIdleConns, &opt.MaxActiveConns,
+			&opt.PoolTimeout, &opt.ConnMaxIdleTime,
+		})
+		pool = rdb.NewRing(&opt)
+
+	default:
+		return nil, nil
+	}
+
+	// Commands routed to the pool skip the hooks inside the routing hook.
+	addHook(pool, callOptionsHook{})
+
+	return pool, nil
+}
+
+// Pool sends commands to the named pool configured with WithPools.
+//
+// Commands fall back to the main pool when no pool has that name. Commands of
+// transactions, Watch, and Conn stay on their connection.
+func Pool(name string) CallOption {
+	return func(opts *callOptions) {
+		opts.pool = name
+	}
+}
+
+// poolRoutingHook sends commands to named connection pools.
+//
+// Commands of Watch and Conn are not routed, because they depend on the state
+// of their connection, such as watched keys or the writes counted by WAIT.
+type poolRoutingHook struct {
+	passDialHook
+
+	pools  map[string]rdb.UniversalClient
+	chains *connChains
+}
+
+func (h *poolRoutingHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	if h.chains.pinned() {
+		return next
+	}
+
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		name := callOptionsFrom(ctx).pool
+		if name == "" && isBlockingCmd(cmd) {
+			name = BlockingPool
+		}
+
+		if pool, ok := h.pools[name]; ok {
+			return pool.Process(ctx, cmd)
+		}
+
+		return next(ctx, cmd)
+	}
+}
+
+func (h *poolRoutingHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	if h.chains.pinned() {
+		return next
+	}
+
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		pool, ok := h.pools[callOptionsFrom(ctx).pool]
+		if !ok || isTxPipeline(cmds) {
+			return next(ctx, cmds)
+		}
+
+		pipe := pool.Pipeline()
+		for _, cmd := range cmds {
+			_ = pipe.Process(ctx, cmd)
+		}
+
+		_, err := pipe.Exec(ctx)
+
+		return err
+	}
+}
+
+// pubsubConn returns the client that Pub/Sub subscriptions use: the
+// PubSubPool pool when it is configured, or the main client.
+func (c *Client) pubsubConn() rdb.UniversalClient {
+	if pool, ok := c.pools[PubSubPool]; ok {
+		return pool
+	}
+
+	return c.conn
+}
+
+// isBlockingCmd reports whether cmd may block its connection.
+func isBlockingCmd(cmd rdb.Cmder) bool {
+	name := cmd.Name()
+	if _, ok := blockingCommands[name]; ok {
+		return true
+	}
+
+	if name != "xread" && name != "xreadgroup" {
+		return false
+	}
+
+	for _, arg := range cmd.Args()[1:] {
+		s, ok := arg.(string)
+		switch {
+		case !ok:
+			continue
+		case strings.EqualFold(s, "block"):
+			return true
+		case strings.EqualFold(s, "streams"):
+			return false
+		}
+	}
+
+	return false
+}
+
+// isTxPipeline reports whether cmds are a MULTI/EXEC transaction, which must
+// stay on one connection of the main pool.
+func isTxPipeline(cmds []rdb.Cmder) bool {
+	return len(cmds) > 0 && cmds[0].Name() == "multi"
+}
@@ -0,0 +1,158 @@
+package xredis_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Named pools", func() {
+	newPoolClient := func(opts ...xredis.Option) *xredis.Client {
+		client, err := xredis.NewClient(append([]xredis.Option{
+			xredis.WithClientConfig(&xredis.ClientConfig{
+				Addr:        redisAddr,
+				DB:          testDB,
+				PoolSize:    1,
+				PoolTimeout: 200 * time.Millisecond,
+				MaxRetries:  -1, // pool timeouts are retried otherwise
+			}),
+		}, opts...)...)
+		Expect(err).NotTo(HaveOccurred())
+
+		return client
+	}
+
+	// block runs n BLPOP commands on an empty list until they time out.
+	block := func(ctx context.Context, client *xredis.Client, n int) *sync.WaitGroup {
+		var wg sync.WaitGroup
+		for range n {
+			wg.Go(func() {
+				defer GinkgoRecover()
+
+				err := client.Raw().BLPop(ctx, 500*time.Millisecond, "pools:empty").Err()
+				Expect(err).To(Equal(rdb.Nil))
+			})
+		}
+
+		time.Sleep(100 * time.Millisecond)
+
+		return &wg
+	}
+
+	It("keeps blocking commands out of the main pool", func() {
+		client := newPoolClient(xredis.WithPools(map[string]xredis.PoolConfig{
+			xredis.BlockingPool: {Size: 2},
+		}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		wg := block(ctx, client, 2)
+		defer wg.Wait()
+
+		Expect(client.Set(ctx, "pools:key", "value", 0)).To(Succeed())
+	})
+
+	It("routes commands to the pool selected by the call option", func() {
+		client := newPoolClient(xredis.WithPools(map[string]xredis.PoolConfig{
+			"bulk": {Size: 1},
+		}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		wg := block(xredis.WithCallOptions(ctx, xredis.Pool("bulk")), client, 1)
+		defer wg.Wait()
+
+		Expect(client.Set(ctx, "pools:key", "value", 0)).To(Succeed())
+
+		cmds, err := client.Raw().Pipelined(xredis.WithCallOptions(ctx, xredis.Pool("unknown")), func(pipe rdb.Pipeliner) error {
+			pipe.Get(ctx, "pools:key")
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmds[0].(*rdb.StringCmd).Val()).To(Equal("value"))
+	})
+
+	It("keeps commands of Conn and Watch on their connection", func() {
+		client := newPoolClient(xredis.WithPools(map[string]xredis.PoolConfig{
+			xredis.BlockingPool: {Size: 1},
+			"bulk":              {Size: 1},
+		}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		bulkCtx := xredis.WithCallOptions(ctx, xredis.Pool("bulk"))
+
+		conn := client.Raw().(*rdb.Client).Conn()
+		id, err := conn.ClientID(ctx).Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conn.ClientID(bulkCtx).Val()).To(Equal(id))
+		Expect(conn.Close()).To(Succeed())
+
+		err = client.Raw().(*rdb.Client).Watch(bulkCtx, func(tx *rdb.Tx) error {
+			txID, err := tx.ClientID(bulkCtx).Result()
+			if err != nil {
+				return err
+			}
+
+			Expect(tx.ClientID(ctx).Val()).To(Equal(txID))
+			Expect(tx.BLPop(ctx, 100*time.Millisecond, "pools:empty").Err()).To(Equal(rdb.Nil))
+			Expect(tx.ClientID(ctx).Val()).To(Equal(txID))
+
+			return nil
+		}, "pools:key")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("shares the main pool without named pools", func() {
+		client := newPoolClient()
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		wg := block(ctx, client, 1)
+		defer wg.Wait()
+
+		Expect(client.Set(ctx, "pools:key", "value", 0)).To(MatchError(rdb.ErrPoolTimeout))
+	})
+
+	It("dials dedicated addresses for blocking commands and subscriptions", func() {
+		recorder := &endpointRecorder{}
+		client := newPoolClient(
+			xredis.WithDialer(recorder.dial),
+			xredis.WithPools(map[string]xredis.PoolConfig{
+				xredis.BlockingPool: {Addrs: []string{"blocking-node:6379"}},
+				xredis.PubSubPool:   {Addrs: []string{"pubsub-node:6379"}},
+			}),
+		)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		err := client.Raw().BLPop(ctx, 100*time.Millisecond, "pools:empty").Err()
+		Expect(err).To(Equal(rdb.Nil))
+		Expect(recorder.addrs()).To(Equal([]string{"blocking-node:6379"}))
+
+		sub, err := client.Subscribe(ctx, "pools:channel")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sub.Close()).To(Succeed())
+		Expect(recorder.addrs()).To(Equal([]string{"blocking-node:6379", "pubsub-node:6379"}))
+	})
+
+	It("rejects dedicated addresses of cluster pools", func() {
+		_, err := xredis.NewClusterClient(
+			xredis.WithClusterConfig(&xredis.ClusterConfig{Addrs: []string{redisAddr}}),
+			xredis.WithPools(map[string]xredis.PoolConfig{
+				xredis.PubSubPool: {Addrs: []string{"pubsub-node:6379"}},
+			}),
+		)
+		Expect(err).To(MatchError(xredis.ErrInvalidConfig))
+	})
+})
@@ -0,0 +1,109 @@
+package xredis
+
+import (
+	"slices"
+	"time"
+)
+
+// Preset bundles client options and configuration defaults that work well
+// together for a workload.
+//
+// Presets passed to a client constructor are applied before every other
+// option, whatever their position, so explicit options always override them.
+// Configuration defaults, such as timeouts and idle connections, only fill
+// fields left at their zero value, like Profile, and take precedence over
+// the defaults of the profile.
+type Preset struct {
+	name     string
+	defaults profilePreset
+	opts     []Option
+}
+
+var (
+	// PresetHighThroughputCache suits caches serving most requests: short
+	// timeouts with a single retry, warm idle connections, shedding of
+	// best-effort operations under pool pressure, halved cache TTLs under
+	// memory pressure, and a check that Redis evicts keys when memory is
+	// full.
+	PresetHighThroughputCache = Preset{
+		name: "high_throughput_cache",
+		defaults: profilePreset{
+			readTimeout:     500 * time.Millisecond,
+			writeTimeout:    500 * time.Millisecond,
+			poolTimeout:     time.Second,
+			minIdleConns:    8,
+			maxRetries:      1,
+			minRetryBackoff: 8 * time.Millisecond,
+			maxRetryBackoff: 64 * time.Millisecond,
+		},
+		opts: []Option{
+			WithWarmConnections(WarmConnectionsConfig{}),
+			WithLoadShedding(LoadSheddingPolicy{}),
+			WithOOMDegradation(OOMDegradationConfig{TTLFactor: 0.5}),
+			WithEvictionPolicyCheck(EvictionPolicyConfig{Workload: WorkloadCache}),
+		},
+	}
+
+	// PresetDurableStore suits data that must not be lost: timeouts and
+	// retries tolerant of failovers, warm idle connections, and a check that
+	// Redis never evicts keys.
+	PresetDurableStore = Preset{
+		name: "durable_store",
+		defaults: profilePreset{
+			dialTimeout:     5 * time.Second,
+			readTimeout:     3 * time.Second,
+			writeTimeout:    3 * time.Second,
+			poolTimeout:     4 * time.Second,
+			minIdleConns:    2,
+			maxRetries:      3,
+			minRetryBackoff: 8 * time.Millisecond,
+			maxRetryBackoff: 512 * time.Millisecond,
+		},
+		opts: []Option{
+			WithWarmConnections(WarmConnectionsConfig{}),
+			WithEvictionPolicyCheck(EvictionPolicyConfig{Workload: WorkloadStore}),
+		},
+	}
+)
+
+// NewPreset returns a preset named name that applies opts, so teams can
+// share their own option bundles. Presets are composed by including other
+// presets with WithPreset.
+func NewPreset(name string, opts ...Option) Preset {
+	return Preset{name: name, opts: slices.Clone(opts)}
+}
+
+// Name returns the preset name.
+func (p Preset) Name() string {
+	return p.name
+}
+
+// WithPreset applies the options and configuration defaults of presets, in
+// order. Explicit options override them whatever their position.
+func WithPreset(presets ...Preset) Option {
+	return presetOptions(slices.Clone(presets))
+}
+
+// presetOptions applies presets. Client constructors apply top-level preset
+// options before the other options.
+type presetOptions []Preset
+
+func (p presetOptions) apply(opts *options) {
+	for _, preset := range p {
+		opts.presets = append(opts.presets, preset)
+
+		for _, opt := range preset.opts {
+			if opt != nil {
+				opt.apply(opts)
+			}
+		}
+	}
+}
+
+// applyPresetDefaults fills fields left at their zero value with the
+// configuration defaults of the applied presets, in order.
+func (o *options) applyPresetDefaults(fields profileFields) {
+	for _, preset := range o.presets {
+		preset.defaults.apply(fields)
+	}
+}
@@ -0,0 +1,47 @@
+package xredis
+
+// PriorityClass defines how commands degrade during Redis incidents.
+//
+// The class is set per call with the Priority call option and consulted by
+// the load shedder configured with WithLoadShedding and by the retry policy.
+// Limiters configured with WithLimiter do not receive the command context, so
+// they treat all classes alike.
+type PriorityClass int
+
+const (
+	// PriorityNormal is the class of commands without a Priority call option.
+	// They are retried as configured, and shed only when
+	// LoadSheddingPolicy.NormalMaxWait is set.
+	PriorityNormal PriorityClass = iota
+
+	// PriorityCritical commands are retried as configured and never shed.
+	PriorityCritical
+
+	// PriorityBestEffort commands are never retried, so they do not amplify
+	// load during partial outages, and are shed first.
+	PriorityBestEffort
+)
+
+// String returns the lowercase name of the class.
+func (p PriorityClass) String() string {
+	switch p {
+	case PriorityCritical:
+		return "critical"
+	case PriorityBestEffort:
+		return "best_effort"
+	default:
+		return "normal"
+	}
+}
+
+// Priority sets the priority class of commands.
+func Priority(class PriorityClass) CallOption {
+	return func(opts *callOptions) {
+		opts.priority = class
+	}
+}
+
+// BestEffort is a shorthand for Priority(PriorityBestEffort).
+func BestEffort() CallOption {
+	return Priority(PriorityBestEffort)
+}
@@ -0,0 +1,195 @@
+package xredis
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// Profile selects preset configuration defaults for a deployment environment.
+//
+// A profile only fills fields that are left at their zero value, so explicit
+// configuration always wins, and fields left unset by the profile keep the
+// go-redis defaults.
+type Profile string
+
+const (
+	// ProfileDev fails fast for local development: short timeouts, a small
+	// pool, no retries, and command arguments recorded in traces.
+	ProfileDev Profile = "dev"
+
+	// ProfileStaging uses production-like timeouts and retries, and records
+	// command arguments in traces.
+	ProfileStaging Profile = "staging"
+
+	// ProfileProd uses timeouts and retries tolerant of failovers and network
+	// blips, keeps warm idle connections, and omits command arguments from
+	// traces.
+	ProfileProd Profile = "prod"
+)
+
+// profilePreset contains the defaults applied by a profile.
+type profilePreset struct {
+	dialTimeout     time.Duration
+	readTimeout     time.Duration
+	writeTimeout    time.Duration
+	poolTimeout     time.Duration
+	poolSize        int
+	minIdleConns    int
+	maxRetries      int
+	minRetryBackoff time.Duration
+	maxRetryBackoff time.Duration
+
+	// Tracing verbosity, applied only when tracing is enabled.
+	dbStatement bool
+}
+
+var profilePresets = map[Profile]profilePreset{
+	ProfileDev: {
+		dialTimeout:  time.Second,
+		readTimeout:  time.Second,
+		writeTimeout: time.Second,
+		poolTimeout:  2 * time.Second,
+		poolSize:     4,
+		maxRetries:   -1,
+		dbStatement:  true,
+	},
+	ProfileStaging: {
+		dialTimeout:     3 * time.Second,
+		readTimeout:     2 * time.Second,
+		writeTimeout:    2 * time.Second,
+		poolTimeout:     3 * time.Second,
+		maxRetries:      2,
+		minRetryBackoff: 8 * time.Millisecond,
+		maxRetryBackoff: 512 * time.Millisecond,
+		dbStatement:     true,
+	},
+	ProfileProd: {
+		dialTimeout:     5 * time.Second,
+		readTimeout:     3 * time.Second,
+		writeTimeout:    3 * time.Second,
+		poolTimeout:     4 * time.Second,
+		minIdleConns:    2,
+		maxRetries:      3,
+		minRetryBackoff: 8 * time.Millisecond,
+		maxRetryBackoff: 512 * time.Millisecond,
+		dbStatement:     false,
+	},
+}
+
+// profileFields points to the go-redis option fields set by profiles.
+type profileFields struct {
+	dialTimeout     *time.Duration
+	readTimeout     *time.Duration
+	writeTimeout    *time.Duration
+	poolTimeout     *time.Duration
+	poolSize        *int
+	minIdleConns    *int
+	maxRetries      *int
+	minRetryBackoff *time.Duration
+	maxRetryBackoff *time.Duration
+}
+
+func (p profilePreset) apply(fields profileFields) {
+	setDefault(fields.dialTimeout, p.dialTimeout)
+	setDefault(fields.readTimeout, p.readTimeout)
+	setDefault(fields.writeTimeout, p.writeTimeout)
+	setDefault(fields.poolTimeout, p.poolTimeout)
+	setDefault(fields.poolSize, p.poolSize)
+	setDefault(fields.minIdleConns, p.minIdleConns)
+	setDefault(fields.maxRetries, p.maxRetries)
+	setDefault(fields.minRetryBackoff, p.minRetryBackoff)
+	setDefault(fields.maxRetryBackoff, p.maxRetryBackoff)
+}
+
+// setDefault sets *field to value when *field is zero.
+func setDefault[T comparable](field *T, value T) {
+	var zero T
+	if *field == zero {
+		*field = value
+	}
+}
+
+// applyProfile applies the defaults of presets and then those of profile to
+// fields, and the profile trace verbosity. An explicit WithTracingDBStatement
+// still wins.
+func (o *options) applyProfile(profile Profile, fields profileFields) error {
+	o.applyPresetDefaults(fields)
+
+	name := Profile(strings.ToLower(strings.TrimSpace(string(profile))))
+	if name == "" {
+		return nil
+	}
+
+	preset, ok := profilePresets[name]
+	if !ok {
+		return fmt.Errorf("%w: unknown profile %q, use dev, staging, or prod", ErrInvalidConfig, profile)
+	}
+
+	preset.apply(fields)
+	o.profile = name
+
+	if o.traceDBStatement == nil {
+		o.traceDBStatement = &preset.dbStatement
+	}
+
+	return nil
+}
+
+func clientProfileFields(opt *rdb.Options) profileFields {
+	return profileFields{
+		dialTimeout:     &opt.DialTimeout,
+		readTimeout:     &opt.ReadTimeout,
+		writeTimeout:    &opt.WriteTimeout,
+		poolTimeout:     &opt.PoolTimeout,
+		poolSize:        &opt.PoolSize,
+		minIdleConns:    &opt.MinIdleConns,
+		maxRetries:      &opt.MaxRetries,
+		minRetryBackoff: &opt.MinRetryBackoff,
+		maxRetryBackoff: &opt.MaxRetryBackoff,
+	}
+}
+
+func clusterProfileFields(opt *rdb.ClusterOptions) profileFields {
+	return profileFields{
+		dialTimeout:     &opt.DialTimeout,
+		readTimeout:     &opt.ReadTimeout,
+		writeTimeout:    &opt.WriteTimeout,
+		poolTimeout:     &opt.PoolTimeout,
+		poolSize:        &opt.PoolSize,
+		minIdleConns:    &opt.MinIdleConns,
+		maxRetries:      &opt.MaxRetries,
+		minRetryBackoff: &opt.MinRetryBackoff,
+		maxRetryBackoff: &opt.MaxRetryBackoff,
+	}
+}
+
+func failoverProfileFields(opt *rdb.FailoverOptions) profileFields {
+	return profileFields{
+		dialTimeout:     &opt.DialTimeout,
+		readTimeout:     &opt.ReadTimeout,
+		writeTimeout:    &opt.WriteTimeout,
+		poolTimeout:     &opt.PoolTimeout,
+		poolSize:        &opt.PoolSize,
+		minIdleConns:    &opt.MinIdleConns,
+		maxRetries:      &opt.MaxRetries,
+		minRetryBackoff: &opt.MinRetryBackoff,
+		maxRetryBackoff: &opt.MaxRetryBackoff,
+	}
+}
+
+func ringProfileFields(opt *rdb.RingOptions) profileFields {
+	return profileFields{
+		dialTimeout:     &opt.DialTimeout,
+		readTimeout:     &opt.ReadTimeout,
+		writeTimeout:    &opt.WriteTimeout,
+		poolTimeout:     &opt.PoolTimeout,
+		poolSize:        &opt.PoolSize,
+		minIdleConns:    &opt.MinIdleConns,
+		maxRetries:      &opt.MaxRetries,
+		minRetryBackoff: &opt.MinRetryBackoff,
+		maxRetryBackoff: &opt.MaxRetryBackoff,
+	}
+}
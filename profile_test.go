@@ -0,0 +1,114 @@
+package xredis_test
+
+import (
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Profiles", func() {
+	It("fills unset fields with the profile preset", func() {
+		client, err := xredis.NewClient(xredis.WithClientConfig(&xredis.ClientConfig{
+			Profile:     xredis.ProfileDev,
+			Addr:        redisAddr,
+			DB:          testDB,
+			ReadTimeout: 5 * time.Second,
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		opt := client.Raw().(*rdb.Client).Options()
+		Expect(opt.DialTimeout).To(Equal(time.Second))
+		Expect(opt.ReadTimeout).To(Equal(5 * time.Second))
+		Expect(opt.PoolSize).To(Equal(4))
+		Expect(opt.MaxRetries).To(BeZero())
+		Expect(client.Ping(ctx)).To(Succeed())
+	})
+
+	It("applies the profile to URL configuration", func() {
+		client, err := xredis.NewClient(xredis.WithClientConfig(&xredis.ClientConfig{
+			Profile: "PROD",
+			URL:     "redis://" + redisAddr + "/15?max_retries=5",
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		opt := client.Raw().(*rdb.Client).Options()
+		Expect(opt.DialTimeout).To(Equal(5 * time.Second))
+		Expect(opt.MinIdleConns).To(Equal(2))
+		Expect(opt.MaxRetries).To(Equal(5))
+	})
+
+	It("applies the profile to cluster configuration", func() {
+		client, err := xredis.NewClusterClient(xredis.WithClusterConfig(&xredis.ClusterConfig{
+			Profile: xredis.ProfileStaging,
+			Addrs:   []string{redisAddr},
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		opt := client.Raw().(*rdb.ClusterClient).Options()
+		Expect(opt.ReadTimeout).To(Equal(2 * time.Second))
+		Expect(opt.MaxRetries).To(Equal(2))
+	})
+
+	It("rejects unknown profiles", func() {
+		_, err := xredis.NewClient(xredis.WithClientConfig(&xredis.ClientConfig{Profile: "qa", Addr: redisAddr}))
+		Expect(err).To(MatchError(xredis.ErrInvalidConfig))
+		Expect(err.Error()).To(ContainSubstring(`unknown profile "qa"`))
+	})
+})
+
+var _ = Describe("Presets", func() {
+	It("fills unset fields with the preset defaults before the profile", func() {
+		client, err := xredis.NewClient(
+			xredis.WithClientConfig(&xredis.ClientConfig{
+				Profile:      xredis.ProfileDev,
+				Addr:         redisAddr,
+				DB:           testDB,
+				WriteTimeout: 2 * time.Second,
+			}),
+			xredis.WithPreset(xredis.PresetHighThroughputCache),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		opt := client.Raw().(*rdb.Client).Options()
+		Expect(opt.ReadTimeout).To(Equal(500 * time.Millisecond))
+		Expect(opt.WriteTimeout).To(Equal(2 * time.Second))
+		Expect(opt.MinIdleConns).To(Equal(8))
+		Expect(opt.MaxRetries).To(Equal(1))
+		Expect(opt.DialTimeout).To(Equal(time.Second))
+		Expect(opt.PoolSize).To(Equal(4))
+	})
+
+	It("lets explicit options override presets whatever their position", func() {
+		preset := xredis.NewPreset("experiments", xredis.WithFeatureRollout(xredis.FeatureRollout{
+			"hedging":   100,
+			"pipelines": 100,
+		}))
+		Expect(preset.Name()).To(Equal("experiments"))
+
+		client := newTestClient(
+			xredis.WithFeatureRollout(xredis.FeatureRollout{"hedging": 0}),
+			xredis.WithPreset(xredis.NewPreset("team", xredis.WithPreset(preset))),
+		)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.FeatureEnabled("hedging")).To(BeFalse())
+		Expect(client.FeatureEnabled("pipelines")).To(BeTrue())
+	})
+})
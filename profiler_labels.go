@@ -0,0 +1,69 @@
+package xredis
+
+import (
+	"context"
+	"runtime/pprof"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	profilerLabelCommand   = "redis.command"
+	profilerLabelOperation = "redis.operation"
+)
+
+// Operation names the application operation that sends commands, such as
+// "load_profile". With WithProfilerLabels, the name is added to the pprof
+// labels of the commands.
+func Operation(name string) CallOption {
+	return func(opts *callOptions) {
+		opts.operation = name
+	}
+}
+
+// profilerLabelsHook runs commands with pprof labels, so CPU and goroutine
+// profiles show which Redis operations were in flight.
+type profilerLabelsHook struct {
+	passDialHook
+}
+
+func (profilerLabelsHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		// Handshake commands keep the labels of the command that dialed.
+		if isConnectionSetupCmd(cmd) {
+			return next(ctx, cmd)
+		}
+
+		var err error
+
+		pprof.Do(ctx, profilerLabels(ctx, cmd.Name()), func(ctx context.Context) {
+			err = next(ctx, cmd)
+		})
+
+		return err
+	}
+}
+
+func (profilerLabelsHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		if len(cmds) > 0 && isConnectionSetupCmd(cmds[0]) {
+			return next(ctx, cmds)
+		}
+
+		var err error
+
+		pprof.Do(ctx, profilerLabels(ctx, commandNamePipeline), func(ctx context.Context) {
+			err = next(ctx, cmds)
+		})
+
+		return err
+	}
+}
+
+func profilerLabels(ctx context.Context, command string) pprof.LabelSet {
+	if operation := callOptionsFrom(ctx).operation; operation != "" {
+		return pprof.Labels(profilerLabelCommand, command, profilerLabelOperation, operation)
+	}
+
+	return pprof.Labels(profilerLabelCommand, command)
+}
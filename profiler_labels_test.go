@@ -0,0 +1,112 @@
+package xredis_test
+
+import (
+	"context"
+	"runtime/pprof"
+	"sync"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// labelHook records the pprof labels that commands are sent with.
+type labelHook struct {
+	mu     sync.Mutex
+	labels []map[string]string
+}
+
+func (*labelHook) DialHook(next rdb.DialHook) rdb.DialHook {
+	return next
+}
+
+func (h *labelHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		h.record(ctx)
+		return next(ctx, cmd)
+	}
+}
+
+func (h *labelHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		h.record(ctx)
+		return next(ctx, cmds)
+	}
+}
+
+func (h *labelHook) record(ctx context.Context) {
+	labels := map[string]string{}
+	pprof.ForLabels(ctx, func(key, value string) bool {
+		labels[key] = value
+		return true
+	})
+
+	h.mu.Lock()
+	h.labels = append(h.labels, labels)
+	h.mu.Unlock()
+}
+
+func (h *labelHook) last() map[string]string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.labels[len(h.labels)-1]
+}
+
+var _ = Describe("Profiler labels", func() {
+	var (
+		hook   *labelHook
+		client *xredis.Client
+	)
+
+	newLabeledClient := func(opts ...xredis.Option) {
+		hook = &labelHook{}
+		client = newTestClient(opts...)
+		client.Raw().AddHook(hook)
+	}
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("labels commands with their name", func() {
+		newLabeledClient(xredis.WithProfilerLabels(true))
+
+		Expect(client.Raw().Get(ctx, "labels:key").Err()).To(MatchError(rdb.Nil))
+		Expect(hook.last()).To(Equal(map[string]string{"redis.command": "get"}))
+	})
+
+	It("labels commands with the operation call option", func() {
+		newLabeledClient(xredis.WithProfilerLabels(true))
+
+		callCtx := xredis.WithCallOptions(ctx, xredis.Operation("load_profile"))
+		_, err := client.Raw().Pipelined(callCtx, func(pipe rdb.Pipeliner) error {
+			pipe.Get(callCtx, "labels:a")
+			pipe.Get(callCtx, "labels:b")
+			return nil
+		})
+		Expect(err).To(MatchError(rdb.Nil))
+		Expect(hook.last()).To(Equal(map[string]string{
+			"redis.command":   "pipeline",
+			"redis.operation": "load_profile",
+		}))
+	})
+
+	It("keeps labels of the caller", func() {
+		newLabeledClient(xredis.WithProfilerLabels(true))
+
+		pprof.Do(ctx, pprof.Labels("handler", "checkout"), func(ctx context.Context) {
+			Expect(client.Raw().Get(ctx, "labels:key").Err()).To(MatchError(rdb.Nil))
+		})
+		Expect(hook.last()).To(HaveKeyWithValue("handler", "checkout"))
+		Expect(hook.last()).To(HaveKeyWithValue("redis.command", "get"))
+	})
+
+	It("does not label commands by default", func() {
+		newLabeledClient()
+
+		Expect(client.Raw().Get(ctx, "labels:key").Err()).To(MatchError(rdb.Nil))
+		Expect(hook.last()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,250 @@
+package xredis
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultPubSubHistoryMaxLen = 1000
+	pubSubHistoryKeySuffix     = ":history"
+	pubSubHistoryBuffer        = 100
+)
+
+// publishWithHistoryScript appends a message to a capped history stream and
+// publishes it with its stream ID.
+//
+// KEYS[1] - history stream key
+// ARGV[1] - channel
+// ARGV[2] - message
+// ARGV[3] - maximum history length
+//
+// Returns the stream entry ID and the number of receivers.
+var publishWithHistoryScript = rdb.NewScript(`
+local id = redis.call("XADD", KEYS[1], "MAXLEN", ARGV[3], "*", "data", ARGV[2])
+local receivers = redis.call("PUBLISH", ARGV[1], cjson.encode({id = id, data = ARGV[2]}))
+
+return {id, receivers}
+`)
+
+// HistoryMessage is a Pub/Sub message received through SubscribeWithHistory.
+type HistoryMessage struct {
+	// ID is the history stream entry ID.
+	//
+	// ID is empty for messages published without history.
+	ID string
+
+	// Channel is the Pub/Sub channel.
+	Channel string
+
+	// Payload is the message published by the sender.
+	Payload string
+
+	// Replayed reports whether the message was read from history rather than
+	// received live.
+	Replayed bool
+}
+
+// historyEnvelope is the Pub/Sub payload of messages published with history.
+type historyEnvelope struct {
+	ID   string `json:"id"`
+	Data string `json:"data"`
+}
+
+// PubSubHistoryKey returns the key of the capped stream that stores the
+// history of channel.
+func PubSubHistoryKey(channel string) string {
+	return channel + pubSubHistoryKeySuffix
+}
+
+// PublishWithHistory publishes message to channel and appends it to the
+// channel history stream in one Lua script, so subscribers that join later
+// can replay recent messages with SubscribeWithHistory.
+//
+// The history keeps at most maxLen messages; maxLen <= 0 uses 1000.
+// The history is stored at PubSubHistoryKey(channel).
+//
+// Live subscribers receive a JSON envelope with the stream ID and message, so
+// messages published with history should be consumed with
+// SubscribeWithHistory. It returns the history entry ID and the number of
+// clients that received the message.
+func (c *Client) PublishWithHistory(
+	ctx context.Context,
+	channel string,
+	message string,
+	maxLen int64,
+) (id string, receivers int64, err error) {
+	if maxLen <= 0 {
+		maxLen = defaultPubSubHistoryMaxLen
+	}
+
+	result, err := publishWithHistoryScript.Run(
+		ctx,
+		c.conn,
+		[]string{PubSubHistoryKey(channel)},
+		channel,
+		message,
+		maxLen,
+	).Slice()
+	if err != nil {
+		return "", 0, err
+	}
+
+	if len(result) != 2 {
+		return "", 0, ErrInvalidEntry
+	}
+
+	id, ok := result[0].(string)
+	if !ok {
+		return "", 0, ErrInvalidEntry
+	}
+
+	receivers, ok = result[1].(int64)
+	if !ok {
+		return "", 0, ErrInvalidEntry
+	}
+
+	return id, receivers, nil
+}
+
+// HistorySubscription is a channel subscription that started with a replay
+// of recent history.
+type HistorySubscription struct {
+	pubsub   *rdb.PubSub
+	messages chan HistoryMessage
+	health   *subscriptionHealth
+}
+
+// SubscribeWithHistory subscribes to channel and first delivers up to replay
+// most recent messages from its history, followed by live messages.
+//
+// The subscription is established before history is read, and live messages
+// already delivered from history are skipped, so no message published with
+// PublishWithHistory is lost or duplicated between replay and live delivery.
+// replay <= 0 disables replay.
+//
+// Messages published without history are delivered with an empty ID.
+func (c *Client) SubscribeWithHistory(ctx context.Context, channel string, replay int64) (*HistorySubscription, error) {
+	pubsub := c.pubsubConn().Subscribe(ctx, channel)
+
+	health, err := c.watchSubscription(ctx, pubsub, []string{channel})
+	if err != nil {
+		return nil, err
+	}
+
+	var history []rdb.XMessage
+
+	if replay > 0 {
+		history, err = c.conn.XRevRangeN(ctx, PubSubHistoryKey(channel), "+", "-", replay).Result()
+		if err != nil {
+			_ = health.close(pubsub)
+			return nil, err
+		}
+	}
+
+	sub := &HistorySubscription{
+		pubsub:   pubsub,
+		messages: make(chan HistoryMessage, pubSubHistoryBuffer),
+		health:   health,
+	}
+
+	go sub.run(channel, history)
+
+	return sub, nil
+}
+
+// Channel returns the channel of replayed and live messages.
+//
+// The channel is closed after Close.
+func (s *HistorySubscription) Channel() <-chan HistoryMessage {
+	return s.messages
+}
+
+// Close unsubscribes and closes the message channel.
+func (s *HistorySubscription) Close() error {
+	return s.health.close(s.pubsub)
+}
+
+// run delivers history from oldest to newest, then live messages.
+func (s *HistorySubscription) run(channel string, history []rdb.XMessage) {
+	defer close(s.messages)
+
+	var lastID string
+
+	for i := len(history) - 1; i >= 0; i-- {
+		payload, _ := history[i].Values["data"].(string)
+
+		if !s.send(HistoryMessage{ID: history[i].ID, Channel: channel, Payload: payload, Replayed: true}) {
+			return
+		}
+
+		lastID = history[i].ID
+	}
+
+	for received := range s.pubsub.ChannelWithSubscriptions() {
+		msg, ok := received.(*rdb.Message)
+		if !ok {
+			if sub, ok := received.(*rdb.Subscription); ok {
+				s.health.confirm(sub)
+			}
+
+			continue
+		}
+
+		message := HistoryMessage{Channel: msg.Channel, Payload: msg.Payload}
+
+		var envelope historyEnvelope
+		if err := json.Unmarshal([]byte(msg.Payload), &envelope); err == nil && envelope.ID != "" {
+			if !streamIDAfter(envelope.ID, lastID) {
+				continue
+			}
+
+			message.ID = envelope.ID
+			message.Payload = envelope.Data
+		}
+
+		if !s.send(message) {
+			return
+		}
+	}
+}
+
+func (s *HistorySubscription) send(message HistoryMessage) bool {
+	select {
+	case s.messages <- message:
+		return true
+	case <-s.health.done:
+		return false
+	}
+}
+
+// streamIDAfter reports whether stream entry ID id is greater than after.
+//
+// An empty after precedes every ID.
+func streamIDAfter(id, after string) bool {
+	if after == "" {
+		return true
+	}
+
+	idMs, idSeq := parseStreamID(id)
+	afterMs, afterSeq := parseStreamID(after)
+
+	if idMs != afterMs {
+		return idMs > afterMs
+	}
+
+	return idSeq > afterSeq
+}
+
+func parseStreamID(id string) (ms, seq uint64) {
+	msPart, seqPart, _ := strings.Cut(id, "-")
+
+	ms, _ = strconv.ParseUint(msPart, 10, 64)
+	seq, _ = strconv.ParseUint(seqPart, 10, 64)
+
+	return ms, seq
+}
@@ -0,0 +1,92 @@
+package xredis_test
+
+import (
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("Pub/Sub history", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient()
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	receive := func(sub *xredis.HistorySubscription) xredis.HistoryMessage {
+		var message xredis.HistoryMessage
+		Eventually(sub.Channel(), time.Second).Should(Receive(&message))
+
+		return message
+	}
+
+	It("replays recent history before live messages", func() {
+		for _, message := range []string{"one", "two", "three"} {
+			_, _, err := client.PublishWithHistory(ctx, "history:events", message, 10)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		sub, err := client.SubscribeWithHistory(ctx, "history:events", 2)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(sub.Close()).To(Succeed())
+		}()
+
+		first := receive(sub)
+		Expect(first.Payload).To(Equal("two"))
+		Expect(first.Replayed).To(BeTrue())
+		Expect(first.ID).NotTo(BeEmpty())
+
+		Expect(receive(sub).Payload).To(Equal("three"))
+
+		id, receivers, err := client.PublishWithHistory(ctx, "history:events", "four", 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(receivers).To(Equal(int64(1)))
+
+		live := receive(sub)
+		Expect(live.ID).To(Equal(id))
+		Expect(live.Channel).To(Equal("history:events"))
+		Expect(live.Payload).To(Equal("four"))
+		Expect(live.Replayed).To(BeFalse())
+	})
+
+	It("caps the history stream", func() {
+		for range 20 {
+			_, _, err := client.PublishWithHistory(ctx, "history:capped", "message", 5)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		length, err := client.Raw().XLen(ctx, xredis.PubSubHistoryKey("history:capped")).Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(length).To(Equal(int64(5)))
+	})
+
+	It("delivers messages published without history", func() {
+		sub, err := client.SubscribeWithHistory(ctx, "history:plain", 10)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(sub.Close()).To(Succeed())
+		}()
+
+		Expect(client.Raw().Publish(ctx, "history:plain", "plain").Err()).To(Succeed())
+
+		message := receive(sub)
+		Expect(message.ID).To(BeEmpty())
+		Expect(message.Payload).To(Equal("plain"))
+	})
+
+	It("closes the message channel on Close", func() {
+		sub, err := client.SubscribeWithHistory(ctx, "history:closed", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sub.Close()).To(Succeed())
+
+		Eventually(sub.Channel(), time.Second).Should(BeClosed())
+	})
+})
@@ -0,0 +1,396 @@
+package xredis
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const namespaceQuotaKeyPrefix = "xredis:quota:"
+
+// namespaceQuotaReserveScript atomically reserves the size of one key in a
+// namespace byte budget.
+//
+// Entries of expired keys are released before the budget is checked. Writes
+// that do not grow a key are always accepted, so keys can be shrunk or
+// rewritten while the namespace is over budget.
+//
+// KEYS[1] - used bytes counter
+// KEYS[2] - hash of key sizes
+// KEYS[3] - sorted set of key expiration times in milliseconds
+// ARGV[1] - data key
+// ARGV[2] - new key size in bytes
+// ARGV[3] - key TTL in milliseconds, or 0 for keys without expiration
+// ARGV[4] - byte budget
+var namespaceQuotaReserveScript = rdb.NewScript(`
+local size = tonumber(ARGV[2])
+local ttl = tonumber(ARGV[3])
+local limit = tonumber(ARGV[4])
+
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local used = tonumber(redis.call("GET", KEYS[1]) or "0")
+
+local expired = redis.call("ZRANGEBYSCORE", KEYS[3], "-inf", now)
+for _, key in ipairs(expired) do
+	used = used - tonumber(redis.call("HGET", KEYS[2], key) or "0")
+	redis.call("HDEL", KEYS[2], key)
+	redis.call("ZREM", KEYS[3], key)
+end
+
+local old = tonumber(redis.call("HGET", KEYS[2], ARGV[1]) or "0")
+local total = used - old + size
+
+if size > old and total > limit then
+	redis.call("SET", KEYS[1], used)
+	return {0, used}
+end
+
+redis.call("SET", KEYS[1], total)
+redis.call("HSET", KEYS[2], ARGV[1], size)
+
+if ttl > 0 then
+	redis.call("ZADD", KEYS[3], now + ttl, ARGV[1])
+else
+	redis.call("ZREM", KEYS[3], ARGV[1])
+end
+
+return {1, total}
+`)
+
+// namespaceQuotaReleaseScript atomically releases the size of one key from a
+// namespace byte budget.
+//
+// KEYS[1] - used bytes counter
+// KEYS[2] - hash of key sizes
+// KEYS[3] - sorted set of key expiration times in milliseconds
+// ARGV[1] - data key
+var namespaceQuotaReleaseScript = rdb.NewScript(`
+local old = tonumber(redis.call("HGET", KEYS[2], ARGV[1]) or "0")
+if old > 0 then
+	redis.call("DECRBY", KEYS[1], old)
+end
+
+redis.call("HDEL", KEYS[2], ARGV[1])
+redis.call("ZREM", KEYS[3], ARGV[1])
+
+return old
+`)
+
+// NamespaceQuota limits the bytes written with Set, SetStruct, their NX, XX,
+// and GetSet variants, and the same writes of a Batch to keys of one
+// namespace.
+type NamespaceQuota struct {
+	// Namespace is the key prefix before the first ":" separator, such as
+	// "session" for "session:42".
+	Namespace string
+
+	// MaxBytes is the namespace budget. The size of a key is the length of
+	// its name plus the length of its encoded value.
+	MaxBytes ByteSize
+
+	// Evict, when set, is called when a write would exceed the budget instead
+	// of failing right away. It can free space, for example by deleting keys
+	// with Client.Delete; the write is then retried once. Returning an error
+	// fails the write with that error.
+	Evict func(ctx context.Context, exceeded NamespaceQuotaExceeded) error
+}
+
+// NamespaceQuotaExceeded describes a write rejected by a namespace quota.
+type NamespaceQuotaExceeded struct {
+	// Namespace is the namespace of the rejected key.
+	Namespace string
+
+	// Key is the rejected key.
+	Key string
+
+	// Size is the size of the rejected write in bytes.
+	Size int64
+
+	// Used is the number of bytes used by the namespace.
+	Used int64
+
+	// MaxBytes is the namespace budget.
+	MaxBytes ByteSize
+}
+
+// namespaceQuotaKeys returns the accounting keys of namespace.
+//
+// The keys share a hash tag, so the accounting scripts run on one Redis Cluster
+// node regardless of where the data keys are stored.
+func namespaceQuotaKeys(namespace string) []string {
+	prefix := namespaceQuotaKeyPrefix + "{" + namespace + "}"
+
+	return []string{prefix + ":used", prefix + ":sizes", prefix + ":expiry"}
+}
+
+// namespaceQuota returns the quota of the namespace of key.
+func (c *Client) namespaceQuota(key string) (NamespaceQuota, bool) {
+	if len(c.quotas) == 0 {
+		return NamespaceQuota{}, false
+	}
+
+	namespace, _, ok := strings.Cut(key, defaultNamespaceSeparator)
+	if !ok {
+		return NamespaceQuota{}, false
+	}
+
+	quota, ok := c.quotas[namespace]
+
+	return quota, ok
+}
+
+// quotaWrite stores value at key with write, which reports whether it stored
+// the value, and counts it against the namespace quota of key.
+func (c *Client) quotaWrite(
+	ctx context.Context,
+	key string,
+	value any,
+	ttl time.Duration,
+	write func() (bool, error),
+) (bool, error) {
+	quota, ok := c.namespaceQuota(key)
+	if !ok {
+		return write()
+	}
+
+	return c.setWithQuota(ctx, quota, key, value, ttl, write)
+}
+
+// setWithQuota reserves the size of key in its namespace budget and stores
+// value with write.
+//
+// Accounting is kept next to the data and is best effort: keys removed other
+// than by the delete helpers of Client stay counted until their TTL elapses.
+func (c *Client) setWithQuota(
+	ctx context.Context,
+	quota NamespaceQuota,
+	key string,
+	value any,
+	ttl time.Duration,
+	write func() (bool, error),
+) (bool, error) {
+	if err := c.reserveWrite(ctx, quota, key, value, ttl); err != nil {
+		return false, err
+	}
+
+	stored, err := write()
+	if err != nil {
+		// The key state is unknown, so drop its accounting rather than
+		// counting a write that may not have happened.
+		_ = c.releaseQuota(ctx, quota.Namespace, key)
+		return false, err
+	}
+
+	if !stored {
+		// A conditional write kept the previous value, if any.
+		if err = c.recountQuota(ctx, quota, key); err != nil {
+			_ = c.releaseQuota(ctx, quota.Namespace, key)
+		}
+	}
+
+	return stored, nil
+}
+
+// reserveWrite reserves the size of a write of value to key in its namespace
+// budget, calling the eviction callback of quota once when the budget is
+// exceeded.
+func (c *Client) reserveWrite(
+	ctx context.Context,
+	quota NamespaceQuota,
+	key string,
+	value any,
+	ttl time.Duration,
+) error {
+	size, err := quotaSize(key, value)
+	if err != nil {
+		return err
+	}
+
+	used, ok, err := c.reserveQuota(ctx, quota, key, size, ttl)
+	if err != nil {
+		return err
+	}
+
+	if !ok && quota.Evict != nil {
+		exceeded := NamespaceQuotaExceeded{
+			Namespace: quota.Namespace,
+			Key:       key,
+			Size:      size,
+			Used:      used,
+			MaxBytes:  quota.MaxBytes,
+		}
+		if err = quota.Evict(ctx, exceeded); err != nil {
+			return err
+		}
+
+		used, ok, err = c.reserveQuota(ctx, quota, key, size, ttl)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !ok {
+		return fmt.Errorf(
+			"%w: namespace %q uses %d of %d bytes, write of %d bytes rejected",
+			ErrNamespaceQuotaExceeded, quota.Namespace, used, int64(quota.MaxBytes), size,
+		)
+	}
+
+	return nil
+}
+
+// recountWritten counts the value stored at key against the namespace quota
+// of key after a write that could not be reserved in advance, such as a
+// server-side patch. The write is counted even when it exceeds the budget.
+func (c *Client) recountWritten(ctx context.Context, key string) error {
+	quota, ok := c.namespaceQuota(key)
+	if !ok {
+		return nil
+	}
+
+	return c.recountQuota(ctx, quota, key)
+}
+
+// recountQuota replaces the reserved size of key with the size of the value
+// stored at key, or releases key when it does not exist.
+func (c *Client) recountQuota(ctx context.Context, quota NamespaceQuota, key string) error {
+	var (
+		length *rdb.IntCmd
+		ttl    *rdb.DurationCmd
+	)
+
+	_, err := c.conn.Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+		length = pipe.StrLen(ctx, key)
+		ttl = pipe.PTTL(ctx, key)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// PTTL returns -2 for missing keys and -1 for keys without expiration.
+	if ttl.Val() == -2 {
+		return c.releaseQuota(ctx, quota.Namespace, key)
+	}
+
+	// The stored value is counted even when it exceeds the budget.
+	quota.MaxBytes = math.MaxInt64
+	_, _, err = c.reserveQuota(ctx, quota, key, int64(len(key))+length.Val(), max(ttl.Val(), 0))
+
+	return err
+}
+
+func (c *Client) reserveQuota(
+	ctx context.Context,
+	quota NamespaceQuota,
+	key string,
+	size int64,
+	ttl time.Duration,
+) (used int64, ok bool, err error) {
+	result, err := namespaceQuotaReserveScript.Run(
+		ctx,
+		c.conn,
+		namespaceQuotaKeys(quota.Namespace),
+		key,
+		size,
+		durationToMs(ttl),
+		int64(quota.MaxBytes),
+	).Int64Slice()
+	if err != nil {
+		return 0, false, err
+	}
+
+	if len(result) != 2 {
+		return 0, false, fmt.Errorf("%w: unexpected namespace quota result", ErrInvalidEntry)
+	}
+
+	return result[1], result[0] == 1, nil
+}
+
+func (c *Client) releaseQuota(ctx context.Context, namespace, key string) error {
+	return namespaceQuotaReleaseScript.Run(ctx, c.conn, namespaceQuotaKeys(namespace), key).Err()
+}
+
+// releaseQuotas releases the keys of namespaces configured with
+// WithNamespaceQuota from their budgets, except the failed ones.
+func (c *Client) releaseQuotas(ctx context.Context, keys, failed []string) error {
+	if len(c.quotas) == 0 {
+		return nil
+	}
+
+	type release struct {
+		namespace string
+		key       string
+	}
+
+	releases := make([]release, 0, len(keys))
+
+	for _, key := range keys {
+		if quota, ok := c.namespaceQuota(key); ok && !slices.Contains(failed, key) {
+			releases = append(releases, release{namespace: quota.Namespace, key: key})
+		}
+	}
+
+	if len(releases) == 0 {
+		return nil
+	}
+
+	_, err := c.conn.Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+		for _, r := range releases {
+			namespaceQuotaReleaseScript.Eval(ctx, pipe, namespaceQuotaKeys(r.namespace), r.key)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// NamespaceUsage returns the bytes counted against the quota of namespace.
+//
+// Keys that expired since the last write to the namespace are still counted.
+func (c *Client) NamespaceUsage(ctx context.Context, namespace string) (int64, error) {
+	used, err := c.conn.Get(ctx, namespaceQuotaKeys(namespace)[0]).Int64()
+	if errors.Is(err, rdb.Nil) {
+		return 0, nil
+	}
+
+	return used, err
+}
+
+// quotaSize returns the size of key and value counted against a namespace
+// quota. Values other than strings, byte slices, and binary marshalers are
+// measured in their fmt representation, which is close to the go-redis
+// encoding.
+func quotaSize(key string, value any) (int64, error) {
+	size := int64(len(key))
+
+	switch v := value.(type) {
+	case nil:
+	case string:
+		size += int64(len(v))
+	case []byte:
+		size += int64(len(v))
+	case encoding.BinaryMarshaler:
+		data, err := v.MarshalBinary()
+		if err != nil {
+			return 0, err
+		}
+
+		size += int64(len(data))
+	default:
+		size += int64(len(fmt.Sprint(v)))
+	}
+
+	return size, nil
+}
@@ -0,0 +1,252 @@
+package xredis_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("Namespace quotas", func() {
+	BeforeEach(func() {
+		client := newTestClient()
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	It("rejects writes beyond the namespace budget", func() {
+		client := newTestClient(xredis.WithNamespaceQuota(xredis.NamespaceQuota{Namespace: "team", MaxBytes: 32}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		// "team:a" + "0123456789" is 16 bytes.
+		Expect(client.Set(ctx, "team:a", "0123456789", 0)).To(Succeed())
+		Expect(client.Set(ctx, "team:b", "0123456789", time.Minute)).To(Succeed())
+
+		err := client.Set(ctx, "team:c", "0123456789", 0)
+		Expect(err).To(MatchError(xredis.ErrNamespaceQuotaExceeded))
+
+		exists, err := client.Exists(ctx, "team:c")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+
+		used, err := client.NamespaceUsage(ctx, "team")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(used).To(Equal(int64(32)))
+
+		// Rewrites that do not grow a key and writes to other namespaces pass.
+		Expect(client.Set(ctx, "team:a", "01234", 0)).To(Succeed())
+		Expect(client.SetStruct(ctx, "other:a", map[string]string{"large": "value"}, 0)).To(Succeed())
+
+		used, err = client.NamespaceUsage(ctx, "team")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(used).To(Equal(int64(27)))
+	})
+
+	It("releases deleted keys", func() {
+		client := newTestClient(xredis.WithNamespaceQuota(xredis.NamespaceQuota{Namespace: "team", MaxBytes: 16}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Set(ctx, "team:a", "0123456789", 0)).To(Succeed())
+		Expect(client.Set(ctx, "team:b", "0123456789", 0)).To(MatchError(xredis.ErrNamespaceQuotaExceeded))
+
+		Expect(client.Delete(ctx, "team:a")).Error().To(Succeed())
+		Expect(client.Set(ctx, "team:b", "0123456789", 0)).To(Succeed())
+	})
+
+	It("counts conditional writes and GetSetStruct", func() {
+		client := newTestClient(xredis.WithNamespaceQuota(xredis.NamespaceQuota{Namespace: "team", MaxBytes: 32}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		ok, err := client.SetStructNX(ctx, "team:a", "01234567", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		// A rejected NX write keeps the stored value counted.
+		ok, err = client.SetNX(ctx, "team:a", "0", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		// "team:a" + `"01234567"` is 16 bytes.
+		used, err := client.NamespaceUsage(ctx, "team")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(used).To(Equal(int64(16)))
+
+		ok, err = client.SetStructXX(ctx, "team:b", "01234567", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		var prev string
+		ok, err = client.GetSetStruct(ctx, "team:b", "01234567", &prev, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		_, err = client.SetStructXX(ctx, "team:a", "0123456789", 0)
+		Expect(err).To(MatchError(xredis.ErrNamespaceQuotaExceeded))
+
+		used, err = client.NamespaceUsage(ctx, "team")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(used).To(Equal(int64(32)))
+	})
+
+	It("releases keys deleted in bulk", func() {
+		client := newTestClient(xredis.WithNamespaceQuota(xredis.NamespaceQuota{Namespace: "team", MaxBytes: 64}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		for _, key := range []string{"team:a", "team:b", "team:c", "team:d"} {
+			Expect(client.Set(ctx, key, "0123456789", 0)).To(Succeed())
+		}
+
+		result, err := client.DeleteMany(ctx, []string{"team:a", "team:b"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Deleted).To(Equal(int64(2)))
+
+		_, ok, err := client.GetDel(ctx, "team:c")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		_, err = client.ScanDelete(ctx, xredis.ScanOptions{Match: "team:*"})
+		Expect(err).NotTo(HaveOccurred())
+
+		used, err := client.NamespaceUsage(ctx, "team")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(used).To(BeZero())
+	})
+
+	It("counts batched writes, patches, and history appends", func() {
+		client := newTestClient(xredis.WithNamespaceQuota(xredis.NamespaceQuota{Namespace: "team", MaxBytes: 40}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		errs := client.Batch().
+			Set("team:a", "0123456789", 0).
+			SetStruct("team:b", "01234567", 0).
+			Set("team:c", "0123456789", 0).
+			Exec(ctx)
+		Expect(errs[0]).NotTo(HaveOccurred())
+		Expect(errs[1]).NotTo(HaveOccurred())
+		Expect(errs[2]).To(MatchError(xredis.ErrNamespaceQuotaExceeded))
+
+		exists, err := client.Exists(ctx, "team:c")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+
+		used, err := client.NamespaceUsage(ctx, "team")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(used).To(Equal(int64(32)))
+
+		// {"n":"0123456789"} is 18 bytes; patches are counted after the write.
+		Expect(client.Delete(ctx, "team:b")).Error().To(Succeed())
+		Expect(client.SetStruct(ctx, "team:p", map[string]string{"n": ""}, 0)).To(Succeed())
+
+		ok, err := client.PatchStruct(ctx, "team:p", map[string]any{"n": "0123456789"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		used, err = client.NamespaceUsage(ctx, "team")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(used).To(Equal(int64(16 + 24)))
+
+		_, err = client.AppendHistory(ctx, "team:p", map[string]string{"n": "0"}, 10)
+		Expect(err).NotTo(HaveOccurred())
+
+		used, err = client.NamespaceUsage(ctx, "team")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(used).To(Equal(int64(16 + 15)))
+	})
+
+	It("does not count writes in read-only mode", func() {
+		client := newTestClient(
+			xredis.WithReadOnlyMode(true),
+			xredis.WithNamespaceQuota(xredis.NamespaceQuota{Namespace: "team", MaxBytes: 1}),
+		)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Set(ctx, "team:a", "0123456789", 0)).To(Succeed())
+		Expect(client.Batch().Set("team:b", "0123456789", 0).Exec(ctx)).To(Equal([]error{nil}))
+		Expect(client.GetDel(ctx, "team:a")).Error().To(Succeed())
+
+		used, err := client.NamespaceUsage(ctx, "team")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(used).To(BeZero())
+	})
+
+	It("releases expired keys", func() {
+		client := newTestClient(xredis.WithNamespaceQuota(xredis.NamespaceQuota{Namespace: "team", MaxBytes: 16}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Set(ctx, "team:a", "0123456789", 50*time.Millisecond)).To(Succeed())
+		time.Sleep(100 * time.Millisecond)
+
+		Expect(client.Set(ctx, "team:b", "0123456789", 0)).To(Succeed())
+	})
+
+	It("calls the eviction callback and retries the write", func() {
+		var client *xredis.Client
+
+		var evicted []xredis.NamespaceQuotaExceeded
+		client = newTestClient(xredis.WithNamespaceQuota(xredis.NamespaceQuota{
+			Namespace: "team",
+			MaxBytes:  16,
+			Evict: func(ctx context.Context, exceeded xredis.NamespaceQuotaExceeded) error {
+				evicted = append(evicted, exceeded)
+				_, err := client.Delete(ctx, "team:a")
+
+				return err
+			},
+		}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Set(ctx, "team:a", "0123456789", 0)).To(Succeed())
+		Expect(client.Set(ctx, "team:b", "0123456789", 0)).To(Succeed())
+
+		Expect(evicted).To(Equal([]xredis.NamespaceQuotaExceeded{{
+			Namespace: "team",
+			Key:       "team:b",
+			Size:      16,
+			Used:      16,
+			MaxBytes:  16,
+		}}))
+
+		exists, err := client.Exists(ctx, "team:a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+
+	It("returns eviction callback errors", func() {
+		errEvict := errors.New("evict failed")
+
+		client := newTestClient(xredis.WithNamespaceQuota(xredis.NamespaceQuota{
+			Namespace: "team",
+			MaxBytes:  1,
+			Evict: func(context.Context, xredis.NamespaceQuotaExceeded) error {
+				return errEvict
+			},
+		}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Set(ctx, "team:a", "value", 0)).To(MatchError(errEvict))
+	})
+})
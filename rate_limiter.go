@@ -6,7 +6,6 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/google/uuid"
 	rdb "github.com/redis/go-redis/v9"
 )
 
@@ -270,7 +269,7 @@ func newRateLimiter(client *Client, opts ...RateLimiterOption) (*RateLimiter, er
 	return &RateLimiter{
 		client: client,
 		prefix: options.prefix,
-		id:     uuid.NewString(),
+		id:     client.newID(),
 	}, nil
 }
 
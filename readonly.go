@@ -0,0 +1,288 @@
+package xredis
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// readOnlyHook turns mutating commands into no-ops.
+//
+// Commands that read and write, such as GETEX and GETDEL, are sent as the
+// read alone, so they return the stored value without changing it.
+// Skipped commands succeed with zero values, except status replies, which
+// report OK, and pops, which report rdb.Nil as if there was nothing to pop.
+// Blocking pops wait for their timeout before.
+// Script and function calls are rejected with ErrReadOnlyMode
+// because it is not known whether they write, and so are transactions that
+// write, because skipping part of a transaction would break its atomicity.
+type readOnlyHook struct {
+	passDialHook
+
+	logger *slog.Logger
+}
+
+func newReadOnlyHook(logger *slog.Logger) *readOnlyHook {
+	return &readOnlyHook{logger: logger}
+}
+
+func (h *readOnlyHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		if read := readPart(ctx, cmd); read != nil {
+			h.logSkipped(ctx, cmd)
+			_ = next(ctx, read)
+			setReadResult(cmd, read)
+
+			return cmd.Err()
+		}
+
+		if h.intercept(ctx, cmd) {
+			return cmd.Err()
+		}
+
+		return next(ctx, cmd)
+	}
+}
+
+func (h *readOnlyHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		var (
+			sent  = cmds
+			reads = make(map[int]rdb.Cmder)
+		)
+
+		for i, cmd := range cmds {
+			if read := readPart(ctx, cmd); read != nil {
+				if len(reads) == 0 {
+					sent = slices.Clone(cmds)
+				}
+
+				h.logSkipped(ctx, cmd)
+				reads[i] = read
+				sent[i] = read
+			}
+		}
+
+		// Skipped writes succeed without an error, so a transaction that
+		// contains one is rejected to fail as a whole.
+		tx := isTxPipeline(cmds)
+
+		err := rejectPipeline(ctx, sent, func(cmd rdb.Cmder) bool {
+			if tx && isWriteCmd(cmd) && !isConnectionSetupCmd(cmd) {
+				cmd.SetErr(fmt.Errorf("%w: %s is not allowed in a transaction", ErrReadOnlyMode, cmd.Name()))
+				return true
+			}
+
+			return h.intercept(ctx, cmd)
+		}, next)
+
+		for i, read := range reads {
+			setReadResult(cmds[i], read)
+		}
+
+		if len(reads) > 0 {
+			return firstCmdErr(cmds)
+		}
+
+		return err
+	}
+}
+
+// intercept handles cmd without sending it to Redis when cmd may write.
+func (h *readOnlyHook) intercept(ctx context.Context, cmd rdb.Cmder) bool {
+	switch {
+	case isConnectionSetupCmd(cmd):
+		return false
+	case isScriptCmd(cmd):
+		cmd.SetErr(fmt.Errorf("%w: %s is not allowed", ErrReadOnlyMode, cmd.Name()))
+		return true
+	case isWriteCmd(cmd):
+		if status, ok := cmd.(*rdb.StatusCmd); ok {
+			status.SetVal("OK")
+		}
+
+		if _, ok := popCommands[cmd.Name()]; ok {
+			cmd.SetErr(skippedPopErr(ctx, cmd))
+		}
+
+		h.logSkipped(ctx, cmd)
+
+		return true
+	default:
+		return false
+	}
+}
+
+// readPart returns the read that serves cmd in read-only mode when cmd both
+// reads and writes, or nil otherwise. GETEX and GETDEL become GET, and HGETEX
+// and HGETDEL become HMGET of the same fields.
+func readPart(ctx context.Context, cmd rdb.Cmder) rdb.Cmder {
+	args := cmd.Args()
+	if len(args) < 2 {
+		return nil
+	}
+
+	var read []any
+
+	switch cmd.Name() {
+	case "getex", "getdel":
+		read = []any{"get", args[1]}
+	case "hgetex", "hgetdel":
+		for i := 2; i+1 < len(args); i++ {
+			if strings.EqualFold(fmt.Sprint(args[i]), "fields") {
+				read = append([]any{"hmget", args[1]}, args[i+2:]...)
+				break
+			}
+		}
+	}
+
+	if read == nil {
+		return nil
+	}
+
+	switch cmd.(type) {
+	case *rdb.StringCmd:
+		return rdb.NewStringCmd(ctx, read...)
+	case *rdb.StringSliceCmd:
+		return rdb.NewStringSliceCmd(ctx, read...)
+	case *rdb.Cmd:
+		return rdb.NewCmd(ctx, read...)
+	default:
+		return nil
+	}
+}
+
+// setReadResult copies the reply of read, returned by readPart, to cmd.
+func setReadResult(cmd, read rdb.Cmder) {
+	switch cmd := cmd.(type) {
+	case *rdb.StringCmd:
+		cmd.SetVal(read.(*rdb.StringCmd).Val())
+	case *rdb.StringSliceCmd:
+		cmd.SetVal(read.(*rdb.StringSliceCmd).Val())
+	case *rdb.Cmd:
+		cmd.SetVal(read.(*rdb.Cmd).Val())
+	}
+
+	cmd.SetErr(read.Err())
+}
+
+// skippedPopErr returns rdb.Nil for a skipped pop. Blocking pops wait for
+// their timeout first, as on empty keys, so callers that pop in a loop do not
+// spin, and return the context error when ctx is done first.
+func skippedPopErr(ctx context.Context, cmd rdb.Cmder) error {
+	if _, ok := blockingCommands[cmd.Name()]; !ok {
+		return rdb.Nil
+	}
+
+	args := cmd.Args()
+
+	timeout := args[len(args)-1]
+	if name := cmd.Name(); name == "blmpop" || name == "bzmpop" {
+		timeout = args[1]
+	}
+
+	seconds, err := strconv.ParseFloat(fmt.Sprint(timeout), 64)
+	if err != nil {
+		return rdb.Nil
+	}
+
+	// A zero timeout blocks until ctx is done.
+	var expired <-chan time.Time
+	if seconds > 0 {
+		timer := time.NewTimer(time.Duration(seconds * float64(time.Second)))
+		defer timer.Stop()
+
+		expired = timer.C
+	}
+
+	select {
+	case <-expired:
+		return rdb.Nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (h *readOnlyHook) logSkipped(ctx context.Context, cmd rdb.Cmder) {
+	if !h.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	key, _ := cmdFirstKey(cmd)
+	h.logger.LogAttrs(
+		ctx,
+		slog.LevelDebug,
+		"redis write skipped in read-only mode",
+		slog.String("command", cmd.Name()),
+		slog.String("key", key),
+	)
+}
+
+func firstCmdErr(cmds []rdb.Cmder) error {
+	for _, cmd := range cmds {
+		if err := cmd.Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rejectPipeline sends the commands of cmds that reject does not fail. A
+// transaction with a rejected command fails as a whole, because forwarding the
+// rest of it would break its atomicity.
+func rejectPipeline(
+	ctx context.Context,
+	cmds []rdb.Cmder,
+	reject func(rdb.Cmder) bool,
+	next rdb.ProcessPipelineHook,
+) error {
+	var forwarded []rdb.Cmder
+
+	for i, cmd := range cmds {
+		switch {
+		case reject(cmd):
+			if forwarded == nil {
+				forwarded = make([]rdb.Cmder, i, len(cmds))
+				copy(forwarded, cmds[:i])
+			}
+		case forwarded != nil:
+			forwarded = append(forwarded, cmd)
+		}
+	}
+
+	if forwarded == nil {
+		return next(ctx, cmds)
+	}
+
+	if isTxPipeline(cmds) {
+		return failTx(cmds)
+	}
+
+	if len(forwarded) > 0 {
+		_ = next(ctx, forwarded)
+	}
+
+	return firstCmdErr(cmds)
+}
+
+// failTx fails every command of a MULTI/EXEC transaction in which a hook
+// rejected a command, so no command of the transaction is applied, and returns
+// the rejection.
+func failTx(cmds []rdb.Cmder) error {
+	err := firstCmdErr(cmds)
+
+	for _, cmd := range cmds {
+		if cmd.Err() == nil {
+			cmd.SetErr(err)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,176 @@
+package xredis_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("ReadOnlyMode", func() {
+	var (
+		client   *xredis.Client
+		readOnly *xredis.Client
+		output   bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		output.Reset()
+
+		client = newTestClient()
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+
+		readOnly = newTestClient(
+			xredis.WithReadOnlyMode(true),
+			xredis.WithLogger(slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		)
+	})
+
+	AfterEach(func() {
+		Expect(readOnly.Close()).To(Succeed())
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("skips writes and serves reads", func() {
+		Expect(client.Set(ctx, "readonly:existing", "value", 0)).To(Succeed())
+
+		Expect(readOnly.Set(ctx, "readonly:new", "value", time.Minute)).To(Succeed())
+		Expect(readOnly.Delete(ctx, "readonly:existing")).Error().To(Succeed())
+
+		counter, err := readOnly.Incr(ctx, "readonly:counter")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(counter).To(BeZero())
+
+		value, ok, err := readOnly.String(ctx, "readonly:existing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("value"))
+
+		exists, err := client.Exists(ctx, "readonly:new")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+
+		Expect(output.String()).To(ContainSubstring(`"msg":"redis write skipped in read-only mode"`))
+		Expect(output.String()).To(ContainSubstring(`"key":"readonly:new"`))
+	})
+
+	It("filters writes out of pipelines", func() {
+		Expect(client.Set(ctx, "readonly:existing", "value", 0)).To(Succeed())
+
+		var get *rdb.StringCmd
+
+		_, err := readOnly.Raw().Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+			pipe.Set(ctx, "readonly:piped", "value", 0)
+			get = pipe.Get(ctx, "readonly:existing")
+
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(get.Val()).To(Equal("value"))
+
+		exists, err := client.Exists(ctx, "readonly:piped")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+
+	It("rejects transactions that write", func() {
+		Expect(client.Set(ctx, "readonly:existing", "value", 0)).To(Succeed())
+
+		var get *rdb.StringCmd
+
+		_, err := readOnly.Raw().TxPipelined(ctx, func(pipe rdb.Pipeliner) error {
+			pipe.Set(ctx, "readonly:piped", "value", 0)
+			get = pipe.Get(ctx, "readonly:existing")
+
+			return nil
+		})
+		Expect(err).To(MatchError(xredis.ErrReadOnlyMode))
+		Expect(get.Err()).To(MatchError(xredis.ErrReadOnlyMode))
+
+		values, err := readOnly.Raw().TxPipelined(ctx, func(pipe rdb.Pipeliner) error {
+			pipe.Get(ctx, "readonly:existing")
+
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values[0].(*rdb.StringCmd).Val()).To(Equal("value"))
+	})
+
+	It("serves the read of commands that read and write", func() {
+		Expect(client.Set(ctx, "readonly:existing", "value", time.Minute)).To(Succeed())
+		Expect(client.SetStruct(ctx, "readonly:struct", map[string]string{"name": "ada"}, 0)).To(Succeed())
+		Expect(client.Raw().HSet(ctx, "readonly:hash", "field", "value").Err()).To(Succeed())
+
+		value, ok, err := readOnly.GetEx(ctx, "readonly:existing", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("value"))
+
+		var decoded map[string]string
+		ok, err = readOnly.GetStructEx(ctx, "readonly:struct", &decoded, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(decoded).To(HaveKeyWithValue("name", "ada"))
+
+		value, ok, err = readOnly.GetDel(ctx, "readonly:existing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("value"))
+
+		fields, err := readOnly.Raw().HGetDel(ctx, "readonly:hash", "field", "missing").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fields).To(Equal([]string{"value", ""}))
+
+		ttl, err := client.Raw().TTL(ctx, "readonly:existing").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ttl).To(BeNumerically(">", 0))
+
+		ttl, err = client.Raw().TTL(ctx, "readonly:struct").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ttl).To(Equal(time.Duration(-1)))
+
+		exists, err := client.Raw().HExists(ctx, "readonly:hash", "field").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeTrue())
+	})
+
+	It("reports skipped pops as empty", func() {
+		Expect(client.Raw().RPush(ctx, "{readonly}:list", "value").Err()).To(Succeed())
+
+		_, err := readOnly.Raw().LPop(ctx, "{readonly}:list").Result()
+		Expect(err).To(Equal(rdb.Nil))
+
+		_, _, ok, err := readOnly.BLPop(ctx, 100*time.Millisecond, "{readonly}:list")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		_, _, ok, err = readOnly.BRPop(ctx, 100*time.Millisecond, "{readonly}:list")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		_, ok, err = readOnly.LMPop(ctx, xredis.ListLeft, 1, "{readonly}:list")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		_, ok, err = readOnly.BZMPop(ctx, 100*time.Millisecond, xredis.ScoreMin, 1, "{readonly}:zset")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		length, err := client.Raw().LLen(ctx, "{readonly}:list").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(length).To(Equal(int64(1)))
+	})
+
+	It("rejects scripts", func() {
+		Expect(client.Set(ctx, "readonly:existing", "value", 0)).To(Succeed())
+
+		deleted, err := readOnly.CompareAndDelete(ctx, "readonly:existing", "value")
+		Expect(errors.Is(err, xredis.ErrReadOnlyMode)).To(BeTrue())
+		Expect(deleted).To(BeFalse())
+	})
+})
@@ -0,0 +1,267 @@
+package xredis
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// RecordedCommand is one command and its raw RESP reply captured by a
+// recording client.
+type RecordedCommand struct {
+	// Args contains the command name and arguments as sent to Redis.
+	Args [][]byte `json:"args"`
+
+	// Reply contains the raw RESP reply frame.
+	Reply []byte `json:"reply"`
+}
+
+// Name returns the lowercase command name.
+func (c RecordedCommand) Name() string {
+	return respCommandName(c.Args)
+}
+
+// ReadRecording reads commands written by a recording client.
+func ReadRecording(r io.Reader) ([]RecordedCommand, error) {
+	var commands []RecordedCommand
+
+	decoder := json.NewDecoder(r)
+	for {
+		var command RecordedCommand
+		if err := decoder.Decode(&command); err != nil {
+			if errors.Is(err, io.EOF) {
+				return commands, nil
+			}
+
+			return nil, fmt.Errorf("read recording: %w", err)
+		}
+
+		commands = append(commands, command)
+	}
+}
+
+// recorder writes command/reply pairs as JSON lines.
+type recorder struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+func newRecorder(w io.Writer) *recorder {
+	return &recorder{encoder: json.NewEncoder(w)}
+}
+
+func (r *recorder) record(command RecordedCommand) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Recording is best effort and must not fail Redis commands.
+	_ = r.encoder.Encode(command)
+}
+
+// recordingHook captures commands and replies at the RESP protocol level.
+type recordingHook struct {
+	recorder *recorder
+}
+
+func (h *recordingHook) DialHook(next rdb.DialHook) rdb.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := next(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		return &recordingConn{Conn: conn, recorder: h.recorder}, nil
+	}
+}
+
+func (*recordingHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return next
+}
+
+func (*recordingHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return next
+}
+
+// recordingConn pairs commands written to a connection with replies read from it.
+type recordingConn struct {
+	net.Conn
+
+	recorder *recorder
+
+	mu      sync.Mutex
+	tracker respTracker
+	pending [][][]byte
+}
+
+func (c *recordingConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pending = append(c.pending, c.tracker.writeCommands(p[:n])...)
+
+	return n, err
+}
+
+func (c *recordingConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, reply := range c.tracker.readReplies(p[:n]) {
+		if len(c.pending) == 0 {
+			continue
+		}
+
+		args := c.pending[0]
+		c.pending = c.pending[1:]
+		c.recorder.record(RecordedCommand{Args: args, Reply: reply})
+	}
+
+	return n, err
+}
+
+// replayer serves recorded replies for commands received over in-memory
+// connections.
+type replayer struct {
+	mu      sync.Mutex
+	replies map[string][][]byte
+	served  map[string]int
+}
+
+func newReplayer(commands []RecordedCommand) *replayer {
+	r := &replayer{
+		replies: make(map[string][][]byte),
+		served:  make(map[string]int),
+	}
+
+	for _, command := range commands {
+		key := replayKey(command.Args)
+		r.replies[key] = append(r.replies[key], command.Reply)
+	}
+
+	return r
+}
+
+// reply returns the next recorded reply for args.
+//
+// Identical commands are answered in recording order; once the recorded
+// replies are exhausted, the last one is repeated.
+func (r *replayer) reply(args [][]byte) []byte {
+	key := replayKey(args)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	replies := r.replies[key]
+	if len(replies) == 0 {
+		return unrecordedReply(args)
+	}
+
+	i := min(r.served[key], len(replies)-1)
+	r.served[key]++
+
+	return replies[i]
+}
+
+func (r *replayer) dial(_ context.Context, _, _ string) (net.Conn, error) {
+	client, server := net.Pipe()
+
+	go r.serve(server)
+
+	return client, nil
+}
+
+func (r *replayer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	buf := make([]byte, 0, 4096)
+	chunk := make([]byte, 4096)
+
+	for {
+		n, err := reader.Read(chunk)
+		if err != nil {
+			return
+		}
+
+		buf = append(buf, chunk[:n]...)
+		for {
+			args, size, ok, parseErr := parseRESPCommand(buf)
+			if parseErr != nil {
+				return
+			}
+
+			if !ok {
+				break
+			}
+
+			buf = buf[size:]
+			if _, err = conn.Write(r.reply(args)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// replayKey returns the lookup key of a command.
+//
+// Connection setup commands are matched by name because they contain
+// run-specific values, such as client names and library versions.
+func replayKey(args [][]byte) string {
+	if len(args) == 0 {
+		return ""
+	}
+
+	name := strings.ToLower(string(args[0]))
+	switch name {
+	case "hello", "auth", "select", "readonly":
+		return name
+	case "client":
+		if len(args) > 1 {
+			return name + " " + strings.ToLower(string(args[1]))
+		}
+	}
+
+	return string(bytes.Join(args, []byte{0}))
+}
+
+func unrecordedReply(args [][]byte) []byte {
+	switch replayKey(args) {
+	case "select", "readonly", "client setname", "client setinfo", "client maint_notifications":
+		return []byte("+OK\r\n")
+	}
+
+	return fmt.Appendf(nil, "-ERR xredis replay: no recorded reply for %s command\r\n", respCommandName(args))
+}
+
+// NewReplayClient creates a standalone client that serves replies captured by
+// WithRecording instead of connecting to Redis.
+//
+// Commands are matched by their exact arguments and answered in recording
+// order. Commands without a recorded reply fail with a Redis error, so replayed
+// test suites stay deterministic. Connection setup commands are matched by
+// name only.
+//
+// opts may configure the client like NewClient; the dialer is always replaced.
+func NewReplayClient(recording io.Reader, opts ...Option) (*Client, error) {
+	commands, err := ReadRecording(recording)
+	if err != nil {
+		return nil, err
+	}
+
+	replay := newReplayer(commands)
+
+	return NewClient(append(opts, WithDialer(replay.dial))...)
+}
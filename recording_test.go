@@ -0,0 +1,80 @@
+package xredis_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("Recording", func() {
+	It("replays recorded replies without Redis", func() {
+		cleanup := newTestClient()
+		Expect(cleanup.Raw().FlushDB(ctx).Err()).To(Succeed())
+		Expect(cleanup.Close()).To(Succeed())
+
+		var recording bytes.Buffer
+
+		run := func(client *xredis.Client) {
+			Expect(client.Set(ctx, "recording:string", "value", time.Minute)).To(Succeed())
+
+			value, ok, err := client.String(ctx, "recording:string")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal("value"))
+
+			_, ok, err = client.String(ctx, "recording:missing")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+
+			Expect(client.HSet(ctx, "recording:hash", 0, "field", "1")).Error().To(Succeed())
+
+			field, ok, err := client.HGet(ctx, "recording:hash", "field")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(field).To(Equal("1"))
+
+			first, err := client.Incr(ctx, "recording:counter")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(first).To(Equal(int64(1)))
+
+			second, err := client.Incr(ctx, "recording:counter")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second).To(Equal(int64(2)))
+		}
+
+		recorded := newTestClient(xredis.WithRecording(&recording))
+		run(recorded)
+		Expect(recorded.Close()).To(Succeed())
+
+		commands, err := xredis.ReadRecording(bytes.NewReader(recording.Bytes()))
+		Expect(err).NotTo(HaveOccurred())
+
+		names := make([]string, 0, len(commands))
+		for _, command := range commands {
+			names = append(names, command.Name())
+		}
+		Expect(names).To(ContainElements("set", "get", "hset", "hget", "incr"))
+
+		replayed, err := xredis.NewReplayClient(bytes.NewReader(recording.Bytes()))
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(replayed.Close()).To(Succeed())
+		}()
+
+		run(replayed)
+	})
+
+	It("fails commands without a recorded reply", func() {
+		replayed, err := xredis.NewReplayClient(bytes.NewReader(nil))
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(replayed.Close()).To(Succeed())
+		}()
+
+		_, _, err = replayed.String(ctx, "recording:unknown")
+		Expect(err).To(MatchError(ContainSubstring("no recorded reply for get command")))
+	})
+})
@@ -0,0 +1,316 @@
+// Package redisbench generates load through an xredis client for capacity
+// planning, by replaying recorded command traces or synthesizing read/write
+// mixes, and reports the achieved throughput and latency percentiles.
+package redisbench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const defaultConcurrency = 16
+
+// ErrInvalidConfig is returned when a benchmark workload or configuration is
+// invalid.
+var ErrInvalidConfig = errors.New("invalid benchmark config")
+
+// Workload produces the commands sent by Run.
+//
+// Next is called concurrently by the workers and must be safe for concurrent
+// use.
+type Workload interface {
+	// Next returns the command name and arguments of request n, counted from
+	// zero.
+	Next(n int64) []any
+}
+
+// Config configures a benchmark run.
+type Config struct {
+	// Concurrency is the number of workers sending commands. Zero uses 16.
+	Concurrency int
+
+	// Requests stops the run after that many commands. Zero means no limit.
+	Requests int64
+
+	// Duration stops the run after that long. Zero means no limit.
+	Duration time.Duration
+
+	// Rate limits the commands sent per second across all workers, so the
+	// latency is measured at a target load instead of saturation. Zero means
+	// no limit.
+	Rate float64
+}
+
+// Report contains the results of a benchmark run.
+type Report struct {
+	// Requests is the number of commands sent.
+	Requests int64
+
+	// Errors is the number of commands that failed. Missing keys are not
+	// errors.
+	Errors int64
+
+	// Elapsed is the wall time of the run.
+	Elapsed time.Duration
+
+	// QPS is the achieved number of commands per second.
+	QPS float64
+
+	// Latency percentiles of all commands.
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+// String formats the report as one line.
+func (r Report) String() string {
+	return fmt.Sprintf(
+		"requests=%d errors=%d elapsed=%s qps=%.0f p50=%s p90=%s p99=%s max=%s",
+		r.Requests, r.Errors, r.Elapsed.Round(time.Millisecond), r.QPS, r.P50, r.P90, r.P99, r.Max,
+	)
+}
+
+// Run sends the commands of workload through client until the configured
+// request count or duration is reached, or ctx is done, and reports the
+// achieved throughput and latency.
+//
+// Commands are sent with client.Raw, so they pass through the client hooks,
+// such as metrics and tracing, like application commands. Stopping on ctx is
+// not an error.
+func Run(ctx context.Context, client *xredis.Client, workload Workload, cfg Config) (Report, error) {
+	if client == nil || workload == nil {
+		return Report{}, fmt.Errorf("%w: client and workload are required", ErrInvalidConfig)
+	}
+
+	if cfg.Concurrency < 0 || cfg.Requests < 0 || cfg.Duration < 0 || cfg.Rate < 0 {
+		return Report{}, fmt.Errorf("%w: limits must not be negative", ErrInvalidConfig)
+	}
+
+	if cfg.Requests == 0 && cfg.Duration == 0 {
+		if _, ok := ctx.Deadline(); !ok {
+			return Report{}, fmt.Errorf("%w: set Requests, Duration, or a context deadline", ErrInvalidConfig)
+		}
+	}
+
+	if cfg.Concurrency == 0 {
+		cfg.Concurrency = defaultConcurrency
+	}
+
+	if cfg.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Duration)
+		defer cancel()
+	}
+
+	var (
+		next    atomic.Int64
+		errs    atomic.Int64
+		wg      sync.WaitGroup
+		samples = make([][]time.Duration, cfg.Concurrency)
+		started = time.Now()
+	)
+
+	for worker := range cfg.Concurrency {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				n := next.Add(1) - 1
+				if cfg.Requests > 0 && n >= cfg.Requests {
+					return
+				}
+
+				if cfg.Rate > 0 && !waitUntil(ctx, started.Add(time.Duration(float64(n)/cfg.Rate*float64(time.Second)))) {
+					return
+				}
+
+				if ctx.Err() != nil {
+					return
+				}
+
+				sent := time.Now()
+				err := client.Raw().Do(ctx, workload.Next(n)...).Err()
+				latency := time.Since(sent)
+
+				// Commands interrupted by the end of the run are not counted.
+				if ctx.Err() != nil {
+					return
+				}
+
+				if err != nil && !errors.Is(err, rdb.Nil) {
+					errs.Add(1)
+				}
+
+				samples[worker] = append(samples[worker], latency)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return newReport(slices.Concat(samples...), errs.Load(), time.Since(started)), nil
+}
+
+// waitUntil sleeps until t and reports false when ctx is done first.
+func waitUntil(ctx context.Context, t time.Time) bool {
+	wait := time.Until(t)
+	if wait <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func newReport(latencies []time.Duration, errs int64, elapsed time.Duration) Report {
+	report := Report{
+		Requests: int64(len(latencies)),
+		Errors:   errs,
+		Elapsed:  elapsed,
+	}
+
+	if len(latencies) == 0 {
+		return report
+	}
+
+	slices.Sort(latencies)
+
+	report.QPS = float64(len(latencies)) / elapsed.Seconds()
+	report.P50 = percentile(latencies, 0.50)
+	report.P90 = percentile(latencies, 0.90)
+	report.P99 = percentile(latencies, 0.99)
+	report.Max = latencies[len(latencies)-1]
+
+	return report
+}
+
+// percentile returns the nearest-rank percentile p of sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	i := int(p*float64(len(sorted)) + 0.5)
+	return sorted[min(max(i-1, 0), len(sorted)-1)]
+}
+
+// Replay returns a workload that sends recorded commands in recording order,
+// starting over after the last one. Recordings are written by clients
+// configured with xredis.WithRecording and read with xredis.ReadRecording.
+//
+// Connection setup commands, such as HELLO and CLIENT SETNAME, are skipped.
+func Replay(commands []xredis.RecordedCommand) (Workload, error) {
+	var workload replayWorkload
+
+	for _, command := range commands {
+		if len(command.Args) == 0 || isSetupCommand(command) {
+			continue
+		}
+
+		args := make([]any, len(command.Args))
+		for i, arg := range command.Args {
+			args[i] = string(arg)
+		}
+
+		workload = append(workload, args)
+	}
+
+	if len(workload) == 0 {
+		return nil, fmt.Errorf("%w: recording contains no commands", ErrInvalidConfig)
+	}
+
+	return workload, nil
+}
+
+type replayWorkload [][]any
+
+func (w replayWorkload) Next(n int64) []any {
+	// Hooks may rewrite arguments in place, such as WithKeyHasher.
+	return slices.Clone(w[n%int64(len(w))])
+}
+
+func isSetupCommand(command xredis.RecordedCommand) bool {
+	switch command.Name() {
+	case "hello", "auth", "select", "readonly", "client":
+		return true
+	default:
+		return false
+	}
+}
+
+// Mix configures a synthesized workload of GET and SET commands.
+type Mix struct {
+	// ReadRatio is the share of GET commands, from 0 to 1.
+	ReadRatio float64
+
+	// Keys is the number of distinct keys. Zero uses 10000.
+	Keys int
+
+	// KeyPrefix prefixes the generated keys. Empty uses "redisbench:".
+	KeyPrefix string
+
+	// ValueSize is the size of SET values in bytes. Zero uses 64.
+	ValueSize int
+
+	// TTL expires the written keys, so benchmarks against shared clusters
+	// clean up after themselves. Zero keeps them without expiration.
+	TTL time.Duration
+}
+
+// Synthesize returns a workload of GET and SET commands on uniformly chosen
+// keys.
+func Synthesize(mix Mix) (Workload, error) {
+	if mix.ReadRatio < 0 || mix.ReadRatio > 1 || mix.Keys < 0 || mix.ValueSize < 0 || mix.TTL < 0 {
+		return nil, fmt.Errorf("%w: invalid mix", ErrInvalidConfig)
+	}
+
+	if mix.Keys == 0 {
+		mix.Keys = 10000
+	}
+
+	if mix.KeyPrefix == "" {
+		mix.KeyPrefix = "redisbench:"
+	}
+
+	if mix.ValueSize == 0 {
+		mix.ValueSize = 64
+	}
+
+	return &mixWorkload{mix: mix, value: strings.Repeat("x", mix.ValueSize)}, nil
+}
+
+type mixWorkload struct {
+	mix   Mix
+	value string
+}
+
+func (w *mixWorkload) Next(int64) []any {
+	key := w.mix.KeyPrefix + strconv.Itoa(rand.IntN(w.mix.Keys))
+
+	if rand.Float64() < w.mix.ReadRatio {
+		return []any{"get", key}
+	}
+
+	if w.mix.TTL > 0 {
+		return []any{"set", key, w.value, "px", w.mix.TTL.Milliseconds()}
+	}
+
+	return []any{"set", key, w.value}
+}
@@ -0,0 +1,111 @@
+package redisbench_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	"github.com/mkbeh/xredis/redisbench"
+)
+
+var ctx = context.TODO()
+
+func TestGinkgoSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "redisbench")
+}
+
+var _ = Describe("Run", func() {
+	var (
+		client   *xredis.Client
+		commands []xredis.RecordedCommand
+	)
+
+	BeforeEach(func() {
+		commands = []xredis.RecordedCommand{
+			{Args: [][]byte{[]byte("hello"), []byte("3")}, Reply: []byte("-ERR unknown command\r\n")},
+			{Args: [][]byte{[]byte("set"), []byte("bench:1"), []byte("v")}, Reply: []byte("+OK\r\n")},
+			{Args: [][]byte{[]byte("get"), []byte("bench:1")}, Reply: []byte("$1\r\nv\r\n")},
+			{Args: [][]byte{[]byte("get"), []byte("bench:2")}, Reply: []byte("$-1\r\n")},
+		}
+
+		var recording bytes.Buffer
+
+		encoder := json.NewEncoder(&recording)
+		for _, command := range commands {
+			Expect(encoder.Encode(command)).To(Succeed())
+		}
+
+		var err error
+		client, err = xredis.NewReplayClient(&recording)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("replays recorded commands", func() {
+		workload, err := redisbench.Replay(commands)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(workload.Next(0)).To(Equal([]any{"set", "bench:1", "v"}))
+		Expect(workload.Next(3)).To(Equal([]any{"set", "bench:1", "v"}))
+
+		report, err := redisbench.Run(ctx, client, workload, redisbench.Config{Concurrency: 4, Requests: 90})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Requests).To(Equal(int64(90)))
+		Expect(report.Errors).To(BeZero())
+		Expect(report.QPS).To(BeNumerically(">", 0))
+		Expect(report.P50).To(BeNumerically("<=", report.P99))
+		Expect(report.P99).To(BeNumerically("<=", report.Max))
+	})
+
+	It("counts failed commands of a synthesized mix", func() {
+		workload, err := redisbench.Synthesize(redisbench.Mix{ReadRatio: 0.5, Keys: 10, TTL: time.Minute})
+		Expect(err).NotTo(HaveOccurred())
+
+		report, err := redisbench.Run(ctx, client, workload, redisbench.Config{Requests: 50})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Requests).To(Equal(int64(50)))
+		Expect(report.Errors).To(Equal(int64(50)))
+	})
+
+	It("limits the request rate", func() {
+		workload, err := redisbench.Replay(commands)
+		Expect(err).NotTo(HaveOccurred())
+
+		report, err := redisbench.Run(ctx, client, workload, redisbench.Config{Requests: 21, Rate: 100})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Requests).To(Equal(int64(21)))
+		Expect(report.Elapsed).To(BeNumerically(">=", 200*time.Millisecond))
+	})
+
+	It("stops after the duration", func() {
+		workload, err := redisbench.Replay(commands)
+		Expect(err).NotTo(HaveOccurred())
+
+		report, err := redisbench.Run(ctx, client, workload, redisbench.Config{Duration: 100 * time.Millisecond})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Requests).To(BeNumerically(">", 0))
+		Expect(report.Elapsed).To(BeNumerically("<", time.Second))
+	})
+
+	It("validates the configuration", func() {
+		_, err := redisbench.Replay(commands[:1])
+		Expect(err).To(MatchError(redisbench.ErrInvalidConfig))
+
+		_, err = redisbench.Synthesize(redisbench.Mix{ReadRatio: 2})
+		Expect(err).To(MatchError(redisbench.ErrInvalidConfig))
+
+		workload, err := redisbench.Replay(commands)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = redisbench.Run(ctx, client, workload, redisbench.Config{})
+		Expect(err).To(MatchError(redisbench.ErrInvalidConfig))
+	})
+})
@@ -0,0 +1,380 @@
+// Package redistest loads deterministic fixtures into a test Redis through an
+// xredis client, so integration tests of services built on xredis start from
+// reproducible state.
+package redistest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidFixture is returned when a fixture file cannot be parsed or
+// describes an invalid key.
+var ErrInvalidFixture = errors.New("invalid fixture")
+
+// Key types of fixtures.
+const (
+	TypeString = "string"
+	TypeHash   = "hash"
+	TypeList   = "list"
+	TypeSet    = "set"
+	TypeZSet   = "zset"
+	TypeStream = "stream"
+)
+
+// fixtureFile is the document of a fixture file.
+type fixtureFile struct {
+	Keys []fixture `json:"keys" yaml:"keys"`
+}
+
+// fixture describes one key.
+type fixture struct {
+	Key   string `json:"key"   yaml:"key"`
+	Type  string `json:"type"  yaml:"type"`
+	TTL   string `json:"ttl"   yaml:"ttl"`
+	Value any    `json:"value" yaml:"value"`
+}
+
+// LoadFixtures loads the fixture files of fsys, usually an embed.FS, into
+// Redis.
+//
+// Files ending in .json, .yaml, or .yml are loaded in lexical path order;
+// other files are ignored. Each file lists keys with their type, value, and
+// optional TTL:
+//
+//	keys:
+//	  - key: user:42
+//	    type: hash
+//	    ttl: 1h
+//	    value: {name: Ada, age: 36}
+//	  - key: user:42:roles
+//	    type: set
+//	    value: [admin, billing]
+//	  - key: leaderboard
+//	    type: zset
+//	    value: {ada: 120, bob: 95}
+//	  - key: user:42:events
+//	    type: stream
+//	    value:
+//	      - {id: 1-1, fields: {action: login}}
+//
+// Types are string, hash, list, set, zset, and stream. Strings take a scalar,
+// hashes a field-value map, lists and sets a sequence, sorted sets a
+// member-score map, and streams a sequence of entries with fields and an
+// optional ID, which defaults to "*". TTLs use time.ParseDuration syntax.
+//
+// Every key is deleted before it is written, so loading fixtures twice yields
+// the same state, while keys not listed are kept. Files are validated before
+// anything is written; a malformed file, an unknown type, or a value of the
+// wrong shape returns ErrInvalidFixture.
+func LoadFixtures(ctx context.Context, c *xredis.Client, fsys fs.FS) error {
+	if c == nil || fsys == nil {
+		return fmt.Errorf("%w: nil client or file system", ErrInvalidFixture)
+	}
+
+	var writes []func(rdb.Pipeliner)
+
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() || !isFixtureFile(name) {
+			return nil
+		}
+
+		fixtures, err := readFixtures(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		for _, f := range fixtures {
+			write, err := f.write(ctx)
+			if err != nil {
+				return fmt.Errorf("%w: %s: key %q: %w", ErrInvalidFixture, name, f.Key, err)
+			}
+
+			writes = append(writes, write)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(writes) == 0 {
+		return nil
+	}
+
+	_, err = c.Raw().Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+		for _, write := range writes {
+			write(pipe)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func isFixtureFile(name string) bool {
+	switch path.Ext(name) {
+	case ".json", ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+func readFixtures(fsys fs.FS, name string) ([]fixture, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var file fixtureFile
+
+	if path.Ext(name) == ".json" {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&file)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&file)
+	}
+
+	// Empty files describe no keys.
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidFixture, name, err)
+	}
+
+	return file.Keys, nil
+}
+
+// write validates the fixture and returns the commands that store it.
+func (f fixture) write(ctx context.Context) (func(rdb.Pipeliner), error) {
+	if f.Key == "" {
+		return nil, errors.New("empty key")
+	}
+
+	var ttl time.Duration
+	if f.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(f.TTL); err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("invalid ttl %q", f.TTL)
+		}
+	}
+
+	store, err := f.store(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(pipe rdb.Pipeliner) {
+		pipe.Del(ctx, f.Key)
+		store(pipe)
+
+		if ttl > 0 {
+			pipe.PExpire(ctx, f.Key, ttl)
+		}
+	}, nil
+}
+
+// store returns the command that writes the value of the fixture.
+func (f fixture) store(ctx context.Context) (func(rdb.Pipeliner), error) {
+	switch f.Type {
+	case TypeString:
+		value, err := scalar(f.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(pipe rdb.Pipeliner) { pipe.Set(ctx, f.Key, value, 0) }, nil
+
+	case TypeHash:
+		fields, err := stringMap(f.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]any, 0, 2*len(fields))
+		for _, name := range slices.Sorted(maps.Keys(fields)) {
+			values = append(values, name, fields[name])
+		}
+
+		return func(pipe rdb.Pipeliner) { pipe.HSet(ctx, f.Key, values...) }, nil
+
+	case TypeList, TypeSet:
+		members, err := scalars(f.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		if f.Type == TypeList {
+			return func(pipe rdb.Pipeliner) { pipe.RPush(ctx, f.Key, members...) }, nil
+		}
+
+		return func(pipe rdb.Pipeliner) { pipe.SAdd(ctx, f.Key, members...) }, nil
+
+	case TypeZSet:
+		scores, err := stringMap(f.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		members := make([]rdb.Z, 0, len(scores))
+		for _, member := range slices.Sorted(maps.Keys(scores)) {
+			score, err := strconv.ParseFloat(scores[member], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid score of member %q", member)
+			}
+
+			members = append(members, rdb.Z{Score: score, Member: member})
+		}
+
+		return func(pipe rdb.Pipeliner) { pipe.ZAdd(ctx, f.Key, members...) }, nil
+
+	case TypeStream:
+		entries, err := streamEntries(f.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(pipe rdb.Pipeliner) {
+			for _, entry := range entries {
+				pipe.XAdd(ctx, &rdb.XAddArgs{Stream: f.Key, ID: entry.id, Values: entry.values})
+			}
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown type %q", f.Type)
+	}
+}
+
+// streamEntry is one entry of a stream fixture.
+type streamEntry struct {
+	id     string
+	values []any
+}
+
+func streamEntries(value any) ([]streamEntry, error) {
+	items, ok := value.([]any)
+	if !ok || len(items) == 0 {
+		return nil, errors.New("stream value must be a non-empty sequence of entries")
+	}
+
+	entries := make([]streamEntry, 0, len(items))
+
+	for i, item := range items {
+		object, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("stream entry %d must be a map", i)
+		}
+
+		entry := streamEntry{id: "*"}
+
+		if id, ok := object["id"]; ok {
+			s, err := scalar(id)
+			if err != nil {
+				return nil, fmt.Errorf("stream entry %d: id: %w", i, err)
+			}
+
+			entry.id = s
+		}
+
+		fields, err := stringMap(object["fields"])
+		if err != nil {
+			return nil, fmt.Errorf("stream entry %d: fields: %w", i, err)
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(fields)) {
+			entry.values = append(entry.values, name, fields[name])
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// stringMap converts a non-empty map of scalars.
+func stringMap(value any) (map[string]string, error) {
+	object, ok := value.(map[string]any)
+	if !ok || len(object) == 0 {
+		return nil, errors.New("value must be a non-empty map")
+	}
+
+	out := make(map[string]string, len(object))
+
+	for name, v := range object {
+		s, err := scalar(v)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", name, err)
+		}
+
+		out[name] = s
+	}
+
+	return out, nil
+}
+
+// scalars converts a non-empty sequence of scalars.
+func scalars(value any) ([]any, error) {
+	items, ok := value.([]any)
+	if !ok || len(items) == 0 {
+		return nil, errors.New("value must be a non-empty sequence")
+	}
+
+	out := make([]any, len(items))
+
+	for i, item := range items {
+		s, err := scalar(item)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+
+		out[i] = s
+	}
+
+	return out, nil
+}
+
+// scalar formats a string, number, or boolean like it is written in the
+// fixture file.
+func scalar(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", fmt.Errorf("value must be a string, number, or boolean, got %T", value)
+	}
+}
@@ -0,0 +1,105 @@
+package redistest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"testing/fstest"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	"github.com/mkbeh/xredis/redistest"
+)
+
+var ctx = context.TODO()
+
+func TestGinkgoSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "redistest")
+}
+
+func replayClient(commands ...[]string) *xredis.Client {
+	var recording bytes.Buffer
+
+	encoder := json.NewEncoder(&recording)
+	for _, args := range commands {
+		command := xredis.RecordedCommand{Reply: []byte(":1\r\n")}
+		for _, arg := range args {
+			command.Args = append(command.Args, []byte(arg))
+		}
+
+		Expect(encoder.Encode(command)).To(Succeed())
+	}
+
+	client, err := xredis.NewReplayClient(&recording)
+	Expect(err).NotTo(HaveOccurred())
+
+	return client
+}
+
+var _ = Describe("LoadFixtures", func() {
+	It("writes the keys of every fixture file", func() {
+		client := replayClient(
+			[]string{"del", "leaderboard"},
+			[]string{"zadd", "leaderboard", "120", "ada", "95.5", "bob"},
+			[]string{"del", "user:42"},
+			[]string{"hset", "user:42", "age", "36", "name", "Ada"},
+			[]string{"pexpire", "user:42", "3600000"},
+			[]string{"del", "user:42:roles"},
+			[]string{"sadd", "user:42:roles", "admin", "billing"},
+			[]string{"del", "user:42:events"},
+			[]string{"xadd", "user:42:events", "1-1", "action", "login"},
+			[]string{"del", "greeting"},
+			[]string{"set", "greeting", "hello"},
+		)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		fsys := fstest.MapFS{
+			"fixtures/a.json": {Data: []byte(`{"keys": [
+				{"key": "leaderboard", "type": "zset", "value": {"bob": 95.5, "ada": 120}}
+			]}`)},
+			"fixtures/b.yaml": {Data: []byte(`
+keys:
+  - key: user:42
+    type: hash
+    ttl: 1h
+    value: {name: Ada, age: 36}
+  - key: user:42:roles
+    type: set
+    value: [admin, billing]
+  - key: user:42:events
+    type: stream
+    value:
+      - {id: 1-1, fields: {action: login}}
+`)},
+			"fixtures/c.yml":     {Data: []byte("keys:\n  - {key: greeting, type: string, value: hello}\n")},
+			"fixtures/README.md": {Data: []byte("not a fixture")},
+			"fixtures/empty.yml": {Data: nil},
+		}
+
+		Expect(redistest.LoadFixtures(ctx, client, fsys)).To(Succeed())
+	})
+
+	DescribeTable("rejects invalid fixtures",
+		func(name, data string) {
+			client := replayClient()
+			defer func() {
+				Expect(client.Close()).To(Succeed())
+			}()
+
+			fsys := fstest.MapFS{name: {Data: []byte(data)}}
+			Expect(redistest.LoadFixtures(ctx, client, fsys)).To(MatchError(redistest.ErrInvalidFixture))
+		},
+		Entry("malformed file", "keys.json", `{"keys": [`),
+		Entry("unknown field", "keys.yaml", "keys:\n  - {key: k, type: string, value: v, expire: 1s}\n"),
+		Entry("unknown type", "keys.yaml", "keys:\n  - {key: k, type: json, value: v}\n"),
+		Entry("empty key", "keys.yaml", "keys:\n  - {type: string, value: v}\n"),
+		Entry("invalid ttl", "keys.yaml", "keys:\n  - {key: k, type: string, ttl: soon, value: v}\n"),
+		Entry("wrong value shape", "keys.yaml", "keys:\n  - {key: k, type: hash, value: [a, b]}\n"),
+		Entry("invalid score", "keys.yaml", "keys:\n  - {key: k, type: zset, value: {a: high}}\n"),
+	)
+})
@@ -0,0 +1,297 @@
+package xredis
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// RenamePrefixOption configures RenamePrefix.
+type RenamePrefixOption func(*renamePrefixOptions)
+
+type renamePrefixOptions struct {
+	count    int64
+	replace  bool
+	progress func(RenamePrefixResult)
+}
+
+// WithRenameCount configures the SCAN work hint of RenamePrefix.
+//
+// Non-positive values use the Redis default.
+func WithRenameCount(count int64) RenamePrefixOption {
+	return func(opts *renamePrefixOptions) {
+		opts.count = max(count, 0)
+	}
+}
+
+// WithRenameReplace overwrites existing target keys. By default, keys whose
+// target already exists are skipped.
+func WithRenameReplace() RenamePrefixOption {
+	return func(opts *renamePrefixOptions) {
+		opts.replace = true
+	}
+}
+
+// WithRenameProgress calls fn with the running totals after every scanned
+// batch. fn is called from one goroutine at a time.
+func WithRenameProgress(fn func(RenamePrefixResult)) RenamePrefixOption {
+	return func(opts *renamePrefixOptions) {
+		opts.progress = fn
+	}
+}
+
+// RenamePrefixResult reports the outcome of RenamePrefix.
+type RenamePrefixResult struct {
+	// Scanned is the number of keys found with the source prefix. SCAN may
+	// return a key more than once, so it can exceed the number of distinct
+	// keys.
+	Scanned int64
+
+	// Renamed is the number of keys moved to the target prefix.
+	Renamed int64
+
+	// Skipped is the number of keys left in place because their target
+	// already existed, or that disappeared before they were moved.
+	Skipped int64
+
+	// Failed contains keys whose move failed.
+	Failed []string
+}
+
+func (r *RenamePrefixResult) add(other RenamePrefixResult) {
+	r.Scanned += other.Scanned
+	r.Renamed += other.Renamed
+	r.Skipped += other.Skipped
+	r.Failed = append(r.Failed, other.Failed...)
+}
+
+// RenamePrefix moves every key starting with from to the same name starting
+// with to, for namespace refactors without downtime.
+//
+// Keys are moved with RENAMENX, or RENAME with WithRenameReplace, which keeps
+// their value and TTL atomically. Keys whose target lives in another Redis
+// Cluster hash slot or Ring shard are copied with DUMP and RESTORE and then
+// deleted, which is not atomic: writes to such a key between the copy and
+// the delete are lost, so writers should already use the new name.
+//
+// to must not start with from, so moved keys are not scanned again. Failed
+// moves do not stop the scan, and the first error is returned after the scan
+// completes, like ScanDelete. Scan errors stop the scan immediately.
+func (c *Client) RenamePrefix(ctx context.Context, from, to string, opts ...RenamePrefixOption) (RenamePrefixResult, error) {
+	if from == "" || from == to || strings.HasPrefix(to, from) {
+		return RenamePrefixResult{}, ErrInvalidScan
+	}
+
+	var options renamePrefixOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&options)
+		}
+	}
+
+	var (
+		mu        sync.Mutex
+		result    RenamePrefixResult
+		renameErr error
+	)
+
+	scanOpts := ScanOptions{Match: escapeGlob(from) + "*", Count: options.count}
+
+	err := c.ScanEachBatch(ctx, scanOpts, func(ctx context.Context, keys []string) error {
+		batch, err := c.renameBatch(ctx, keys, from, to, options.replace)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		result.add(batch)
+		if err != nil && renameErr == nil {
+			renameErr = err
+		}
+
+		if options.progress != nil {
+			options.progress(result)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	return result, renameErr
+}
+
+// renameBatch renames keys in one pipeline and copies the keys that cannot be
+// renamed because their target hashes to another slot or shard.
+func (c *Client) renameBatch(ctx context.Context, keys []string, from, to string, replace bool) (RenamePrefixResult, error) {
+	result := RenamePrefixResult{Scanned: int64(len(keys))}
+
+	targets := make([]string, len(keys))
+	for i, key := range keys {
+		targets[i] = to + strings.TrimPrefix(key, from)
+	}
+
+	// Ring does not reject renames across shards, so every key is copied.
+	if _, ok := c.conn.(*rdb.Ring); ok {
+		return c.copyKeys(ctx, keys, targets, replace, result)
+	}
+
+	cmds := make([]rdb.Cmder, len(keys))
+
+	// Per-command errors are collected below.
+	_, _ = c.conn.Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+		for i, key := range keys {
+			if replace {
+				cmds[i] = pipe.Rename(ctx, key, targets[i])
+			} else {
+				cmds[i] = pipe.RenameNX(ctx, key, targets[i])
+			}
+		}
+
+		return nil
+	})
+
+	var (
+		firstErr    error
+		copyKeys    []string
+		copyTargets []string
+	)
+
+	for i, cmd := range cmds {
+		err := cmd.Err()
+
+		switch {
+		case err == nil:
+			if renamed, ok := cmd.(*rdb.BoolCmd); ok && !renamed.Val() {
+				result.Skipped++
+			} else {
+				result.Renamed++
+			}
+		case classifyError(err) == errorClassCrossSlot:
+			copyKeys = append(copyKeys, keys[i])
+			copyTargets = append(copyTargets, targets[i])
+		case isNoSuchKey(err):
+			result.Skipped++
+		default:
+			result.Failed = append(result.Failed, keys[i])
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	if len(copyKeys) == 0 {
+		return result, firstErr
+	}
+
+	result, err := c.copyKeys(ctx, copyKeys, copyTargets, replace, result)
+	if firstErr == nil {
+		firstErr = err
+	}
+
+	return result, firstErr
+}
+
+// copyKeys moves keys to targets with DUMP, RESTORE, and DEL, one key at a
+// time, since sources and targets may live on different nodes.
+func (c *Client) copyKeys(
+	ctx context.Context,
+	keys, targets []string,
+	replace bool,
+	result RenamePrefixResult,
+) (RenamePrefixResult, error) {
+	var firstErr error
+
+	for i, key := range keys {
+		moved, err := c.copyKey(ctx, key, targets[i], replace)
+
+		switch {
+		case err != nil:
+			result.Failed = append(result.Failed, key)
+			if firstErr == nil {
+				firstErr = err
+			}
+		case moved:
+			result.Renamed++
+		default:
+			result.Skipped++
+		}
+	}
+
+	return result, firstErr
+}
+
+func (c *Client) copyKey(ctx context.Context, key, target string, replace bool) (bool, error) {
+	var (
+		dump *rdb.StringCmd
+		pttl *rdb.DurationCmd
+	)
+
+	_, err := c.conn.Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+		dump = pipe.Dump(ctx, key)
+		pttl = pipe.PTTL(ctx, key)
+
+		return nil
+	})
+	if errors.Is(err, rdb.Nil) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	// go-redis reports -1 for keys without an expiration and -2 for missing
+	// keys as raw durations.
+	ttl := pttl.Val()
+	switch {
+	case ttl == -2:
+		return false, nil
+	case ttl < 0:
+		ttl = 0
+	}
+
+	if replace {
+		err = c.conn.RestoreReplace(ctx, target, ttl, dump.Val()).Err()
+	} else {
+		err = c.conn.Restore(ctx, target, ttl, dump.Val()).Err()
+	}
+
+	if isBusyKey(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, c.conn.Del(ctx, key).Err()
+}
+
+// escapeGlob escapes the special characters of Redis glob-style patterns.
+func escapeGlob(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// isNoSuchKey reports whether err is the RENAME error of a missing source key.
+func isNoSuchKey(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "no such key")
+}
+
+// isBusyKey reports whether err is the RESTORE error of an existing target.
+func isBusyKey(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "BUSYKEY")
+}
@@ -0,0 +1,65 @@
+package xredis_test
+
+import (
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("RenamePrefix", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient()
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+
+		Expect(client.Raw().Set(ctx, "user:1", "a", time.Hour).Err()).To(Succeed())
+		Expect(client.Raw().Set(ctx, "user:2", "b", 0).Err()).To(Succeed())
+		Expect(client.Raw().HSet(ctx, "user:3", "name", "c").Err()).To(Succeed())
+		Expect(client.Raw().Set(ctx, "user*:4", "d", 0).Err()).To(Succeed())
+		Expect(client.Raw().Set(ctx, "users:5", "e", 0).Err()).To(Succeed())
+		Expect(client.Raw().Set(ctx, "account:2", "existing", 0).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("moves keys to the new prefix and keeps their TTL", func() {
+		var progress []xredis.RenamePrefixResult
+
+		result, err := client.RenamePrefix(ctx, "user:", "account:", xredis.WithRenameProgress(func(r xredis.RenamePrefixResult) {
+			progress = append(progress, r)
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(xredis.RenamePrefixResult{Scanned: 3, Renamed: 2, Skipped: 1}))
+		Expect(progress).NotTo(BeEmpty())
+		Expect(progress[len(progress)-1]).To(Equal(result))
+
+		Expect(client.Raw().Get(ctx, "account:1").Val()).To(Equal("a"))
+		Expect(client.Raw().TTL(ctx, "account:1").Val()).To(BeNumerically("~", time.Hour, time.Minute))
+		Expect(client.Raw().HGet(ctx, "account:3", "name").Val()).To(Equal("c"))
+
+		Expect(client.Raw().Get(ctx, "account:2").Val()).To(Equal("existing"))
+		Expect(client.Raw().Get(ctx, "user:2").Val()).To(Equal("b"))
+
+		Expect(client.Raw().Exists(ctx, "user*:4", "users:5").Val()).To(Equal(int64(2)))
+	})
+
+	It("overwrites existing targets with WithRenameReplace", func() {
+		result, err := client.RenamePrefix(ctx, "user:", "account:", xredis.WithRenameReplace())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Renamed).To(Equal(int64(3)))
+		Expect(client.Raw().Get(ctx, "account:2").Val()).To(Equal("b"))
+	})
+
+	It("rejects targets that would be scanned again", func() {
+		_, err := client.RenamePrefix(ctx, "user:", "user:v2:")
+		Expect(err).To(MatchError(xredis.ErrInvalidScan))
+
+		_, err = client.RenamePrefix(ctx, "", "account:")
+		Expect(err).To(MatchError(xredis.ErrInvalidScan))
+	})
+})
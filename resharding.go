@@ -0,0 +1,109 @@
+package xredis
+
+import (
+	"context"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// maxReshardingRetries limits how many times batch operations retry keys that
+// still fail with a redirect after go-redis used up its redirects.
+const maxReshardingRetries = 2
+
+// reshardingHook counts MOVED replies of one cluster node.
+//
+// go-redis follows redirects inside the cluster client, so they are only
+// visible to hooks of the node clients.
+type reshardingHook struct {
+	passDialHook
+
+	metrics *metrics
+}
+
+func (h *reshardingHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		err := next(ctx, cmd)
+		if isMovedError(err) {
+			h.metrics.recordClusterResharding(ctx, 1)
+		}
+
+		return err
+	}
+}
+
+func (h *reshardingHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		err := next(ctx, cmds)
+
+		moved := 0
+		for _, cmd := range cmds {
+			if isMovedError(cmd.Err()) {
+				moved++
+			}
+		}
+
+		if moved > 0 {
+			h.metrics.recordClusterResharding(ctx, moved)
+		}
+
+		return err
+	}
+}
+
+// addReshardingHook installs reshardingHook on every node of a cluster client.
+func addReshardingHook(conn rdb.UniversalClient, m *metrics) {
+	cluster, ok := conn.(*rdb.ClusterClient)
+	if !ok || m == nil {
+		return
+	}
+
+	cluster.OnNewNode(func(node *rdb.Client) {
+		node.AddHook(&reshardingHook{metrics: m})
+	})
+}
+
+func isMovedError(err error) bool {
+	return err != nil && classifyError(err) == errorClassMoved
+}
+
+func isRedirectError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	class := classifyError(err)
+
+	return class == errorClassMoved || class == errorClassAsk
+}
+
+// retryRedirected retries the commands of a slot-grouped batch that failed
+// with MOVED or ASK after go-redis used up its redirects, which happens while
+// many slots migrate at once.
+//
+// failed reports whether the command at index i failed with a redirect, and
+// run pipelines the commands at indexes again. Every retry requests a reload of
+// the cluster slots and gets a new redirect budget, and only the affected keys
+// are sent again.
+func (c *Client) retryRedirected(ctx context.Context, n int, failed func(i int) bool, run func(indexes []int)) {
+	cluster, ok := c.conn.(*rdb.ClusterClient)
+	if !ok {
+		return
+	}
+
+	for range maxReshardingRetries {
+		var indexes []int
+
+		for i := range n {
+			if failed(i) {
+				indexes = append(indexes, i)
+			}
+		}
+
+		if len(indexes) == 0 || ctx.Err() != nil {
+			return
+		}
+
+		cluster.ReloadState(ctx)
+		run(indexes)
+	}
+}
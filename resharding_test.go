@@ -0,0 +1,118 @@
+package xredis_test
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// movedError is a MOVED reply as returned by Redis.
+type movedError string
+
+func (e movedError) Error() string {
+	return string(e)
+}
+
+func (movedError) RedisError() {}
+
+// movedHook fails pipelined commands for keys with MOVED the given number of
+// times, as if the redirects were used up while their slots migrate.
+type movedHook struct {
+	mu    sync.Mutex
+	fails map[string]int
+}
+
+func (*movedHook) DialHook(next rdb.DialHook) rdb.DialHook {
+	return next
+}
+
+func (*movedHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return next
+}
+
+func (h *movedHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		err := next(ctx, cmds)
+
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		for _, cmd := range cmds {
+			key, _ := cmd.Args()[1].(string)
+			if h.fails[key] > 0 {
+				h.fails[key]--
+				cmd.SetErr(movedError("MOVED 1234 " + redisAddr))
+			}
+		}
+
+		return err
+	}
+}
+
+func newSingleNodeCluster(hook rdb.Hook) *xredis.Client {
+	client, err := xredis.NewClusterClient(
+		xredis.WithClusterConfig(&xredis.ClusterConfig{Addrs: []string{redisAddr}}),
+		xredis.WithClusterSlots(func(context.Context) ([]rdb.ClusterSlot, error) {
+			return []rdb.ClusterSlot{{Start: 0, End: 16383, Nodes: []rdb.ClusterNode{{Addr: redisAddr}}}}, nil
+		}),
+	)
+	Expect(err).NotTo(HaveOccurred())
+
+	client.Raw().(*rdb.ClusterClient).OnNewNode(func(node *rdb.Client) {
+		node.AddHook(hook)
+	})
+
+	return client
+}
+
+var _ = Describe("Cluster resharding", func() {
+	var (
+		hook   *movedHook
+		client *xredis.Client
+	)
+
+	BeforeEach(func() {
+		hook = &movedHook{fails: map[string]int{}}
+		client = newSingleNodeCluster(hook)
+
+		Expect(client.Raw().Del(ctx, "resharding:a", "resharding:b", "resharding:c").Err()).To(Succeed())
+		Expect(client.Raw().MSet(ctx, "resharding:a", "1", "resharding:b", "2", "resharding:c", "3").Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Raw().Del(ctx, "resharding:a", "resharding:b", "resharding:c").Err()).To(Succeed())
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("retries only the redirected keys of a batch", func() {
+		hook.mu.Lock()
+		hook.fails["resharding:b"] = 1
+		hook.mu.Unlock()
+
+		values, err := client.MGetOrdered(ctx, []string{"resharding:a", "resharding:b", "resharding:c"}, func() any {
+			return new(int)
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(HaveLen(3))
+		Expect(*values[1].(*int)).To(Equal(2))
+	})
+
+	It("reports keys that stay redirected", func() {
+		hook.mu.Lock()
+		hook.fails["resharding:c"] = 10
+		hook.mu.Unlock()
+
+		result, err := client.DeleteMany(ctx, []string{"resharding:a", "resharding:b", "resharding:c"})
+		Expect(err).To(MatchError(ContainSubstring("MOVED")))
+		Expect(result.Deleted).To(Equal(int64(2)))
+		Expect(result.Failed).To(Equal([]string{"resharding:c"}))
+
+		hook.mu.Lock()
+		Expect(hook.fails["resharding:c"]).To(Equal(7))
+		hook.mu.Unlock()
+	})
+})
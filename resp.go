@@ -0,0 +1,188 @@
+package xredis
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var errInvalidRESP = errors.New("invalid RESP frame")
+
+// respFrameLen returns the length of the first complete RESP2 or RESP3 frame
+// in b.
+//
+// It returns ok=false when b does not contain a complete frame yet.
+func respFrameLen(b []byte) (n int, ok bool, err error) {
+	if len(b) == 0 {
+		return 0, false, nil
+	}
+
+	lineEnd := bytes.Index(b, []byte("\r\n"))
+	if lineEnd < 0 {
+		return 0, false, nil
+	}
+
+	header := lineEnd + 2
+	line := b[1:lineEnd]
+
+	switch b[0] {
+	case '+', '-', ':', '_', ',', '#', '(':
+		return header, true, nil
+
+	case '$', '!', '=':
+		size, err := strconv.Atoi(string(line))
+		if err != nil {
+			return 0, false, fmt.Errorf("%w: %w", errInvalidRESP, err)
+		}
+
+		if size < 0 {
+			return header, true, nil
+		}
+
+		total := header + size + 2
+		if len(b) < total {
+			return 0, false, nil
+		}
+
+		return total, true, nil
+
+	case '*', '~', '>', '%', '|':
+		count, err := strconv.Atoi(string(line))
+		if err != nil {
+			return 0, false, fmt.Errorf("%w: %w", errInvalidRESP, err)
+		}
+
+		if b[0] == '%' || b[0] == '|' {
+			count *= 2
+		}
+
+		total := header
+		for range max(count, 0) {
+			elemLen, ok, err := respFrameLen(b[total:])
+			if err != nil || !ok {
+				return 0, false, err
+			}
+
+			total += elemLen
+		}
+
+		return total, true, nil
+
+	default:
+		return 0, false, fmt.Errorf("%w: unexpected type %q", errInvalidRESP, b[0])
+	}
+}
+
+// parseRESPCommand parses the first complete command in b.
+//
+// Commands are RESP arrays of bulk strings. It returns ok=false when b does
+// not contain a complete command yet.
+func parseRESPCommand(b []byte) (args [][]byte, n int, ok bool, err error) {
+	n, ok, err = respFrameLen(b)
+	if err != nil || !ok {
+		return nil, 0, ok, err
+	}
+
+	if b[0] != '*' {
+		return nil, 0, false, fmt.Errorf("%w: command is not an array", errInvalidRESP)
+	}
+
+	frame := b[:n]
+	lineEnd := bytes.Index(frame, []byte("\r\n"))
+	count, _ := strconv.Atoi(string(frame[1:lineEnd]))
+	pos := lineEnd + 2
+
+	args = make([][]byte, 0, max(count, 0))
+	for range max(count, 0) {
+		if frame[pos] != '$' {
+			return nil, 0, false, fmt.Errorf("%w: command argument is not a bulk string", errInvalidRESP)
+		}
+
+		argLineEnd := pos + bytes.Index(frame[pos:], []byte("\r\n"))
+		size, _ := strconv.Atoi(string(frame[pos+1 : argLineEnd]))
+		if size < 0 {
+			return nil, 0, false, fmt.Errorf("%w: null command argument", errInvalidRESP)
+		}
+
+		start := argLineEnd + 2
+
+		args = append(args, bytes.Clone(frame[start:start+size]))
+		pos = start + size + 2
+	}
+
+	return args, n, true, nil
+}
+
+// respTracker splits the byte streams of one connection into commands and
+// reply frames.
+//
+// After a malformed frame, the tracker stops parsing and reports nothing more
+// for the connection.
+type respTracker struct {
+	written []byte
+	read    []byte
+	broken  bool
+}
+
+// writeCommands consumes bytes written to the connection and returns the
+// commands they complete.
+func (t *respTracker) writeCommands(p []byte) [][][]byte {
+	if t.broken {
+		return nil
+	}
+
+	var commands [][][]byte
+
+	t.written = append(t.written, p...)
+	for {
+		args, size, ok, err := parseRESPCommand(t.written)
+		if err != nil {
+			t.broken = true
+			return commands
+		}
+
+		if !ok {
+			return commands
+		}
+
+		commands = append(commands, args)
+		t.written = t.written[size:]
+	}
+}
+
+// readReplies consumes bytes read from the connection and returns the reply
+// frames they complete. Push frames are skipped because they do not answer
+// commands.
+func (t *respTracker) readReplies(p []byte) [][]byte {
+	if t.broken {
+		return nil
+	}
+
+	var replies [][]byte
+
+	t.read = append(t.read, p...)
+	for {
+		size, ok, err := respFrameLen(t.read)
+		if err != nil {
+			t.broken = true
+			return replies
+		}
+
+		if !ok {
+			return replies
+		}
+
+		frame := bytes.Clone(t.read[:size])
+		t.read = t.read[size:]
+
+		if frame[0] != '>' {
+			replies = append(replies, frame)
+		}
+	}
+}
+
+// readPending reports whether a partially read frame is buffered.
+func (t *respTracker) readPending() bool {
+	return len(t.read) > 0
+}
@@ -0,0 +1,63 @@
+package xredis
+
+import (
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+)
+
+var _ = Describe("RESP parsing", func() {
+	DescribeTable("measures complete frames",
+		func(frame string, size int) {
+			n, ok, err := respFrameLen([]byte(frame + "tail"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(n).To(Equal(size))
+		},
+		Entry("simple string", "+OK\r\n", 5),
+		Entry("error", "-ERR boom\r\n", 11),
+		Entry("integer", ":42\r\n", 5),
+		Entry("bulk string", "$5\r\nhello\r\n", 11),
+		Entry("null bulk string", "$-1\r\n", 5),
+		Entry("array", "*2\r\n$1\r\na\r\n:1\r\n", 15),
+		Entry("RESP3 map", "%1\r\n+k\r\n+v\r\n", 12),
+		Entry("RESP3 null", "_\r\n", 3),
+	)
+
+	It("reports incomplete frames", func() {
+		for _, frame := range []string{"", "+OK", "$5\r\nhel", "*2\r\n$1\r\na\r\n"} {
+			_, ok, err := respFrameLen([]byte(frame))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse(), frame)
+		}
+	})
+
+	It("rejects malformed frames", func() {
+		_, _, err := respFrameLen([]byte("?\r\n"))
+		Expect(err).To(MatchError(errInvalidRESP))
+
+		_, _, _, err = parseRESPCommand([]byte("+OK\r\n"))
+		Expect(err).To(MatchError(errInvalidRESP))
+	})
+
+	It("parses commands", func() {
+		args, n, ok, err := parseRESPCommand([]byte("*2\r\n$3\r\nGET\r\n$3\r\nkey\r\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(n).To(Equal(22))
+		Expect(args).To(Equal([][]byte{[]byte("GET"), []byte("key")}))
+	})
+
+	It("pairs split writes and reads into commands and replies", func() {
+		var tracker respTracker
+
+		Expect(tracker.writeCommands([]byte("*1\r\n$4\r\nPI"))).To(BeEmpty())
+		Expect(tracker.writeCommands([]byte("NG\r\n*1\r\n$4\r\nPING\r\n"))).To(HaveLen(2))
+
+		Expect(tracker.readReplies([]byte(">2\r\n+push\r\n+msg\r\n+PO"))).To(BeEmpty())
+		Expect(tracker.readPending()).To(BeTrue())
+
+		replies := tracker.readReplies([]byte("NG\r\n+PONG\r\n"))
+		Expect(replies).To(Equal([][]byte{[]byte("+PONG\r\n"), []byte("+PONG\r\n")}))
+		Expect(tracker.readPending()).To(BeFalse())
+	})
+})
@@ -0,0 +1,192 @@
+package xredis
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultRetentionInterval = time.Minute
+	retentionRunTimeout      = time.Minute
+)
+
+// RetentionPolicy declares how long members of sorted sets scored by time are
+// kept, for sliding-window structures such as recent events.
+//
+// Scores are Unix timestamps in milliseconds, like the scores of the sliding
+// window rate limiter.
+type RetentionPolicy struct {
+	// Match selects the sorted sets by Redis glob-style pattern, such as
+	// "events:*". It must not be empty.
+	Match string
+
+	// MaxAge is the age after which members are removed.
+	MaxAge time.Duration
+
+	// Interval defines how often WithRetention applies the policy.
+	//
+	// Zero uses 1 minute.
+	Interval time.Duration
+
+	// Count is a SCAN work hint. Zero uses the Redis default.
+	Count int64
+}
+
+// RetentionResult reports the outcome of ApplyRetention.
+type RetentionResult struct {
+	// Scanned is the number of sorted sets checked. SCAN may return a key
+	// more than once, so it can exceed the number of distinct keys.
+	Scanned int64
+
+	// Removed is the number of members removed.
+	Removed int64
+
+	// Failed contains keys whose trim failed.
+	Failed []string
+}
+
+func (r *RetentionResult) add(other RetentionResult) {
+	r.Scanned += other.Scanned
+	r.Removed += other.Removed
+	r.Failed = append(r.Failed, other.Failed...)
+}
+
+// TrimByScore removes the members of the sorted set at key with a score
+// before olderThan, as a Unix timestamp in milliseconds, and returns the
+// number of removed members.
+func (c *Client) TrimByScore(ctx context.Context, key string, olderThan time.Time) (int64, error) {
+	return c.conn.ZRemRangeByScore(ctx, key, "-inf", retentionMaxScore(olderThan)).Result()
+}
+
+// ApplyRetention scans the sorted sets matching policy.Match and removes
+// members older than policy.MaxAge, measured from the client clock.
+//
+// Failed trims do not stop the scan, and the first trim error is returned
+// after the scan completes, like RepairTTL. Scan errors stop the scan
+// immediately.
+func (c *Client) ApplyRetention(ctx context.Context, policy RetentionPolicy) (RetentionResult, error) {
+	if policy.Match == "" {
+		return RetentionResult{}, ErrInvalidScan
+	}
+
+	if policy.MaxAge < time.Millisecond {
+		return RetentionResult{}, ErrInvalidTTL
+	}
+
+	var (
+		mu      sync.Mutex
+		result  RetentionResult
+		trimErr error
+	)
+
+	maxScore := retentionMaxScore(time.Now().Add(-policy.MaxAge))
+	opts := ScanOptions{Match: policy.Match, Count: policy.Count, Type: "zset"}
+
+	err := c.ScanEachBatch(ctx, opts, func(ctx context.Context, keys []string) error {
+		batch, err := c.trimBatch(ctx, keys, maxScore)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		result.add(batch)
+		if err != nil && trimErr == nil {
+			trimErr = err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	return result, trimErr
+}
+
+func (c *Client) trimBatch(ctx context.Context, keys []string, maxScore string) (RetentionResult, error) {
+	cmds := make([]*rdb.IntCmd, len(keys))
+
+	// Per-command errors are collected below.
+	_, _ = c.conn.Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.ZRemRangeByScore(ctx, key, "-inf", maxScore)
+		}
+
+		return nil
+	})
+
+	result := RetentionResult{Scanned: int64(len(keys))}
+
+	var firstErr error
+
+	for i, cmd := range cmds {
+		removed, err := cmd.Result()
+		if err != nil {
+			result.Failed = append(result.Failed, keys[i])
+			if firstErr == nil {
+				firstErr = err
+			}
+
+			continue
+		}
+
+		result.Removed += removed
+	}
+
+	return result, firstErr
+}
+
+// retentionMaxScore returns the exclusive score bound of members older than t.
+func retentionMaxScore(t time.Time) string {
+	return "(" + strconv.FormatInt(t.UnixMilli(), 10)
+}
+
+// watchRetention applies policy at its interval until the client is closed.
+func (c *Client) watchRetention(policy RetentionPolicy, done <-chan struct{}) {
+	ticker := time.NewTicker(policy.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			c.applyRetention(policy)
+		}
+	}
+}
+
+func (c *Client) applyRetention(policy RetentionPolicy) {
+	ctx, cancel := context.WithTimeout(context.Background(), min(policy.Interval, retentionRunTimeout))
+	defer cancel()
+
+	result, err := c.ApplyRetention(ctx, policy)
+	if err != nil {
+		c.logger.LogAttrs(
+			ctx,
+			slog.LevelWarn,
+			"redis retention failed",
+			slog.String("match", policy.Match),
+			slog.Int64("removed", result.Removed),
+			slog.Int("failed", len(result.Failed)),
+			slog.String("error", err.Error()),
+		)
+
+		return
+	}
+
+	if result.Removed > 0 {
+		c.logger.LogAttrs(
+			ctx,
+			slog.LevelDebug,
+			"redis retention applied",
+			slog.String("match", policy.Match),
+			slog.Int64("scanned", result.Scanned),
+			slog.Int64("removed", result.Removed),
+		)
+	}
+}
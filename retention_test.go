@@ -0,0 +1,76 @@
+package xredis_test
+
+import (
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Sorted set retention", func() {
+	var client *xredis.Client
+
+	addEvents := func(key string, ages ...time.Duration) {
+		now := time.Now()
+		for i, age := range ages {
+			member := rdb.Z{Score: float64(now.Add(-age).UnixMilli()), Member: i}
+			Expect(client.Raw().ZAdd(ctx, key, member).Err()).To(Succeed())
+		}
+	}
+
+	BeforeEach(func() {
+		client = newTestClient()
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+
+		addEvents("events:a", 2*time.Hour, 90*time.Minute, time.Minute)
+		addEvents("events:b", 3*time.Hour)
+		addEvents("other:c", 3*time.Hour)
+		Expect(client.Raw().Set(ctx, "events:string", "v", 0).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("trims members older than a time", func() {
+		removed, err := client.TrimByScore(ctx, "events:a", time.Now().Add(-time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(removed).To(Equal(int64(2)))
+		Expect(client.Raw().ZCard(ctx, "events:a").Val()).To(Equal(int64(1)))
+	})
+
+	It("applies a policy to matching sorted sets", func() {
+		result, err := client.ApplyRetention(ctx, xredis.RetentionPolicy{Match: "events:*", MaxAge: time.Hour})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(xredis.RetentionResult{Scanned: 2, Removed: 3}))
+
+		Expect(client.Raw().ZCard(ctx, "events:a").Val()).To(Equal(int64(1)))
+		Expect(client.Raw().Exists(ctx, "events:b").Val()).To(BeZero())
+		Expect(client.Raw().ZCard(ctx, "other:c").Val()).To(Equal(int64(1)))
+	})
+
+	It("applies policies in the background", func() {
+		trimming := newTestClient(xredis.WithRetention(xredis.RetentionPolicy{
+			Match:    "events:*",
+			MaxAge:   time.Hour,
+			Interval: 50 * time.Millisecond,
+		}))
+		defer func() {
+			Expect(trimming.Close()).To(Succeed())
+		}()
+
+		Eventually(func() int64 {
+			return client.Raw().ZCard(ctx, "events:a").Val()
+		}).Should(Equal(int64(1)))
+	})
+
+	It("rejects invalid policies", func() {
+		_, err := client.ApplyRetention(ctx, xredis.RetentionPolicy{MaxAge: time.Hour})
+		Expect(err).To(MatchError(xredis.ErrInvalidScan))
+
+		_, err = client.ApplyRetention(ctx, xredis.RetentionPolicy{Match: "events:*"})
+		Expect(err).To(MatchError(xredis.ErrInvalidTTL))
+	})
+})
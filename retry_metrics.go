@@ -0,0 +1,186 @@
+package xredis
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// retryStats collects the failed attempts of one command or pipeline.
+type retryStats struct {
+	mu       sync.Mutex
+	failures int
+	failedAt time.Time
+	delay    time.Duration
+}
+
+// failed records an attempt that go-redis considers for a retry.
+func (s *retryStats) failed(now time.Time) {
+	s.mu.Lock()
+	s.failures++
+	s.failedAt = now
+	s.mu.Unlock()
+}
+
+// attempted records the start of an attempt and adds the time since the
+// previous failure to the retry delay.
+func (s *retryStats) attempted(now time.Time) {
+	s.mu.Lock()
+	if !s.failedAt.IsZero() {
+		s.delay += now.Sub(s.failedAt)
+		s.failedAt = time.Time{}
+	}
+	s.mu.Unlock()
+}
+
+func (s *retryStats) result() (int, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.failures, s.delay
+}
+
+type retryStatsKey struct{}
+
+func retryStatsFrom(ctx context.Context) *retryStats {
+	stats, _ := ctx.Value(retryStatsKey{}).(*retryStats)
+	return stats
+}
+
+// retryMetricsHook records retries of commands after go-redis finished them.
+type retryMetricsHook struct {
+	passDialHook
+
+	metrics *metrics
+}
+
+func (h *retryMetricsHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		// Handshake failures fail the attempt of the command that dialed.
+		if isConnectionSetupCmd(cmd) {
+			return next(ctx, cmd)
+		}
+
+		stats := &retryStats{}
+
+		err := next(context.WithValue(ctx, retryStatsKey{}, stats), cmd)
+		h.record(ctx, cmd.Name(), stats, err)
+
+		return err
+	}
+}
+
+func (h *retryMetricsHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		stats := &retryStats{}
+
+		err := next(context.WithValue(ctx, retryStatsKey{}, stats), cmds)
+		h.record(ctx, commandNamePipeline, stats, err)
+
+		return err
+	}
+}
+
+func (h *retryMetricsHook) record(ctx context.Context, command string, stats *retryStats, err error) {
+	failures, delay := stats.result()
+	if failures == 0 {
+		return
+	}
+
+	errorClass := errorClassNone
+	if err != nil {
+		errorClass = classifyError(err)
+	}
+
+	h.metrics.recordCommandRetries(ctx, command, errorClass, failures, delay)
+}
+
+// retryAttemptHook observes the retry loop of go-redis.
+//
+// It must be installed on the clients that run the loop: the client itself
+// for standalone clients and the node clients of Redis Cluster and Ring
+// clients, so every attempt on the wire is counted once.
+type retryAttemptHook struct {
+	passDialHook
+}
+
+func (retryAttemptHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		stats := retryStatsFrom(ctx)
+		if stats == nil || isConnectionSetupCmd(cmd) {
+			return next(ctx, cmd)
+		}
+
+		return next(ctx, retryObservedCmd{Cmder: cmd, stats: stats})
+	}
+}
+
+func (retryAttemptHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		stats := retryStatsFrom(ctx)
+		if stats == nil || len(cmds) == 0 {
+			return next(ctx, cmds)
+		}
+
+		// go-redis asks the commands of a pipeline in order whether they may be
+		// retried and writes them in order, so the first command observes every
+		// attempt.
+		observed := make([]rdb.Cmder, len(cmds))
+		copy(observed, cmds)
+		observed[0] = retryObservedCmd{Cmder: cmds[0], stats: stats}
+
+		return next(ctx, observed)
+	}
+}
+
+// retryObservedCmd reports attempts of a command to retryStats.
+//
+// go-redis writes the arguments of a command once per attempt, and asks
+// whether a command may be retried once per attempt that failed with a
+// retryable error.
+type retryObservedCmd struct {
+	rdb.Cmder
+
+	stats *retryStats
+}
+
+func (c retryObservedCmd) Args() []any {
+	c.stats.attempted(time.Now())
+	return c.Cmder.Args()
+}
+
+func (c retryObservedCmd) NoRetry() bool {
+	if c.Cmder.NoRetry() {
+		return true
+	}
+
+	c.stats.failed(time.Now())
+
+	return false
+}
+
+// addRetryMetricsHooks installs the hooks recording retries of conn.
+func addRetryMetricsHooks(conn rdb.UniversalClient, m *metrics) {
+	addHook(conn, &retryMetricsHook{metrics: m})
+
+	switch conn := conn.(type) {
+	case *rdb.ClusterClient:
+		conn.OnNewNode(func(node *rdb.Client) {
+			node.AddHook(retryAttemptHook{})
+		})
+	case *rdb.Ring:
+		conn.OnNewNode(func(node *rdb.Client) {
+			node.AddHook(retryAttemptHook{})
+		})
+	}
+}
+
+// addRetryAttemptHook installs retryAttemptHook on a standalone client, right
+// before callOptionsHook.
+func addRetryAttemptHook(conn rdb.UniversalClient, m *metrics) {
+	if _, ok := conn.(*rdb.Client); ok && m != nil {
+		addHook(conn, retryAttemptHook{})
+	}
+}
@@ -0,0 +1,142 @@
+package xredis
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FeatureRollout maps feature names to the percentage of client instances,
+// from 0 to 100, that enable them.
+//
+// It can be loaded from strings such as "auto_pipelining=10,hedging=50",
+// for example from an environment variable, by configuration loaders that
+// support encoding.TextUnmarshaler.
+type FeatureRollout map[string]int
+
+// ParseFeatureRollout parses comma-separated name=percent pairs, such as
+// "auto_pipelining=10,hedging=50". A name without a percentage is enabled on
+// every instance.
+func ParseFeatureRollout(s string) (FeatureRollout, error) {
+	rollout := make(FeatureRollout)
+
+	for pair := range strings.SplitSeq(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, value, found := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: invalid feature rollout %q: missing feature name", ErrInvalidConfig, pair)
+		}
+
+		percent := 100
+		if found {
+			value = strings.TrimSuffix(strings.TrimSpace(value), "%")
+
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 || n > 100 {
+				return nil, fmt.Errorf("%w: invalid feature rollout %q: use a percentage from 0 to 100", ErrInvalidConfig, pair)
+			}
+
+			percent = n
+		}
+
+		rollout[name] = percent
+	}
+
+	return rollout, nil
+}
+
+// String formats the rollout as sorted name=percent pairs.
+func (r FeatureRollout) String() string {
+	pairs := make([]string, 0, len(r))
+	for name, percent := range r {
+		pairs = append(pairs, name+"="+strconv.Itoa(percent))
+	}
+
+	slices.Sort(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r FeatureRollout) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *FeatureRollout) UnmarshalText(text []byte) error {
+	rollout, err := ParseFeatureRollout(string(text))
+	if err != nil {
+		return err
+	}
+
+	*r = rollout
+
+	return nil
+}
+
+// featureGate holds options applied only when the instance is in the
+// rollout of a feature.
+type featureGate struct {
+	name string
+	opts []Option
+}
+
+// featureEnabled reports whether the instance identified by instance falls
+// within the rollout percentage of feature.
+//
+// The bucket hashes the feature name with the instance, so a feature rolled
+// out from 10% to 20% stays enabled on the first 10%, and different features
+// select different instances.
+func featureEnabled(rollout FeatureRollout, feature, instance string) bool {
+	percent, ok := rollout[feature]
+	if !ok || percent <= 0 {
+		return false
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(feature))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(instance))
+
+	return int(h.Sum32()%100) < percent
+}
+
+// applyFeatureGates resolves the features enabled on this instance,
+// identified by the client ID, and applies their gated options.
+func (o *options) applyFeatureGates() {
+	for name := range o.featureRollout {
+		if featureEnabled(o.featureRollout, name, o.clientID) {
+			o.features = append(o.features, name)
+		}
+	}
+
+	slices.Sort(o.features)
+
+	for _, gate := range o.featureGates {
+		if !slices.Contains(o.features, gate.name) {
+			continue
+		}
+
+		for _, opt := range gate.opts {
+			if opt != nil {
+				opt.apply(o)
+			}
+		}
+	}
+}
+
+// FeatureEnabled reports whether the feature is enabled on this client by
+// the rollout of WithFeatureRollout.
+//
+// The decision is made once, when the client is created, so code paths can
+// branch on it for experimental behavior outside of client options.
+func (c *Client) FeatureEnabled(name string) bool {
+	return slices.Contains(c.opts.features, name)
+}
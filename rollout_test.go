@@ -0,0 +1,106 @@
+package xredis_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("Feature rollout", func() {
+	newRolloutClient := func(id string, opts ...xredis.Option) *xredis.Client {
+		client, err := xredis.NewClient(append([]xredis.Option{
+			xredis.WithClientConfig(&xredis.ClientConfig{Addr: redisAddr, DB: testDB}),
+			xredis.WithClientID(id),
+		}, opts...)...)
+		Expect(err).NotTo(HaveOccurred())
+
+		return client
+	}
+
+	It("parses rollouts from text-based configuration", func() {
+		rollout, err := xredis.ParseFeatureRollout(" hedging=50, auto_pipelining=10%,client_side_cache ")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rollout).To(Equal(xredis.FeatureRollout{"hedging": 50, "auto_pipelining": 10, "client_side_cache": 100}))
+		Expect(rollout.String()).To(Equal("auto_pipelining=10,client_side_cache=100,hedging=50"))
+
+		var cfg struct {
+			Features xredis.FeatureRollout `json:"features"`
+		}
+
+		Expect(json.Unmarshal([]byte(`{"features":"hedging=5"}`), &cfg)).To(Succeed())
+		Expect(cfg.Features).To(Equal(xredis.FeatureRollout{"hedging": 5}))
+	})
+
+	DescribeTable("rejects invalid rollouts",
+		func(input string) {
+			_, err := xredis.ParseFeatureRollout(input)
+			Expect(err).To(MatchError(xredis.ErrInvalidConfig))
+		},
+		Entry("missing name", "=10"),
+		Entry("malformed percentage", "hedging=ten"),
+		Entry("percentage above 100", "hedging=101"),
+	)
+
+	It("enables features on their percentage of instances", func() {
+		rollout := xredis.FeatureRollout{"always": 100, "never": 0, "half": 50}
+
+		enabled := 0
+		for i := range 200 {
+			client := newRolloutClient(fmt.Sprintf("instance-%d", i), xredis.WithFeatureRollout(rollout))
+			Expect(client.FeatureEnabled("always")).To(BeTrue())
+			Expect(client.FeatureEnabled("never")).To(BeFalse())
+			Expect(client.FeatureEnabled("unknown")).To(BeFalse())
+
+			if client.FeatureEnabled("half") {
+				enabled++
+			}
+
+			Expect(client.Close()).To(Succeed())
+		}
+
+		Expect(enabled).To(BeNumerically("~", 100, 30))
+	})
+
+	It("keeps selected instances when the percentage grows", func() {
+		for i := range 100 {
+			id := fmt.Sprintf("instance-%d", i)
+
+			small := newRolloutClient(id, xredis.WithFeatureRollout(xredis.FeatureRollout{"hedging": 10}))
+			large := newRolloutClient(id, xredis.WithFeatureRollout(xredis.FeatureRollout{"hedging": 40}))
+
+			if small.FeatureEnabled("hedging") {
+				Expect(large.FeatureEnabled("hedging")).To(BeTrue())
+			}
+
+			Expect(small.Close()).To(Succeed())
+			Expect(large.Close()).To(Succeed())
+		}
+	})
+
+	It("applies gated options only where the feature is enabled", func() {
+		gated := func(percent int) *xredis.Client {
+			return newRolloutClient("rollout-instance",
+				xredis.WithFeatureRollout(xredis.FeatureRollout{"read_only": percent}),
+				xredis.WithFeature("read_only", xredis.WithReadOnlyMode(true)),
+			)
+		}
+
+		client := gated(0)
+		Expect(client.Set(ctx, "rollout:key", "value", 0)).To(Succeed())
+		Expect(client.Close()).To(Succeed())
+
+		client = gated(100)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Set(ctx, "rollout:key", "changed", 0)).To(Succeed())
+
+		value, _, err := client.String(ctx, "rollout:key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal("value"))
+	})
+})
@@ -164,10 +164,13 @@ func (c *Client) ScanEachBatch(ctx context.Context, opts ScanOptions, fn ScanBat
 //
 // For Redis Cluster and Ring clients, deletion is executed as pipelined
 // single-key DEL commands to avoid multi-key hash-slot constraints.
-func (c *Client) ScanDelete(ctx context.Context, opts ScanOptions) error {
-	return c.ScanEachBatch(ctx, opts, func(ctx context.Context, keys []string) error {
-		return c.DeleteMany(ctx, keys)
-	})
+//
+// Failed deletions do not stop the scan. The result accumulates deleted and
+// failed keys over the whole scan, and the first deletion error is returned
+// after the scan completes. Scan errors stop the scan immediately. Namespace
+// quotas are released as for DeleteMany.
+func (c *Client) ScanDelete(ctx context.Context, opts ScanOptions) (DeleteResult, error) {
+	return c.scanRemove(ctx, opts, c.DeleteMany)
 }
 
 // ScanUnlink unlinks all Redis keys matching options using UNLINK.
@@ -177,10 +180,41 @@ func (c *Client) ScanDelete(ctx context.Context, opts ScanOptions) error {
 //
 // For Redis Cluster and Ring clients, unlinking is executed as pipelined
 // single-key UNLINK commands to avoid multi-key hash-slot constraints.
-func (c *Client) ScanUnlink(ctx context.Context, opts ScanOptions) error {
-	return c.ScanEachBatch(ctx, opts, func(ctx context.Context, keys []string) error {
-		return c.UnlinkMany(ctx, keys)
+//
+// The result is reported like ScanDelete.
+func (c *Client) ScanUnlink(ctx context.Context, opts ScanOptions) (DeleteResult, error) {
+	return c.scanRemove(ctx, opts, c.UnlinkMany)
+}
+
+func (c *Client) scanRemove(
+	ctx context.Context,
+	opts ScanOptions,
+	remove func(context.Context, []string) (DeleteResult, error),
+) (DeleteResult, error) {
+	var (
+		mu        sync.Mutex
+		result    DeleteResult
+		removeErr error
+	)
+
+	err := c.ScanEachBatch(ctx, opts, func(ctx context.Context, keys []string) error {
+		batch, err := remove(ctx, keys)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		result.add(batch)
+		if err != nil && removeErr == nil {
+			removeErr = err
+		}
+
+		return nil
 	})
+	if err != nil {
+		return result, err
+	}
+
+	return result, removeErr
 }
 
 type keyScanner interface {
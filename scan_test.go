@@ -73,7 +73,7 @@ var _ = Describe("Scan", func() {
 				"scan:type:hash",
 				0,
 				"field", "value",
-			)).To(Succeed())
+			)).Error().To(Succeed())
 
 			_, err := client.Raw().XAdd(ctx, &rdb.XAddArgs{
 				Stream: "scan:type:stream",
@@ -289,10 +289,13 @@ var _ = Describe("Scan", func() {
 				Expect(client.Set(ctx, key, "value", 0)).To(Succeed())
 			}
 
-			Expect(client.ScanDelete(ctx, xredis.ScanOptions{
+			result, err := client.ScanDelete(ctx, xredis.ScanOptions{
 				Match: "scan:delete:*",
 				Count: 1,
-			})).To(Succeed())
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Deleted).To(Equal(int64(3)))
+			Expect(result.Failed).To(BeEmpty())
 
 			for _, key := range []string{
 				"scan:delete:1",
@@ -321,10 +324,13 @@ var _ = Describe("Scan", func() {
 				Expect(client.Set(ctx, key, "value", 0)).To(Succeed())
 			}
 
-			Expect(client.ScanUnlink(ctx, xredis.ScanOptions{
+			result, err := client.ScanUnlink(ctx, xredis.ScanOptions{
 				Match: "scan:unlink:*",
 				Count: 1,
-			})).To(Succeed())
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Deleted).To(Equal(int64(3)))
+			Expect(result.Failed).To(BeEmpty())
 
 			for _, key := range []string{
 				"scan:unlink:1",
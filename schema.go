@@ -0,0 +1,187 @@
+package xredis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const hashSchemaKeyPrefix = "xredis:schema:"
+
+// hashSchemaUpdateScript stores a hash schema unless a newer version is
+// already stored.
+//
+// KEYS[1] - schema key
+// ARGV[1] - schema version
+// ARGV[2] - schema hash
+// ARGV[3] - JSON-encoded field types
+var hashSchemaUpdateScript = rdb.NewScript(`
+local stored = tonumber(redis.call("HGET", KEYS[1], "version"))
+if stored and stored > tonumber(ARGV[1]) then
+	return 0
+end
+
+redis.call("HSET", KEYS[1], "version", ARGV[1], "hash", ARGV[2], "fields", ARGV[3])
+
+return 1
+`)
+
+// HashSchema registers a struct type used with HSet and HGetAll under a name
+// and version.
+type HashSchema struct {
+	// Name identifies the schema, usually the key namespace of the hashes.
+	Name string
+
+	// Version is the schema version. Clients with an older version than the
+	// stored one do not overwrite the stored schema.
+	Version int
+
+	// Type is a struct value or pointer of the registered type, such as
+	// User{} or (*User)(nil).
+	Type any
+}
+
+// hashSchemaFields maps Redis hash field names to type classes.
+type hashSchemaFields map[string]string
+
+// newHashSchemaFields returns the hash fields of the struct type of v.
+//
+// Fields are named by their "redis" tag, like HSet maps structs, including the
+// fields promoted from embedded structs. Types are
+// reduced to classes that scan into each other, so changing int to int64 is
+// compatible while changing int to string is not.
+func newHashSchemaFields(v any) (hashSchemaFields, error) {
+	typ := reflect.TypeOf(v)
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: schema type %v is not a struct", ErrInvalidHashObject, typ)
+	}
+
+	fields := make(hashSchemaFields)
+	for _, field := range hashStructOf(typ).fields {
+		fields[field.name] = hashFieldClass(field.typ)
+	}
+
+	return fields, nil
+}
+
+func hashFieldClass(typ reflect.Type) string {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "uint"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	default:
+		return typ.String()
+	}
+}
+
+// hash returns a stable digest of the fields.
+func (f hashSchemaFields) hash() string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	digest := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(digest, "%s=%s\n", name, f[name])
+	}
+
+	return hex.EncodeToString(digest.Sum(nil)[:8])
+}
+
+// incompatible returns the fields that both schemas contain with different
+// type classes, sorted by name.
+func (f hashSchemaFields) incompatible(stored hashSchemaFields) []string {
+	var changed []string
+
+	for name, class := range f {
+		if storedClass, ok := stored[name]; ok && storedClass != class {
+			changed = append(changed, fmt.Sprintf("%s (%s -> %s)", name, storedClass, class))
+		}
+	}
+
+	sort.Strings(changed)
+
+	return changed
+}
+
+// checkHashSchemas compares registered hash schemas with the schemas stored
+// in Redis and stores new or compatible schemas.
+func (c *Client) checkHashSchemas(ctx context.Context, schemas []HashSchema) error {
+	for _, schema := range schemas {
+		if err := c.checkHashSchema(ctx, schema); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) checkHashSchema(ctx context.Context, schema HashSchema) error {
+	if schema.Name == "" {
+		return fmt.Errorf("%w: schema name is required", ErrInvalidHashObject)
+	}
+
+	fields, err := newHashSchemaFields(schema.Type)
+	if err != nil {
+		return err
+	}
+
+	key := hashSchemaKeyPrefix + schema.Name
+
+	stored, err := c.conn.HGetAll(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	hash := fields.hash()
+	if stored["hash"] == hash {
+		return nil
+	}
+
+	if raw, ok := stored["fields"]; ok {
+		var storedFields hashSchemaFields
+		if err = json.Unmarshal([]byte(raw), &storedFields); err != nil {
+			return fmt.Errorf("%w: schema %q: %w", ErrInvalidEntry, schema.Name, err)
+		}
+
+		if changed := fields.incompatible(storedFields); len(changed) > 0 {
+			return fmt.Errorf(
+				"%w: schema %q version %d changes field types of stored version %s: %s",
+				ErrIncompatibleSchema, schema.Name, schema.Version, stored["version"], strings.Join(changed, ", "),
+			)
+		}
+	}
+
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	return hashSchemaUpdateScript.Run(ctx, c.conn, []string{key}, strconv.Itoa(schema.Version), hash, encoded).Err()
+}
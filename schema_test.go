@@ -0,0 +1,84 @@
+package xredis_test
+
+import (
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+type schemaUserV1 struct {
+	Name  string `redis:"name"`
+	Age   int    `redis:"age"`
+	Notes string
+}
+
+type schemaUserV2 struct {
+	Name  string `redis:"name"`
+	Age   int64  `redis:"age"`
+	Email string `redis:"email,omitempty"`
+}
+
+type schemaUserV3 struct {
+	Name string `redis:"name"`
+	Age  string `redis:"age"`
+}
+
+var _ = Describe("Hash schemas", func() {
+	BeforeEach(func() {
+		client := newTestClient()
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	It("stores new schemas and accepts compatible changes", func() {
+		client := newTestClient(xredis.WithHashSchemas(xredis.HashSchema{Name: "user", Version: 1, Type: schemaUserV1{}}))
+		Expect(client.Close()).To(Succeed())
+
+		client = newTestClient(xredis.WithHashSchemas(xredis.HashSchema{Name: "user", Version: 2, Type: (*schemaUserV2)(nil)}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		version, ok, err := client.HGet(ctx, "xredis:schema:user", "version")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(version).To(Equal("2"))
+	})
+
+	It("rejects field type changes at startup", func() {
+		client := newTestClient(xredis.WithHashSchemas(xredis.HashSchema{Name: "user", Version: 1, Type: schemaUserV1{}}))
+		Expect(client.Close()).To(Succeed())
+
+		_, err := xredis.NewClient(
+			xredis.WithClientConfig(&xredis.ClientConfig{Addr: redisAddr, DB: testDB}),
+			xredis.WithHashSchemas(xredis.HashSchema{Name: "user", Version: 3, Type: schemaUserV3{}}),
+		)
+		Expect(err).To(MatchError(xredis.ErrIncompatibleSchema))
+		Expect(err).To(MatchError(ContainSubstring("age (int -> string)")))
+	})
+
+	It("does not overwrite newer stored versions", func() {
+		client := newTestClient(xredis.WithHashSchemas(xredis.HashSchema{Name: "user", Version: 2, Type: schemaUserV2{}}))
+		Expect(client.Close()).To(Succeed())
+
+		client = newTestClient(xredis.WithHashSchemas(xredis.HashSchema{Name: "user", Version: 1, Type: schemaUserV1{}}))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		version, _, err := client.HGet(ctx, "xredis:schema:user", "version")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(version).To(Equal("2"))
+	})
+
+	It("rejects invalid schemas", func() {
+		_, err := xredis.NewClient(
+			xredis.WithClientConfig(&xredis.ClientConfig{Addr: redisAddr, DB: testDB}),
+			xredis.WithHashSchemas(xredis.HashSchema{Name: "user", Version: 1, Type: "not a struct"}),
+		)
+		Expect(err).To(MatchError(xredis.ErrInvalidHashObject))
+	})
+})
@@ -0,0 +1,100 @@
+package xredis
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// scriptSource is a set of Lua scripts registered with WithScripts.
+type scriptSource struct {
+	fsys fs.FS
+	glob string
+}
+
+// loadScripts reads the scripts of sources and returns them by file name.
+func loadScripts(sources []scriptSource) (map[string]*rdb.Script, error) {
+	scripts := make(map[string]*rdb.Script)
+
+	for _, source := range sources {
+		if source.fsys == nil {
+			return nil, fmt.Errorf("%w: scripts %q: file system is required", ErrInvalidConfig, source.glob)
+		}
+
+		names, err := fs.Glob(source.fsys, source.glob)
+		if err != nil {
+			return nil, fmt.Errorf("%w: scripts %q: %w", ErrInvalidConfig, source.glob, err)
+		}
+
+		if len(names) == 0 {
+			return nil, fmt.Errorf("%w: scripts %q match no files", ErrInvalidConfig, source.glob)
+		}
+
+		for _, name := range names {
+			body, err := fs.ReadFile(source.fsys, name)
+			if err != nil {
+				return nil, fmt.Errorf("%w: script %q: %w", ErrInvalidConfig, name, err)
+			}
+
+			base := path.Base(name)
+			if _, ok := scripts[base]; ok {
+				return nil, fmt.Errorf("%w: script %q is registered twice", ErrInvalidConfig, base)
+			}
+
+			scripts[base] = rdb.NewScript(string(body))
+		}
+	}
+
+	return scripts, nil
+}
+
+// preloadScripts loads scripts into the script cache of every master, so the
+// first EVALSHA of each script does not fall back to EVAL.
+func (c *Client) preloadScripts(ctx context.Context, scripts map[string]*rdb.Script) error {
+	if len(scripts) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(scripts))
+	for name := range scripts {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	load := func(ctx context.Context, client rdb.Cmdable) error {
+		for _, name := range names {
+			if err := scripts[name].Load(ctx, client).Err(); err != nil {
+				return fmt.Errorf("load script %q: %w", name, err)
+			}
+		}
+
+		return nil
+	}
+
+	forEachNode := func(ctx context.Context, client *rdb.Client) error {
+		return load(ctx, client)
+	}
+
+	switch conn := c.conn.(type) {
+	case *rdb.ClusterClient:
+		return conn.ForEachMaster(ctx, forEachNode)
+	case *rdb.Ring:
+		return conn.ForEachShard(ctx, forEachNode)
+	default:
+		return load(ctx, conn)
+	}
+}
+
+// Script returns the Lua script registered with WithScripts under its file
+// name, such as "rate_limit.lua", or nil when no script has the name.
+//
+// Scripts run with EVALSHA and fall back to EVAL when a node lost its script
+// cache, for example after a restart or failover.
+func (c *Client) Script(name string) *rdb.Script {
+	return c.scripts[name]
+}
@@ -0,0 +1,74 @@
+package xredis_test
+
+import (
+	"testing/fstest"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("WithScripts", func() {
+	scripts := fstest.MapFS{
+		"scripts/incr_by.lua": {Data: []byte(`return redis.call("INCRBY", KEYS[1], ARGV[1])`)},
+		"scripts/echo.lua":    {Data: []byte(`return ARGV[1]`)},
+		"scripts/README.md":   {Data: []byte(`# Scripts`)},
+	}
+
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		cleanup := newTestClient()
+		Expect(cleanup.Raw().ScriptFlush(ctx).Err()).To(Succeed())
+		Expect(cleanup.Raw().Del(ctx, "scripts:counter").Err()).To(Succeed())
+		Expect(cleanup.Close()).To(Succeed())
+
+		client = newTestClient(xredis.WithScripts(scripts, "scripts/*.lua"))
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("preloads the matching scripts", func() {
+		incrBy := client.Script("incr_by.lua")
+		Expect(incrBy).NotTo(BeNil())
+		Expect(client.Script("echo.lua")).NotTo(BeNil())
+		Expect(client.Script("README.md")).To(BeNil())
+
+		exists, err := client.Raw().ScriptExists(ctx, incrBy.Hash(), client.Script("echo.lua").Hash()).Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(Equal([]bool{true, true}))
+
+		value, err := incrBy.EvalSha(ctx, client.Raw(), []string{"scripts:counter"}, 2).Int64()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal(int64(2)))
+	})
+
+	It("reloads scripts lost from the script cache", func() {
+		Expect(client.Raw().ScriptFlush(ctx).Err()).To(Succeed())
+
+		value, err := client.Script("echo.lua").Run(ctx, client.Raw(), nil, "hello").Text()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal("hello"))
+	})
+
+	It("fails construction with invalid scripts", func() {
+		for _, opt := range []xredis.Option{
+			xredis.WithScripts(scripts, "lua/*.lua"),
+			xredis.WithScripts(scripts, "scripts/[.lua"),
+			xredis.WithScripts(nil, "*.lua"),
+			xredis.WithScripts(fstest.MapFS{
+				"a/echo.lua": {Data: []byte(`return 1`)},
+				"b/echo.lua": {Data: []byte(`return 2`)},
+			}, "*/*.lua"),
+		} {
+			_, err := xredis.NewClient(
+				xredis.WithClientConfig(&xredis.ClientConfig{Addr: redisAddr, DB: testDB, DialTimeout: 5 * time.Second}),
+				opt,
+			)
+			Expect(err).To(MatchError(xredis.ErrInvalidConfig))
+		}
+	})
+})
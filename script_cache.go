@@ -0,0 +1,216 @@
+package xredis
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // Redis identifies scripts by SHA1.
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultScriptResultCacheTTL        = time.Second
+	defaultScriptResultCacheMaxEntries = 1024
+)
+
+// ScriptResultCacheConfig configures the client-side cache of script and
+// function results.
+type ScriptResultCacheConfig struct {
+	// Scripts contains the cacheable scripts and functions: SHA1 digests of
+	// script bodies, as returned by Script.Hash, and function names.
+	//
+	// Only list scripts and functions whose result depends on nothing but
+	// their keys and arguments, and that do not write.
+	Scripts []string
+
+	// TTL defines how long a result is reused.
+	//
+	// Zero uses 1 second.
+	TTL time.Duration
+
+	// MaxEntries limits the number of cached results.
+	//
+	// Zero uses 1024.
+	MaxEntries int
+}
+
+func normalizeScriptResultCacheConfig(cfg ScriptResultCacheConfig) ScriptResultCacheConfig {
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultScriptResultCacheTTL
+	}
+
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = defaultScriptResultCacheMaxEntries
+	}
+
+	return cfg
+}
+
+type scriptResult struct {
+	value   any
+	expires time.Time
+}
+
+// scriptResultCache caches results of EVAL, EVALSHA, and FCALL commands and
+// their read-only variants by script and arguments.
+type scriptResultCache struct {
+	cfg     ScriptResultCacheConfig
+	scripts map[string]struct{}
+
+	mu      sync.Mutex
+	results map[string]scriptResult
+	group   singleflight.Group
+}
+
+func newScriptResultCache(cfg ScriptResultCacheConfig) *scriptResultCache {
+	scripts := make(map[string]struct{}, len(cfg.Scripts))
+	for _, script := range cfg.Scripts {
+		scripts[script] = struct{}{}
+		scripts[strings.ToLower(script)] = struct{}{}
+	}
+
+	return &scriptResultCache{
+		cfg:     cfg,
+		scripts: scripts,
+		results: make(map[string]scriptResult),
+	}
+}
+
+// key returns the cache key of cmd, or ok=false when cmd is not a call of a
+// cacheable script or function.
+func (c *scriptResultCache) key(cmd rdb.Cmder) (string, bool) {
+	args := cmd.Args()
+	if len(args) < 2 {
+		return "", false
+	}
+
+	first, ok := args[1].(string)
+	if !ok {
+		return "", false
+	}
+
+	var script string
+
+	switch cmd.Name() {
+	case "eval", "eval_ro":
+		sum := sha1.Sum([]byte(first)) //nolint:gosec // Redis identifies scripts by SHA1.
+		script = hex.EncodeToString(sum[:])
+	case "evalsha", "evalsha_ro":
+		script = strings.ToLower(first)
+	case "fcall", "fcall_ro":
+		script = first
+	default:
+		return "", false
+	}
+
+	if _, ok = c.scripts[script]; !ok {
+		return "", false
+	}
+
+	var b strings.Builder
+
+	b.WriteString(script)
+
+	for _, arg := range args[2:] {
+		value := fmt.Sprint(arg)
+		b.WriteByte(0)
+		b.WriteString(strconv.Itoa(len(value)))
+		b.WriteByte(':')
+		b.WriteString(value)
+	}
+
+	return b.String(), true
+}
+
+func (c *scriptResultCache) get(key string, now time.Time) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result, ok := c.results[key]
+	if !ok || !now.Before(result.expires) {
+		return nil, false
+	}
+
+	return result.value, true
+}
+
+func (c *scriptResultCache) put(key string, value any, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.results) >= c.cfg.MaxEntries {
+		for k, result := range c.results {
+			if !now.Before(result.expires) {
+				delete(c.results, k)
+			}
+		}
+	}
+
+	// Without expired results to drop, drop an arbitrary one.
+	for k := range c.results {
+		if len(c.results) < c.cfg.MaxEntries {
+			break
+		}
+
+		delete(c.results, k)
+	}
+
+	c.results[key] = scriptResult{value: value, expires: now.Add(c.cfg.TTL)}
+}
+
+// scriptResultCacheHook serves repeated script calls from the cache.
+//
+// Concurrent identical calls share one Redis round trip. Errors are not
+// cached, and pipelines are never served from the cache.
+type scriptResultCacheHook struct {
+	passDialHook
+
+	cache *scriptResultCache
+}
+
+func (h *scriptResultCacheHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		call, ok := cmd.(*rdb.Cmd)
+		if !ok {
+			return next(ctx, cmd)
+		}
+
+		key, ok := h.cache.key(cmd)
+		if !ok {
+			return next(ctx, cmd)
+		}
+
+		if value, ok := h.cache.get(key, time.Now()); ok {
+			call.SetVal(value)
+			return nil
+		}
+
+		value, err, _ := h.cache.group.Do(key, func() (any, error) {
+			if err := next(ctx, call); err != nil {
+				return nil, err
+			}
+
+			h.cache.put(key, call.Val(), time.Now())
+
+			return call.Val(), nil
+		})
+		if err != nil {
+			call.SetErr(err)
+			return err
+		}
+
+		call.SetVal(value)
+
+		return nil
+	}
+}
+
+func (*scriptResultCacheHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return next
+}
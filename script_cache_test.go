@@ -0,0 +1,67 @@
+package xredis_test
+
+import (
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Script result cache", func() {
+	var (
+		client *xredis.Client
+		script *rdb.Script
+	)
+
+	BeforeEach(func() {
+		script = rdb.NewScript(`return redis.call("GET", KEYS[1])`)
+
+		client = newTestClient(xredis.WithScriptResultCache(xredis.ScriptResultCacheConfig{
+			Scripts: []string{script.Hash()},
+			TTL:     200 * time.Millisecond,
+		}))
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("reuses results of identical calls until they expire", func() {
+		raw := client.Raw()
+		Expect(raw.Set(ctx, "script:key", "first", 0).Err()).To(Succeed())
+		Expect(script.Run(ctx, raw, []string{"script:key"}).Text()).To(Equal("first"))
+
+		Expect(raw.Set(ctx, "script:key", "second", 0).Err()).To(Succeed())
+		Expect(script.Run(ctx, raw, []string{"script:key"}).Text()).To(Equal("first"))
+
+		Eventually(func() (string, error) {
+			return script.Run(ctx, raw, []string{"script:key"}).Text()
+		}).Should(Equal("second"))
+	})
+
+	It("keys results by arguments", func() {
+		raw := client.Raw()
+		Expect(raw.Set(ctx, "script:a", "a", 0).Err()).To(Succeed())
+		Expect(raw.Set(ctx, "script:b", "b", 0).Err()).To(Succeed())
+
+		Expect(script.Run(ctx, raw, []string{"script:a"}).Text()).To(Equal("a"))
+		Expect(script.Run(ctx, raw, []string{"script:b"}).Text()).To(Equal("b"))
+	})
+
+	It("does not cache unlisted scripts or errors", func() {
+		raw := client.Raw()
+		other := rdb.NewScript(`return redis.call("GET", KEYS[1])  `)
+
+		Expect(raw.Set(ctx, "script:key", "first", 0).Err()).To(Succeed())
+		Expect(other.Run(ctx, raw, []string{"script:key"}).Text()).To(Equal("first"))
+		Expect(raw.Set(ctx, "script:key", "second", 0).Err()).To(Succeed())
+		Expect(other.Run(ctx, raw, []string{"script:key"}).Text()).To(Equal("second"))
+
+		Expect(script.Run(ctx, raw, []string{"script:missing"}).Err()).To(Equal(rdb.Nil))
+		Expect(raw.Set(ctx, "script:missing", "set", 0).Err()).To(Succeed())
+		Expect(script.Run(ctx, raw, []string{"script:missing"}).Text()).To(Equal("set"))
+	})
+})
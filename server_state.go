@@ -0,0 +1,225 @@
+package xredis
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultBusyMaxRetries = 5
+	defaultBusyMinBackoff = 100 * time.Millisecond
+	defaultBusyMaxBackoff = 2 * time.Second
+)
+
+// BusyRetryConfig configures retries of commands that Redis rejected with
+// BUSY, while it runs a slow script, or LOADING, while it loads its dataset.
+type BusyRetryConfig struct {
+	// MaxRetries is the number of retries of a rejected command.
+	//
+	// Zero uses 5. A negative value disables the retries.
+	MaxRetries int
+
+	// MinBackoff is the delay before the first retry, doubled for every
+	// following retry.
+	//
+	// Zero uses 100 milliseconds.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the delay between retries.
+	//
+	// Zero uses 2 seconds.
+	MaxBackoff time.Duration
+}
+
+func normalizeBusyRetryConfig(cfg BusyRetryConfig) BusyRetryConfig {
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = defaultBusyMaxRetries
+	}
+
+	if cfg.MinBackoff <= 0 {
+		cfg.MinBackoff = defaultBusyMinBackoff
+	}
+
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaultBusyMaxBackoff
+	}
+
+	cfg.MaxBackoff = max(cfg.MaxBackoff, cfg.MinBackoff)
+
+	return cfg
+}
+
+// backoff returns the delay before retry attempt, starting at zero.
+func (cfg BusyRetryConfig) backoff(attempt int) time.Duration {
+	delay := cfg.MinBackoff
+	for range attempt {
+		if delay >= cfg.MaxBackoff/2 {
+			return cfg.MaxBackoff
+		}
+
+		delay *= 2
+	}
+
+	return delay
+}
+
+// Server states reported by serverState.
+const (
+	serverStateAvailable int32 = iota
+	serverStateBusy
+	serverStateLoading
+)
+
+// serverState tracks whether Redis rejects commands with BUSY or LOADING,
+// according to the latest reply.
+type serverState struct {
+	state atomic.Int32
+}
+
+// observe updates the state from the result of a command and returns the
+// state the result indicates.
+func (s *serverState) observe(err error) int32 {
+	state := serverStateOf(err)
+
+	// Other errors, such as network errors, say nothing about the state.
+	if err == nil || state != serverStateAvailable {
+		s.state.Store(state)
+	}
+
+	return state
+}
+
+func (s *serverState) unavailable() bool {
+	return s.state.Load() != serverStateAvailable
+}
+
+func (s *serverState) is(state int32) bool {
+	return s.state.Load() == state
+}
+
+func serverStateOf(err error) int32 {
+	class, ok := classifyServerError(err)
+	if !ok {
+		return serverStateAvailable
+	}
+
+	switch class {
+	case errorClassBusy:
+		return serverStateBusy
+	case errorClassLoading:
+		return serverStateLoading
+	default:
+		return serverStateAvailable
+	}
+}
+
+// busyRetryHook retries commands rejected with BUSY or LOADING with the
+// schedule of BusyRetryConfig and tracks the server state.
+//
+// go-redis retries LOADING with its regular backoff, which exhausts its
+// retries within milliseconds. While the server is known to be unavailable and
+// retries are enabled, commands are sent without go-redis retries and the hook
+// retries them.
+type busyRetryHook struct {
+	passDialHook
+
+	state *serverState
+	cfg   BusyRetryConfig
+}
+
+func (h *busyRetryHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		if isConnectionSetupCmd(cmd) {
+			return next(ctx, cmd)
+		}
+
+		for attempt := 0; ; attempt++ {
+			sent := cmd
+			if h.cfg.MaxRetries > 0 && h.state.unavailable() {
+				sent = noRetryCmd{Cmder: cmd}
+			}
+
+			err := next(ctx, sent)
+			if h.state.observe(err) == serverStateAvailable || !h.retry(ctx, attempt) {
+				return err
+			}
+
+			cmd.SetErr(nil)
+		}
+	}
+}
+
+func (h *busyRetryHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		if len(cmds) == 0 || isConnectionSetupCmd(cmds[0]) {
+			return next(ctx, cmds)
+		}
+
+		for attempt := 0; ; attempt++ {
+			sent := cmds
+			if h.cfg.MaxRetries > 0 && h.state.unavailable() {
+				// go-redis does not retry a pipeline that contains a
+				// non-retryable command.
+				sent = make([]rdb.Cmder, len(cmds))
+				copy(sent, cmds)
+				sent[0] = noRetryCmd{Cmder: cmds[0]}
+			}
+
+			err := next(ctx, sent)
+
+			state := h.state.observe(err)
+			if state == serverStateAvailable || !allRejected(cmds, state) || !h.retry(ctx, attempt) {
+				return err
+			}
+
+			for _, cmd := range cmds {
+				cmd.SetErr(nil)
+			}
+		}
+	}
+}
+
+// retry waits before retry attempt and reports whether the command should be
+// retried.
+func (h *busyRetryHook) retry(ctx context.Context, attempt int) bool {
+	if attempt >= h.cfg.MaxRetries || !callOptionsFrom(ctx).retryable() {
+		return false
+	}
+
+	timer := time.NewTimer(h.cfg.backoff(attempt))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// allRejected reports whether every command of a pipeline was rejected with
+// state, so none of them was applied and the pipeline can be sent again.
+func allRejected(cmds []rdb.Cmder, state int32) bool {
+	for _, cmd := range cmds {
+		if serverStateOf(cmd.Err()) != state {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ServerBusy reports whether the latest reply of Redis was a BUSY error,
+// because it runs a slow script.
+func (c *Client) ServerBusy() bool {
+	return c.server.is(serverStateBusy)
+}
+
+// ServerLoading reports whether the latest reply of Redis was a LOADING
+// error, because it loads its dataset into memory.
+func (c *Client) ServerLoading() bool {
+	return c.server.is(serverStateLoading)
+}
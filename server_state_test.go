@@ -0,0 +1,72 @@
+package xredis_test
+
+import (
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("BUSY and LOADING retries", func() {
+	busyReply := xredis.RecordedCommand{
+		Args:  recordedArgs("get", "busy:key"),
+		Reply: []byte("-BUSY Redis is busy running a script. You can only call SCRIPT KILL or SHUTDOWN NOSAVE.\r\n"),
+	}
+
+	loadingReply := xredis.RecordedCommand{
+		Args:  recordedArgs("get", "busy:key"),
+		Reply: []byte("-LOADING Redis is loading the dataset in memory\r\n"),
+	}
+
+	valueReply := xredis.RecordedCommand{
+		Args:  recordedArgs("get", "busy:key"),
+		Reply: []byte("$5\r\nready\r\n"),
+	}
+
+	retries := xredis.WithBusyRetry(xredis.BusyRetryConfig{
+		MaxRetries: 2,
+		MinBackoff: time.Millisecond,
+	})
+
+	It("retries commands until the server is available", func() {
+		replay, err := xredis.NewReplayClient(groupRecording(busyReply, loadingReply, valueReply), retries)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(replay.Close()).To(Succeed())
+		}()
+
+		value, ok, err := replay.String(ctx, "busy:key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("ready"))
+
+		Expect(replay.ServerBusy()).To(BeFalse())
+		Expect(replay.ServerLoading()).To(BeFalse())
+	})
+
+	It("reports the state after the retries are exhausted", func() {
+		replay, err := xredis.NewReplayClient(groupRecording(busyReply, busyReply, loadingReply), retries)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(replay.Close()).To(Succeed())
+		}()
+
+		_, _, err = replay.String(ctx, "busy:key")
+		Expect(err).To(MatchError(HavePrefix("LOADING")))
+		Expect(replay.ServerLoading()).To(BeTrue())
+		Expect(replay.ServerBusy()).To(BeFalse())
+	})
+
+	It("does not retry commands sent with NoRetry", func() {
+		replay, err := xredis.NewReplayClient(groupRecording(busyReply, valueReply), retries)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(replay.Close()).To(Succeed())
+		}()
+
+		_, _, err = replay.String(xredis.WithCallOptions(ctx, xredis.NoRetry()), "busy:key")
+		Expect(err).To(MatchError(HavePrefix("BUSY")))
+		Expect(replay.ServerBusy()).To(BeTrue())
+	})
+})
@@ -0,0 +1,311 @@
+package xredis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// KeyDigest identifies the content of one Redis key without storing it.
+type KeyDigest struct {
+	// Type is the Redis type of the key, such as "string" or "hash".
+	Type string
+
+	// Hash is a hex-encoded SHA-256 digest of the canonical key content.
+	// Hash fields and set members are sorted, so the digest does not depend
+	// on the server-side encoding.
+	Hash string
+}
+
+// KeyspaceSnapshot contains the digests of all keys matching a pattern.
+type KeyspaceSnapshot struct {
+	// Pattern is the SCAN pattern of the snapshot.
+	Pattern string
+
+	// TakenAt is the time when the snapshot started.
+	TakenAt time.Time
+
+	// Keys maps key names to their digests.
+	Keys map[string]KeyDigest
+}
+
+// SnapshotDiff reports the differences between two keyspace snapshots.
+// Key lists are sorted.
+type SnapshotDiff struct {
+	// Added contains keys present only in the second snapshot.
+	Added []string
+
+	// Removed contains keys present only in the first snapshot.
+	Removed []string
+
+	// Changed contains keys whose type or content differ.
+	Changed []string
+}
+
+// Empty reports whether the snapshots are equal.
+func (d SnapshotDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Snapshot scans keys matching pattern and records a digest of each key
+// instead of its value, for verifying migrations and dual writes with Diff.
+//
+// An empty pattern matches all keys. Values are read with type-specific
+// commands in pipelined batches, so snapshots of large keyspaces are
+// expensive; prefer a staging environment or a narrow pattern. The snapshot is
+// not atomic: keys changed during the scan may be recorded before or after the
+// change.
+func (c *Client) Snapshot(ctx context.Context, pattern string) (*KeyspaceSnapshot, error) {
+	snapshot := &KeyspaceSnapshot{
+		Pattern: pattern,
+		TakenAt: time.Now(),
+		Keys:    make(map[string]KeyDigest),
+	}
+
+	var mu sync.Mutex
+
+	err := c.ScanEachBatch(ctx, ScanOptions{Match: pattern}, func(ctx context.Context, keys []string) error {
+		digests, err := c.digestKeys(ctx, keys)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		for key, digest := range digests {
+			snapshot.Keys[key] = digest
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// Diff compares snapshot a with the later or other-side snapshot b.
+func Diff(a, b *KeyspaceSnapshot) SnapshotDiff {
+	var diff SnapshotDiff
+
+	for key, digest := range a.Keys {
+		other, ok := b.Keys[key]
+		switch {
+		case !ok:
+			diff.Removed = append(diff.Removed, key)
+		case other != digest:
+			diff.Changed = append(diff.Changed, key)
+		}
+	}
+
+	for key := range b.Keys {
+		if _, ok := a.Keys[key]; !ok {
+			diff.Added = append(diff.Added, key)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Changed)
+
+	return diff
+}
+
+// digestKeys returns the digests of existing keys.
+//
+// Keys that do not exist are omitted.
+func (c *Client) digestKeys(ctx context.Context, keys []string) (map[string]KeyDigest, error) {
+	typePipe := c.conn.Pipeline()
+
+	types := make([]*rdb.StatusCmd, len(keys))
+	for i, key := range keys {
+		types[i] = typePipe.Type(ctx, key)
+	}
+
+	if _, err := typePipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	valuePipe := c.conn.Pipeline()
+
+	values := make([]rdb.Cmder, len(keys))
+	for i, key := range keys {
+		values[i] = queueKeyContent(ctx, valuePipe, key, types[i].Val())
+	}
+
+	if valuePipe.Len() > 0 {
+		// Keys removed between TYPE and the read report redis.Nil and are
+		// checked per command below.
+		if _, err := valuePipe.Exec(ctx); err != nil && !isPipelineNil(values) {
+			return nil, err
+		}
+	}
+
+	digests := make(map[string]KeyDigest, len(keys))
+	for i, key := range keys {
+		if values[i] == nil {
+			continue
+		}
+
+		digest, ok, err := keyContentDigest(types[i].Val(), values[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			digests[key] = digest
+		}
+	}
+
+	return digests, nil
+}
+
+// isPipelineNil reports whether all failed commands failed with redis.Nil.
+func isPipelineNil(cmds []rdb.Cmder) bool {
+	for _, cmd := range cmds {
+		if cmd != nil && isCommandFailure(cmd.Err()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// queueKeyContent queues the command that reads the content of key of type
+// typ, or returns nil when the key does not exist.
+func queueKeyContent(ctx context.Context, pipe rdb.Pipeliner, key, typ string) rdb.Cmder {
+	switch typ {
+	case "none":
+		return nil
+	case "string":
+		return pipe.Get(ctx, key)
+	case "hash":
+		return pipe.HGetAll(ctx, key)
+	case "list":
+		return pipe.LRange(ctx, key, 0, -1)
+	case "set":
+		return pipe.SMembers(ctx, key)
+	case "zset":
+		return pipe.ZRangeWithScores(ctx, key, 0, -1)
+	case "stream":
+		return pipe.XRange(ctx, key, "-", "+")
+	default:
+		// Module types have no generic read command; DUMP covers them, but
+		// its payload depends on the server version.
+		return pipe.Dump(ctx, key)
+	}
+}
+
+// keyContentDigest returns the digest of the content read by cmd.
+//
+// It returns ok=false when the key was removed before it was read.
+func keyContentDigest(typ string, cmd rdb.Cmder) (KeyDigest, bool, error) {
+	if err := cmd.Err(); err != nil {
+		if isCommandFailure(err) {
+			return KeyDigest{}, false, err
+		}
+
+		return KeyDigest{}, false, nil
+	}
+
+	digest := newContentHash()
+
+	switch cmd := cmd.(type) {
+	case *rdb.StringCmd:
+		digest.write(cmd.Val())
+
+	case *rdb.MapStringStringCmd:
+		fields := cmd.Val()
+		if len(fields) == 0 {
+			return KeyDigest{}, false, nil
+		}
+
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			digest.write(name, fields[name])
+		}
+
+	case *rdb.StringSliceCmd:
+		members := cmd.Val()
+		if len(members) == 0 {
+			return KeyDigest{}, false, nil
+		}
+
+		if typ == "set" {
+			members = slices.Sorted(slices.Values(members))
+		}
+
+		digest.write(members...)
+
+	case *rdb.ZSliceCmd:
+		members := cmd.Val()
+		if len(members) == 0 {
+			return KeyDigest{}, false, nil
+		}
+
+		for _, member := range members {
+			name, _ := member.Member.(string)
+			digest.write(name, strconv.FormatFloat(member.Score, 'g', -1, 64))
+		}
+
+	case *rdb.XMessageSliceCmd:
+		for _, message := range cmd.Val() {
+			digest.write(message.ID)
+
+			names := make([]string, 0, len(message.Values))
+			for name := range message.Values {
+				names = append(names, name)
+			}
+
+			sort.Strings(names)
+
+			for _, name := range names {
+				value, _ := message.Values[name].(string)
+				digest.write(name, value)
+			}
+		}
+	}
+
+	return KeyDigest{Type: typ, Hash: digest.sum()}, true, nil
+}
+
+// contentHash hashes length-prefixed strings, so different splits of the
+// same bytes produce different digests.
+type contentHash struct {
+	hash hash.Hash
+}
+
+func newContentHash() contentHash {
+	return contentHash{hash: sha256.New()}
+}
+
+func (h contentHash) write(values ...string) {
+	var size [binary.MaxVarintLen64]byte
+
+	for _, value := range values {
+		n := binary.PutUvarint(size[:], uint64(len(value)))
+		h.hash.Write(size[:n])
+		h.hash.Write([]byte(value))
+	}
+}
+
+func (h contentHash) sum() string {
+	return hex.EncodeToString(h.hash.Sum(nil))
+}
@@ -0,0 +1,83 @@
+package xredis_test
+
+import (
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Keyspace snapshots", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient()
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("captures digests of all key types", func() {
+		raw := client.Raw()
+		Expect(raw.Set(ctx, "snap:string", "value", 0).Err()).To(Succeed())
+		Expect(raw.HSet(ctx, "snap:hash", "a", "1", "b", "2").Err()).To(Succeed())
+		Expect(raw.RPush(ctx, "snap:list", "x", "y").Err()).To(Succeed())
+		Expect(raw.SAdd(ctx, "snap:set", "m1", "m2").Err()).To(Succeed())
+		Expect(raw.ZAdd(ctx, "snap:zset", rdb.Z{Score: 1, Member: "z"}).Err()).To(Succeed())
+		Expect(raw.XAdd(ctx, &rdb.XAddArgs{Stream: "snap:stream", ID: "1-1", Values: []string{"f", "v"}}).Err()).To(Succeed())
+		Expect(raw.Set(ctx, "other", "value", 0).Err()).To(Succeed())
+
+		snapshot, err := client.Snapshot(ctx, "snap:*")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snapshot.Pattern).To(Equal("snap:*"))
+		Expect(snapshot.Keys).To(HaveLen(6))
+		Expect(snapshot.Keys["snap:hash"].Type).To(Equal("hash"))
+		Expect(snapshot.Keys["snap:stream"].Type).To(Equal("stream"))
+		Expect(snapshot.Keys["snap:string"].Hash).To(HaveLen(64))
+
+		again, err := client.Snapshot(ctx, "snap:*")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(xredis.Diff(snapshot, again).Empty()).To(BeTrue())
+	})
+
+	It("reports added, removed, and changed keys", func() {
+		raw := client.Raw()
+		Expect(raw.Set(ctx, "snap:same", "value", 0).Err()).To(Succeed())
+		Expect(raw.Set(ctx, "snap:changed", "before", 0).Err()).To(Succeed())
+		Expect(raw.HSet(ctx, "snap:hash", "a", "1").Err()).To(Succeed())
+		Expect(raw.Set(ctx, "snap:removed", "value", 0).Err()).To(Succeed())
+
+		before, err := client.Snapshot(ctx, "snap:*")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(raw.Set(ctx, "snap:changed", "after", 0).Err()).To(Succeed())
+		Expect(raw.HSet(ctx, "snap:hash", "b", "2").Err()).To(Succeed())
+		Expect(raw.Del(ctx, "snap:removed").Err()).To(Succeed())
+		Expect(raw.Set(ctx, "snap:added", "value", 0).Err()).To(Succeed())
+
+		after, err := client.Snapshot(ctx, "snap:*")
+		Expect(err).NotTo(HaveOccurred())
+
+		diff := xredis.Diff(before, after)
+		Expect(diff.Added).To(Equal([]string{"snap:added"}))
+		Expect(diff.Removed).To(Equal([]string{"snap:removed"}))
+		Expect(diff.Changed).To(Equal([]string{"snap:changed", "snap:hash"}))
+		Expect(diff.Empty()).To(BeFalse())
+	})
+
+	It("treats values of different types as changed", func() {
+		Expect(client.Raw().Set(ctx, "snap:key", "a", 0).Err()).To(Succeed())
+
+		before, err := client.Snapshot(ctx, "snap:*")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(client.Raw().Del(ctx, "snap:key").Err()).To(Succeed())
+		Expect(client.Raw().RPush(ctx, "snap:key", "a").Err()).To(Succeed())
+
+		after, err := client.Snapshot(ctx, "snap:*")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(xredis.Diff(before, after).Changed).To(Equal([]string{"snap:key"}))
+	})
+})
@@ -0,0 +1,158 @@
+package xredis
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	topologyStandalone      = "standalone"
+	topologyCluster         = "cluster"
+	topologyFailover        = "failover"
+	topologyFailoverCluster = "failover_cluster"
+	topologyRing            = "ring"
+)
+
+// logEffectiveConfig logs the resolved client configuration at debug level.
+//
+// Passwords and credentials are never logged; only whether they are set.
+func (c *Client) logEffectiveConfig(opts *options) {
+	ctx := context.Background()
+	if !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{slog.String("topology", opts.topology)}
+	if opts.profile != "" {
+		attrs = append(attrs, slog.String("profile", string(opts.profile)))
+	}
+
+	attrs = append(attrs, connConfigAttrs(c.conn)...)
+
+	if opts.failover != nil {
+		attrs = append(
+			attrs,
+			slog.String("master_name", opts.failover.MasterName),
+			slog.Any("sentinel_addrs", opts.failover.SentinelAddrs),
+			slog.Bool("sentinel_password_set", opts.failover.SentinelPassword != ""),
+		)
+	}
+
+	attrs = append(
+		attrs,
+		slog.String("codec", fmt.Sprintf("%T", opts.codec)),
+		slog.Any("subsystems", opts.enabledSubsystems(c.metrics != nil)),
+	)
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "redis client configured", attrs...)
+}
+
+func connConfigAttrs(conn rdb.UniversalClient) []slog.Attr {
+	switch conn := conn.(type) {
+	case *rdb.Client:
+		opt := conn.Options()
+
+		return []slog.Attr{
+			slog.String("network", opt.Network),
+			slog.String("addr", opt.Addr),
+			slog.Int("db", opt.DB),
+			slog.Int("protocol", opt.Protocol),
+			slog.String("username", opt.Username),
+			slog.Bool("password_set", opt.Password != ""),
+			slog.Bool("tls", opt.TLSConfig != nil),
+			slog.Int("max_retries", opt.MaxRetries),
+			slog.Group(
+				"timeouts",
+				slog.Duration("dial", opt.DialTimeout),
+				slog.Duration("read", opt.ReadTimeout),
+				slog.Duration("write", opt.WriteTimeout),
+				slog.Duration("pool", opt.PoolTimeout),
+			),
+			slog.Group(
+				"pool",
+				slog.Int("size", opt.PoolSize),
+				slog.Int("min_idle_conns", opt.MinIdleConns),
+				slog.Int("max_idle_conns", opt.MaxIdleConns),
+				slog.Int("max_active_conns", opt.MaxActiveConns),
+				slog.Duration("conn_max_idle_time", opt.ConnMaxIdleTime),
+				slog.Duration("conn_max_lifetime", opt.ConnMaxLifetime),
+			),
+		}
+	case *rdb.ClusterClient:
+		opt := conn.Options()
+
+		return []slog.Attr{
+			slog.Any("addrs", opt.Addrs),
+			slog.Int("protocol", opt.Protocol),
+			slog.String("username", opt.Username),
+			slog.Bool("password_set", opt.Password != ""),
+			slog.Bool("tls", opt.TLSConfig != nil),
+			slog.Bool("read_only", opt.ReadOnly),
+			slog.Bool("route_by_latency", opt.RouteByLatency),
+			slog.Bool("route_randomly", opt.RouteRandomly),
+			slog.Int("max_redirects", opt.MaxRedirects),
+			slog.Int("max_retries", opt.MaxRetries),
+			slog.Group(
+				"timeouts",
+				slog.Duration("dial", opt.DialTimeout),
+				slog.Duration("read", opt.ReadTimeout),
+				slog.Duration("write", opt.WriteTimeout),
+				slog.Duration("pool", opt.PoolTimeout),
+			),
+			slog.Group(
+				"pool",
+				slog.Int("size", opt.PoolSize),
+				slog.Int("min_idle_conns", opt.MinIdleConns),
+				slog.Int("max_idle_conns", opt.MaxIdleConns),
+				slog.Int("max_active_conns", opt.MaxActiveConns),
+				slog.Duration("conn_max_idle_time", opt.ConnMaxIdleTime),
+				slog.Duration("conn_max_lifetime", opt.ConnMaxLifetime),
+			),
+		}
+	case *rdb.Ring:
+		opt := conn.Options()
+
+		return []slog.Attr{
+			slog.Any("addrs", ringAddrs(opt.Addrs)),
+			slog.Int("db", opt.DB),
+			slog.Int("protocol", opt.Protocol),
+			slog.String("username", opt.Username),
+			slog.Bool("password_set", opt.Password != ""),
+			slog.Bool("tls", opt.TLSConfig != nil),
+			slog.Int("max_retries", opt.MaxRetries),
+			slog.Group(
+				"timeouts",
+				slog.Duration("dial", opt.DialTimeout),
+				slog.Duration("read", opt.ReadTimeout),
+				slog.Duration("write", opt.WriteTimeout),
+				slog.Duration("pool", opt.PoolTimeout),
+			),
+			slog.Group(
+				"pool",
+				slog.Int("size", opt.PoolSize),
+				slog.Int("min_idle_conns", opt.MinIdleConns),
+				slog.Int("max_idle_conns", opt.MaxIdleConns),
+				slog.Int("max_active_conns", opt.MaxActiveConns),
+				slog.Duration("conn_max_idle_time", opt.ConnMaxIdleTime),
+				slog.Duration("conn_max_lifetime", opt.ConnMaxLifetime),
+			),
+		}
+	default:
+		return nil
+	}
+}
+
+func ringAddrs(addrs map[string]string) []string {
+	shards := make([]string, 0, len(addrs))
+	for name, addr := range addrs {
+		shards = append(shards, name+"="+addr)
+	}
+
+	sort.Strings(shards)
+
+	return shards
+}
@@ -0,0 +1,201 @@
+package xredis
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	// StreamEarliest is the stream position before the first entry. A group
+	// set to it delivers the whole stream again.
+	StreamEarliest = "0"
+
+	// StreamLatest is the stream position after the last entry. A group set to
+	// it skips all existing entries.
+	StreamLatest = "$"
+)
+
+// ConsumerGroupLag reports the progress of a consumer group.
+type ConsumerGroupLag struct {
+	// Group is the consumer group name.
+	Group string
+
+	// LastDeliveredID is the ID of the last entry delivered to the group.
+	LastDeliveredID string
+
+	// Lag is the number of stream entries not yet delivered to the group, or
+	// -1 when Redis cannot determine it, for example after entries were
+	// deleted or with servers older than Redis 7.
+	Lag int64
+
+	// Pending is the number of entries delivered but not acknowledged.
+	Pending int64
+
+	// Consumers reports every consumer of the group, sorted by name.
+	Consumers []ConsumerLag
+}
+
+// ConsumerLag reports the progress of one consumer of a group.
+type ConsumerLag struct {
+	// Name is the consumer name.
+	Name string
+
+	// Pending is the number of entries delivered to the consumer but not
+	// acknowledged.
+	Pending int64
+
+	// Idle is the time since the consumer last interacted with the server.
+	Idle time.Duration
+
+	// Inactive is the time since the consumer last read entries successfully,
+	// or -1 when it never did. Redis versions before 7.2 do not report it.
+	Inactive time.Duration
+}
+
+// XGroupCreate creates a consumer group reading stream from start, creating
+// the stream when it does not exist.
+//
+// start is an entry ID, StreamEarliest, or StreamLatest. It reports false
+// without changing the group when the group already exists.
+func (c *Client) XGroupCreate(ctx context.Context, stream, group, start string) (bool, error) {
+	if stream == "" || group == "" || start == "" {
+		return false, ErrInvalidStream
+	}
+
+	err := c.conn.XGroupCreateMkStream(ctx, stream, group, start).Err()
+	if err != nil {
+		if hasRedisErrorCode(err, "BUSYGROUP") {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// XGroupSetID moves the last delivered ID of a consumer group to id.
+//
+// id is an entry ID, StreamEarliest, or StreamLatest. Pending entries are kept,
+// so consumers still see them with XPENDING and XCLAIM.
+func (c *Client) XGroupSetID(ctx context.Context, stream, group, id string) error {
+	if stream == "" || group == "" || id == "" {
+		return ErrInvalidStream
+	}
+
+	return c.conn.XGroupSetID(ctx, stream, group, id).Err()
+}
+
+// XGroupDestroyConsumer removes consumer from a group and returns the number
+// of pending entries it owned.
+//
+// Those entries are no longer pending, so they are not redelivered. Claim them
+// with XCLAIM or XAUTOCLAIM first to keep them.
+func (c *Client) XGroupDestroyConsumer(ctx context.Context, stream, group, consumer string) (int64, error) {
+	if stream == "" || group == "" || consumer == "" {
+		return 0, ErrInvalidStream
+	}
+
+	return c.conn.XGroupDelConsumer(ctx, stream, group, consumer).Result()
+}
+
+// ResetGroup moves a consumer group to id, creating the group and the stream
+// when they do not exist.
+//
+// It recovers consumers stuck on a group that was deleted, or one positioned
+// past entries that must be processed again. id is an entry ID,
+// StreamEarliest, or StreamLatest.
+func (c *Client) ResetGroup(ctx context.Context, stream, group, id string) error {
+	err := c.XGroupSetID(ctx, stream, group, id)
+	if err == nil || !isMissingGroup(err) {
+		return err
+	}
+
+	// The group may be created concurrently, so the ID is set again when it
+	// already exists.
+	created, err := c.XGroupCreate(ctx, stream, group, id)
+	if err != nil || created {
+		return err
+	}
+
+	return c.XGroupSetID(ctx, stream, group, id)
+}
+
+// GroupLag reports the lag of a consumer group and the pending entries of
+// each of its consumers.
+//
+// It returns the Redis NOGROUP error when the group does not exist.
+func (c *Client) GroupLag(ctx context.Context, stream, group string) (ConsumerGroupLag, error) {
+	if stream == "" || group == "" {
+		return ConsumerGroupLag{}, ErrInvalidStream
+	}
+
+	var (
+		groups    *rdb.XInfoGroupsCmd
+		consumers *rdb.XInfoConsumersCmd
+	)
+
+	// Per-command errors are checked below.
+	_, _ = c.conn.Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+		groups = pipe.XInfoGroups(ctx, stream)
+		consumers = pipe.XInfoConsumers(ctx, stream, group)
+
+		return nil
+	})
+
+	infos, err := consumers.Result()
+	if err != nil {
+		return ConsumerGroupLag{}, err
+	}
+
+	groupInfos, err := groups.Result()
+	if err != nil {
+		return ConsumerGroupLag{}, err
+	}
+
+	lag := ConsumerGroupLag{Group: group, Lag: -1}
+
+	for _, info := range groupInfos {
+		if info.Name == group {
+			lag.LastDeliveredID = info.LastDeliveredID
+			lag.Lag = info.Lag
+			lag.Pending = info.Pending
+
+			break
+		}
+	}
+
+	lag.Consumers = make([]ConsumerLag, len(infos))
+	for i, info := range infos {
+		lag.Consumers[i] = ConsumerLag(info)
+	}
+
+	return lag, nil
+}
+
+// hasRedisErrorCode reports whether err is a Redis error with the given
+// code, such as "NOGROUP".
+func hasRedisErrorCode(err error, code string) bool {
+	var redisErr rdb.Error
+	if !errors.As(err, &redisErr) {
+		return false
+	}
+
+	prefix, _, _ := strings.Cut(redisErr.Error(), " ")
+
+	return prefix == code
+}
+
+// isMissingGroup reports whether err rejects an XGROUP command because the
+// group or its stream does not exist.
+func isMissingGroup(err error) bool {
+	if hasRedisErrorCode(err, "NOGROUP") {
+		return true
+	}
+
+	return hasRedisErrorCode(err, "ERR") && strings.Contains(err.Error(), "requires the key to exist")
+}
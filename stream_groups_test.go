@@ -0,0 +1,175 @@
+package xredis_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// groupRecording returns a recording that answers commands with RESP replies
+// in order.
+func groupRecording(commands ...xredis.RecordedCommand) *bytes.Buffer {
+	var recording bytes.Buffer
+
+	encoder := json.NewEncoder(&recording)
+	for _, command := range commands {
+		Expect(encoder.Encode(command)).To(Succeed())
+	}
+
+	return &recording
+}
+
+func recordedArgs(args ...string) [][]byte {
+	out := make([][]byte, len(args))
+	for i, arg := range args {
+		out[i] = []byte(arg)
+	}
+
+	return out
+}
+
+var _ = Describe("Consumer groups", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient()
+		Expect(client.Raw().Del(ctx, "groups:stream").Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("creates groups once together with the stream", func() {
+		created, err := client.XGroupCreate(ctx, "groups:stream", "workers", xredis.StreamEarliest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeTrue())
+
+		created, err = client.XGroupCreate(ctx, "groups:stream", "workers", xredis.StreamLatest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeFalse())
+
+		Expect(client.Raw().Exists(ctx, "groups:stream").Val()).To(Equal(int64(1)))
+	})
+
+	It("reports consumer lag and removes consumers", func() {
+		_, err := client.XGroupCreate(ctx, "groups:stream", "workers", xredis.StreamEarliest)
+		Expect(err).NotTo(HaveOccurred())
+
+		for range 3 {
+			Expect(client.Raw().XAdd(ctx, &rdb.XAddArgs{Stream: "groups:stream", Values: []any{"n", 1}}).Err()).To(Succeed())
+		}
+
+		Expect(client.Raw().XReadGroup(ctx, &rdb.XReadGroupArgs{
+			Group:    "workers",
+			Consumer: "b",
+			Streams:  []string{"groups:stream", ">"},
+			Count:    2,
+		}).Err()).To(Succeed())
+		Expect(client.Raw().XReadGroup(ctx, &rdb.XReadGroupArgs{
+			Group:    "workers",
+			Consumer: "a",
+			Streams:  []string{"groups:stream", ">"},
+			Count:    1,
+		}).Err()).To(Succeed())
+
+		lag, err := client.GroupLag(ctx, "groups:stream", "workers")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lag.Group).To(Equal("workers"))
+		Expect(lag.Pending).To(Equal(int64(3)))
+		Expect(lag.LastDeliveredID).NotTo(BeEmpty())
+		Expect(lag.Consumers).To(HaveLen(2))
+		Expect(lag.Consumers[0].Name).To(Equal("a"))
+		Expect(lag.Consumers[0].Pending).To(Equal(int64(1)))
+		Expect(lag.Consumers[1].Name).To(Equal("b"))
+		Expect(lag.Consumers[1].Pending).To(Equal(int64(2)))
+
+		pending, err := client.XGroupDestroyConsumer(ctx, "groups:stream", "workers", "b")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pending).To(Equal(int64(2)))
+
+		lag, err = client.GroupLag(ctx, "groups:stream", "workers")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lag.Pending).To(Equal(int64(1)))
+		Expect(lag.Consumers).To(HaveLen(1))
+	})
+
+	It("returns the Redis error for missing groups", func() {
+		_, err := client.XGroupCreate(ctx, "groups:stream", "workers", xredis.StreamEarliest)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.GroupLag(ctx, "groups:stream", "missing")
+		Expect(err).To(MatchError(ContainSubstring("NOGROUP")))
+	})
+
+	It("rejects invalid arguments", func() {
+		_, err := client.XGroupCreate(ctx, "", "workers", xredis.StreamEarliest)
+		Expect(err).To(MatchError(xredis.ErrInvalidStream))
+		Expect(client.XGroupSetID(ctx, "groups:stream", "", xredis.StreamLatest)).To(MatchError(xredis.ErrInvalidStream))
+		_, err = client.XGroupDestroyConsumer(ctx, "groups:stream", "workers", "")
+		Expect(err).To(MatchError(xredis.ErrInvalidStream))
+		Expect(client.ResetGroup(ctx, "groups:stream", "workers", "")).To(MatchError(xredis.ErrInvalidStream))
+		_, err = client.GroupLag(ctx, "groups:stream", "")
+		Expect(err).To(MatchError(xredis.ErrInvalidStream))
+	})
+
+	It("resets existing groups", func() {
+		replay, err := xredis.NewReplayClient(groupRecording(xredis.RecordedCommand{
+			Args:  recordedArgs("xgroup", "setid", "groups:stream", "workers", "0"),
+			Reply: []byte("+OK\r\n"),
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(replay.Close()).To(Succeed())
+		}()
+
+		Expect(replay.ResetGroup(ctx, "groups:stream", "workers", xredis.StreamEarliest)).To(Succeed())
+	})
+
+	It("creates missing groups on reset", func() {
+		replay, err := xredis.NewReplayClient(groupRecording(
+			xredis.RecordedCommand{
+				Args:  recordedArgs("xgroup", "setid", "groups:stream", "workers", "$"),
+				Reply: []byte("-NOGROUP No such consumer group 'workers' for key name 'groups:stream'\r\n"),
+			},
+			xredis.RecordedCommand{
+				Args:  recordedArgs("xgroup", "create", "groups:stream", "workers", "$", "mkstream"),
+				Reply: []byte("+OK\r\n"),
+			},
+		))
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(replay.Close()).To(Succeed())
+		}()
+
+		Expect(replay.ResetGroup(ctx, "groups:stream", "workers", xredis.StreamLatest)).To(Succeed())
+	})
+
+	It("sets the group ID again when the group is created concurrently", func() {
+		replay, err := xredis.NewReplayClient(groupRecording(
+			xredis.RecordedCommand{
+				Args: recordedArgs("xgroup", "setid", "groups:stream", "workers", "5-0"),
+				Reply: []byte("-ERR The XGROUP subcommand requires the key to exist. " +
+					"Note that for CREATE you may want to use the MKSTREAM option to create an empty stream automatically.\r\n"),
+			},
+			xredis.RecordedCommand{
+				Args:  recordedArgs("xgroup", "create", "groups:stream", "workers", "5-0", "mkstream"),
+				Reply: []byte("-BUSYGROUP Consumer Group name already exists\r\n"),
+			},
+			xredis.RecordedCommand{
+				Args:  recordedArgs("xgroup", "setid", "groups:stream", "workers", "5-0"),
+				Reply: []byte("+OK\r\n"),
+			},
+		))
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(replay.Close()).To(Succeed())
+		}()
+
+		Expect(replay.ResetGroup(ctx, "groups:stream", "workers", "5-0")).To(Succeed())
+	})
+})
@@ -0,0 +1,69 @@
+package xredis
+
+import (
+	"fmt"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// StreamMessage is a stream entry decoded into T.
+type StreamMessage[T any] struct {
+	// ID is the stream entry ID.
+	ID string
+
+	// Value holds the entry fields scanned into T.
+	Value T
+}
+
+// ScanStreamMessage scans the fields of a stream entry into dst, a pointer to
+// a struct with redis tags.
+//
+// Entries are mapped field by field like HGetAll maps hashes, so streams can
+// be written with structs, for example with StreamProducer.Publish or XADD
+// values, and still be read by other languages and XRANGE tooling. Fields that
+// dst does not declare are ignored, so producers can add fields before all
+// consumers know them.
+func ScanStreamMessage(msg rdb.XMessage, dst any) error {
+	if dst == nil {
+		return ErrInvalidStream
+	}
+
+	values := make(map[string]string, len(msg.Values))
+	for field, value := range msg.Values {
+		switch value := value.(type) {
+		case string:
+			values[field] = value
+		case nil:
+		default:
+			values[field] = fmt.Sprint(value)
+		}
+	}
+
+	if err := rdb.NewMapStringStringResult(values, nil).Scan(dst); err != nil {
+		return fmt.Errorf("scan stream entry %s: %w", msg.ID, err)
+	}
+
+	return nil
+}
+
+// DecodeStreamMessages scans stream entries, such as the result of XRANGE or
+// XREADGROUP, into T.
+//
+// T is a struct with redis tags or a pointer to one. Decoding stops at the
+// first entry that cannot be scanned.
+func DecodeStreamMessages[T any](msgs []rdb.XMessage) ([]StreamMessage[T], error) {
+	decoded := make([]StreamMessage[T], len(msgs))
+
+	for i, msg := range msgs {
+		value, err := decodeInto[T](func(dst any) error {
+			return ScanStreamMessage(msg, dst)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		decoded[i] = StreamMessage[T]{ID: msg.ID, Value: value}
+	}
+
+	return decoded, nil
+}
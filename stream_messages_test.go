@@ -0,0 +1,72 @@
+package xredis_test
+
+import (
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+type orderEvent struct {
+	Order  string  `redis:"order"`
+	Amount float64 `redis:"amount"`
+	Paid   bool    `redis:"paid"`
+	Note   string  `redis:"note,omitempty"`
+	local  string
+}
+
+var _ = Describe("Stream messages", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient()
+		Expect(client.Raw().Del(ctx, "messages:stream").Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("writes structs as entry fields and scans them back", func() {
+		producer, err := client.StreamProducer("messages:stream", xredis.WithStreamProducerFlushInterval(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(producer.Publish(ctx, orderEvent{Order: "o-1", Amount: 9.5, Paid: true, local: "skip"})).To(Succeed())
+		Expect(producer.Publish(ctx, &orderEvent{Order: "o-2", Amount: 3, Note: "gift"})).To(Succeed())
+		Expect(producer.Close()).To(Succeed())
+
+		entries, err := client.Raw().XRange(ctx, "messages:stream", "-", "+").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[0].Values).To(Equal(map[string]any{"order": "o-1", "amount": "9.5", "paid": "1"}))
+
+		var event orderEvent
+		Expect(xredis.ScanStreamMessage(entries[1], &event)).To(Succeed())
+		Expect(event).To(Equal(orderEvent{Order: "o-2", Amount: 3, Note: "gift"}))
+
+		messages, err := xredis.DecodeStreamMessages[*orderEvent](entries)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(messages).To(HaveLen(2))
+		Expect(messages[0].ID).To(Equal(entries[0].ID))
+		Expect(*messages[0].Value).To(Equal(orderEvent{Order: "o-1", Amount: 9.5, Paid: true}))
+	})
+
+	It("ignores fields the struct does not declare", func() {
+		var event orderEvent
+
+		msg := rdb.XMessage{ID: "1-0", Values: map[string]any{"order": "o-3", "version": "2"}}
+		Expect(xredis.ScanStreamMessage(msg, &event)).To(Succeed())
+		Expect(event.Order).To(Equal("o-3"))
+	})
+
+	It("reports entries that cannot be scanned", func() {
+		msgs := []rdb.XMessage{{ID: "1-0", Values: map[string]any{"amount": "lots"}}}
+
+		_, err := xredis.DecodeStreamMessages[orderEvent](msgs)
+		Expect(err).To(MatchError(ContainSubstring("scan stream entry 1-0")))
+
+		Expect(xredis.ScanStreamMessage(msgs[0], nil)).To(MatchError(xredis.ErrInvalidStream))
+	})
+})
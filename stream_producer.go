@@ -0,0 +1,380 @@
+package xredis
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultStreamProducerBuffer        = 1024
+	defaultStreamProducerBatchSize     = 100
+	defaultStreamProducerFlushInterval = 10 * time.Millisecond
+	streamProducerErrorBuffer          = 64
+)
+
+// StreamProducer publishes stream entries asynchronously.
+//
+// Entries are buffered and written with pipelined XADD commands in batches,
+// so request paths do not wait for a round trip per entry. When the buffer is
+// full, Publish blocks until there is room or its context is done.
+//
+// Write errors are delivered on Errors. A StreamProducer is safe for
+// concurrent use.
+type StreamProducer struct {
+	client *Client
+	stream string
+	opts   streamProducerOptions
+
+	mu     sync.RWMutex
+	closed bool
+
+	queue chan streamEntry
+	errs  chan StreamPublishError
+	done  chan struct{}
+}
+
+type streamEntry struct {
+	id     string
+	values any
+}
+
+// StreamProducerOption configures StreamProducer.
+type StreamProducerOption func(*streamProducerOptions)
+
+type streamProducerOptions struct {
+	buffer        int
+	batchSize     int
+	flushInterval time.Duration
+	maxLen        int64
+}
+
+// StreamPublishError reports an entry that could not be written.
+type StreamPublishError struct {
+	// ID is the entry ID requested by the producer, or empty for an
+	// auto-generated ID.
+	ID string
+
+	// Values are the entry fields passed to Publish.
+	Values any
+
+	// Err is the XADD error.
+	Err error
+}
+
+func (e StreamPublishError) Error() string {
+	return "publish stream entry: " + e.Err.Error()
+}
+
+func (e StreamPublishError) Unwrap() error {
+	return e.Err
+}
+
+// NewStreamProducer creates an asynchronous producer for stream.
+func NewStreamProducer(client *Client, stream string, opts ...StreamProducerOption) (*StreamProducer, error) {
+	return newStreamProducer(client, stream, opts...)
+}
+
+// StreamProducer creates an asynchronous producer for stream bound to this
+// client.
+func (c *Client) StreamProducer(stream string, opts ...StreamProducerOption) (*StreamProducer, error) {
+	return newStreamProducer(c, stream, opts...)
+}
+
+func newStreamProducer(client *Client, stream string, opts ...StreamProducerOption) (*StreamProducer, error) {
+	if client == nil || client.conn == nil || stream == "" {
+		return nil, ErrInvalidStream
+	}
+
+	options := streamProducerOptions{
+		buffer:        defaultStreamProducerBuffer,
+		batchSize:     defaultStreamProducerBatchSize,
+		flushInterval: defaultStreamProducerFlushInterval,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&options)
+		}
+	}
+
+	p := &StreamProducer{
+		client: client,
+		stream: stream,
+		opts:   options,
+		queue:  make(chan streamEntry, options.buffer),
+		errs:   make(chan StreamPublishError, streamProducerErrorBuffer),
+		done:   make(chan struct{}),
+	}
+
+	// Like goBackground, but the producer is finished once run is not
+	// restarted anymore, also when the client closes while run waits to be
+	// restarted after a panic.
+	client.workers.Add(1)
+
+	go func() {
+		defer client.workers.Done()
+		defer p.finish()
+
+		client.runWorker("stream_producer", p.run)
+	}()
+
+	return p, nil
+}
+
+// WithStreamProducerBuffer configures how many entries can wait to be
+// written before Publish blocks.
+//
+// Non-positive values are ignored. The default is 1024.
+func WithStreamProducerBuffer(size int) StreamProducerOption {
+	return func(opts *streamProducerOptions) {
+		if size > 0 {
+			opts.buffer = size
+		}
+	}
+}
+
+// WithStreamProducerBatchSize configures the largest number of entries
+// written in one pipeline.
+//
+// Non-positive values are ignored. The default is 100.
+func WithStreamProducerBatchSize(size int) StreamProducerOption {
+	return func(opts *streamProducerOptions) {
+		if size > 0 {
+			opts.batchSize = size
+		}
+	}
+}
+
+// WithStreamProducerFlushInterval configures how long buffered entries may
+// wait for a batch to fill up.
+//
+// Non-positive values are ignored. The default is 10 milliseconds.
+func WithStreamProducerFlushInterval(d time.Duration) StreamProducerOption {
+	return func(opts *streamProducerOptions) {
+		if d > 0 {
+			opts.flushInterval = d
+		}
+	}
+}
+
+// WithStreamProducerMaxLen trims the stream to about maxLen entries on every
+// write, using XADD MAXLEN ~.
+//
+// Non-positive values disable trimming, which is the default.
+func WithStreamProducerMaxLen(maxLen int64) StreamProducerOption {
+	return func(opts *streamProducerOptions) {
+		opts.maxLen = max(maxLen, 0)
+	}
+}
+
+// Publish queues an entry with an auto-generated ID.
+//
+// values supports the same formats as rdb.XAddArgs.Values. Structs with redis
+// tags are written as one entry field per struct field and can be read back
+// with ScanStreamMessage. Publish returns ErrStreamProducerClosed after Close,
+// and the context error when the buffer stays full until ctx is done.
+func (p *StreamProducer) Publish(ctx context.Context, values any) error {
+	return p.enqueue(ctx, streamEntry{values: values})
+}
+
+// PublishWithID queues an entry with an explicit ID.
+//
+// When Redis rejects the ID because it is not greater than the last entry of
+// the stream, the entry is written with an auto-generated ID instead, so
+// producers with skewed clocks do not lose entries.
+func (p *StreamProducer) PublishWithID(ctx context.Context, id string, values any) error {
+	if id == "" || id == "*" {
+		return ErrInvalidStream
+	}
+
+	return p.enqueue(ctx, streamEntry{id: id, values: values})
+}
+
+// Errors returns the channel of write errors.
+//
+// The channel is buffered and closed after Close. Errors are dropped when it
+// is full, so a producer never blocks on an unread error channel.
+func (p *StreamProducer) Errors() <-chan StreamPublishError {
+	return p.errs
+}
+
+// Close stops accepting entries, writes the buffered ones, and closes the
+// error channel. It is safe to call Close more than once.
+//
+// Closing the client closes its producers the same way.
+func (p *StreamProducer) Close() error {
+	p.closeQueue()
+	<-p.done
+
+	return nil
+}
+
+func (p *StreamProducer) closeQueue() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+}
+
+func (p *StreamProducer) enqueue(ctx context.Context, entry streamEntry) error {
+	if entry.values == nil {
+		return ErrInvalidStream
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrStreamProducerClosed
+	}
+
+	select {
+	case p.queue <- entry:
+		return nil
+	case <-p.client.done:
+		return ErrStreamProducerClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run writes queued entries until the producer or the client is closed.
+//
+// It runs as a client worker, so a panic restarts it and loses only the
+// entries of the batch being collected.
+func (p *StreamProducer) run(clientDone <-chan struct{}) {
+	ticker := time.NewTicker(p.opts.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]streamEntry, 0, p.opts.batchSize)
+
+	for {
+		select {
+		case <-clientDone:
+			// The buffered entries are still written before the client
+			// closes its connections.
+			p.closeQueue()
+			clientDone = nil
+
+		case entry, ok := <-p.queue:
+			if !ok {
+				p.flush(batch)
+				return
+			}
+
+			batch = append(batch, entry)
+			if len(batch) >= p.opts.batchSize {
+				p.flush(batch)
+				batch = batch[:0]
+			}
+
+		case <-ticker.C:
+			p.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+// finish writes the entries left in the queue and closes the channels of the
+// producer.
+func (p *StreamProducer) finish() {
+	p.closeQueue()
+
+	batch := make([]streamEntry, 0, p.opts.batchSize)
+	for entry := range p.queue {
+		batch = append(batch, entry)
+		if len(batch) >= p.opts.batchSize {
+			p.flush(batch)
+			batch = batch[:0]
+		}
+	}
+
+	p.flush(batch)
+
+	close(p.errs)
+	close(p.done)
+}
+
+// flush writes batch in one pipeline and rewrites entries with conflicting
+// IDs using auto-generated ones.
+func (p *StreamProducer) flush(batch []streamEntry) {
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx := context.Background()
+
+	var conflicts []streamEntry
+
+	for i, err := range p.write(ctx, batch) {
+		switch {
+		case err == nil:
+		case batch[i].id != "" && isStreamIDConflict(err):
+			conflicts = append(conflicts, streamEntry{values: batch[i].values})
+		default:
+			p.report(batch[i], err)
+		}
+	}
+
+	if len(conflicts) == 0 {
+		return
+	}
+
+	for i, err := range p.write(ctx, conflicts) {
+		if err != nil {
+			p.report(conflicts[i], err)
+		}
+	}
+}
+
+func (p *StreamProducer) write(ctx context.Context, entries []streamEntry) []error {
+	cmds := make([]*rdb.StringCmd, len(entries))
+
+	// Per-command errors are collected below.
+	_, _ = p.client.conn.Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+		for i, entry := range entries {
+			cmds[i] = pipe.XAdd(ctx, &rdb.XAddArgs{
+				Stream: p.stream,
+				ID:     entry.id,
+				Values: entry.values,
+				MaxLen: p.opts.maxLen,
+				Approx: p.opts.maxLen > 0,
+			})
+		}
+
+		return nil
+	})
+
+	errs := make([]error, len(cmds))
+	for i, cmd := range cmds {
+		errs[i] = cmd.Err()
+	}
+
+	return errs
+}
+
+func (p *StreamProducer) report(entry streamEntry, err error) {
+	select {
+	case p.errs <- StreamPublishError{ID: entry.id, Values: entry.values, Err: err}:
+	default:
+	}
+}
+
+// isStreamIDConflict reports whether err rejects an XADD ID that is not
+// greater than the last entry of the stream.
+func isStreamIDConflict(err error) bool {
+	var redisErr rdb.Error
+	if !errors.As(err, &redisErr) {
+		return false
+	}
+
+	return strings.Contains(redisErr.Error(), "equal or smaller than the target stream top item")
+}
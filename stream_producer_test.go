@@ -0,0 +1,215 @@
+package xredis_test
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// gateHook holds pipelines until open is closed.
+type gateHook struct {
+	open    chan struct{}
+	waiting atomic.Bool
+}
+
+func (*gateHook) DialHook(next rdb.DialHook) rdb.DialHook {
+	return next
+}
+
+func (*gateHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return next
+}
+
+func (h *gateHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		h.waiting.Store(true)
+		<-h.open
+
+		return next(ctx, cmds)
+	}
+}
+
+// panicValue panics when go-redis encodes it.
+type panicValue struct{}
+
+func (panicValue) MarshalBinary() ([]byte, error) {
+	panic("marshal")
+}
+
+var _ = Describe("StreamProducer", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient()
+		Expect(client.Raw().Del(ctx, "producer:stream").Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("writes buffered entries and flushes them on close", func() {
+		producer, err := client.StreamProducer(
+			"producer:stream",
+			xredis.WithStreamProducerBatchSize(7),
+			xredis.WithStreamProducerFlushInterval(time.Hour),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		for i := range 20 {
+			Expect(producer.Publish(ctx, map[string]any{"n": i})).To(Succeed())
+		}
+
+		Expect(producer.Close()).To(Succeed())
+		Expect(producer.Close()).To(Succeed())
+		Eventually(producer.Errors()).Should(BeClosed())
+
+		entries, err := client.Raw().XRange(ctx, "producer:stream", "-", "+").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(20))
+		Expect(entries[0].Values).To(HaveKeyWithValue("n", "0"))
+		Expect(entries[19].Values).To(HaveKeyWithValue("n", "19"))
+
+		Expect(producer.Publish(ctx, map[string]any{"n": 20})).To(MatchError(xredis.ErrStreamProducerClosed))
+	})
+
+	It("flushes and closes when the client is closed", func() {
+		owner := newTestClient()
+
+		producer, err := owner.StreamProducer("producer:stream", xredis.WithStreamProducerFlushInterval(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(producer.Publish(ctx, map[string]any{"n": 1})).To(Succeed())
+
+		Expect(owner.Close()).To(Succeed())
+		Expect(producer.Errors()).To(BeClosed())
+		Expect(producer.Close()).To(Succeed())
+		Expect(producer.Publish(ctx, map[string]any{"n": 2})).To(MatchError(xredis.ErrStreamProducerClosed))
+
+		Expect(client.Raw().XLen(ctx, "producer:stream").Val()).To(Equal(int64(1)))
+	})
+
+	It("finishes when the client is closed while restarting after a panic", func() {
+		owner := newTestClient(xredis.WithPanicHandler(func(xredis.BackgroundPanic) {}))
+
+		producer, err := owner.StreamProducer(
+			"producer:stream",
+			xredis.WithStreamProducerBatchSize(1),
+			xredis.WithStreamProducerFlushInterval(time.Millisecond),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(producer.Publish(ctx, map[string]any{"n": panicValue{}})).To(Succeed())
+		time.Sleep(50 * time.Millisecond)
+		Expect(producer.Publish(ctx, map[string]any{"n": 1})).To(Succeed())
+
+		Expect(owner.Close()).To(Succeed())
+		Expect(producer.Errors()).To(BeClosed())
+		Expect(producer.Close()).To(Succeed())
+
+		Expect(client.Raw().XLen(ctx, "producer:stream").Val()).To(Equal(int64(1)))
+	})
+
+	It("flushes partial batches after the flush interval", func() {
+		producer, err := client.StreamProducer("producer:stream")
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(producer.Close()).To(Succeed())
+		}()
+
+		Expect(producer.Publish(ctx, map[string]any{"n": 1})).To(Succeed())
+		Eventually(func() int64 {
+			return client.Raw().XLen(ctx, "producer:stream").Val()
+		}).Should(BeEquivalentTo(1))
+	})
+
+	It("trims the stream", func() {
+		producer, err := client.StreamProducer("producer:stream", xredis.WithStreamProducerMaxLen(10))
+		Expect(err).NotTo(HaveOccurred())
+
+		for i := range 50 {
+			Expect(producer.Publish(ctx, map[string]any{"n": i})).To(Succeed())
+		}
+
+		Expect(producer.Close()).To(Succeed())
+		Expect(client.Raw().XLen(ctx, "producer:stream").Val()).To(BeNumerically("<", 50))
+	})
+
+	It("writes entries with conflicting IDs under auto-generated IDs", func() {
+		Expect(client.Raw().XAdd(ctx, &rdb.XAddArgs{Stream: "producer:stream", ID: "2000-0", Values: []any{"n", "0"}}).Err()).To(Succeed())
+
+		producer, err := client.StreamProducer("producer:stream")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(producer.PublishWithID(ctx, "1000-0", map[string]any{"n": 1})).To(Succeed())
+		Expect(producer.PublishWithID(ctx, "3000-0", map[string]any{"n": 2})).To(Succeed())
+		Expect(producer.Close()).To(Succeed())
+
+		entries, err := client.Raw().XRange(ctx, "producer:stream", "-", "+").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(3))
+		Expect(entries[1].ID).To(Equal("3000-0"))
+		Expect(entries[2].Values).To(HaveKeyWithValue("n", "1"))
+		Expect(entries[2].ID).NotTo(Equal("1000-0"))
+	})
+
+	It("reports write errors on the error channel", func() {
+		Expect(client.Raw().Set(ctx, "producer:stream", "not a stream", 0).Err()).To(Succeed())
+
+		producer, err := client.StreamProducer("producer:stream")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(producer.Publish(ctx, map[string]any{"n": 1})).To(Succeed())
+
+		var publishErr xredis.StreamPublishError
+		Eventually(producer.Errors()).Should(Receive(&publishErr))
+		Expect(publishErr.Values).To(Equal(map[string]any{"n": 1}))
+		Expect(publishErr).To(MatchError(ContainSubstring("WRONGTYPE")))
+
+		Expect(producer.Close()).To(Succeed())
+	})
+
+	It("applies backpressure when the buffer is full", func() {
+		gate := &gateHook{open: make(chan struct{})}
+		client.Raw().AddHook(gate)
+
+		producer, err := client.StreamProducer(
+			"producer:stream",
+			xredis.WithStreamProducerBuffer(1),
+			xredis.WithStreamProducerBatchSize(1),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		// The first entry is being written, the second one fills the buffer.
+		Expect(producer.Publish(ctx, map[string]any{"n": 1})).To(Succeed())
+		Eventually(gate.waiting.Load).Should(BeTrue())
+		Expect(producer.Publish(ctx, map[string]any{"n": 2})).To(Succeed())
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		Expect(producer.Publish(timeoutCtx, map[string]any{"n": 3})).To(MatchError(context.DeadlineExceeded))
+
+		close(gate.open)
+		Expect(producer.Close()).To(Succeed())
+		Expect(client.Raw().XLen(ctx, "producer:stream").Val()).To(BeEquivalentTo(2))
+	})
+
+	It("rejects invalid arguments", func() {
+		_, err := xredis.NewStreamProducer(client, "")
+		Expect(err).To(MatchError(xredis.ErrInvalidStream))
+
+		producer, err := client.StreamProducer("producer:stream")
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(producer.Close()).To(Succeed())
+		}()
+
+		Expect(producer.Publish(ctx, nil)).To(MatchError(xredis.ErrInvalidStream))
+		Expect(producer.PublishWithID(ctx, "*", map[string]any{"n": 1})).To(MatchError(xredis.ErrInvalidStream))
+	})
+})
@@ -0,0 +1,98 @@
+package xredis
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+const structTTLTagOption = "ttl="
+
+// TTLer is implemented by values that declare the TTL SetStruct applies when
+// the caller passes 0.
+type TTLer interface {
+	RedisTTL() time.Duration
+}
+
+type structTTL struct {
+	ttl time.Duration
+	err error
+}
+
+// structTTLs caches the TTL declared by the marker field of struct types.
+var structTTLs sync.Map // map[reflect.Type]structTTL
+
+// defaultStructTTL returns the TTL declared by value, or ttl when the caller
+// passed a non-zero TTL.
+//
+// A value declares its TTL by implementing TTLer, or with a blank marker
+// field tagged with the ttl option:
+//
+//	type Session struct {
+//		_ struct{} `redis:"ttl=15m"`
+//
+//		UserID string `json:"user_id"`
+//	}
+//
+// Values that declare nothing are stored without expiration, like before.
+func defaultStructTTL(value any, ttl time.Duration) (time.Duration, error) {
+	if ttl != 0 {
+		return ttl, nil
+	}
+
+	if ttler, ok := value.(TTLer); ok {
+		ttl = ttler.RedisTTL()
+		if ttl < 0 {
+			return 0, ErrInvalidTTL
+		}
+
+		return ttl, nil
+	}
+
+	typ := reflect.TypeOf(value)
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return 0, nil
+	}
+
+	if cached, ok := structTTLs.Load(typ); ok {
+		declared := cached.(structTTL)
+		return declared.ttl, declared.err
+	}
+
+	declared := parseStructTTL(typ)
+	structTTLs.Store(typ, declared)
+
+	return declared.ttl, declared.err
+}
+
+// parseStructTTL reads the ttl option of the blank marker fields of typ.
+func parseStructTTL(typ reflect.Type) structTTL {
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if field.Name != "_" {
+			continue
+		}
+
+		for option := range strings.SplitSeq(field.Tag.Get("redis"), ",") {
+			value, ok := strings.CutPrefix(strings.TrimSpace(option), structTTLTagOption)
+			if !ok {
+				continue
+			}
+
+			ttl, err := time.ParseDuration(value)
+			if err != nil || ttl < 0 {
+				return structTTL{err: fmt.Errorf("%w: invalid ttl tag %q on %s", ErrInvalidTTL, option, typ)}
+			}
+
+			return structTTL{ttl: ttl}
+		}
+	}
+
+	return structTTL{}
+}
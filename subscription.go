@@ -0,0 +1,316 @@
+package xredis
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultSubscriptionHealthInterval  = 5 * time.Second
+	defaultSubscriptionBrokenThreshold = 30 * time.Second
+	subscriptionBuffer                 = 100
+)
+
+// SubscriptionHealthConfig configures health checks of Pub/Sub
+// subscriptions.
+type SubscriptionHealthConfig struct {
+	// Interval is the time between health check pings.
+	//
+	// Zero uses 5 seconds.
+	Interval time.Duration
+
+	// Threshold is how long a subscription may stay broken before it is
+	// reported.
+	//
+	// Zero uses 30 seconds.
+	Threshold time.Duration
+
+	// OnBroken is called once per outage when a subscription stays broken for
+	// Threshold. It runs on the health check goroutine and must not block.
+	OnBroken func(SubscriptionBroken)
+}
+
+// SubscriptionBroken describes a subscription that has been broken longer
+// than the configured threshold.
+type SubscriptionBroken struct {
+	// Channels contains the subscribed channels.
+	Channels []string
+
+	// Since is the time of the first failed health check.
+	Since time.Time
+}
+
+func normalizeSubscriptionHealthConfig(cfg SubscriptionHealthConfig) SubscriptionHealthConfig {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultSubscriptionHealthInterval
+	}
+
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = defaultSubscriptionBrokenThreshold
+	}
+
+	return cfg
+}
+
+// Subscription is a Pub/Sub subscription with health checks.
+//
+// go-redis reconnects and resubscribes broken subscriptions on its own, but
+// the outage is silent: messages published in the meantime are lost. A
+// Subscription pings its connection, logs and reports outages longer than
+// the configured threshold, and records resubscriptions in metrics.
+type Subscription struct {
+	pubsub   *rdb.PubSub
+	messages chan *rdb.Message
+	health   *subscriptionHealth
+}
+
+// Subscribe subscribes to channels and waits for the first subscription to be
+// confirmed.
+//
+// Health checks are configured with WithSubscriptionHealth.
+func (c *Client) Subscribe(ctx context.Context, channels ...string) (*Subscription, error) {
+	pubsub := c.pubsubConn().Subscribe(ctx, channels...)
+
+	health, err := c.watchSubscription(ctx, pubsub, channels)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &Subscription{
+		pubsub:   pubsub,
+		messages: make(chan *rdb.Message, subscriptionBuffer),
+		health:   health,
+	}
+
+	go sub.run()
+
+	return sub, nil
+}
+
+// Channel returns the channel of received messages.
+//
+// The channel is closed after Close.
+func (s *Subscription) Channel() <-chan *rdb.Message {
+	return s.messages
+}
+
+// Close unsubscribes and closes the message channel.
+func (s *Subscription) Close() error {
+	return s.health.close(s.pubsub)
+}
+
+func (s *Subscription) run() {
+	defer close(s.messages)
+
+	for msg := range s.pubsub.ChannelWithSubscriptions() {
+		switch msg := msg.(type) {
+		case *rdb.Subscription:
+			s.health.confirm(msg)
+		case *rdb.Message:
+			select {
+			case s.messages <- msg:
+			case <-s.health.done:
+				return
+			}
+		}
+	}
+}
+
+// subscriptionHealth tracks the health of one Pub/Sub connection.
+type subscriptionHealth struct {
+	client   *Client
+	cfg      SubscriptionHealthConfig
+	channels []string
+
+	closeOnce sync.Once
+	done      chan struct{}
+
+	mu          sync.Mutex
+	confirmed   map[string]struct{}
+	brokenSince time.Time
+	reported    bool
+}
+
+// watchSubscription waits for the first subscription confirmation of pubsub
+// and starts its health checks.
+//
+// pubsub is closed when confirmation fails.
+func (c *Client) watchSubscription(
+	ctx context.Context,
+	pubsub *rdb.PubSub,
+	channels []string,
+) (*subscriptionHealth, error) {
+	msg, err := pubsub.Receive(ctx)
+	if err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+
+	health := &subscriptionHealth{
+		client:    c,
+		cfg:       c.subscriptionHealth,
+		channels:  slices.Clone(channels),
+		done:      make(chan struct{}),
+		confirmed: make(map[string]struct{}, len(channels)),
+	}
+
+	if sub, ok := msg.(*rdb.Subscription); ok {
+		health.confirm(sub)
+	}
+
+	c.metrics.addPubSubSubscriptions(ctx, 1)
+
+	c.goBackground("subscription_health", func(done <-chan struct{}) {
+		health.watch(pubsub, done)
+	})
+
+	return health, nil
+}
+
+func (h *subscriptionHealth) close(pubsub *rdb.PubSub) error {
+	var err error
+
+	h.closeOnce.Do(func() {
+		close(h.done)
+		err = pubsub.Close()
+		h.client.metrics.addPubSubSubscriptions(context.Background(), -1)
+	})
+
+	return err
+}
+
+// watch pings pubsub until the subscription or the client is closed.
+func (h *subscriptionHealth) watch(pubsub *rdb.PubSub, clientDone <-chan struct{}) {
+	ticker := time.NewTicker(h.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.done:
+			return
+		case <-clientDone:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), h.cfg.Interval)
+			err := pubsub.Ping(ctx)
+			cancel()
+
+			if err != nil {
+				h.fail(err)
+			} else {
+				h.pass()
+			}
+		}
+	}
+}
+
+// pass records a successful health check, which ends an outage that did not
+// need a resubscription, such as a timed out ping.
+func (h *subscriptionHealth) pass() {
+	h.mu.Lock()
+	h.brokenSince = time.Time{}
+	h.reported = false
+	h.mu.Unlock()
+}
+
+// fail records a failed health check and reports the outage once it lasts
+// longer than the threshold.
+func (h *subscriptionHealth) fail(err error) {
+	now := time.Now()
+
+	h.mu.Lock()
+	if h.brokenSince.IsZero() {
+		h.brokenSince = now
+	}
+
+	since := h.brokenSince
+	report := !h.reported && now.Sub(since) >= h.cfg.Threshold
+	if report {
+		h.reported = true
+	}
+
+	channels := slices.Clone(h.channels)
+	h.mu.Unlock()
+
+	if !report {
+		return
+	}
+
+	h.client.logger.LogAttrs(
+		context.Background(),
+		slog.LevelWarn,
+		"redis subscription broken",
+		slog.Any("channels", channels),
+		slog.Time("since", since),
+		slog.String("error", err.Error()),
+	)
+
+	if h.cfg.OnBroken != nil {
+		h.cfg.OnBroken(SubscriptionBroken{Channels: channels, Since: since})
+	}
+}
+
+// confirm handles a subscription confirmation. A repeated confirmation of a
+// channel is a resubscription after go-redis reconnected.
+func (h *subscriptionHealth) confirm(sub *rdb.Subscription) {
+	switch sub.Kind {
+	case "subscribe", "psubscribe", "ssubscribe":
+	default:
+		return
+	}
+
+	h.mu.Lock()
+	if _, ok := h.confirmed[sub.Channel]; !ok {
+		h.confirmed[sub.Channel] = struct{}{}
+		h.mu.Unlock()
+
+		return
+	}
+
+	var downtime time.Duration
+	if !h.brokenSince.IsZero() {
+		downtime = time.Since(h.brokenSince)
+	}
+
+	h.brokenSince = time.Time{}
+	h.reported = false
+	h.mu.Unlock()
+
+	ctx := context.Background()
+	h.client.metrics.recordPubSubResubscribe(ctx, downtime)
+	h.client.logger.LogAttrs(
+		ctx,
+		slog.LevelInfo,
+		"redis subscription restored",
+		slog.String("channel", sub.Channel),
+		slog.Duration("downtime", downtime),
+	)
+}
+
+// subscribe adds channels subscribed after the first confirmation, for
+// connections shared by a SubscriptionMux.
+func (h *subscriptionHealth) subscribe(channels []string) {
+	h.mu.Lock()
+	h.channels = append(h.channels, channels...)
+	h.mu.Unlock()
+}
+
+// unsubscribe removes channels, so a later subscription to one of them is not
+// taken for a resubscription.
+func (h *subscriptionHealth) unsubscribe(channels []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.channels = slices.DeleteFunc(h.channels, func(channel string) bool {
+		return slices.Contains(channels, channel)
+	})
+
+	for _, channel := range channels {
+		delete(h.confirmed, channel)
+	}
+}
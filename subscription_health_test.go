@@ -0,0 +1,44 @@
+package xredis
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+)
+
+var _ = Describe("subscriptionHealth", func() {
+	It("ends an outage when a health check passes", func() {
+		var reports []SubscriptionBroken
+
+		health := &subscriptionHealth{
+			client: &Client{logger: slog.New(slog.DiscardHandler)},
+			cfg: SubscriptionHealthConfig{
+				Threshold: 50 * time.Millisecond,
+				OnBroken: func(b SubscriptionBroken) {
+					reports = append(reports, b)
+				},
+			},
+			channels:  []string{"channel"},
+			confirmed: map[string]struct{}{},
+		}
+
+		errPing := errors.New("ping timeout")
+
+		health.fail(errPing)
+		time.Sleep(60 * time.Millisecond)
+		health.pass()
+
+		restarted := time.Now()
+
+		health.fail(errPing)
+		Expect(reports).To(BeEmpty())
+
+		time.Sleep(60 * time.Millisecond)
+		health.fail(errPing)
+		Expect(reports).To(HaveLen(1))
+		Expect(reports[0].Since).To(BeTemporally(">=", restarted))
+	})
+})
@@ -0,0 +1,419 @@
+package xredis
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// SubscriptionMux shares one Pub/Sub connection per node across many logical
+// subscriptions, for services that subscribe to hundreds of channels
+// dynamically.
+//
+// Channels are subscribed on Redis while at least one subscription of the
+// multiplexer uses them. Ring shards each get their own connection, other
+// topologies share a single one. Shared connections are health checked like
+// Subscribe, configured with WithSubscriptionHealth.
+//
+// Messages are delivered to every subscription of their channel in order. A
+// subscription whose channel buffer is full delays the other subscriptions of
+// its connection, so consume messages promptly.
+type SubscriptionMux struct {
+	client *Client
+
+	mu     sync.Mutex
+	nodes  map[string]*muxNode
+	closed bool
+}
+
+// muxNode is the shared connection of one node and its subscribers by
+// channel.
+//
+// io serializes the Redis commands of the node, so they never run under
+// mux.mu, which deliveries take for every message.
+type muxNode struct {
+	mux  *SubscriptionMux
+	addr string
+
+	// Guarded by io. pubsub is nil until the node is connected.
+	io     sync.Mutex
+	pubsub *rdb.PubSub
+	health *subscriptionHealth
+	closed bool
+
+	// Changed with both io and mux.mu held, so either suffices to read it.
+	subscribers map[string][]*MuxSubscription
+}
+
+// MuxSubscription is a logical subscription of a SubscriptionMux.
+type MuxSubscription struct {
+	mux      *SubscriptionMux
+	channels []string
+	messages chan *rdb.Message
+
+	closeOnce sync.Once
+	done      chan struct{}
+
+	// mu serializes deliveries with closing the message channel.
+	mu     sync.Mutex
+	closed bool
+}
+
+// SubscriptionMux creates a Pub/Sub multiplexer bound to this client.
+//
+// Close the multiplexer to close its connections and subscriptions.
+func (c *Client) SubscriptionMux() *SubscriptionMux {
+	return &SubscriptionMux{
+		client: c,
+		nodes:  make(map[string]*muxNode),
+	}
+}
+
+// Subscribe subscribes to channels over the shared connections, subscribing
+// on Redis to channels that no other subscription uses, and waits until a new
+// connection confirms its first subscription.
+func (m *SubscriptionMux) Subscribe(ctx context.Context, channels ...string) (*MuxSubscription, error) {
+	sub := &MuxSubscription{
+		mux:      m,
+		channels: slices.Compact(slices.Sorted(slices.Values(channels))),
+		messages: make(chan *rdb.Message, subscriptionBuffer),
+		done:     make(chan struct{}),
+	}
+
+	groups, err := m.groupByNode(sub.channels)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscribed []*muxNode
+
+	for _, group := range groups {
+		node, err := m.subscribeNode(ctx, group, sub)
+		if err != nil {
+			for _, node := range subscribed {
+				node.io.Lock()
+				node.remove(context.WithoutCancel(ctx), sub)
+				node.io.Unlock()
+			}
+
+			return nil, err
+		}
+
+		subscribed = append(subscribed, node)
+	}
+
+	return sub, nil
+}
+
+// Close closes the shared connections and the message channels of all
+// subscriptions.
+func (m *SubscriptionMux) Close() error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil
+	}
+
+	m.closed = true
+	nodes := m.nodes
+	m.nodes = nil
+	m.mu.Unlock()
+
+	var errs []error
+
+	for _, node := range nodes {
+		node.io.Lock()
+		if err := node.close(); err != nil {
+			errs = append(errs, err)
+		}
+
+		for _, subs := range node.subscribers {
+			for _, sub := range subs {
+				sub.shutdown()
+			}
+		}
+		node.io.Unlock()
+	}
+
+	return errors.Join(errs...)
+}
+
+// muxGroup contains the channels of a subscription served by one node.
+type muxGroup struct {
+	addr     string
+	conn     rdb.UniversalClient
+	channels []string
+}
+
+// groupByNode groups channels by the node that serves them. Ring shards
+// serve the channels that hash to them; other topologies broadcast messages,
+// so one connection serves every channel.
+func (m *SubscriptionMux) groupByNode(channels []string) ([]muxGroup, error) {
+	conn := m.client.pubsubConn()
+
+	ring, ok := conn.(*rdb.Ring)
+	if !ok {
+		if len(channels) == 0 {
+			return nil, nil
+		}
+
+		return []muxGroup{{conn: conn, channels: channels}}, nil
+	}
+
+	var groups []muxGroup
+
+	for _, channel := range channels {
+		shard, err := ring.GetShardClientForKey(channel)
+		if err != nil {
+			return nil, err
+		}
+
+		addr := shard.Options().Addr
+
+		i := slices.IndexFunc(groups, func(group muxGroup) bool { return group.addr == addr })
+		if i < 0 {
+			groups = append(groups, muxGroup{addr: addr, conn: shard})
+			i = len(groups) - 1
+		}
+
+		groups[i].channels = append(groups[i].channels, channel)
+	}
+
+	return groups, nil
+}
+
+// subscribeNode adds sub to the node of group, connecting the node or
+// subscribing to its new channels first.
+func (m *SubscriptionMux) subscribeNode(ctx context.Context, group muxGroup, sub *MuxSubscription) (*muxNode, error) {
+	for {
+		m.mu.Lock()
+		if m.closed {
+			m.mu.Unlock()
+			return nil, ErrSubscriptionMuxClosed
+		}
+
+		node, ok := m.nodes[group.addr]
+		if !ok {
+			node = &muxNode{
+				mux:         m,
+				addr:        group.addr,
+				subscribers: make(map[string][]*MuxSubscription),
+			}
+			m.nodes[group.addr] = node
+		}
+		m.mu.Unlock()
+
+		node.io.Lock()
+		if node.closed {
+			// The last subscription of the node left meanwhile.
+			node.io.Unlock()
+			continue
+		}
+
+		err := node.add(ctx, group, sub)
+		node.io.Unlock()
+
+		return node, err
+	}
+}
+
+// add adds sub to the node, connecting it or subscribing to its new channels
+// first. n.io must be held.
+func (n *muxNode) add(ctx context.Context, group muxGroup, sub *MuxSubscription) error {
+	if n.pubsub == nil {
+		pubsub := group.conn.Subscribe(ctx, group.channels...)
+
+		health, err := n.mux.client.watchSubscription(ctx, pubsub, group.channels)
+		if err != nil {
+			n.closed = true
+			n.detach()
+
+			return err
+		}
+
+		n.pubsub = pubsub
+		n.health = health
+
+		go n.run()
+	} else {
+		var added []string
+
+		for _, channel := range group.channels {
+			if len(n.subscribers[channel]) == 0 {
+				added = append(added, channel)
+			}
+		}
+
+		if len(added) > 0 {
+			if err := n.pubsub.Subscribe(ctx, added...); err != nil {
+				return err
+			}
+
+			n.health.subscribe(added)
+		}
+	}
+
+	n.mux.mu.Lock()
+	defer n.mux.mu.Unlock()
+
+	// Close may have taken the node while it was subscribing, so it is closed
+	// by Close then.
+	if n.mux.closed {
+		return ErrSubscriptionMuxClosed
+	}
+
+	for _, channel := range group.channels {
+		n.subscribers[channel] = append(n.subscribers[channel], sub)
+	}
+
+	return nil
+}
+
+// remove removes sub from the node, unsubscribing from channels that no
+// subscription uses anymore and closing the node without channels. n.io must
+// be held.
+func (n *muxNode) remove(ctx context.Context, sub *MuxSubscription) {
+	if n.closed {
+		return
+	}
+
+	var unused []string
+
+	n.mux.mu.Lock()
+	for _, channel := range sub.channels {
+		subs, ok := n.subscribers[channel]
+		if !ok {
+			continue
+		}
+
+		subs = slices.DeleteFunc(subs, func(s *MuxSubscription) bool { return s == sub })
+		if len(subs) > 0 {
+			n.subscribers[channel] = subs
+			continue
+		}
+
+		delete(n.subscribers, channel)
+		unused = append(unused, channel)
+	}
+	n.mux.mu.Unlock()
+
+	if len(unused) == 0 {
+		return
+	}
+
+	if len(n.subscribers) == 0 {
+		n.detach()
+		_ = n.close()
+
+		return
+	}
+
+	n.health.unsubscribe(unused)
+	_ = n.pubsub.Unsubscribe(ctx, unused...)
+}
+
+// detach removes the node from the multiplexer, so new subscriptions connect
+// a new one.
+func (n *muxNode) detach() {
+	n.mux.mu.Lock()
+	if n.mux.nodes[n.addr] == n {
+		delete(n.mux.nodes, n.addr)
+	}
+	n.mux.mu.Unlock()
+}
+
+// close closes the connection of the node. n.io must be held.
+func (n *muxNode) close() error {
+	if n.closed {
+		return nil
+	}
+
+	n.closed = true
+
+	if n.pubsub == nil {
+		return nil
+	}
+
+	return n.health.close(n.pubsub)
+}
+
+// run delivers messages of the shared connection until it is closed.
+func (n *muxNode) run() {
+	for msg := range n.pubsub.ChannelWithSubscriptions() {
+		switch msg := msg.(type) {
+		case *rdb.Subscription:
+			n.health.confirm(msg)
+		case *rdb.Message:
+			n.mux.mu.Lock()
+			subs := slices.Clone(n.subscribers[msg.Channel])
+			n.mux.mu.Unlock()
+
+			for _, sub := range subs {
+				if !sub.deliver(msg, n.health.done) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Channel returns the channel of received messages.
+//
+// The channel is closed after Close or SubscriptionMux.Close.
+func (s *MuxSubscription) Channel() <-chan *rdb.Message {
+	return s.messages
+}
+
+// Close removes the subscription from the multiplexer and closes its message
+// channel. Channels that no other subscription uses are unsubscribed.
+func (s *MuxSubscription) Close() error {
+	s.mux.mu.Lock()
+	nodes := slices.Collect(maps.Values(s.mux.nodes))
+	s.mux.mu.Unlock()
+
+	for _, node := range nodes {
+		node.io.Lock()
+		node.remove(context.Background(), s)
+		node.io.Unlock()
+	}
+
+	s.shutdown()
+
+	return nil
+}
+
+// deliver sends msg to the subscription. It returns false when the node was
+// closed while waiting.
+func (s *MuxSubscription) deliver(msg *rdb.Message, nodeDone <-chan struct{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return true
+	}
+
+	select {
+	case s.messages <- msg:
+	case <-s.done:
+	case <-nodeDone:
+		return false
+	}
+
+	return true
+}
+
+// shutdown closes the message channel once no delivery is in progress.
+func (s *MuxSubscription) shutdown() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+
+		s.mu.Lock()
+		s.closed = true
+		close(s.messages)
+		s.mu.Unlock()
+	})
+}
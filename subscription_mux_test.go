@@ -0,0 +1,114 @@
+package xredis_test
+
+import (
+	"fmt"
+	"sync"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("SubscriptionMux", func() {
+	var (
+		client *xredis.Client
+		mux    *xredis.SubscriptionMux
+	)
+
+	BeforeEach(func() {
+		client = newTestClient(xredis.WithClientID("xredis-mux-test"))
+		mux = client.SubscriptionMux()
+	})
+
+	AfterEach(func() {
+		Expect(mux.Close()).To(Succeed())
+		Expect(client.Close()).To(Succeed())
+	})
+
+	numSub := func(channel string) int64 {
+		counts, err := client.Raw().PubSubNumSub(ctx, channel).Result()
+		Expect(err).NotTo(HaveOccurred())
+
+		return counts[channel]
+	}
+
+	It("shares one connection across subscriptions", func() {
+		first, err := mux.Subscribe(ctx, "mux:orders", "mux:users")
+		Expect(err).NotTo(HaveOccurred())
+
+		second, err := mux.Subscribe(ctx, "mux:orders")
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func() int64 { return numSub("mux:orders") }).Should(Equal(int64(1)))
+		Expect(numSub("mux:users")).To(Equal(int64(1)))
+
+		Expect(client.Raw().Publish(ctx, "mux:orders", "created").Err()).To(Succeed())
+		Expect(client.Raw().Publish(ctx, "mux:users", "joined").Err()).To(Succeed())
+
+		var msg any
+		Eventually(first.Channel()).Should(Receive(&msg))
+		Expect(msg).To(HaveField("Payload", "created"))
+		Eventually(first.Channel()).Should(Receive(&msg))
+		Expect(msg).To(HaveField("Payload", "joined"))
+
+		Eventually(second.Channel()).Should(Receive(&msg))
+		Expect(msg).To(HaveField("Payload", "created"))
+		Consistently(second.Channel()).ShouldNot(Receive())
+	})
+
+	It("unsubscribes channels without subscriptions", func() {
+		first, err := mux.Subscribe(ctx, "mux:orders", "mux:users")
+		Expect(err).NotTo(HaveOccurred())
+
+		second, err := mux.Subscribe(ctx, "mux:orders")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(first.Close()).To(Succeed())
+		Eventually(first.Channel()).Should(BeClosed())
+		Eventually(func() int64 { return numSub("mux:users") }).Should(BeZero())
+		Expect(numSub("mux:orders")).To(Equal(int64(1)))
+
+		Expect(client.Raw().Publish(ctx, "mux:orders", "created").Err()).To(Succeed())
+		Eventually(second.Channel()).Should(Receive())
+
+		Expect(second.Close()).To(Succeed())
+		Eventually(func() int64 { return numSub("mux:orders") }).Should(BeZero())
+	})
+
+	It("subscribes concurrently while delivering messages", func() {
+		first, err := mux.Subscribe(ctx, "mux:orders")
+		Expect(err).NotTo(HaveOccurred())
+
+		var wg sync.WaitGroup
+		for i := range 10 {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				sub, err := mux.Subscribe(ctx, fmt.Sprintf("mux:churn:%d", i%3))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sub.Close()).To(Succeed())
+			}()
+		}
+
+		Expect(client.Raw().Publish(ctx, "mux:orders", "created").Err()).To(Succeed())
+		Eventually(first.Channel()).Should(Receive())
+
+		wg.Wait()
+		Eventually(func() int64 { return numSub("mux:churn:0") }).Should(BeZero())
+		Expect(numSub("mux:orders")).To(Equal(int64(1)))
+	})
+
+	It("closes subscriptions with the multiplexer", func() {
+		sub, err := mux.Subscribe(ctx, "mux:orders")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(mux.Close()).To(Succeed())
+		Eventually(sub.Channel()).Should(BeClosed())
+		Expect(sub.Close()).To(Succeed())
+
+		_, err = mux.Subscribe(ctx, "mux:orders")
+		Expect(err).To(MatchError(xredis.ErrSubscriptionMuxClosed))
+	})
+})
@@ -0,0 +1,126 @@
+package xredis_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+// outageDialer dials Redis until an outage starts, which closes the dialed
+// connections and refuses new ones.
+type outageDialer struct {
+	down atomic.Bool
+
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+func (d *outageDialer) dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	if d.down.Load() {
+		return nil, errors.New("redis is down")
+	}
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	d.conns = append(d.conns, conn)
+	d.mu.Unlock()
+
+	return conn, nil
+}
+
+func (d *outageDialer) startOutage() {
+	d.down.Store(true)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, conn := range d.conns {
+		_ = conn.Close()
+	}
+
+	d.conns = nil
+}
+
+var _ = Describe("Subscriptions", func() {
+	It("delivers published messages", func() {
+		client := newTestClient()
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		sub, err := client.Subscribe(ctx, "subscription:messages")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(client.Raw().Publish(ctx, "subscription:messages", "hello").Err()).To(Succeed())
+
+		var msg any
+		Eventually(sub.Channel()).Should(Receive(&msg))
+		Expect(msg).To(HaveField("Payload", "hello"))
+
+		Expect(sub.Close()).To(Succeed())
+		Eventually(sub.Channel()).Should(BeClosed())
+	})
+
+	It("reports broken subscriptions and logs resubscriptions", func() {
+		var output syncBuffer
+
+		dialer := &outageDialer{}
+		broken := make(chan xredis.SubscriptionBroken, 1)
+
+		client := newTestClient(
+			xredis.WithDialer(dialer.dial),
+			xredis.WithDialerRetryBackoff(func(int) time.Duration { return time.Millisecond }),
+			xredis.WithLogger(slog.New(slog.NewJSONHandler(&output, nil))),
+			xredis.WithSubscriptionHealth(xredis.SubscriptionHealthConfig{
+				Interval:  20 * time.Millisecond,
+				Threshold: 60 * time.Millisecond,
+				OnBroken: func(b xredis.SubscriptionBroken) {
+					broken <- b
+				},
+			}),
+		)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		publisher := newTestClient()
+		defer func() {
+			Expect(publisher.Close()).To(Succeed())
+		}()
+
+		sub, err := client.Subscribe(ctx, "subscription:health")
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(sub.Close()).To(Succeed())
+		}()
+
+		dialer.startOutage()
+
+		var report xredis.SubscriptionBroken
+		Eventually(broken, 2*time.Second).Should(Receive(&report))
+		Expect(report.Channels).To(Equal([]string{"subscription:health"}))
+		Expect(output.String()).To(ContainSubstring(`"msg":"redis subscription broken"`))
+
+		dialer.down.Store(false)
+
+		Eventually(output.String, 2*time.Second).Should(ContainSubstring(`"msg":"redis subscription restored"`))
+
+		Expect(publisher.Raw().Publish(ctx, "subscription:health", "after").Err()).To(Succeed())
+
+		var msg any
+		Eventually(sub.Channel(), 2*time.Second).Should(Receive(&msg))
+		Expect(msg).To(HaveField("Payload", "after"))
+	})
+})
@@ -0,0 +1,132 @@
+package xredis
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/redis/go-redis/extra/rediscmd/v9"
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	rdb "github.com/redis/go-redis/v9"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceVerbosity controls how much of a command is recorded in its trace span.
+type TraceVerbosity int
+
+const (
+	// TraceVerbosityBasic records command names without their arguments.
+	TraceVerbosityBasic TraceVerbosity = iota
+
+	// TraceVerbosityStatements also records full commands, including their
+	// arguments, in the db.statement span attribute.
+	TraceVerbosityStatements
+)
+
+// String returns the lowercase name of the verbosity level.
+func (v TraceVerbosity) String() string {
+	switch v {
+	case TraceVerbosityBasic:
+		return "basic"
+	case TraceVerbosityStatements:
+		return "statements"
+	default:
+		return "unknown"
+	}
+}
+
+// SetTraceVerbosity changes how much of each command is recorded in trace
+// spans, without recreating the client.
+//
+// It can temporarily capture full commands while debugging an incident.
+// The initial level follows WithTracingDBStatement. SetTraceVerbosity has no
+// effect when tracing is disabled.
+func (c *Client) SetTraceVerbosity(level TraceVerbosity) {
+	if c.traceStatements == nil {
+		return
+	}
+
+	on := level == TraceVerbosityStatements
+	if c.traceStatements.Swap(on) != on {
+		c.logger.LogAttrs(
+			context.Background(),
+			slog.LevelInfo,
+			"redis trace verbosity changed",
+			slog.String("verbosity", c.TraceVerbosity().String()),
+		)
+	}
+}
+
+// TraceVerbosity returns the current trace verbosity. It returns
+// TraceVerbosityBasic when tracing is disabled.
+func (c *Client) TraceVerbosity() TraceVerbosity {
+	if c.traceStatements == nil || !c.traceStatements.Load() {
+		return TraceVerbosityBasic
+	}
+
+	return TraceVerbosityStatements
+}
+
+// statementHook records full commands on the spans started by redisotel when
+// statements are enabled.
+//
+// It skips the commands filtered out of tracing, so statements never end up
+// on a parent span.
+type statementHook struct {
+	passDialHook
+
+	statements     *atomic.Bool
+	filter         func(cmd rdb.Cmder) bool
+	filterPipeline func(cmds []rdb.Cmder) bool
+}
+
+func newStatementHook(statements *atomic.Bool, opts *options) *statementHook {
+	hook := &statementHook{
+		statements:     statements,
+		filter:         redisotel.DefaultCommandFilter,
+		filterPipeline: defaultCommandsFilter,
+	}
+
+	if opts.traceCommandFilter != nil {
+		hook.filter = opts.traceCommandFilter
+	}
+
+	if opts.traceCommandsFilter != nil {
+		hook.filterPipeline = opts.traceCommandsFilter
+	}
+
+	return hook
+}
+
+func (h *statementHook) ProcessHook(next rdb.ProcessHook) rdb.ProcessHook {
+	return func(ctx context.Context, cmd rdb.Cmder) error {
+		if h.statements.Load() && !h.filter(cmd) {
+			trace.SpanFromContext(ctx).SetAttributes(semconv.DBStatement(rediscmd.CmdString(cmd)))
+		}
+
+		return next(ctx, cmd)
+	}
+}
+
+func (h *statementHook) ProcessPipelineHook(next rdb.ProcessPipelineHook) rdb.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []rdb.Cmder) error {
+		if h.statements.Load() && !h.filterPipeline(cmds) {
+			_, statement := rediscmd.CmdsString(cmds)
+			trace.SpanFromContext(ctx).SetAttributes(semconv.DBStatement(statement))
+		}
+
+		return next(ctx, cmds)
+	}
+}
+
+// defaultCommandsFilter mirrors the default pipeline filter of redisotel.
+func defaultCommandsFilter(cmds []rdb.Cmder) bool {
+	for _, cmd := range cmds {
+		if redisotel.DefaultCommandFilter(cmd) {
+			return true
+		}
+	}
+
+	return false
+}
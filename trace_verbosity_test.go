@@ -0,0 +1,173 @@
+package xredis_test
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type recordingTracerProvider struct {
+	embedded.TracerProvider
+
+	tracer *recordingTracer
+}
+
+func newRecordingTracerProvider() *recordingTracerProvider {
+	return &recordingTracerProvider{tracer: &recordingTracer{}}
+}
+
+func (p *recordingTracerProvider) Tracer(_ string, _ ...trace.TracerOption) trace.Tracer {
+	return p.tracer
+}
+
+type recordingTracer struct {
+	embedded.Tracer
+
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+func (t *recordingTracer) Start(
+	ctx context.Context,
+	name string,
+	opts ...trace.SpanStartOption,
+) (context.Context, trace.Span) {
+	config := trace.NewSpanStartConfig(opts...)
+
+	span := &recordingSpan{name: name}
+	span.SetAttributes(config.Attributes()...)
+
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+// statements returns the db.statement attributes of spans with the given name.
+func (t *recordingTracer) statements(name string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var statements []string
+	for _, span := range t.spans {
+		if span.name == name {
+			statements = append(statements, span.attribute("db.statement"))
+		}
+	}
+
+	return statements
+}
+
+type recordingSpan struct {
+	noop.Span
+
+	name string
+
+	mu    sync.Mutex
+	attrs []attribute.KeyValue
+}
+
+func (*recordingSpan) IsRecording() bool {
+	return true
+}
+
+func (s *recordingSpan) SetAttributes(attrs ...attribute.KeyValue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attrs = append(s.attrs, attrs...)
+}
+
+func (s *recordingSpan) attribute(key attribute.Key) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, attr := range s.attrs {
+		if attr.Key == key {
+			return attr.Value.AsString()
+		}
+	}
+
+	return ""
+}
+
+var _ = Describe("Trace verbosity", func() {
+	It("records statements by default", func() {
+		provider := newRecordingTracerProvider()
+		client := newTestClient(xredis.WithTracerProvider(provider))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.TraceVerbosity()).To(Equal(xredis.TraceVerbosityStatements))
+		Expect(client.Raw().Get(ctx, "trace:key").Err()).To(MatchError(ContainSubstring("nil")))
+		Expect(provider.tracer.statements("get")).To(Equal([]string{"get trace:key"}))
+	})
+
+	It("switches statements at runtime", func() {
+		provider := newRecordingTracerProvider()
+		client := newTestClient(
+			xredis.WithTracerProvider(provider),
+			xredis.WithTracingDBStatement(false),
+		)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.TraceVerbosity()).To(Equal(xredis.TraceVerbosityBasic))
+		Expect(client.Raw().Set(ctx, "trace:key", "before", 0).Err()).To(Succeed())
+
+		client.SetTraceVerbosity(xredis.TraceVerbosityStatements)
+		Expect(client.TraceVerbosity()).To(Equal(xredis.TraceVerbosityStatements))
+		Expect(client.Raw().Set(ctx, "trace:key", "during", 0).Err()).To(Succeed())
+
+		pipe := client.Raw().Pipeline()
+		pipe.Get(ctx, "trace:key")
+		pipe.Get(ctx, "trace:other")
+		_, _ = pipe.Exec(ctx)
+
+		client.SetTraceVerbosity(xredis.TraceVerbosityBasic)
+		Expect(client.Raw().Set(ctx, "trace:key", "after", 0).Err()).To(Succeed())
+
+		Expect(provider.tracer.statements("set")).To(Equal([]string{"", "set trace:key during", ""}))
+		Expect(provider.tracer.statements("redis.pipeline get")).To(Equal([]string{"get trace:key\nget trace:other"}))
+	})
+
+	It("does not record statements of filtered commands", func() {
+		provider := newRecordingTracerProvider()
+		client := newTestClient(
+			xredis.WithTracerProvider(provider),
+			xredis.WithTracingCommandFilter(func(cmd rdb.Cmder) bool {
+				return cmd.Name() == "get"
+			}),
+		)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		parent, span := provider.tracer.Start(ctx, "parent")
+		_ = client.Raw().Get(parent, "trace:key").Err()
+
+		Expect(provider.tracer.statements("get")).To(BeEmpty())
+		Expect(span.(*recordingSpan).attribute("db.statement")).To(BeEmpty())
+	})
+
+	It("ignores verbosity changes when tracing is disabled", func() {
+		client := newTestClient()
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		client.SetTraceVerbosity(xredis.TraceVerbosityStatements)
+		Expect(client.TraceVerbosity()).To(Equal(xredis.TraceVerbosityBasic))
+	})
+})
@@ -0,0 +1,165 @@
+package xredis
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// repairTTLScript applies a TTL limit to one key.
+//
+// KEYS[1] - key
+// ARGV[1] - maximum TTL in milliseconds
+// ARGV[2] - "1" to report changes without applying them
+//
+// Returns 1 when the key had no expiration, 2 when its TTL was longer than
+// the limit, and 0 otherwise, including for missing keys.
+var repairTTLScript = rdb.NewScript(`
+local ttl = redis.call("PTTL", KEYS[1])
+local limit = tonumber(ARGV[1])
+
+local result = 0
+if ttl == -1 then
+	result = 1
+elseif ttl > limit then
+	result = 2
+end
+
+if result ~= 0 and ARGV[2] ~= "1" then
+	redis.call("PEXPIRE", KEYS[1], limit)
+end
+
+return result
+`)
+
+// TTLPolicy declares the longest lifetime of keys in a namespace.
+type TTLPolicy struct {
+	// Match selects the keys of the namespace by Redis glob-style pattern,
+	// such as "sess:*". It must not be empty.
+	Match string
+
+	// MaxTTL is the longest allowed TTL. Keys without an expiration get
+	// MaxTTL, and keys expiring later are shortened to it.
+	MaxTTL time.Duration
+
+	// Count is a SCAN work hint. Zero uses the Redis default.
+	Count int64
+
+	// DryRun reports keys violating the policy without changing them.
+	DryRun bool
+}
+
+// TTLRepairResult reports the outcome of RepairTTL.
+type TTLRepairResult struct {
+	// Scanned is the number of keys checked. SCAN may return a key more than
+	// once, so it can exceed the number of distinct keys.
+	Scanned int64
+
+	// Persistent is the number of keys that had no expiration.
+	Persistent int64
+
+	// Shortened is the number of keys whose TTL exceeded the policy.
+	Shortened int64
+
+	// Failed contains keys whose check or repair failed.
+	Failed []string
+}
+
+func (r *TTLRepairResult) add(other TTLRepairResult) {
+	r.Scanned += other.Scanned
+	r.Persistent += other.Persistent
+	r.Shortened += other.Shortened
+	r.Failed = append(r.Failed, other.Failed...)
+}
+
+// RepairTTL scans the keys matching policy.Match and applies policy.MaxTTL
+// to keys without an expiration or with a longer one, fixing keys leaked by
+// writers that crashed between setting a value and its expiry.
+//
+// Each key is checked and repaired atomically, so a TTL set concurrently by a
+// writer is never extended. With DryRun, violations are only counted.
+//
+// Failed repairs do not stop the scan, and the first repair error is returned
+// after the scan completes, like ScanDelete. Scan errors stop the scan
+// immediately.
+func (c *Client) RepairTTL(ctx context.Context, policy TTLPolicy) (TTLRepairResult, error) {
+	if policy.Match == "" {
+		return TTLRepairResult{}, ErrInvalidScan
+	}
+
+	if policy.MaxTTL < time.Millisecond {
+		return TTLRepairResult{}, ErrInvalidTTL
+	}
+
+	var (
+		mu        sync.Mutex
+		result    TTLRepairResult
+		repairErr error
+	)
+
+	opts := ScanOptions{Match: policy.Match, Count: policy.Count}
+
+	err := c.ScanEachBatch(ctx, opts, func(ctx context.Context, keys []string) error {
+		batch, err := c.repairTTLBatch(ctx, keys, policy)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		result.add(batch)
+		if err != nil && repairErr == nil {
+			repairErr = err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	return result, repairErr
+}
+
+func (c *Client) repairTTLBatch(ctx context.Context, keys []string, policy TTLPolicy) (TTLRepairResult, error) {
+	dryRun := "0"
+	if policy.DryRun {
+		dryRun = "1"
+	}
+
+	cmds := make([]*rdb.Cmd, len(keys))
+
+	// Per-command errors are collected below.
+	_, _ = c.conn.Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = repairTTLScript.Eval(ctx, pipe, []string{key}, policy.MaxTTL.Milliseconds(), dryRun)
+		}
+
+		return nil
+	})
+
+	result := TTLRepairResult{Scanned: int64(len(keys))}
+
+	var firstErr error
+
+	for i, cmd := range cmds {
+		code, err := cmd.Int()
+		if err != nil {
+			result.Failed = append(result.Failed, keys[i])
+			if firstErr == nil {
+				firstErr = err
+			}
+
+			continue
+		}
+
+		switch code {
+		case 1:
+			result.Persistent++
+		case 2:
+			result.Shortened++
+		}
+	}
+
+	return result, firstErr
+}
@@ -0,0 +1,55 @@
+package xredis_test
+
+import (
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("RepairTTL", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient()
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+
+		Expect(client.Raw().Set(ctx, "sess:leaked", "v", 0).Err()).To(Succeed())
+		Expect(client.Raw().Set(ctx, "sess:long", "v", 48*time.Hour).Err()).To(Succeed())
+		Expect(client.Raw().Set(ctx, "sess:ok", "v", time.Hour).Err()).To(Succeed())
+		Expect(client.Raw().Set(ctx, "other:leaked", "v", 0).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	It("applies the maximum TTL to keys of the namespace", func() {
+		result, err := client.RepairTTL(ctx, xredis.TTLPolicy{Match: "sess:*", MaxTTL: 24 * time.Hour})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(xredis.TTLRepairResult{Scanned: 3, Persistent: 1, Shortened: 1}))
+
+		Expect(client.Raw().TTL(ctx, "sess:leaked").Val()).To(BeNumerically("~", 24*time.Hour, time.Minute))
+		Expect(client.Raw().TTL(ctx, "sess:long").Val()).To(BeNumerically("~", 24*time.Hour, time.Minute))
+		Expect(client.Raw().TTL(ctx, "sess:ok").Val()).To(BeNumerically("~", time.Hour, time.Minute))
+		Expect(client.Raw().TTL(ctx, "other:leaked").Val()).To(Equal(time.Duration(-1)))
+	})
+
+	It("reports violations without changing keys in dry-run mode", func() {
+		result, err := client.RepairTTL(ctx, xredis.TTLPolicy{Match: "sess:*", MaxTTL: 24 * time.Hour, DryRun: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(xredis.TTLRepairResult{Scanned: 3, Persistent: 1, Shortened: 1}))
+
+		Expect(client.Raw().TTL(ctx, "sess:leaked").Val()).To(Equal(time.Duration(-1)))
+		Expect(client.Raw().TTL(ctx, "sess:long").Val()).To(BeNumerically(">", 24*time.Hour))
+	})
+
+	It("rejects invalid policies", func() {
+		_, err := client.RepairTTL(ctx, xredis.TTLPolicy{MaxTTL: time.Hour})
+		Expect(err).To(MatchError(xredis.ErrInvalidScan))
+
+		_, err = client.RepairTTL(ctx, xredis.TTLPolicy{Match: "sess:*"})
+		Expect(err).To(MatchError(xredis.ErrInvalidTTL))
+	})
+})
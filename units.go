@@ -0,0 +1,169 @@
+package xredis
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Byte size units. Sizes are formatted with the binary units, like the "kb",
+// "mb", and "gb" units of redis.conf.
+const (
+	Byte     ByteSize = 1
+	Kilobyte          = 1024 * Byte
+	Megabyte          = 1024 * Kilobyte
+	Gigabyte          = 1024 * Megabyte
+	Terabyte          = 1024 * Gigabyte
+)
+
+// byteSizeUnits follows redis.conf: "k" is 1000 bytes, while "kb" and "kib"
+// are 1024 bytes.
+var byteSizeUnits = map[string]ByteSize{
+	"": Byte, "b": Byte,
+	"k": 1000, "kb": Kilobyte, "kib": Kilobyte,
+	"m": 1000 * 1000, "mb": Megabyte, "mib": Megabyte,
+	"g": 1000 * 1000 * 1000, "gb": Gigabyte, "gib": Gigabyte,
+	"t": 1000 * 1000 * 1000 * 1000, "tb": Terabyte, "tib": Terabyte,
+}
+
+// ByteSize is a number of bytes that can be loaded from human-readable
+// strings, such as "64MB", by configuration loaders that support
+// encoding.TextUnmarshaler.
+type ByteSize int64
+
+// ParseByteSize parses a non-negative size with an optional unit, such as
+// "512", "64MB", or "1.5gb". Units are case-insensitive and follow redis.conf:
+// "k", "m", "g", and "t" are powers of 1000, while "kb", "mb", "gb", "tb", and
+// their "kib" forms are powers of 1024.
+func ParseByteSize(s string) (ByteSize, error) {
+	value := strings.TrimSpace(s)
+
+	split := strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if split < 0 {
+		split = len(value)
+	}
+
+	number, unit := value[:split], strings.ToLower(strings.TrimSpace(value[split:]))
+	if number == "" {
+		return 0, fmt.Errorf("%w: invalid size %q: missing number", ErrInvalidConfig, s)
+	}
+
+	multiplier, ok := byteSizeUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("%w: invalid size %q: unknown unit %q, use B, KB, MB, GB, or TB", ErrInvalidConfig, s, unit)
+	}
+
+	if n, err := strconv.ParseInt(number, 10, 64); err == nil {
+		if n > math.MaxInt64/int64(multiplier) {
+			return 0, fmt.Errorf("%w: invalid size %q: too large", ErrInvalidConfig, s)
+		}
+
+		return ByteSize(n) * multiplier, nil
+	}
+
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid size %q: malformed number", ErrInvalidConfig, s)
+	}
+
+	size := n * float64(multiplier)
+	if size >= math.MaxInt64 {
+		return 0, fmt.Errorf("%w: invalid size %q: too large", ErrInvalidConfig, s)
+	}
+
+	return ByteSize(size), nil
+}
+
+// String formats the size with the largest binary unit that represents it
+// exactly, such as "64MB" or "1500B".
+func (s ByteSize) String() string {
+	units := []struct {
+		size ByteSize
+		name string
+	}{
+		{Terabyte, "TB"},
+		{Gigabyte, "GB"},
+		{Megabyte, "MB"},
+		{Kilobyte, "KB"},
+	}
+
+	for _, unit := range units {
+		if s != 0 && s%unit.size == 0 {
+			return strconv.FormatInt(int64(s/unit.size), 10) + unit.name
+		}
+	}
+
+	return strconv.FormatInt(int64(s), 10) + "B"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ByteSize) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ByteSize) UnmarshalText(text []byte) error {
+	size, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = size
+
+	return nil
+}
+
+// Duration is a time.Duration that can be loaded from strings, such as
+// "500ms" or "2m", by configuration loaders that support
+// encoding.TextUnmarshaler, including encoding/json.
+type Duration time.Duration
+
+// ParseDuration parses a duration in time.ParseDuration format. Unlike
+// time.ParseDuration, errors explain the expected format, and a bare number
+// other than zero is rejected because its unit would be ambiguous.
+func ParseDuration(s string) (Duration, error) {
+	value := strings.TrimSpace(s)
+
+	d, err := time.ParseDuration(value)
+	if err == nil {
+		return Duration(d), nil
+	}
+
+	if _, numErr := strconv.ParseFloat(value, 64); numErr == nil {
+		return 0, fmt.Errorf("%w: invalid duration %q: missing unit, such as %sms or %ss", ErrInvalidConfig, s, value, value)
+	}
+
+	return 0, fmt.Errorf("%w: invalid duration %q: use a number with a unit (ns, us, ms, s, m, h), such as 500ms or 2m",
+		ErrInvalidConfig, s)
+}
+
+// Std returns d as a time.Duration.
+func (d Duration) Std() time.Duration {
+	return time.Duration(d)
+}
+
+// String formats d like time.Duration.
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package xredis_test
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+var _ = Describe("Config units", func() {
+	DescribeTable("parses byte sizes",
+		func(input string, expected xredis.ByteSize) {
+			size, err := xredis.ParseByteSize(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(Equal(expected))
+		},
+		Entry("bare bytes", "512", xredis.ByteSize(512)),
+		Entry("bytes", "512B", xredis.ByteSize(512)),
+		Entry("binary megabytes", "64MB", 64*xredis.Megabyte),
+		Entry("lowercase", "64mb", 64*xredis.Megabyte),
+		Entry("kibibytes", "2KiB", 2*xredis.Kilobyte),
+		Entry("decimal kilobytes", "2k", xredis.ByteSize(2000)),
+		Entry("fractions", "1.5GB", 3*xredis.Gigabyte/2),
+		Entry("spaces", " 10 MB ", 10*xredis.Megabyte),
+	)
+
+	DescribeTable("rejects invalid byte sizes",
+		func(input, message string) {
+			_, err := xredis.ParseByteSize(input)
+			Expect(err).To(MatchError(xredis.ErrInvalidConfig))
+			Expect(err.Error()).To(ContainSubstring(message))
+		},
+		Entry("empty", "", "missing number"),
+		Entry("negative", "-1MB", "missing number"),
+		Entry("unknown unit", "64XB", `unknown unit "xb"`),
+		Entry("malformed number", "1.2.3MB", "malformed number"),
+		Entry("overflow", "9999999999TB", "too large"),
+	)
+
+	It("formats byte sizes with the largest exact unit", func() {
+		Expect((64 * xredis.Megabyte).String()).To(Equal("64MB"))
+		Expect(xredis.ByteSize(1500).String()).To(Equal("1500B"))
+		Expect(xredis.ByteSize(0).String()).To(Equal("0B"))
+	})
+
+	It("parses durations with clear errors", func() {
+		d, err := xredis.ParseDuration("500ms")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(d.Std()).To(Equal(500 * time.Millisecond))
+
+		_, err = xredis.ParseDuration("500")
+		Expect(err).To(MatchError(xredis.ErrInvalidConfig))
+		Expect(err.Error()).To(ContainSubstring("missing unit, such as 500ms or 500s"))
+
+		_, err = xredis.ParseDuration("soon")
+		Expect(err).To(MatchError(xredis.ErrInvalidConfig))
+		Expect(err.Error()).To(ContainSubstring("such as 500ms or 2m"))
+	})
+
+	It("loads units from text-based configuration", func() {
+		var cfg struct {
+			Budget  xredis.ByteSize `json:"budget"`
+			Timeout xredis.Duration `json:"timeout"`
+		}
+
+		Expect(json.Unmarshal([]byte(`{"budget":"64MB","timeout":"2m"}`), &cfg)).To(Succeed())
+		Expect(cfg.Budget).To(Equal(64 * xredis.Megabyte))
+		Expect(cfg.Timeout.Std()).To(Equal(2 * time.Minute))
+
+		out, err := json.Marshal(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(Equal(`{"budget":"64MB","timeout":"2m0s"}`))
+
+		Expect(json.Unmarshal([]byte(`{"budget":"64XB"}`), &cfg)).To(MatchError(xredis.ErrInvalidConfig))
+	})
+})
@@ -0,0 +1,112 @@
+package xredis
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// UnknownFieldPolicy controls how hash reads handle fields that the
+// destination struct does not declare.
+type UnknownFieldPolicy int
+
+const (
+	// UnknownFieldsIgnore silently skips unknown fields, like go-redis does.
+	UnknownFieldsIgnore UnknownFieldPolicy = iota
+
+	// UnknownFieldsLog logs unknown fields at warn level through the client
+	// logger and returns the scanned value.
+	UnknownFieldsLog
+
+	// UnknownFieldsError fails the read with ErrUnknownHashFields.
+	UnknownFieldsError
+)
+
+// hashStructFields caches the set of hash field names declared by struct
+// types.
+var hashStructFields sync.Map // map[reflect.Type]map[string]struct{}
+
+// declaredHashFields returns the hash field names declared by the struct type
+// of dst, or ok=false when dst is not a pointer to a struct.
+func declaredHashFields(dst any) (map[string]struct{}, bool) {
+	typ := reflect.TypeOf(dst)
+	if typ == nil || typ.Kind() != reflect.Pointer || typ.Elem().Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	typ = typ.Elem()
+	if fields, ok := hashStructFields.Load(typ); ok {
+		return fields.(map[string]struct{}), true
+	}
+
+	mapping := hashStructOf(typ)
+
+	fields := make(map[string]struct{}, len(mapping.fields))
+	for _, field := range mapping.fields {
+		fields[field.name] = struct{}{}
+	}
+
+	hashStructFields.Store(typ, fields)
+
+	return fields, true
+}
+
+// scanHash scans the reply of HGETALL into dst and applies the unknown field
+// policy of the client.
+func (c *Client) scanHash(ctx context.Context, key string, res *rdb.MapStringStringCmd, dst any) error {
+	values, err := c.converters.scanStruct(res.Val(), dst)
+	if err != nil {
+		return err
+	}
+
+	if err := rdb.NewMapStringStringResult(values, res.Err()).Scan(dst); err != nil {
+		return err
+	}
+
+	if err := scanEmbeddedHashFields(values, dst); err != nil {
+		return err
+	}
+
+	if c.unknownFields == UnknownFieldsIgnore {
+		return nil
+	}
+
+	declared, ok := declaredHashFields(dst)
+	if !ok {
+		return nil
+	}
+
+	var unknown []string
+	for field := range res.Val() {
+		if _, ok := declared[field]; !ok {
+			unknown = append(unknown, field)
+		}
+	}
+
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknown)
+
+	if c.unknownFields == UnknownFieldsError {
+		return fmt.Errorf("%w: key %q: %s", ErrUnknownHashFields, key, strings.Join(unknown, ", "))
+	}
+
+	c.logger.LogAttrs(
+		ctx,
+		slog.LevelWarn,
+		"redis hash has unknown fields",
+		slog.String("key", key),
+		slog.Any("fields", unknown),
+		slog.String("type", reflect.TypeOf(dst).Elem().String()),
+	)
+
+	return nil
+}
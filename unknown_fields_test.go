@@ -0,0 +1,76 @@
+package xredis_test
+
+import (
+	"log/slog"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+type unknownFieldsUser struct {
+	Name string `redis:"name"`
+	Age  int    `redis:"age"`
+}
+
+var _ = Describe("Unknown hash fields", func() {
+	BeforeEach(func() {
+		client := newTestClient()
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+		Expect(client.Raw().HSet(ctx, "unknown:user", "name", "Ann", "age", "42", "email", "ann@example.com").Err()).To(Succeed())
+	})
+
+	It("ignores unknown fields by default", func() {
+		client := newTestClient()
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		var user unknownFieldsUser
+		ok, err := client.HGetAll(ctx, "unknown:user", &user)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(user).To(Equal(unknownFieldsUser{Name: "Ann", Age: 42}))
+	})
+
+	It("rejects unknown fields in strict mode", func() {
+		client := newTestClient(xredis.WithUnknownHashFields(xredis.UnknownFieldsError))
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		var user unknownFieldsUser
+		ok, err := client.HGetAll(ctx, "unknown:user", &user)
+		Expect(err).To(MatchError(xredis.ErrUnknownHashFields))
+		Expect(err).To(MatchError(ContainSubstring("email")))
+		Expect(ok).To(BeFalse())
+
+		errs := client.Batch().HGetAll("unknown:user", &user).Exec(ctx)
+		Expect(errs[0]).To(MatchError(xredis.ErrUnknownHashFields))
+	})
+
+	It("logs unknown fields", func() {
+		var output syncBuffer
+
+		client := newTestClient(
+			xredis.WithUnknownHashFields(xredis.UnknownFieldsLog),
+			xredis.WithLogger(slog.New(slog.NewJSONHandler(&output, nil))),
+		)
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		var user unknownFieldsUser
+		ok, err := client.HGetAll(ctx, "unknown:user", &user)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(user.Name).To(Equal("Ann"))
+
+		Expect(output.String()).To(ContainSubstring(`"msg":"redis hash has unknown fields"`))
+		Expect(output.String()).To(ContainSubstring(`"fields":["email"]`))
+	})
+})
@@ -0,0 +1,484 @@
+package xredis
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"crypto/sha1" //nolint:gosec // Redis sha1hex compares the current value.
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultValueHistoryMaxEntries = 100
+	valueHistoryKeySuffix         = ":history"
+
+	// valueHistoryCompressMin is the smallest entry compressed with DEFLATE;
+	// smaller entries rarely shrink.
+	valueHistoryCompressMin = 256
+)
+
+// Formats of value history entries.
+const (
+	// historyFormatCreate marks the first value of a key.
+	historyFormatCreate = "create"
+
+	// historyFormatPatch stores a JSON merge patch that turns the value into
+	// the previous one.
+	historyFormatPatch = "patch"
+
+	// historyFormatSnapshot stores the previous value.
+	historyFormatSnapshot = "snapshot"
+)
+
+const historyEncodingDeflate = "deflate"
+
+// appendHistoryScript stores a value and appends a history entry, unless the
+// value was changed since it was read.
+//
+// KEYS[1] - value key
+// KEYS[2] - history stream key
+// ARGV[1] - SHA1 of the value that was read, empty when the key was missing
+// ARGV[2] - new value
+// ARGV[3] - entry format, empty when the value is unchanged
+// ARGV[4] - entry encoding
+// ARGV[5] - entry data
+// ARGV[6] - maximum number of entries
+//
+// Returns {1, entry ID} when the value was stored and {0, ""} on a conflict.
+var appendHistoryScript = rdb.NewScript(`
+local current = redis.call("GET", KEYS[1])
+local digest = ""
+if current then
+	digest = redis.sha1hex(current)
+end
+
+if digest ~= ARGV[1] then
+	return {0, ""}
+end
+
+redis.call("SET", KEYS[1], ARGV[2])
+
+if ARGV[3] == "" then
+	return {1, ""}
+end
+
+local id = redis.call("XADD", KEYS[2], "MAXLEN", ARGV[6], "*",
+	"format", ARGV[3], "encoding", ARGV[4], "data", ARGV[5])
+
+return {1, id}
+`)
+
+// HistoryEntry is a value stored with AppendHistory.
+type HistoryEntry struct {
+	// ID is the history stream entry ID.
+	ID string
+
+	// Time is when the value was stored.
+	Time time.Time
+
+	// Value is the stored value encoded with the client codec.
+	//
+	// With JSONCodec, values older than the current one are rebuilt from
+	// JSON diffs, so they are equal JSON documents but may differ in object
+	// key order and whitespace.
+	Value []byte
+}
+
+// ValueHistoryKey returns the key of the capped stream that stores the
+// history of key written with AppendHistory. It hashes to the same Redis
+// Cluster slot as key.
+func ValueHistoryKey(key string) string {
+	return sameSlotKey(key, valueHistoryKeySuffix)
+}
+
+// AppendHistory stores newValue at key, encoded with the client codec and
+// without expiration, and records the change in the capped history stream at
+// ValueHistoryKey(key), so History can list the previous values.
+//
+// The history keeps at most maxEntries changes; maxEntries <= 0 uses 100.
+// With JSONCodec, an entry stores only a JSON merge patch back to the
+// previous value, and the previous value itself when it cannot be expressed
+// as a patch, for example when an object field is set to null; other codecs
+// store the previous value. Entries of 256 bytes or more are compressed with
+// DEFLATE.
+//
+// Keys with history must be written only with AppendHistory, because History
+// rebuilds older values from the current one. Concurrent appends are applied
+// one after another. It returns the entry ID, or an empty ID when newValue
+// equals the stored value and no entry was added.
+//
+// A key in a namespace configured with WithNamespaceQuota is counted with its
+// new size after the append, like PatchStruct; the history stream is not
+// counted.
+func (c *Client) AppendHistory(ctx context.Context, key string, newValue any, maxEntries int64) (string, error) {
+	if maxEntries <= 0 {
+		maxEntries = defaultValueHistoryMaxEntries
+	}
+
+	value, err := c.codec.Marshal(newValue)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		current, err := c.conn.Get(ctx, key).Bytes()
+
+		exists := err == nil
+		if err != nil && !errors.Is(err, rdb.Nil) {
+			return "", err
+		}
+
+		format, data, err := c.historyEntry(current, exists, value)
+		if err != nil {
+			return "", err
+		}
+
+		var digest, encoding string
+		if exists {
+			sum := sha1.Sum(current) //nolint:gosec // Redis sha1hex compares the current value.
+			digest = hex.EncodeToString(sum[:])
+		}
+
+		if data, encoding, err = compressHistoryEntry(data); err != nil {
+			return "", err
+		}
+
+		result, err := appendHistoryScript.Run(
+			ctx,
+			c.conn,
+			[]string{key, ValueHistoryKey(key)},
+			digest,
+			value,
+			format,
+			encoding,
+			data,
+			maxEntries,
+		).Slice()
+		if err != nil {
+			return "", err
+		}
+
+		stored, id, err := parseAppendHistoryResult(result)
+		if err != nil {
+			return "", err
+		}
+
+		if stored {
+			if err = c.recountWritten(ctx, key); err != nil {
+				return id, err
+			}
+
+			return id, nil
+		}
+
+		// The value changed since it was read; diff against the new one.
+		if err = ctx.Err(); err != nil {
+			return "", err
+		}
+	}
+}
+
+// historyEntry returns the format and data of the entry that leads from value
+// back to current, and an empty format when value is unchanged.
+func (c *Client) historyEntry(current []byte, exists bool, value []byte) (string, []byte, error) {
+	switch {
+	case !exists:
+		return historyFormatCreate, nil, nil
+	case bytes.Equal(current, value):
+		return "", nil, nil
+	case !c.jsonCodec():
+		return historyFormatSnapshot, current, nil
+	}
+
+	from, err := decodeHistoryJSON(value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	to, err := decodeHistoryJSON(current)
+	if err != nil {
+		// The stored value was not written by AppendHistory.
+		return historyFormatSnapshot, current, nil
+	}
+
+	patch, ok := mergePatch(from, to)
+	if !ok {
+		return historyFormatSnapshot, current, nil
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return historyFormatPatch, data, nil
+}
+
+func (c *Client) jsonCodec() bool {
+	switch c.codec.(type) {
+	case JSONCodec, *JSONCodec:
+		return true
+	default:
+		return false
+	}
+}
+
+// History returns the values stored at key with AppendHistory, newest first,
+// as far back as the history stream reaches. The first entry holds the
+// current value.
+//
+// It returns no entries when the key does not exist. ErrInvalidEntry is
+// returned when an entry cannot be decoded.
+func (c *Client) History(ctx context.Context, key string) ([]HistoryEntry, error) {
+	var (
+		get     *rdb.StringCmd
+		entries *rdb.XMessageSliceCmd
+	)
+
+	_, err := c.conn.TxPipelined(ctx, func(pipe rdb.Pipeliner) error {
+		get = pipe.Get(ctx, key)
+		entries = pipe.XRevRange(ctx, ValueHistoryKey(key), "+", "-")
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, rdb.Nil) {
+		return nil, err
+	}
+
+	value, err := get.Bytes()
+	if errors.Is(err, rdb.Nil) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]HistoryEntry, 0, len(entries.Val()))
+
+	for _, message := range entries.Val() {
+		ms, _ := parseStreamID(message.ID)
+
+		history = append(history, HistoryEntry{
+			ID:    message.ID,
+			Time:  time.UnixMilli(int64(ms)), //nolint:gosec // Stream IDs are Unix milliseconds.
+			Value: value,
+		})
+
+		previous, ok, err := previousHistoryValue(value, message.Values)
+		if err != nil {
+			return nil, fmt.Errorf("%w: history entry %s: %w", ErrInvalidEntry, message.ID, err)
+		}
+
+		if !ok {
+			break
+		}
+
+		value = previous
+	}
+
+	return history, nil
+}
+
+// previousHistoryValue applies a history entry to value and returns the value
+// before the change, and ok=false for the first value of the key.
+func previousHistoryValue(value []byte, fields map[string]any) ([]byte, bool, error) {
+	format, _ := fields["format"].(string)
+	encoding, _ := fields["encoding"].(string)
+	raw, _ := fields["data"].(string)
+
+	if format == historyFormatCreate {
+		return nil, false, nil
+	}
+
+	data, err := decompressHistoryEntry([]byte(raw), encoding)
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch format {
+	case historyFormatSnapshot:
+		return data, true, nil
+	case historyFormatPatch:
+		target, err := decodeHistoryJSON(value)
+		if err != nil {
+			return nil, false, err
+		}
+
+		patch, err := decodeHistoryJSON(data)
+		if err != nil {
+			return nil, false, err
+		}
+
+		previous, err := json.Marshal(applyMergePatch(target, patch))
+		if err != nil {
+			return nil, false, err
+		}
+
+		return previous, true, nil
+	default:
+		return nil, false, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func parseAppendHistoryResult(result []any) (bool, string, error) {
+	if len(result) != 2 {
+		return false, "", fmt.Errorf("%w: unexpected history result", ErrInvalidEntry)
+	}
+
+	stored, ok := result[0].(int64)
+	if !ok {
+		return false, "", fmt.Errorf("%w: unexpected history result", ErrInvalidEntry)
+	}
+
+	id, _ := result[1].(string)
+
+	return stored == 1, id, nil
+}
+
+func decodeHistoryJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// mergePatch returns the JSON merge patch (RFC 7386) that turns from into to.
+//
+// It returns ok=false when to cannot be reached with a merge patch, because
+// it sets an object field to null, which a merge patch reads as a deletion.
+func mergePatch(from, to any) (any, bool) {
+	toObject, ok := to.(map[string]any)
+	if !ok {
+		return to, true
+	}
+
+	fromObject, ok := from.(map[string]any)
+	if !ok {
+		// The patch replaces from, so it must be a valid merge patch itself.
+		return to, !containsNull(toObject)
+	}
+
+	patch := make(map[string]any)
+
+	for name := range fromObject {
+		if _, ok := toObject[name]; !ok {
+			patch[name] = nil
+		}
+	}
+
+	for name, value := range toObject {
+		if value == nil {
+			return nil, false
+		}
+
+		previous, ok := fromObject[name]
+		if ok && reflect.DeepEqual(previous, value) {
+			continue
+		}
+
+		field, ok := mergePatch(previous, value)
+		if !ok {
+			return nil, false
+		}
+
+		patch[name] = field
+	}
+
+	return patch, true
+}
+
+func containsNull(object map[string]any) bool {
+	for _, value := range object {
+		switch v := value.(type) {
+		case nil:
+			return true
+		case map[string]any:
+			if containsNull(v) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// applyMergePatch applies a JSON merge patch (RFC 7386) to target.
+func applyMergePatch(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if ok {
+		targetObject = maps.Clone(targetObject)
+	} else {
+		targetObject = make(map[string]any, len(patchObject))
+	}
+
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+
+		targetObject[name] = applyMergePatch(targetObject[name], value)
+	}
+
+	return targetObject
+}
+
+// compressHistoryEntry compresses data with DEFLATE when it is large enough
+// and shrinks, and returns the encoding of the result.
+func compressHistoryEntry(data []byte) ([]byte, string, error) {
+	if len(data) < valueHistoryCompressMin {
+		return data, "", nil
+	}
+
+	var buf bytes.Buffer
+
+	writer, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if _, err = writer.Write(data); err != nil {
+		return nil, "", err
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, "", err
+	}
+
+	if buf.Len() >= len(data) {
+		return data, "", nil
+	}
+
+	return buf.Bytes(), historyEncodingDeflate, nil
+}
+
+func decompressHistoryEntry(data []byte, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return data, nil
+	case historyEncodingDeflate:
+		return io.ReadAll(flate.NewReader(bytes.NewReader(data)))
+	default:
+		return nil, fmt.Errorf("unknown encoding %q", encoding)
+	}
+}
@@ -0,0 +1,88 @@
+package xredis_test
+
+import (
+	"encoding/json"
+	"strings"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+)
+
+type historyConfig struct {
+	Name     string            `json:"name"`
+	Replicas int               `json:"replicas"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Notes    string            `json:"notes,omitempty"`
+}
+
+var _ = Describe("Value history", func() {
+	var client *xredis.Client
+
+	BeforeEach(func() {
+		client = newTestClient()
+		Expect(client.Raw().FlushDB(ctx).Err()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).To(Succeed())
+	})
+
+	decode := func(entry xredis.HistoryEntry) historyConfig {
+		var config historyConfig
+		Expect(json.Unmarshal(entry.Value, &config)).To(Succeed())
+
+		return config
+	}
+
+	It("rebuilds previous values from the history", func() {
+		versions := []historyConfig{
+			{Name: "api", Replicas: 2},
+			{Name: "api", Replicas: 3, Labels: map[string]string{"tier": "web"}, Notes: strings.Repeat("scale ", 100)},
+			{Name: "api", Replicas: 3, Labels: map[string]string{"tier": "edge"}},
+		}
+
+		for _, version := range versions {
+			id, err := client.AppendHistory(ctx, "config:api", version, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).NotTo(BeEmpty())
+		}
+
+		id, err := client.AppendHistory(ctx, "config:api", versions[2], 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(BeEmpty())
+
+		var current historyConfig
+		ok, err := client.GetStruct(ctx, "config:api", &current)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(current).To(Equal(versions[2]))
+
+		history, err := client.History(ctx, "config:api")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history).To(HaveLen(3))
+		Expect(decode(history[0])).To(Equal(versions[2]))
+		Expect(decode(history[1])).To(Equal(versions[1]))
+		Expect(decode(history[2])).To(Equal(versions[0]))
+		Expect(history[0].Time).NotTo(BeZero())
+	})
+
+	It("keeps at most maxEntries changes", func() {
+		for replicas := range 4 {
+			_, err := client.AppendHistory(ctx, "config:api", historyConfig{Name: "api", Replicas: replicas}, 2)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		history, err := client.History(ctx, "config:api")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history).To(HaveLen(2))
+		Expect(decode(history[0]).Replicas).To(Equal(3))
+		Expect(decode(history[1]).Replicas).To(Equal(2))
+	})
+
+	It("returns no history for missing keys", func() {
+		history, err := client.History(ctx, "config:missing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history).To(BeEmpty())
+	})
+})
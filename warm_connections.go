@@ -0,0 +1,111 @@
+package xredis
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	rdb "github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultWarmConnectionsInterval = 10 * time.Second
+	warmConnectionTimeout          = time.Second
+)
+
+// WarmConnectionsConfig configures warm standby connections to all nodes.
+type WarmConnectionsConfig struct {
+	// CheckInterval defines how often every node is pinged.
+	//
+	// Zero uses 10 seconds.
+	CheckInterval time.Duration
+}
+
+func normalizeWarmConnectionsConfig(cfg WarmConnectionsConfig) WarmConnectionsConfig {
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = defaultWarmConnectionsInterval
+	}
+
+	return cfg
+}
+
+// warmNodes tracks which nodes failed the last ping, so failures are logged
+// once per node until it recovers.
+type warmNodes struct {
+	mu     sync.Mutex
+	failed map[string]bool
+}
+
+// warmConnections pings every node once, which keeps at least one healthy
+// connection in each node pool.
+//
+// go-redis checks idle connections when taking them from the pool, so a broken
+// connection is replaced by a new one on the next ping instead of on the first
+// command after a failover.
+func (c *Client) warmConnections(nodes *warmNodes) {
+	ctx, cancel := context.WithTimeout(context.Background(), warmConnectionTimeout)
+	defer cancel()
+
+	_ = forEachNode(ctx, c.conn, func(ctx context.Context, node *rdb.Client) error {
+		addr := node.Options().Addr
+		err := node.Ping(ctx).Err()
+
+		nodes.mu.Lock()
+		failed := nodes.failed[addr]
+		nodes.failed[addr] = err != nil
+		nodes.mu.Unlock()
+
+		switch {
+		case err != nil && !failed:
+			c.logger.LogAttrs(
+				ctx,
+				slog.LevelWarn,
+				"redis warm connection failed",
+				slog.String("addr", addr),
+				slog.String("error", err.Error()),
+			)
+		case err == nil && failed:
+			c.logger.LogAttrs(ctx, slog.LevelInfo, "redis warm connection restored", slog.String("addr", addr))
+		}
+
+		return nil
+	})
+}
+
+func (c *Client) watchWarmConnections(cfg WarmConnectionsConfig, done <-chan struct{}) {
+	nodes := &warmNodes{failed: make(map[string]bool)}
+
+	c.warmConnections(nodes)
+
+	ticker := time.NewTicker(cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			c.warmConnections(nodes)
+		}
+	}
+}
+
+// forEachNode calls fn concurrently for every node of conn: every master and
+// replica of a cluster, every shard of a ring, or the client itself.
+func forEachNode(
+	ctx context.Context,
+	conn rdb.UniversalClient,
+	fn func(context.Context, *rdb.Client) error,
+) error {
+	switch conn := conn.(type) {
+	case *rdb.ClusterClient:
+		return conn.ForEachShard(ctx, fn)
+	case *rdb.Ring:
+		return conn.ForEachShard(ctx, fn)
+	case *rdb.Client:
+		return fn(ctx, conn)
+	default:
+		return nil
+	}
+}
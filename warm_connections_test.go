@@ -0,0 +1,64 @@
+package xredis_test
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+
+	. "github.com/bsm/ginkgo/v2"
+	. "github.com/bsm/gomega"
+	"github.com/mkbeh/xredis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Warm connections", func() {
+	It("opens connections to unused cluster nodes", func() {
+		client, err := xredis.NewClusterClient(
+			xredis.WithClusterConfig(&xredis.ClusterConfig{Addrs: []string{redisAddr}}),
+			xredis.WithClusterSlots(func(context.Context) ([]rdb.ClusterSlot, error) {
+				return []rdb.ClusterSlot{{Start: 0, End: 16383, Nodes: []rdb.ClusterNode{{Addr: redisAddr}}}}, nil
+			}),
+			xredis.WithWarmConnections(xredis.WarmConnectionsConfig{CheckInterval: 10 * time.Millisecond}),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Eventually(func() uint32 {
+			return client.Raw().PoolStats().IdleConns
+		}).Should(BeNumerically(">=", 1))
+	})
+
+	It("logs unreachable nodes once", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		addr := listener.Addr().String()
+		Expect(listener.Close()).To(Succeed())
+
+		var output syncBuffer
+
+		client, err := xredis.NewClient(
+			xredis.WithClientConfig(&xredis.ClientConfig{
+				Addr:          addr,
+				DialTimeout:   100 * time.Millisecond,
+				DialerRetries: 1,
+				MaxRetries:    -1,
+			}),
+			xredis.WithLogger(slog.New(slog.NewJSONHandler(&output, nil))),
+			xredis.WithWarmConnections(xredis.WarmConnectionsConfig{CheckInterval: 10 * time.Millisecond}),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(client.Close()).To(Succeed())
+		}()
+
+		Eventually(output.String).Should(ContainSubstring(`"msg":"redis warm connection failed"`))
+		Expect(output.String()).To(ContainSubstring(`"addr":"` + addr + `"`))
+
+		time.Sleep(50 * time.Millisecond)
+		Expect(strings.Count(output.String(), "redis warm connection failed")).To(Equal(1))
+	})
+})